@@ -30,6 +30,10 @@ type Client interface {
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) error
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
+	BackupDatabase(ctx context.Context, path string, options ...rpc.Option) (*BackupStatus, error)
+	GetBackupStatus(context.Context, ...rpc.Option) (*BackupStatus, error)
+	CancelBackup(context.Context, ...rpc.Option) error
+	SetMaintenanceMode(ctx context.Context, enabled bool, reason string, options ...rpc.Option) error
 }
 
 // Client implementation for the Coinflect Platform Info API Endpoint
@@ -140,3 +144,28 @@ func (c *client) GetConfig(ctx context.Context, options ...rpc.Option) (interfac
 	err := c.requester.SendRequest(ctx, "admin.getConfig", struct{}{}, &res, options...)
 	return res, err
 }
+
+func (c *client) BackupDatabase(ctx context.Context, path string, options ...rpc.Option) (*BackupStatus, error) {
+	res := &BackupStatus{}
+	err := c.requester.SendRequest(ctx, "admin.backupDatabase", &BackupDatabaseArgs{
+		Path: path,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) GetBackupStatus(ctx context.Context, options ...rpc.Option) (*BackupStatus, error) {
+	res := &BackupStatus{}
+	err := c.requester.SendRequest(ctx, "admin.getBackupStatus", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) CancelBackup(ctx context.Context, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.cancelBackup", struct{}{}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) SetMaintenanceMode(ctx context.Context, enabled bool, reason string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.setMaintenanceMode", &SetMaintenanceModeArgs{
+		Enabled: enabled,
+		Reason:  reason,
+	}, &api.EmptyReply{}, options...)
+}
@@ -65,6 +65,9 @@ func (mc *mockClient) SendRequest(_ context.Context, _ string, _ interface{}, re
 	case *GetLoggerLevelReply:
 		response := mc.response.(*GetLoggerLevelReply)
 		*p = *response
+	case *BackupStatus:
+		response := mc.response.(*BackupStatus)
+		*p = *response
 	case *interface{}:
 		response := mc.response.(*interface{})
 		*p = *response
@@ -386,3 +389,36 @@ func TestGetConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestBackupDatabase(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := &BackupStatus{
+			Path:            "backup.gz",
+			DatabaseVersion: "v1.4.5",
+			State:           BackupRunning,
+		}
+		mockClient := client{requester: NewMockClient(expectedReply, nil)}
+
+		reply, err := mockClient.BackupDatabase(context.Background(), expectedReply.Path)
+		require.NoError(t, err)
+		require.Equal(t, expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&BackupStatus{}, errors.New("some error"))}
+
+		_, err := mockClient.BackupDatabase(context.Background(), "/tmp/backup.gz")
+
+		require.EqualError(t, err, "some error")
+	})
+}
+
+func TestSetMaintenanceMode(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.SetMaintenanceMode(context.Background(), true, "upgrade")
+		require.ErrorIs(t, err, test.Err)
+	}
+}
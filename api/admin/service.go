@@ -5,17 +5,27 @@
 package admin
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"os"
 	"path"
-
-	"github.com/gorilla/rpc/v2"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/coinflect/coinflectchain/api"
+	"github.com/coinflect/coinflectchain/api/health"
+	"github.com/coinflect/coinflectchain/api/openrpc"
 	"github.com/coinflect/coinflectchain/api/server"
 	"github.com/coinflect/coinflectchain/chains"
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/backup"
+	"github.com/coinflect/coinflectchain/database/manager"
 	"github.com/coinflect/coinflectchain/ids"
 	"github.com/coinflect/coinflectchain/snow/engine/common"
 	"github.com/coinflect/coinflectchain/utils"
@@ -33,11 +43,22 @@ const (
 
 	// Name of file that stacktraces are written to
 	stacktraceFile = "stacktrace.txt"
+
+	// States of a database backup
+	BackupRunning   = "running"
+	BackupSucceeded = "succeeded"
+	BackupFailed    = "failed"
+	BackupCancelled = "cancelled"
 )
 
 var (
-	errAliasTooLong = errors.New("alias length is too long")
-	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+	errAliasTooLong    = errors.New("alias length is too long")
+	errNoLogLevel      = errors.New("need to specify either displayLevel or logLevel")
+	errNoBackupPath    = errors.New("need to specify a backup path")
+	errInvalidBackup   = errors.New("backup path must be relative to the backup directory")
+	errBackupRunning   = errors.New("a database backup is already running")
+	errNoBackup        = errors.New("no database backup was started")
+	errBackupNotActive = errors.New("the database backup isn't running")
 )
 
 type Config struct {
@@ -49,18 +70,36 @@ type Config struct {
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
+	DBManager    manager.Manager
+	Health       health.Health
+	NetworkID    uint32
+	// GenesisID is the hash of the genesis bytes of the network
+	GenesisID ids.ID
+	// BackupDir is the directory that database backups are written to
+	BackupDir string
 }
 
 // Admin is the API service for node admin management
 type Admin struct {
 	Config
 	profiler profiler.Profiler
+
+	// backupLock protects [backup]
+	backupLock sync.Mutex
+	// backup is the last database backup that was started, or nil if none was
+	backup *backupJob
+}
+
+// backupJob is a database backup that is written in the background
+type backupJob struct {
+	cancel context.CancelFunc
+	status BackupStatus
 }
 
 // NewService returns a new admin API service.
 // All of the fields in [config] must be set.
 func NewService(config Config) (*common.HTTPHandler, error) {
-	newServer := rpc.NewServer()
+	newServer := openrpc.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -315,3 +354,210 @@ func (service *Admin) LoadVMs(r *http.Request, _ *struct{}, reply *LoadVMsReply)
 	reply.NewVMs, err = ids.GetRelevantAliases(service.VMManager, loadedVMs)
 	return err
 }
+
+// BackupDatabaseArgs are the arguments for calling BackupDatabase
+type BackupDatabaseArgs struct {
+	// Path of the archive to create, relative to the node's backup directory.
+	// The file must not already exist.
+	Path string `json:"path"`
+}
+
+// BackupStatus reports the progress of a database backup
+type BackupStatus struct {
+	// Path of the archive, including the node's backup directory
+	Path            string `json:"path"`
+	DatabaseVersion string `json:"databaseVersion"`
+	// State is one of BackupRunning, BackupSucceeded, BackupFailed or
+	// BackupCancelled
+	State     string    `json:"state"`
+	StartTime time.Time `json:"startTime"`
+	// EndTime is set once the backup is no longer running
+	EndTime *time.Time `json:"endTime,omitempty"`
+	// NumKeys is the number of keys written so far
+	NumKeys json.Uint64 `json:"numKeys"`
+	// Checksum is set once the backup succeeded
+	Checksum ids.ID `json:"checksum"`
+	// Error is set if the backup failed
+	Error string `json:"error,omitempty"`
+}
+
+// BackupDatabase starts writing a consistent archive of the current database
+// to the specified path in the node's backup directory while the node
+// continues to run. The archive is
+// written in the background; its progress is reported by GetBackupStatus and
+// it can be stopped with CancelBackup. Only one backup can run at a time. The
+// archive can be restored with the offline "db restore" command.
+func (service *Admin) BackupDatabase(_ *http.Request, args *BackupDatabaseArgs, reply *BackupStatus) error {
+	service.Log.Debug("Admin: BackupDatabase called",
+		zap.String("path", args.Path),
+	)
+
+	archivePath, err := service.backupPath(args.Path)
+	if err != nil {
+		return err
+	}
+	if err := backup.CheckPath(archivePath); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(archivePath), perms.ReadWriteExecute); err != nil {
+		return err
+	}
+
+	service.backupLock.Lock()
+	defer service.backupLock.Unlock()
+
+	if service.backup != nil && service.backup.status.State == BackupRunning {
+		return errBackupRunning
+	}
+
+	// The snapshot is taken before returning so that the archive reflects the
+	// database at the time of the call.
+	current := service.DBManager.Current()
+	snapshot, err := database.NewSnapshot(current.Database)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &backupJob{
+		cancel: cancel,
+		status: BackupStatus{
+			Path:            archivePath,
+			DatabaseVersion: current.Version.String(),
+			State:           BackupRunning,
+			StartTime:       time.Now().UTC(),
+		},
+	}
+	service.backup = job
+	go service.Log.RecoverAndPanic(func() {
+		service.runBackup(ctx, job, snapshot)
+	})
+
+	*reply = job.status
+	return nil
+}
+
+// backupPath returns the path of the archive [name] in the backup directory.
+// Absolute paths and paths that leave the backup directory are refused.
+func (service *Admin) backupPath(name string) (string, error) {
+	if name == "" {
+		return "", errNoBackupPath
+	}
+	if filepath.IsAbs(name) {
+		return "", fmt.Errorf("%w: %q", errInvalidBackup, name)
+	}
+	for _, elem := range strings.Split(filepath.ToSlash(name), "/") {
+		if elem == ".." {
+			return "", fmt.Errorf("%w: %q", errInvalidBackup, name)
+		}
+	}
+	return filepath.Join(service.BackupDir, name), nil
+}
+
+// runBackup writes the archive of [job] and records its outcome.
+func (service *Admin) runBackup(ctx context.Context, job *backupJob, snapshot database.Snapshot) {
+	defer snapshot.Release()
+	defer job.cancel()
+
+	service.backupLock.Lock()
+	path := job.status.Path
+	header := backup.Header{
+		NetworkID:       service.NetworkID,
+		GenesisID:       service.GenesisID,
+		DatabaseVersion: job.status.DatabaseVersion,
+		Timestamp:       job.status.StartTime,
+	}
+	service.backupLock.Unlock()
+
+	result, err := backup.WriteFile(ctx, path, header, snapshot, func(numKeys uint64) {
+		service.backupLock.Lock()
+		job.status.NumKeys = json.Uint64(numKeys)
+		service.backupLock.Unlock()
+	})
+
+	service.backupLock.Lock()
+	defer service.backupLock.Unlock()
+
+	endTime := time.Now().UTC()
+	job.status.EndTime = &endTime
+	switch {
+	case err == nil:
+		job.status.State = BackupSucceeded
+		job.status.NumKeys = json.Uint64(result.NumKeys)
+		job.status.Checksum = result.Checksum
+		service.Log.Info("created database backup",
+			zap.String("path", path),
+			zap.String("version", header.DatabaseVersion),
+			zap.Uint64("numKeys", result.NumKeys),
+			zap.Stringer("checksum", result.Checksum),
+			zap.Duration("duration", endTime.Sub(job.status.StartTime)),
+		)
+	case errors.Is(err, context.Canceled):
+		job.status.State = BackupCancelled
+		service.Log.Info("cancelled database backup",
+			zap.String("path", path),
+		)
+	default:
+		job.status.State = BackupFailed
+		job.status.Error = err.Error()
+		service.Log.Error("failed to create database backup",
+			zap.String("path", path),
+			zap.Error(err),
+		)
+	}
+}
+
+// GetBackupStatus returns the status of the last database backup that was
+// started
+func (service *Admin) GetBackupStatus(_ *http.Request, _ *struct{}, reply *BackupStatus) error {
+	service.Log.Debug("Admin: GetBackupStatus called")
+
+	service.backupLock.Lock()
+	defer service.backupLock.Unlock()
+
+	if service.backup == nil {
+		return errNoBackup
+	}
+	*reply = service.backup.status
+	return nil
+}
+
+// CancelBackup stops the running database backup. The partially written
+// archive is removed.
+func (service *Admin) CancelBackup(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
+	service.Log.Debug("Admin: CancelBackup called")
+
+	service.backupLock.Lock()
+	defer service.backupLock.Unlock()
+
+	switch {
+	case service.backup == nil:
+		return errNoBackup
+	case service.backup.status.State != BackupRunning:
+		return errBackupNotActive
+	default:
+		service.backup.cancel()
+		return nil
+	}
+}
+
+// SetMaintenanceModeArgs are the arguments for calling SetMaintenanceMode
+type SetMaintenanceModeArgs struct {
+	Enabled bool `json:"enabled"`
+	// Reason is reported by the readiness check while the node is in
+	// maintenance mode
+	Reason string `json:"reason"`
+}
+
+// SetMaintenanceMode enters or leaves maintenance mode. While the node is in
+// maintenance mode, it reports that it isn't ready so that load balancers stop
+// routing requests to it. The node keeps participating in consensus.
+func (service *Admin) SetMaintenanceMode(_ *http.Request, args *SetMaintenanceModeArgs, _ *api.EmptyReply) error {
+	service.Log.Debug("Admin: SetMaintenanceMode called",
+		zap.Bool("enabled", args.Enabled),
+		logging.UserString("reason", args.Reason),
+	)
+
+	service.Health.SetMaintenanceMode(args.Enabled, args.Reason)
+	return nil
+}
@@ -7,14 +7,20 @@ package admin
 import (
 	"errors"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/coinflect/coinflectchain/database/manager"
 	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/utils/json"
 	"github.com/coinflect/coinflectchain/utils/logging"
+	"github.com/coinflect/coinflectchain/version"
 	"github.com/coinflect/coinflectchain/vms"
 	"github.com/coinflect/coinflectchain/vms/registry"
 )
@@ -122,3 +128,52 @@ func TestLoadVMsGetAliasesFails(t *testing.T) {
 
 	require.Equal(t, err, errOops)
 }
+
+func TestBackupDatabaseInBackground(t *testing.T) {
+	require := require.New(t)
+
+	dbManager := manager.NewMemDB(version.Semantic1_0_0)
+	db := dbManager.Current().Database
+	for i := 0; i < 10; i++ {
+		require.NoError(db.Put([]byte{byte(i)}, []byte{byte(i)}))
+	}
+	backupDir := filepath.Join(t.TempDir(), "backups")
+	admin := &Admin{Config: Config{
+		Log:       logging.NoLog{},
+		DBManager: dbManager,
+		BackupDir: backupDir,
+	}}
+
+	status := &BackupStatus{}
+	require.ErrorIs(admin.GetBackupStatus(nil, nil, status), errNoBackup)
+	require.ErrorIs(admin.BackupDatabase(nil, &BackupDatabaseArgs{}, status), errNoBackupPath)
+
+	// Archives can only be written to the backup directory
+	for _, invalidPath := range []string{
+		filepath.Join(t.TempDir(), "backup.gz"),
+		"../backup.gz",
+		"daily/../../backup.gz",
+	} {
+		err := admin.BackupDatabase(nil, &BackupDatabaseArgs{Path: invalidPath}, status)
+		require.ErrorIs(err, errInvalidBackup)
+	}
+
+	require.NoError(admin.BackupDatabase(nil, &BackupDatabaseArgs{Path: "daily/backup.gz"}, status))
+	path := filepath.Join(backupDir, "daily", "backup.gz")
+	require.Equal(path, status.Path)
+	require.Equal(version.Semantic1_0_0.String(), status.DatabaseVersion)
+
+	require.Eventually(func() bool {
+		require.NoError(admin.GetBackupStatus(nil, nil, status))
+		return status.State != BackupRunning
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(BackupSucceeded, status.State)
+	require.Equal(json.Uint64(10), status.NumKeys)
+	require.NotNil(status.EndTime)
+	_, err := os.Stat(path)
+	require.NoError(err)
+
+	require.ErrorIs(admin.CancelBackup(nil, nil, nil), errBackupNotActive)
+	// The archive isn't overwritten.
+	require.Error(admin.BackupDatabase(nil, &BackupDatabaseArgs{Path: "daily/backup.gz"}, status))
+}
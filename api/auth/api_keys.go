@@ -0,0 +1,185 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	stdjson "encoding/json"
+
+	"github.com/golang-jwt/jwt"
+
+	"go.uber.org/zap"
+
+	"github.com/coinflect/coinflectchain/utils/json"
+)
+
+const maxAPIKeyNameLen = 64
+
+var (
+	errNoAPIKeyName      = errors.New("API key name can't be empty")
+	errAPIKeyNameTooLong = fmt.Errorf("API key name can be at most %d characters", maxAPIKeyNameLen)
+	errAPIKeyExists      = errors.New("API key already exists")
+	errAPIKeyNotFound    = errors.New("API key not found")
+	errAPIKeyRevoked     = errors.New("API key was already revoked")
+)
+
+// APIKey is a named token whose access is restricted to the methods that its
+// roles may call.
+type APIKey struct {
+	Name      string      `json:"name"`
+	Roles     []Role      `json:"roles"`
+	CreatedAt json.Uint64 `json:"createdAt"`
+	Revoked   bool        `json:"revoked"`
+}
+
+// apiKey is the persisted representation of an API key
+type apiKey struct {
+	APIKey
+	// ID of the API key's token
+	TokenID string `json:"tokenID"`
+}
+
+// loadAPIKeys reads the persisted API keys from [a.db]
+func (a *auth) loadAPIKeys() error {
+	a.apiKeys = make(map[string]*apiKey)
+
+	it := a.db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		key := &apiKey{}
+		if err := stdjson.Unmarshal(it.Value(), key); err != nil {
+			return fmt.Errorf("couldn't parse API key %q: %w", it.Key(), err)
+		}
+		a.apiKeys[key.Name] = key
+	}
+	return it.Error()
+}
+
+func (a *auth) NewAPIKey(pw, name string, roles []Role) (string, error) {
+	switch {
+	case pw == "":
+		return "", errNoPassword
+	case name == "":
+		return "", errNoAPIKeyName
+	case len(name) > maxAPIKeyNameLen:
+		return "", errAPIKeyNameTooLong
+	}
+	if err := verifyRoles(roles); err != nil {
+		return "", err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if !a.password.Check(pw) {
+		return "", errWrongPassword
+	}
+	if key, ok := a.apiKeys[name]; ok && !key.Revoked {
+		return "", fmt.Errorf("%w: %q", errAPIKeyExists, name)
+	}
+
+	id, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+	key := &apiKey{
+		APIKey: APIKey{
+			Name:      name,
+			Roles:     append([]Role(nil), roles...),
+			CreatedAt: json.Uint64(a.clock.Unix()),
+		},
+		TokenID: id,
+	}
+
+	// The token is persisted before it is returned, so that it is never
+	// handed out without being revocable.
+	if err := a.putAPIKey(key); err != nil {
+		return "", err
+	}
+	a.apiKeys[name] = key
+
+	a.auditLog.Info("created API key",
+		zap.String("apiKey", name),
+		zap.Reflect("roles", roles),
+	)
+
+	claims := endpointClaims{
+		StandardClaims: jwt.StandardClaims{
+			Id:      id,
+			Subject: name,
+		},
+		Endpoints: []string{"*"},
+		Roles:     roles,
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims)
+	return token.SignedString(a.password.Password[:])
+}
+
+func (a *auth) RevokeAPIKey(pw, name string) error {
+	if pw == "" {
+		return errNoPassword
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if !a.password.Check(pw) {
+		return errWrongPassword
+	}
+	key, ok := a.apiKeys[name]
+	switch {
+	case !ok:
+		return fmt.Errorf("%w: %q", errAPIKeyNotFound, name)
+	case key.Revoked:
+		return fmt.Errorf("%w: %q", errAPIKeyRevoked, name)
+	}
+
+	revokedKey := *key
+	revokedKey.Revoked = true
+	if err := a.putAPIKey(&revokedKey); err != nil {
+		return err
+	}
+	a.apiKeys[name] = &revokedKey
+
+	a.auditLog.Info("revoked API key",
+		zap.String("apiKey", name),
+	)
+	return nil
+}
+
+func (a *auth) ListAPIKeys(pw string) ([]APIKey, error) {
+	if pw == "" {
+		return nil, errNoPassword
+	}
+
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	if !a.password.Check(pw) {
+		return nil, errWrongPassword
+	}
+
+	keys := make([]APIKey, 0, len(a.apiKeys))
+	for _, key := range a.apiKeys {
+		keys = append(keys, key.APIKey)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Name < keys[j].Name
+	})
+	return keys, nil
+}
+
+// Assumes [a.lock] is held
+func (a *auth) putAPIKey(key *apiKey) error {
+	keyBytes, err := stdjson.Marshal(key)
+	if err != nil {
+		return err
+	}
+	return a.db.Put([]byte(key.Name), keyBytes)
+}
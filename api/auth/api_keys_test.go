@@ -0,0 +1,207 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/database/memdb"
+	"github.com/coinflect/coinflectchain/utils/logging"
+)
+
+func serveWithToken(h http.Handler, tokenStr, endpoint, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:9650"+endpoint, strings.NewReader(body))
+	req.Header.Add(headerKey, headerValStart+tokenStr)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestAPIKeyRoles(t *testing.T) {
+	require := require.New(t)
+
+	auth := newTestAuth(t)
+	readOnlyToken, err := auth.NewAPIKey(testPassword, "monitoring", []Role{ReadOnlyRole})
+	require.NoError(err)
+	keystoreToken, err := auth.NewAPIKey(testPassword, "wallet", []Role{KeystoreRole})
+	require.NoError(err)
+	adminToken, err := auth.NewAPIKey(testPassword, "ops", []Role{AdminRole})
+	require.NoError(err)
+
+	var bodies []string
+	h := auth.WrapHandler(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(err)
+		bodies = append(bodies, string(body))
+	}))
+
+	getBalance := `{"jsonrpc":"2.0","id":1,"method":"platform.getBalance","params":{}}`
+	addValidator := `{"jsonrpc":"2.0","id":1,"method":"platform.AddValidator","params":{}}`
+	setLoggerLevel := `{"jsonrpc":"2.0","id":1,"method":"admin.setLoggerLevel","params":{}}`
+
+	tests := []struct {
+		token        string
+		endpoint     string
+		body         string
+		expectedCode int
+	}{
+		{readOnlyToken, "/ext/bc/P", getBalance, http.StatusOK},
+		{readOnlyToken, "/ext/bc/P", addValidator, http.StatusUnauthorized},
+		{readOnlyToken, "/ext/admin", setLoggerLevel, http.StatusUnauthorized},
+		{readOnlyToken, "/ext/bc/P", "[" + getBalance + "," + addValidator + "]", http.StatusUnauthorized},
+		{keystoreToken, "/ext/bc/P", getBalance, http.StatusOK},
+		{keystoreToken, "/ext/bc/P", addValidator, http.StatusOK},
+		{keystoreToken, "/ext/admin", setLoggerLevel, http.StatusUnauthorized},
+		{adminToken, "/ext/bc/P", addValidator, http.StatusOK},
+		{adminToken, "/ext/admin", setLoggerLevel, http.StatusOK},
+	}
+	for _, test := range tests {
+		rr := serveWithToken(h, test.token, test.endpoint, test.body)
+		require.Equal(test.expectedCode, rr.Code, test.body)
+		if test.expectedCode == http.StatusUnauthorized {
+			require.Contains(rr.Body.String(), errMethodNotPermitted.Error())
+		}
+	}
+
+	// The handler reads the complete body of the allowed calls.
+	require.Equal([]string{getBalance, getBalance, addValidator, addValidator, setLoggerLevel}, bodies)
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	require := require.New(t)
+
+	auth := newTestAuth(t)
+	tokenStr, err := auth.NewAPIKey(testPassword, "ops", []Role{AdminRole})
+	require.NoError(err)
+	require.NoError(auth.AuthenticateToken(tokenStr, "/ext/admin"))
+
+	_, err = auth.NewAPIKey(testPassword, "ops", []Role{AdminRole})
+	require.ErrorIs(err, errAPIKeyExists)
+
+	require.ErrorIs(auth.RevokeAPIKey("notThePassword", "ops"), errWrongPassword)
+	require.ErrorIs(auth.RevokeAPIKey(testPassword, "unknown"), errAPIKeyNotFound)
+	require.NoError(auth.RevokeAPIKey(testPassword, "ops"))
+	require.ErrorIs(auth.RevokeAPIKey(testPassword, "ops"), errAPIKeyRevoked)
+	require.ErrorIs(auth.AuthenticateToken(tokenStr, "/ext/admin"), errTokenRevoked)
+
+	// Re-creating a revoked API key doesn't restore its previous token.
+	newTokenStr, err := auth.NewAPIKey(testPassword, "ops", []Role{ReadOnlyRole})
+	require.NoError(err)
+	require.NoError(auth.AuthenticateToken(newTokenStr, "/ext/admin"))
+	require.ErrorIs(auth.AuthenticateToken(tokenStr, "/ext/admin"), errTokenRevoked)
+}
+
+func TestAPIKeysArePersisted(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	a, err := NewFromHash(logging.NoLog{}, logging.NoLog{}, "auth", hashedPassword, db, nil)
+	require.NoError(err)
+
+	revokedToken, err := a.NewAPIKey(testPassword, "b", []Role{ReadOnlyRole})
+	require.NoError(err)
+	tokenStr, err := a.NewAPIKey(testPassword, "a", []Role{KeystoreRole, ReadOnlyRole})
+	require.NoError(err)
+	require.NoError(a.RevokeAPIKey(testPassword, "b"))
+
+	// A restarted node loads the API keys from its database.
+	a, err = NewFromHash(logging.NoLog{}, logging.NoLog{}, "auth", hashedPassword, db, nil)
+	require.NoError(err)
+	require.NoError(a.AuthenticateToken(tokenStr, "/ext/bc/X"))
+	require.ErrorIs(a.AuthenticateToken(revokedToken, "/ext/bc/X"), errTokenRevoked)
+
+	_, err = a.ListAPIKeys("notThePassword")
+	require.ErrorIs(err, errWrongPassword)
+
+	keys, err := a.ListAPIKeys(testPassword)
+	require.NoError(err)
+	require.Len(keys, 2)
+	require.Equal("a", keys[0].Name)
+	require.Equal([]Role{KeystoreRole, ReadOnlyRole}, keys[0].Roles)
+	require.False(keys[0].Revoked)
+	require.Equal("b", keys[1].Name)
+	require.True(keys[1].Revoked)
+}
+
+func TestNewAPIKeyInvalidArgs(t *testing.T) {
+	auth := newTestAuth(t)
+
+	tests := []struct {
+		name        string
+		pw          string
+		keyName     string
+		roles       []Role
+		expectedErr error
+	}{
+		{
+			name:        "no password",
+			keyName:     "ops",
+			roles:       []Role{AdminRole},
+			expectedErr: errNoPassword,
+		},
+		{
+			name:        "wrong password",
+			pw:          "notThePassword",
+			keyName:     "ops",
+			roles:       []Role{AdminRole},
+			expectedErr: errWrongPassword,
+		},
+		{
+			name:        "no name",
+			pw:          testPassword,
+			roles:       []Role{AdminRole},
+			expectedErr: errNoAPIKeyName,
+		},
+		{
+			name:        "name too long",
+			pw:          testPassword,
+			keyName:     strings.Repeat("a", maxAPIKeyNameLen+1),
+			roles:       []Role{AdminRole},
+			expectedErr: errAPIKeyNameTooLong,
+		},
+		{
+			name:        "no roles",
+			pw:          testPassword,
+			keyName:     "ops",
+			expectedErr: errNoRoles,
+		},
+		{
+			name:        "unknown role",
+			pw:          testPassword,
+			keyName:     "ops",
+			roles:       []Role{"root"},
+			expectedErr: errUnknownRole,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := auth.NewAPIKey(test.pw, test.keyName, test.roles)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestCanCall(t *testing.T) {
+	require := require.New(t)
+
+	require.True(canCall([]Role{ReadOnlyRole}, "info.peers"))
+	require.False(canCall([]Role{ReadOnlyRole}, "avm.send"))
+	require.False(canCall([]Role{ReadOnlyRole}, "avm.Send"))
+	require.True(canCall([]Role{ReadOnlyRole, KeystoreRole}, "avm.send"))
+	require.True(canCall([]Role{KeystoreRole}, "keystore.createUser"))
+	require.False(canCall([]Role{KeystoreRole}, "ipcs.publishBlockchain"))
+	require.True(canCall([]Role{AdminRole}, "ipcs.publishBlockchain"))
+	require.False(canCall(nil, "info.peers"))
+
+	require.True(isPrivileged("admin.setLoggerLevel"))
+	require.True(isPrivileged("platform.addValidator"))
+	require.False(isPrivileged("platform.getBalance"))
+}
@@ -5,20 +5,27 @@
 package auth
 
 import (
+	"bytes"
+	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"path"
 	"strings"
 	"sync"
 	"time"
 
+	stdjson "encoding/json"
+
 	"github.com/golang-jwt/jwt"
 
-	"github.com/gorilla/rpc/v2"
+	"go.uber.org/zap"
 
+	"github.com/coinflect/coinflectchain/api/openrpc"
+	"github.com/coinflect/coinflectchain/database"
 	"github.com/coinflect/coinflectchain/utils/json"
 	"github.com/coinflect/coinflectchain/utils/logging"
 	"github.com/coinflect/coinflectchain/utils/password"
@@ -36,6 +43,11 @@ const (
 	defaultTokenLifespan = time.Hour * 12
 
 	maxEndpoints = 128
+
+	// maxBodySize is the largest request body that is parsed to find the
+	// JSON-RPC methods that are called. Calls with larger bodies may only be
+	// made with tokens that may call every method.
+	maxBodySize = 1024 * 1024 // 1 MiB
 )
 
 var (
@@ -53,6 +65,8 @@ var (
 	errNoPassword                  = errors.New("no password")
 	errNoEndpoints                 = errors.New("must name at least one endpoint")
 	errTooManyEndpoints            = fmt.Errorf("can only name at most %d endpoints", maxEndpoints)
+	errMethodNotPermitted          = errors.New("the provided auth token does not allow calling this method")
+	errUnknownMethods              = errors.New("couldn't determine the methods called by the request")
 
 	_ Auth = (*auth)(nil)
 )
@@ -78,14 +92,28 @@ type Auth interface {
 	// [newPW] is the new password. It can't be the empty string and it can't be
 	//         unreasonably long.
 	// Changing the password makes tokens issued under a previous password
-	// invalid.
+	// invalid, including the tokens of API keys.
 	ChangePassword(oldPW, newPW string) error
 
+	// Create the API key [name] with [roles] and return its token. The token
+	// doesn't expire, but it stops being accepted once the API key is revoked.
+	// A revoked API key can be re-created with the same name.
+	NewAPIKey(pw, name string, roles []Role) (string, error)
+
+	// Revoke the API key [name]. Its token will not be accepted as
+	// authorization for future API calls.
+	RevokeAPIKey(pw, name string) error
+
+	// Return the API keys, including the revoked ones, sorted by name.
+	ListAPIKeys(pw string) ([]APIKey, error)
+
 	// Create the API endpoint for this auth handler.
 	CreateHandler() (http.Handler, error)
 
 	// WrapHandler wraps an http.Handler. Before passing a request to the
-	// provided handler, the auth token is authenticated.
+	// provided handler, the auth token is authenticated. Requests without an
+	// auth token are authorized with the roles of their client certificate's
+	// subject, if any.
 	WrapHandler(h http.Handler) http.Handler
 }
 
@@ -93,7 +121,9 @@ type auth struct {
 	// Used to mock time.
 	clock mockable.Clock
 
-	log      logging.Logger
+	log logging.Logger
+	// Records the privileged calls and the changes to the API keys.
+	auditLog logging.Logger
 	endpoint string
 
 	lock sync.RWMutex
@@ -101,24 +131,57 @@ type auth struct {
 	password password.Hash
 	// Set of token IDs that have been revoked
 	revoked map[string]struct{}
+	// Persists the API keys
+	db database.Database
+	// API key name --> API key
+	apiKeys map[string]*apiKey
+	// Roles of the clients that authenticate with a certificate
+	certRoles CertificateRoles
 }
 
-func New(log logging.Logger, endpoint, pw string) (Auth, error) {
+// New returns a new Auth that persists its API keys in [db] and records the
+// privileged calls in [auditLog]. Clients that present a verified certificate
+// whose subject is in [certRoles] are authorized with the associated roles.
+func New(
+	log,
+	auditLog logging.Logger,
+	endpoint,
+	pw string,
+	db database.Database,
+	certRoles CertificateRoles,
+) (Auth, error) {
 	a := &auth{
-		log:      log,
-		endpoint: endpoint,
-		revoked:  make(map[string]struct{}),
+		log:       log,
+		auditLog:  auditLog,
+		endpoint:  endpoint,
+		revoked:   make(map[string]struct{}),
+		db:        db,
+		certRoles: certRoles,
+	}
+	if err := a.password.Set(pw); err != nil {
+		return nil, err
 	}
-	return a, a.password.Set(pw)
+	return a, a.loadAPIKeys()
 }
 
-func NewFromHash(log logging.Logger, endpoint string, pw password.Hash) Auth {
-	return &auth{
-		log:      log,
-		endpoint: endpoint,
-		password: pw,
-		revoked:  make(map[string]struct{}),
+func NewFromHash(
+	log,
+	auditLog logging.Logger,
+	endpoint string,
+	pw password.Hash,
+	db database.Database,
+	certRoles CertificateRoles,
+) (Auth, error) {
+	a := &auth{
+		log:       log,
+		auditLog:  auditLog,
+		endpoint:  endpoint,
+		password:  pw,
+		revoked:   make(map[string]struct{}),
+		db:        db,
+		certRoles: certRoles,
 	}
+	return a, a.loadAPIKeys()
 }
 
 func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (string, error) {
@@ -146,11 +209,10 @@ func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (
 		}
 	}
 
-	idBytes := [tokenIDByteLen]byte{}
-	if _, err := rand.Read(idBytes[:]); err != nil {
-		return "", fmt.Errorf("failed to generate the unique token ID due to %w", err)
+	id, err := newTokenID()
+	if err != nil {
+		return "", err
 	}
-	id := base64.URLEncoding.EncodeToString(idBytes[:])
 
 	claims := endpointClaims{
 		StandardClaims: jwt.StandardClaims{
@@ -202,12 +264,19 @@ func (a *auth) RevokeToken(tokenStr, pw string) error {
 }
 
 func (a *auth) AuthenticateToken(tokenStr, url string) error {
+	_, err := a.authenticateToken(tokenStr, url)
+	return err
+}
+
+// authenticateToken returns the claims of [tokenStr] if it gives access to
+// [url].
+func (a *auth) authenticateToken(tokenStr, url string) (*endpointClaims, error) {
 	a.lock.RLock()
 	defer a.lock.RUnlock()
 
 	token, err := jwt.ParseWithClaims(tokenStr, &endpointClaims{}, a.getTokenKey)
 	if err != nil { // Probably because signature wrong
-		return err
+		return nil, err
 	}
 
 	// Make sure this token gives access to the requested endpoint
@@ -215,20 +284,28 @@ func (a *auth) AuthenticateToken(tokenStr, url string) error {
 	if !ok {
 		// Error is intentionally dropped here as there is nothing left to do
 		// with it.
-		return fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
+		return nil, fmt.Errorf("expected auth token's claims to be type endpointClaims but is %T", token.Claims)
 	}
 
 	_, revoked := a.revoked[claims.Id]
 	if revoked {
-		return errTokenRevoked
+		return nil, errTokenRevoked
+	}
+
+	// Tokens of API keys are only valid while their API key exists.
+	if len(claims.Roles) > 0 {
+		key, ok := a.apiKeys[claims.Subject]
+		if !ok || key.Revoked || key.TokenID != claims.Id {
+			return nil, errTokenRevoked
+		}
 	}
 
 	for _, endpoint := range claims.Endpoints {
 		if endpoint == "*" || strings.HasSuffix(url, endpoint) {
-			return nil
+			return claims, nil
 		}
 	}
-	return errTokenInsufficientPermission
+	return nil, errTokenInsufficientPermission
 }
 
 func (a *auth) ChangePassword(oldPW, newPW string) error {
@@ -256,7 +333,7 @@ func (a *auth) ChangePassword(oldPW, newPW string) error {
 }
 
 func (a *auth) CreateHandler() (http.Handler, error) {
-	server := rpc.NewServer()
+	server := openrpc.NewServer()
 	codec := json.NewCodec()
 	server.RegisterCodec(codec, "application/json")
 	server.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -277,7 +354,16 @@ func (a *auth) WrapHandler(h http.Handler) http.Handler {
 		// Should be "Bearer AUTH.TOKEN.HERE"
 		rawHeader := r.Header.Get(headerKey)
 		if rawHeader == "" {
-			writeUnauthorizedResponse(w, errNoToken)
+			subject, ok := CertificateSubject(r)
+			if !ok {
+				writeUnauthorizedResponse(w, errNoToken)
+				return
+			}
+			if err := a.authorizeCertificate(r, subject); err != nil {
+				writeUnauthorizedResponse(w, err)
+				return
+			}
+			h.ServeHTTP(w, r)
 			return
 		}
 		if !strings.HasPrefix(rawHeader, headerValStart) {
@@ -289,7 +375,23 @@ func (a *auth) WrapHandler(h http.Handler) http.Handler {
 		// Returns actual auth token. Slice guaranteed to not go OOB
 		tokenStr := rawHeader[len(headerValStart):]
 
-		if err := a.AuthenticateToken(tokenStr, r.URL.Path); err != nil {
+		claims, err := a.authenticateToken(tokenStr, r.URL.Path)
+		if err != nil {
+			writeUnauthorizedResponse(w, err)
+			return
+		}
+		client := "jti:" + claims.Id
+		if claims.Subject != "" {
+			client = "sub:" + claims.Subject
+		}
+		r = r.WithContext(WithClient(r.Context(), client))
+		err = a.authorizeMethods(
+			r,
+			claims.Roles,
+			zap.String("apiKey", claims.Subject),
+			zap.String("tokenID", claims.Id),
+		)
+		if err != nil {
 			writeUnauthorizedResponse(w, err)
 			return
 		}
@@ -298,6 +400,134 @@ func (a *auth) WrapHandler(h http.Handler) http.Handler {
 	})
 }
 
+type clientContextKey struct{}
+
+// WithClient returns a copy of [ctx] that records that the request was made by
+// [client], whose auth token was verified.
+func WithClient(ctx context.Context, client string) context.Context {
+	return context.WithValue(ctx, clientContextKey{}, client)
+}
+
+// Client returns the client recorded in [ctx] by WithClient, if any. Clients
+// are identified by the API key or, for tokens not issued for an API key, the
+// ID of their token.
+func Client(ctx context.Context) (string, bool) {
+	client, ok := ctx.Value(clientContextKey{}).(string)
+	return client, ok
+}
+
+// authorizeCertificate returns an error if the client certificate with
+// [subject] doesn't allow calling the JSON-RPC methods called by [r].
+func (a *auth) authorizeCertificate(r *http.Request, subject string) error {
+	roles, ok := a.certRoles[subject]
+	if !ok {
+		a.auditLog.Info("unauthorized client certificate",
+			zap.String("certificate", subject),
+			zap.String("endpoint", r.URL.Path),
+			zap.String("remoteAddr", r.RemoteAddr),
+		)
+		return fmt.Errorf("%w: %q", errCertificateNotAuthorized, subject)
+	}
+	return a.authorizeMethods(r, roles, zap.String("certificate", subject))
+}
+
+// authorizeMethods returns an error if a JSON-RPC method called by [r] may
+// not be called by a client with [roles]. A client without roles may call
+// every method. Every privileged call, and every call whose methods couldn't
+// be determined, is recorded in the audit log along with the fields that
+// identify the client.
+func (a *auth) authorizeMethods(r *http.Request, roles []Role, client ...zap.Field) error {
+	restricted := len(roles) > 0
+	methods, ok := requestMethods(r)
+	if !ok {
+		// The body may call privileged methods, so it's recorded even if the
+		// client may call every method.
+		fields := make([]zap.Field, 0, len(client)+3)
+		fields = append(fields, client...)
+		fields = append(fields,
+			zap.String("endpoint", r.URL.Path),
+			zap.String("remoteAddr", r.RemoteAddr),
+			zap.Bool("allowed", !restricted),
+		)
+		a.auditLog.Info("API call with unknown methods", fields...)
+		if restricted {
+			return errUnknownMethods
+		}
+		return nil
+	}
+
+	for _, method := range methods {
+		allowed := !restricted || canCall(roles, method)
+		if isPrivileged(method) || !allowed {
+			fields := make([]zap.Field, 0, len(client)+4)
+			fields = append(fields, client...)
+			fields = append(fields,
+				zap.String("method", method),
+				zap.String("endpoint", r.URL.Path),
+				zap.String("remoteAddr", r.RemoteAddr),
+				zap.Bool("allowed", allowed),
+			)
+			a.auditLog.Info("privileged API call", fields...)
+		}
+		if !allowed {
+			return fmt.Errorf("%w: %q", errMethodNotPermitted, method)
+		}
+	}
+	return nil
+}
+
+// requestMethods returns the JSON-RPC methods called by [r]. The body of [r]
+// is replaced so that it can still be read by the handler. Returns false if
+// the body couldn't be parsed.
+func requestMethods(r *http.Request) ([]string, bool) {
+	if r.Method != http.MethodPost || r.Body == nil {
+		return nil, true
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: io.MultiReader(bytes.NewReader(body), r.Body),
+		Closer: r.Body,
+	}
+	if err != nil || len(body) > maxBodySize {
+		return nil, false
+	}
+
+	type request struct {
+		Method string `json:"method"`
+	}
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var requests []request
+		if err := stdjson.Unmarshal(body, &requests); err != nil {
+			return nil, false
+		}
+		methods := make([]string, len(requests))
+		for i, req := range requests {
+			methods[i] = req.Method
+		}
+		return methods, true
+	}
+
+	var req request
+	if err := stdjson.Unmarshal(body, &req); err != nil {
+		return nil, false
+	}
+	return []string{req.Method}, true
+}
+
+// newTokenID returns a new random token ID
+func newTokenID() (string, error) {
+	idBytes := [tokenIDByteLen]byte{}
+	if _, err := rand.Read(idBytes[:]); err != nil {
+		return "", fmt.Errorf("failed to generate the unique token ID due to %w", err)
+	}
+	return base64.URLEncoding.EncodeToString(idBytes[:]), nil
+}
+
 // getTokenKey returns the key to use when making and parsing tokens
 func (a *auth) getTokenKey(t *jwt.Token) (interface{}, error) {
 	if t.Method != jwt.SigningMethodHS256 {
@@ -0,0 +1,37 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var errCertificateNotAuthorized = errors.New("the provided client certificate isn't authorized")
+
+// CertificateRoles maps the subjects of client certificates to the roles that
+// the clients presenting them are authorized with. Subjects are formatted as
+// by pkix.Name.String, e.g. "CN=automation,O=Coinflect".
+type CertificateRoles map[string][]Role
+
+func (c CertificateRoles) Verify() error {
+	for subject, roles := range c {
+		if err := verifyRoles(roles); err != nil {
+			return fmt.Errorf("invalid roles for certificate %q: %w", subject, err)
+		}
+	}
+	return nil
+}
+
+// CertificateSubject returns the subject of the client certificate of [r].
+// Returns false if the client didn't present a certificate that was verified
+// during the TLS handshake.
+func CertificateSubject(r *http.Request) (string, bool) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+	return r.TLS.VerifiedChains[0][0].Subject.String(), true
+}
@@ -0,0 +1,175 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/database/memdb"
+	"github.com/coinflect/coinflectchain/utils/logging"
+)
+
+// newCertificateRequest returns a request calling [method] from a client that
+// presented a verified certificate with [subject]
+func newCertificateRequest(subject pkix.Name, method string) *http.Request {
+	body := `{"jsonrpc":"2.0","method":"` + method + `","params":{},"id":1}`
+	req := httptest.NewRequest(http.MethodPost, "http://127.0.0.1:9650/ext/bc/X", strings.NewReader(body))
+	req.TLS = &tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{
+			{Subject: subject},
+		}},
+	}
+	return req
+}
+
+func TestWrapHandlerCertificate(t *testing.T) {
+	var (
+		automation = pkix.Name{CommonName: "automation", Organization: []string{"Coinflect"}}
+		unknown    = pkix.Name{CommonName: "unknown"}
+	)
+	a, err := NewFromHash(
+		logging.NoLog{},
+		logging.NoLog{},
+		"auth",
+		hashedPassword,
+		memdb.New(),
+		CertificateRoles{
+			automation.String(): {ReadOnlyRole},
+		},
+	)
+	require.NoError(t, err)
+	wrappedHandler := a.WrapHandler(dummyHandler)
+
+	tests := []struct {
+		name         string
+		req          *http.Request
+		expectedCode int
+		expectedErr  error
+	}{
+		{
+			name:         "read-only method",
+			req:          newCertificateRequest(automation, "avm.getBalance"),
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "privileged method",
+			req:          newCertificateRequest(automation, "avm.send"),
+			expectedCode: http.StatusUnauthorized,
+			expectedErr:  errMethodNotPermitted,
+		},
+		{
+			name:         "unknown subject",
+			req:          newCertificateRequest(unknown, "avm.getBalance"),
+			expectedCode: http.StatusUnauthorized,
+			expectedErr:  errCertificateNotAuthorized,
+		},
+		{
+			name: "no certificate",
+			req: httptest.NewRequest(
+				http.MethodPost,
+				"http://127.0.0.1:9650/ext/bc/X",
+				strings.NewReader(`{"jsonrpc":"2.0","method":"avm.getBalance","params":{},"id":1}`),
+			),
+			expectedCode: http.StatusUnauthorized,
+			expectedErr:  errNoToken,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			rr := httptest.NewRecorder()
+			wrappedHandler.ServeHTTP(rr, test.req)
+			require.Equal(test.expectedCode, rr.Code)
+			if test.expectedErr != nil {
+				require.Contains(rr.Body.String(), test.expectedErr.Error())
+			}
+		})
+	}
+}
+
+func TestWrapHandlerTokenOverridesCertificate(t *testing.T) {
+	require := require.New(t)
+
+	automation := pkix.Name{CommonName: "automation"}
+	a, err := NewFromHash(
+		logging.NoLog{},
+		logging.NoLog{},
+		"auth",
+		hashedPassword,
+		memdb.New(),
+		CertificateRoles{
+			automation.String(): {AdminRole},
+		},
+	)
+	require.NoError(err)
+
+	tokenStr, err := a.NewAPIKey(testPassword, "reader", []Role{ReadOnlyRole})
+	require.NoError(err)
+
+	// The token is used even though the certificate allows more methods
+	req := newCertificateRequest(automation, "avm.send")
+	req.Header.Add("Authorization", "Bearer "+tokenStr)
+	rr := httptest.NewRecorder()
+	a.WrapHandler(dummyHandler).ServeHTTP(rr, req)
+	require.Equal(http.StatusUnauthorized, rr.Code)
+	require.Contains(rr.Body.String(), errMethodNotPermitted.Error())
+}
+
+func TestWrapHandlerAuditsUnknownMethods(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	automation := pkix.Name{CommonName: "automation"}
+	auditLog := logging.NewMockLogger(ctrl)
+	a, err := NewFromHash(
+		logging.NoLog{},
+		auditLog,
+		"auth",
+		hashedPassword,
+		memdb.New(),
+		CertificateRoles{
+			automation.String(): {ReadOnlyRole},
+		},
+	)
+	require.NoError(err)
+	wrappedHandler := a.WrapHandler(dummyHandler)
+
+	// Calls whose methods can't be determined are audited, even if the client
+	// may call every method.
+	auditLog.EXPECT().Info("API call with unknown methods", gomock.Any()).Times(2)
+
+	tokenStr, err := a.NewToken(testPassword, defaultTokenLifespan, []string{"/ext/bc/X"})
+	require.NoError(err)
+	rr := serveWithToken(wrappedHandler, tokenStr, "/ext/bc/X", "not json")
+	require.Equal(http.StatusOK, rr.Code)
+
+	req := newCertificateRequest(automation, "")
+	req.Body = io.NopCloser(strings.NewReader("not json"))
+	rr = httptest.NewRecorder()
+	wrappedHandler.ServeHTTP(rr, req)
+	require.Equal(http.StatusUnauthorized, rr.Code)
+	require.Contains(rr.Body.String(), errUnknownMethods.Error())
+}
+
+func TestCertificateRolesVerify(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(CertificateRoles{"CN=a": {AdminRole}}.Verify())
+	require.ErrorIs(CertificateRoles{"CN=a": nil}.Verify(), errNoRoles)
+	require.ErrorIs(CertificateRoles{"CN=a": {"root"}}.Verify(), errUnknownRole)
+}
@@ -16,4 +16,9 @@ type endpointClaims struct {
 	// If endpoints has an element "*", allows access to all API endpoints
 	// In this case, "*" should be the only element of [endpoints]
 	Endpoints []string `json:"endpoints,omitempty"`
+
+	// Roles of the API key the token was issued for. The name of the API key
+	// is the token's subject. Tokens that weren't issued for an API key don't
+	// have any roles and may call every method.
+	Roles []Role `json:"roles,omitempty"`
 }
@@ -0,0 +1,147 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// ReadOnlyRole may call the methods that don't change the state of the
+	// node or of its chains.
+	ReadOnlyRole Role = "read-only"
+	// KeystoreRole may call the read-only methods, the methods that use the
+	// keystore's users and the methods that issue transactions.
+	KeystoreRole Role = "keystore"
+	// AdminRole may call every method.
+	AdminRole Role = "admin"
+
+	maxRoles = 3
+)
+
+var (
+	errNoRoles      = errors.New("must name at least one role")
+	errTooManyRoles = fmt.Errorf("can only name at most %d roles", maxRoles)
+	errUnknownRole  = errors.New("unknown role")
+
+	// methodRoles maps the privileged methods to the roles, other than
+	// AdminRole, that may call them. Methods that aren't privileged may be
+	// called by every role. An entry ending with ".*" matches every method of
+	// the service.
+	methodRoles = map[string][]Role{
+		"admin.*": nil,
+		"ipcs.*":  nil,
+
+		"keystore.*": {KeystoreRole},
+		"wallet.*":   {KeystoreRole},
+
+		"avm.issueTx":                {KeystoreRole},
+		"avm.issueStopVertex":        {KeystoreRole},
+		"avm.createAsset":            {KeystoreRole},
+		"avm.createFixedCapAsset":    {KeystoreRole},
+		"avm.createVariableCapAsset": {KeystoreRole},
+		"avm.createNFTAsset":         {KeystoreRole},
+		"avm.createAddress":          {KeystoreRole},
+		"avm.listAddresses":          {KeystoreRole},
+		"avm.exportKey":              {KeystoreRole},
+		"avm.importKey":              {KeystoreRole},
+		"avm.send":                   {KeystoreRole},
+		"avm.sendMultiple":           {KeystoreRole},
+		"avm.mint":                   {KeystoreRole},
+		"avm.sendNFT":                {KeystoreRole},
+		"avm.mintNFT":                {KeystoreRole},
+		"avm.import":                 {KeystoreRole},
+		"avm.export":                 {KeystoreRole},
+
+		"platform.issueTx":            {KeystoreRole},
+		"platform.exportKey":          {KeystoreRole},
+		"platform.importKey":          {KeystoreRole},
+		"platform.createAddress":      {KeystoreRole},
+		"platform.listAddresses":      {KeystoreRole},
+		"platform.addValidator":       {KeystoreRole},
+		"platform.addDelegator":       {KeystoreRole},
+		"platform.addSubnetValidator": {KeystoreRole},
+		"platform.createSubnet":       {KeystoreRole},
+		"platform.exportCFLT":         {KeystoreRole},
+		"platform.importCFLT":         {KeystoreRole},
+		"platform.createBlockchain":   {KeystoreRole},
+	}
+)
+
+// Role of an API key. The role determines which methods the key may call.
+type Role string
+
+func (r Role) Verify() error {
+	switch r {
+	case ReadOnlyRole, KeystoreRole, AdminRole:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", errUnknownRole, r)
+	}
+}
+
+func verifyRoles(roles []Role) error {
+	switch l := len(roles); {
+	case l == 0:
+		return errNoRoles
+	case l > maxRoles:
+		return errTooManyRoles
+	}
+	for _, role := range roles {
+		if err := role.Verify(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isPrivileged returns true if [method] may not be called by every role.
+func isPrivileged(method string) bool {
+	_, privileged := lookupMethodRoles(normalizeMethod(method))
+	return privileged
+}
+
+// canCall returns true if any of [roles] may call [method].
+func canCall(roles []Role, method string) bool {
+	allowedRoles, privileged := lookupMethodRoles(normalizeMethod(method))
+	for _, role := range roles {
+		if role == AdminRole || !privileged {
+			return true
+		}
+		for _, allowedRole := range allowedRoles {
+			if role == allowedRole {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func lookupMethodRoles(method string) ([]Role, bool) {
+	if roles, ok := methodRoles[method]; ok {
+		return roles, true
+	}
+	service, _, ok := strings.Cut(method, ".")
+	if !ok {
+		return nil, false
+	}
+	roles, ok := methodRoles[service+".*"]
+	return roles, ok
+}
+
+// normalizeMethod lowercases the first letter of the method's name, as the
+// JSON-RPC server accepts both "avm.send" and "avm.Send".
+func normalizeMethod(method string) string {
+	service, name, ok := strings.Cut(method, ".")
+	if !ok || name == "" {
+		return method
+	}
+	r, size := utf8.DecodeRuneInString(name)
+	return service + "." + string(unicode.ToLower(r)) + name[size:]
+}
@@ -62,3 +62,44 @@ func (s *Service) ChangePassword(_ *http.Request, args *ChangePasswordArgs, _ *a
 
 	return s.auth.ChangePassword(args.OldPassword, args.NewPassword)
 }
+
+type NewAPIKeyArgs struct {
+	Password
+	// Name that identifies the API key
+	Name string `json:"name"`
+	// Roles of the API key, which determine the methods it may call.
+	// [Roles] must have between 1 and [maxRoles] elements
+	Roles []Role `json:"roles"`
+}
+
+func (s *Service) NewAPIKey(_ *http.Request, args *NewAPIKeyArgs, reply *Token) error {
+	s.auth.log.Debug("Auth: NewAPIKey called")
+
+	var err error
+	reply.Token, err = s.auth.NewAPIKey(args.Password.Password, args.Name, args.Roles)
+	return err
+}
+
+type RevokeAPIKeyArgs struct {
+	Password
+	// Name of the API key to revoke
+	Name string `json:"name"`
+}
+
+func (s *Service) RevokeAPIKey(_ *http.Request, args *RevokeAPIKeyArgs, _ *api.EmptyReply) error {
+	s.auth.log.Debug("Auth: RevokeAPIKey called")
+
+	return s.auth.RevokeAPIKey(args.Password.Password, args.Name)
+}
+
+type ListAPIKeysReply struct {
+	APIKeys []APIKey `json:"apiKeys"`
+}
+
+func (s *Service) ListAPIKeys(_ *http.Request, args *Password, reply *ListAPIKeysReply) error {
+	s.auth.log.Debug("Auth: ListAPIKeys called")
+
+	var err error
+	reply.APIKeys, err = s.auth.ListAPIKeys(args.Password)
+	return err
+}
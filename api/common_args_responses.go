@@ -20,6 +20,27 @@ type JSONTxID struct {
 	TxID ids.ID `json:"txID"`
 }
 
+// TxEvent is sent to the subscribers of a chain's events when a transaction
+// that matches their filter is accepted
+type TxEvent struct {
+	TxID ids.ID `json:"txID"`
+	// Height of the block that accepted the transaction. Not set on chains
+	// that aren't linear.
+	Height *json.Uint64 `json:"height,omitempty"`
+	// Addresses of the filter that the transaction's outputs are sent to
+	Addresses []string `json:"addresses"`
+	// Assets sent to [Addresses] by the transaction
+	Assets []AssetAmount `json:"assets"`
+	// NodeIDs of the filter that the transaction references
+	NodeIDs []ids.NodeID `json:"nodeIDs,omitempty"`
+}
+
+// AssetAmount is an amount of an asset
+type AssetAmount struct {
+	AssetID ids.ID      `json:"assetID"`
+	Amount  json.Uint64 `json:"amount"`
+}
+
 // UserPass contains a username and a password
 type UserPass struct {
 	Username string `json:"username"`
@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package events publishes the events of the node's chains to WebSocket
+// subscribers.
+package events
+
+import (
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/utils/formatting"
+	"github.com/coinflect/coinflectchain/utils/json"
+)
+
+const (
+	// ValidatorsTopic is the topic of the changes to the Primary Network's
+	// validator set, as maintained by the P-chain.
+	ValidatorsTopic = "validators"
+
+	acceptedTopicPrefix = "accepted/"
+	txStatusTopicPrefix = "tx/"
+
+	// ValidatorAdded is the change of a validator joining the validator set
+	ValidatorAdded = "added"
+	// ValidatorRemoved is the change of a validator leaving the validator set
+	ValidatorRemoved = "removed"
+	// ValidatorWeightChanged is the change of a validator's weight
+	ValidatorWeightChanged = "weightChanged"
+)
+
+// AcceptedTopic returns the topic of the containers accepted on [chainID]
+func AcceptedTopic(chainID ids.ID) string {
+	return acceptedTopicPrefix + chainID.String()
+}
+
+// TxStatusTopic returns the topic of the status changes of [txID]
+func TxStatusTopic(txID ids.ID) string {
+	return txStatusTopicPrefix + txID.String()
+}
+
+// Accepted is published to AcceptedTopic when a container, either a block in
+// snowman or a vertex in coinflect, is accepted.
+type Accepted struct {
+	ChainID     ids.ID              `json:"chainID"`
+	ContainerID ids.ID              `json:"containerID"`
+	Container   string              `json:"container"`
+	Encoding    formatting.Encoding `json:"encoding"`
+}
+
+// TxStatus is published to TxStatusTopic when the status of a transaction
+// changes. The status is the one reported by the chain's getTxStatus method:
+// "Accepted" on the X-chain, and "Committed" or "Aborted" on the P-chain. The
+// transaction of a P-chain proposal block is published once the proposal is
+// committed or aborted. Only decided transactions are published: X-chain
+// transactions that are rejected, and transactions that are dropped before
+// being issued into a block, never are.
+type TxStatus struct {
+	ChainID ids.ID `json:"chainID"`
+	TxID    ids.ID `json:"txID"`
+	Status  string `json:"status"`
+}
+
+// ValidatorChange is published to ValidatorsTopic when a validator is added,
+// removed, or its weight changes.
+type ValidatorChange struct {
+	NodeID         ids.NodeID  `json:"nodeID"`
+	Change         string      `json:"change"`
+	PreviousWeight json.Uint64 `json:"previousWeight"`
+	Weight         json.Uint64 `json:"weight"`
+}
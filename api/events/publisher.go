@@ -0,0 +1,292 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package events
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/coinflect/coinflectchain/chains"
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/pubsub"
+	"github.com/coinflect/coinflectchain/snow"
+	"github.com/coinflect/coinflectchain/snow/choices"
+	"github.com/coinflect/coinflectchain/snow/engine/coinflect"
+	"github.com/coinflect/coinflectchain/snow/engine/common"
+	"github.com/coinflect/coinflectchain/snow/engine/snowman"
+	"github.com/coinflect/coinflectchain/snow/validators"
+	"github.com/coinflect/coinflectchain/utils/constants"
+	"github.com/coinflect/coinflectchain/utils/formatting"
+	"github.com/coinflect/coinflectchain/utils/json"
+	"github.com/coinflect/coinflectchain/utils/logging"
+	"github.com/coinflect/coinflectchain/utils/wrappers"
+	"github.com/coinflect/coinflectchain/vms/platformvm/blocks"
+	"github.com/coinflect/coinflectchain/vms/platformvm/status"
+)
+
+const acceptorName = "events"
+
+var (
+	_ Publisher = (*publisher)(nil)
+
+	_ snow.Acceptor = (*acceptedAcceptor)(nil)
+	_ snow.Acceptor = (*txStatusAcceptor)(nil)
+	_ snow.Acceptor = (*platformTxStatusAcceptor)(nil)
+)
+
+// Publisher publishes accepted containers, transaction status changes and
+// validator set changes to the subscribers of its WebSocket endpoint.
+//
+// Events are published from the acceptor groups, so they are only sent after
+// the containers are accepted. Subscribers that fall behind are disconnected.
+type Publisher interface {
+	// Publisher learns about new chains so that it can publish their events.
+	chains.Registrant
+
+	// Publisher is notified of the changes to the Primary Network's
+	// validator set.
+	validators.SetCallbackListener
+
+	// Handler returns the WebSocket endpoint that clients subscribe to.
+	Handler() *common.HTTPHandler
+
+	// Close stops publishing the events of all chains.
+	Close() error
+}
+
+// Config for a Publisher
+type Config struct {
+	Log                    logging.Logger
+	DecisionAcceptorGroup  snow.AcceptorGroup
+	ConsensusAcceptorGroup snow.AcceptorGroup
+}
+
+type publisher struct {
+	log                    logging.Logger
+	server                 *pubsub.Server
+	decisionAcceptorGroup  snow.AcceptorGroup
+	consensusAcceptorGroup snow.AcceptorGroup
+
+	lock sync.Mutex
+	// chains whose acceptors are registered on the consensus acceptor group
+	consensusChains []ids.ID
+	// chains whose acceptors are registered on the decision acceptor group
+	decisionChains []ids.ID
+	closed         bool
+}
+
+// NewPublisher returns a new Publisher
+func NewPublisher(config Config) Publisher {
+	return &publisher{
+		log:                    config.Log,
+		server:                 pubsub.New(config.Log),
+		decisionAcceptorGroup:  config.DecisionAcceptorGroup,
+		consensusAcceptorGroup: config.ConsensusAcceptorGroup,
+	}
+}
+
+func (p *publisher) RegisterChain(name string, engine common.Engine) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.closed {
+		return
+	}
+
+	ctx := engine.Context()
+	chainID := ctx.ChainID
+	if err := p.consensusAcceptorGroup.RegisterAcceptor(chainID, acceptorName, &acceptedAcceptor{server: p.server}, false); err != nil {
+		p.log.Error("failed to register accepted events",
+			zap.String("chainName", name),
+			zap.Error(err),
+		)
+		return
+	}
+	p.consensusChains = append(p.consensusChains, chainID)
+
+	// Transactions are only identified on chains whose decisions are
+	// transactions or that are known to the node.
+	var acceptor snow.Acceptor
+	switch engine.(type) {
+	case coinflect.Engine:
+		acceptor = &txStatusAcceptor{server: p.server}
+	case snowman.Engine:
+		if chainID != constants.PlatformChainID {
+			return
+		}
+		acceptor = &platformTxStatusAcceptor{
+			log:    p.log,
+			server: p.server,
+		}
+	default:
+		return
+	}
+
+	if err := p.decisionAcceptorGroup.RegisterAcceptor(chainID, acceptorName, acceptor, false); err != nil {
+		p.log.Error("failed to register transaction events",
+			zap.String("chainName", name),
+			zap.Error(err),
+		)
+		return
+	}
+	p.decisionChains = append(p.decisionChains, chainID)
+}
+
+func (p *publisher) OnValidatorAdded(nodeID ids.NodeID, weight uint64) {
+	p.publishValidatorChange(nodeID, ValidatorAdded, 0, weight)
+}
+
+func (p *publisher) OnValidatorRemoved(nodeID ids.NodeID, weight uint64) {
+	p.publishValidatorChange(nodeID, ValidatorRemoved, weight, 0)
+}
+
+func (p *publisher) OnValidatorWeightChanged(nodeID ids.NodeID, oldWeight, newWeight uint64) {
+	p.publishValidatorChange(nodeID, ValidatorWeightChanged, oldWeight, newWeight)
+}
+
+func (p *publisher) publishValidatorChange(nodeID ids.NodeID, change string, previousWeight, weight uint64) {
+	p.server.PublishTopic(ValidatorsTopic, &ValidatorChange{
+		NodeID:         nodeID,
+		Change:         change,
+		PreviousWeight: json.Uint64(previousWeight),
+		Weight:         json.Uint64(weight),
+	})
+}
+
+func (p *publisher) Handler() *common.HTTPHandler {
+	return &common.HTTPHandler{LockOptions: common.NoLock, Handler: p.server}
+}
+
+func (p *publisher) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+
+	errs := wrappers.Errs{}
+	for _, chainID := range p.consensusChains {
+		errs.Add(p.consensusAcceptorGroup.DeregisterAcceptor(chainID, acceptorName))
+	}
+	for _, chainID := range p.decisionChains {
+		errs.Add(p.decisionAcceptorGroup.DeregisterAcceptor(chainID, acceptorName))
+	}
+	return errs.Err
+}
+
+// acceptedAcceptor publishes the accepted containers of a chain
+type acceptedAcceptor struct {
+	server *pubsub.Server
+}
+
+func (a *acceptedAcceptor) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container []byte) error {
+	topic := AcceptedTopic(ctx.ChainID)
+	if !a.server.HasSubscribers(topic) {
+		return nil
+	}
+
+	containerStr, err := formatting.Encode(formatting.Hex, container)
+	if err != nil {
+		return err
+	}
+	a.server.PublishTopic(topic, &Accepted{
+		ChainID:     ctx.ChainID,
+		ContainerID: containerID,
+		Container:   containerStr,
+		Encoding:    formatting.Hex,
+	})
+	return nil
+}
+
+// txStatusAcceptor publishes the acceptance of the transactions of a
+// coinflect chain, whose decisions are transactions. Acceptor groups are only
+// notified of accepted decisions, so rejected transactions aren't published.
+type txStatusAcceptor struct {
+	server *pubsub.Server
+}
+
+func (a *txStatusAcceptor) Accept(ctx *snow.ConsensusContext, txID ids.ID, _ []byte) error {
+	a.server.PublishTopic(TxStatusTopic(txID), &TxStatus{
+		ChainID: ctx.ChainID,
+		TxID:    txID,
+		Status:  choices.Accepted.String(),
+	})
+	return nil
+}
+
+// platformTxStatusAcceptor publishes the status changes of the transactions
+// of the P-chain.
+//
+// The transactions of decision blocks are committed when their block is
+// accepted. The transaction of a proposal block is only decided once one of
+// the proposal's options is accepted, so it is published as committed or
+// aborted when the option is accepted.
+type platformTxStatusAcceptor struct {
+	log    logging.Logger
+	server *pubsub.Server
+
+	// proposalID is the ID of the last accepted proposal block and
+	// proposalTxID is the ID of its transaction. The option block accepted
+	// after the proposal block decides the transaction. They're tracked even
+	// without subscribers, so that clients subscribing between the proposal
+	// and its option are notified of the outcome.
+	proposalID   ids.ID
+	proposalTxID ids.ID
+}
+
+func (a *platformTxStatusAcceptor) Accept(ctx *snow.ConsensusContext, blkID ids.ID, blkBytes []byte) error {
+	blk, err := blocks.Parse(blocks.Codec, blkBytes)
+	if err != nil {
+		// The block was already verified by the VM, so this should never
+		// happen. The error is logged rather than returned because failing
+		// to publish an event must not stop the chain.
+		a.log.Warn("failed to parse accepted block",
+			zap.Stringer("chainID", ctx.ChainID),
+			zap.Stringer("blkID", blkID),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	switch blk.(type) {
+	case *blocks.ApricotProposalBlock, *blocks.BanffProposalBlock:
+		a.proposalID = blkID
+		a.proposalTxID = blk.Txs()[0].ID()
+	case *blocks.ApricotCommitBlock, *blocks.BanffCommitBlock:
+		a.publishProposalStatus(ctx, blk.Parent(), status.Committed)
+	case *blocks.ApricotAbortBlock, *blocks.BanffAbortBlock:
+		a.publishProposalStatus(ctx, blk.Parent(), status.Aborted)
+	default:
+		if !a.server.HasSubscribersWithPrefix(txStatusTopicPrefix) {
+			return nil
+		}
+		for _, tx := range blk.Txs() {
+			a.publish(ctx, tx.ID(), status.Committed)
+		}
+	}
+	return nil
+}
+
+// publishProposalStatus publishes the status of the transaction of the
+// proposal block [proposalID], which was decided by an accepted option.
+func (a *platformTxStatusAcceptor) publishProposalStatus(ctx *snow.ConsensusContext, proposalID ids.ID, txStatus status.Status) {
+	if proposalID != a.proposalID {
+		// The proposal block was accepted before the node restarted.
+		return
+	}
+	a.proposalID = ids.Empty
+	a.publish(ctx, a.proposalTxID, txStatus)
+}
+
+func (a *platformTxStatusAcceptor) publish(ctx *snow.ConsensusContext, txID ids.ID, txStatus status.Status) {
+	a.server.PublishTopic(TxStatusTopic(txID), &TxStatus{
+		ChainID: ctx.ChainID,
+		TxID:    txID,
+		Status:  txStatus.String(),
+	})
+}
@@ -0,0 +1,247 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package events
+
+import (
+	stdjson "encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/pubsub"
+	"github.com/coinflect/coinflectchain/snow"
+	"github.com/coinflect/coinflectchain/snow/choices"
+	"github.com/coinflect/coinflectchain/snow/engine/snowman"
+	"github.com/coinflect/coinflectchain/utils/constants"
+	"github.com/coinflect/coinflectchain/utils/formatting"
+	"github.com/coinflect/coinflectchain/utils/logging"
+	"github.com/coinflect/coinflectchain/vms/platformvm/blocks"
+	"github.com/coinflect/coinflectchain/vms/platformvm/status"
+	"github.com/coinflect/coinflectchain/vms/platformvm/txs"
+
+	aveng "github.com/coinflect/coinflectchain/snow/engine/coinflect"
+)
+
+type testEvent struct {
+	Topic string             `json:"topic"`
+	Event stdjson.RawMessage `json:"event"`
+}
+
+func newTestPublisher(t *testing.T) (*publisher, snow.AcceptorGroup, snow.AcceptorGroup, *websocket.Conn) {
+	decisionAcceptorGroup := snow.NewAcceptorGroup(logging.NoLog{})
+	consensusAcceptorGroup := snow.NewAcceptorGroup(logging.NoLog{})
+	p := NewPublisher(Config{
+		Log:                    logging.NoLog{},
+		DecisionAcceptorGroup:  decisionAcceptorGroup,
+		ConsensusAcceptorGroup: consensusAcceptorGroup,
+	}).(*publisher)
+
+	httpServer := httptest.NewServer(p.Handler().Handler)
+	t.Cleanup(httpServer.Close)
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return p, decisionAcceptorGroup, consensusAcceptorGroup, conn
+}
+
+func subscribe(t *testing.T, p *publisher, conn *websocket.Conn, topic string) {
+	require.NoError(t, conn.WriteJSON(&pubsub.Command{Subscribe: &pubsub.Subscribe{Topic: topic}}))
+	require.Eventually(t, func() bool {
+		return p.server.HasSubscribers(topic)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func readEvent(t *testing.T, conn *websocket.Conn, expectedTopic string, event interface{}) {
+	var msg testEvent
+	require.NoError(t, conn.ReadJSON(&msg))
+	require.Equal(t, expectedTopic, msg.Topic)
+	require.NoError(t, stdjson.Unmarshal(msg.Event, event))
+}
+
+func TestPublishAccepted(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, decisionAcceptorGroup, consensusAcceptorGroup, conn := newTestPublisher(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.ChainID = ids.GenerateTestID()
+	engine := aveng.NewMockEngine(ctrl)
+	engine.EXPECT().Context().AnyTimes().Return(ctx)
+	p.RegisterChain("chain", engine)
+
+	vtxID := ids.GenerateTestID()
+	txID := ids.GenerateTestID()
+	subscribe(t, p, conn, AcceptedTopic(ctx.ChainID))
+	subscribe(t, p, conn, TxStatusTopic(txID))
+
+	require.NoError(consensusAcceptorGroup.Accept(ctx, vtxID, []byte{1, 2, 3}))
+	require.NoError(decisionAcceptorGroup.Accept(ctx, txID, []byte{4, 5, 6}))
+
+	var accepted Accepted
+	readEvent(t, conn, AcceptedTopic(ctx.ChainID), &accepted)
+	require.Equal(ctx.ChainID, accepted.ChainID)
+	require.Equal(vtxID, accepted.ContainerID)
+	require.Equal(formatting.Hex, accepted.Encoding)
+	container, err := formatting.Decode(accepted.Encoding, accepted.Container)
+	require.NoError(err)
+	require.Equal([]byte{1, 2, 3}, container)
+
+	var status TxStatus
+	readEvent(t, conn, TxStatusTopic(txID), &status)
+	require.Equal(TxStatus{
+		ChainID: ctx.ChainID,
+		TxID:    txID,
+		Status:  choices.Accepted.String(),
+	}, status)
+
+	// Once closed, events are no longer published.
+	require.NoError(p.Close())
+	require.NoError(consensusAcceptorGroup.Accept(ctx, vtxID, []byte{1, 2, 3}))
+	require.Error(consensusAcceptorGroup.DeregisterAcceptor(ctx.ChainID, acceptorName))
+	require.Error(decisionAcceptorGroup.DeregisterAcceptor(ctx.ChainID, acceptorName))
+}
+
+func TestPublishPlatformTxStatus(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, decisionAcceptorGroup, _, conn := newTestPublisher(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.ChainID = constants.PlatformChainID
+	engine := snowman.NewMockEngine(ctrl)
+	engine.EXPECT().Context().AnyTimes().Return(ctx)
+	p.RegisterChain("P", engine)
+
+	newTx := func(time uint64) *txs.Tx {
+		tx := &txs.Tx{Unsigned: &txs.AdvanceTimeTx{Time: time}}
+		require.NoError(tx.Sign(txs.Codec, nil))
+		return tx
+	}
+	decisionTx := newTx(1)
+	committedTx := newTx(2)
+	abortedTx := newTx(3)
+
+	now := time.Now()
+	standardBlk, err := blocks.NewBanffStandardBlock(now, ids.GenerateTestID(), 1, []*txs.Tx{decisionTx})
+	require.NoError(err)
+	committedProposalBlk, err := blocks.NewBanffProposalBlock(now, standardBlk.ID(), 2, committedTx)
+	require.NoError(err)
+	commitBlk, err := blocks.NewBanffCommitBlock(now, committedProposalBlk.ID(), 3)
+	require.NoError(err)
+	abortedProposalBlk, err := blocks.NewBanffProposalBlock(now, commitBlk.ID(), 4, abortedTx)
+	require.NoError(err)
+	abortBlk, err := blocks.NewBanffAbortBlock(now, abortedProposalBlk.ID(), 5)
+	require.NoError(err)
+
+	// The proposal transactions are only published once they are decided.
+	for _, tx := range []*txs.Tx{decisionTx, committedTx, abortedTx} {
+		subscribe(t, p, conn, TxStatusTopic(tx.ID()))
+	}
+	for _, blk := range []blocks.Block{standardBlk, committedProposalBlk, commitBlk, abortedProposalBlk, abortBlk} {
+		require.NoError(decisionAcceptorGroup.Accept(ctx, blk.ID(), blk.Bytes()))
+	}
+
+	expectedStatuses := []TxStatus{
+		{ChainID: ctx.ChainID, TxID: decisionTx.ID(), Status: status.Committed.String()},
+		{ChainID: ctx.ChainID, TxID: committedTx.ID(), Status: status.Committed.String()},
+		{ChainID: ctx.ChainID, TxID: abortedTx.ID(), Status: status.Aborted.String()},
+	}
+	for _, expectedStatus := range expectedStatuses {
+		var txStatus TxStatus
+		readEvent(t, conn, TxStatusTopic(expectedStatus.TxID), &txStatus)
+		require.Equal(expectedStatus, txStatus)
+	}
+}
+
+func TestPublishProposalSubscribedBeforeOption(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, decisionAcceptorGroup, _, conn := newTestPublisher(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.ChainID = constants.PlatformChainID
+	engine := snowman.NewMockEngine(ctrl)
+	engine.EXPECT().Context().AnyTimes().Return(ctx)
+	p.RegisterChain("P", engine)
+
+	tx := &txs.Tx{Unsigned: &txs.AdvanceTimeTx{Time: 1}}
+	require.NoError(tx.Sign(txs.Codec, nil))
+	now := time.Now()
+	proposalBlk, err := blocks.NewBanffProposalBlock(now, ids.GenerateTestID(), 1, tx)
+	require.NoError(err)
+	commitBlk, err := blocks.NewBanffCommitBlock(now, proposalBlk.ID(), 2)
+	require.NoError(err)
+
+	// The proposal is accepted before anyone subscribes to its transaction
+	require.NoError(decisionAcceptorGroup.Accept(ctx, proposalBlk.ID(), proposalBlk.Bytes()))
+	subscribe(t, p, conn, TxStatusTopic(tx.ID()))
+	require.NoError(decisionAcceptorGroup.Accept(ctx, commitBlk.ID(), commitBlk.Bytes()))
+
+	var txStatus TxStatus
+	readEvent(t, conn, TxStatusTopic(tx.ID()), &txStatus)
+	require.Equal(TxStatus{
+		ChainID: ctx.ChainID,
+		TxID:    tx.ID(),
+		Status:  status.Committed.String(),
+	}, txStatus)
+}
+
+func TestSnowmanTxStatusRequiresKnownChain(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p, decisionAcceptorGroup, consensusAcceptorGroup, _ := newTestPublisher(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.ChainID = ids.GenerateTestID()
+	engine := snowman.NewMockEngine(ctrl)
+	engine.EXPECT().Context().AnyTimes().Return(ctx)
+	p.RegisterChain("chain", engine)
+
+	// The blocks of unknown snowman chains can't be parsed into
+	// transactions, so only accepted blocks are published.
+	require.NoError(consensusAcceptorGroup.DeregisterAcceptor(ctx.ChainID, acceptorName))
+	require.Error(decisionAcceptorGroup.DeregisterAcceptor(ctx.ChainID, acceptorName))
+}
+
+func TestPublishValidatorChanges(t *testing.T) {
+	p, _, _, conn := newTestPublisher(t)
+	subscribe(t, p, conn, ValidatorsTopic)
+
+	nodeID := ids.GenerateTestNodeID()
+	p.OnValidatorAdded(nodeID, 1)
+	p.OnValidatorWeightChanged(nodeID, 1, 2)
+	p.OnValidatorRemoved(nodeID, 2)
+
+	expectedChanges := []ValidatorChange{
+		{NodeID: nodeID, Change: ValidatorAdded, PreviousWeight: 0, Weight: 1},
+		{NodeID: nodeID, Change: ValidatorWeightChanged, PreviousWeight: 1, Weight: 2},
+		{NodeID: nodeID, Change: ValidatorRemoved, PreviousWeight: 2, Weight: 0},
+	}
+	for _, expectedChange := range expectedChanges {
+		var change ValidatorChange
+		readEvent(t, conn, ValidatorsTopic, &change)
+		require.Equal(t, expectedChange, change)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gateway
+
+import (
+	"context"
+
+	avmpb "github.com/coinflect/coinflectchain/proto/pb/avm"
+)
+
+const avmEndpoint = "/bc/X"
+
+var _ avmpb.AVMServer = (*avmServer)(nil)
+
+// avmServer serves the read-only methods of the X-chain's API
+type avmServer struct {
+	avmpb.UnimplementedAVMServer
+	*forwarder
+}
+
+func (s *avmServer) GetTx(ctx context.Context, req *avmpb.GetTxRequest) (*avmpb.GetTxResponse, error) {
+	reply := &avmpb.GetTxResponse{}
+	return reply, s.call(ctx, avmEndpoint, "avm.getTx", req, reply)
+}
+
+func (s *avmServer) GetTxStatus(ctx context.Context, req *avmpb.GetTxStatusRequest) (*avmpb.GetTxStatusResponse, error) {
+	reply := &avmpb.GetTxStatusResponse{}
+	return reply, s.call(ctx, avmEndpoint, "avm.getTxStatus", req, reply)
+}
+
+func (s *avmServer) GetBalance(ctx context.Context, req *avmpb.GetBalanceRequest) (*avmpb.GetBalanceResponse, error) {
+	reply := &avmpb.GetBalanceResponse{}
+	return reply, s.call(ctx, avmEndpoint, "avm.getBalance", req, reply)
+}
+
+func (s *avmServer) GetAllBalances(ctx context.Context, req *avmpb.GetAllBalancesRequest) (*avmpb.GetAllBalancesResponse, error) {
+	reply := &avmpb.GetAllBalancesResponse{}
+	return reply, s.call(ctx, avmEndpoint, "avm.getAllBalances", req, reply)
+}
+
+func (s *avmServer) GetAssetDescription(ctx context.Context, req *avmpb.GetAssetDescriptionRequest) (*avmpb.GetAssetDescriptionResponse, error) {
+	reply := &avmpb.GetAssetDescriptionResponse{}
+	return reply, s.call(ctx, avmEndpoint, "avm.getAssetDescription", req, reply)
+}
+
+func (s *avmServer) GetUTXOs(ctx context.Context, req *avmpb.GetUTXOsRequest) (*avmpb.GetUTXOsResponse, error) {
+	reply := &avmpb.GetUTXOsResponse{}
+	return reply, s.call(ctx, avmEndpoint, "avm.getUTXOs", req, reply)
+}
+
+func (s *avmServer) GetAddressTxs(ctx context.Context, req *avmpb.GetAddressTxsRequest) (*avmpb.GetAddressTxsResponse, error) {
+	reply := &avmpb.GetAddressTxsResponse{}
+	return reply, s.call(ctx, avmEndpoint, "avm.getAddressTxs", req, reply)
+}
+
+func (s *avmServer) GetBalanceAt(ctx context.Context, req *avmpb.GetBalanceAtRequest) (*avmpb.GetBalanceAtResponse, error) {
+	reply := &avmpb.GetBalanceAtResponse{}
+	return reply, s.call(ctx, avmEndpoint, "avm.getBalanceAt", req, reply)
+}
+
+func (s *avmServer) GetBalanceHistory(ctx context.Context, req *avmpb.GetBalanceHistoryRequest) (*avmpb.GetBalanceHistoryResponse, error) {
+	reply := &avmpb.GetBalanceHistoryResponse{}
+	return reply, s.call(ctx, avmEndpoint, "avm.getBalanceHistory", req, reply)
+}
@@ -0,0 +1,179 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	rpc "github.com/gorilla/rpc/v2/json2"
+)
+
+const (
+	baseURL = "/ext"
+
+	// authorizationKey is the gRPC metadata key that is forwarded as the
+	// Authorization header of the JSON-RPC requests.
+	authorizationKey = "authorization"
+)
+
+var unmarshalOptions = protojson.UnmarshalOptions{
+	// The JSON-RPC results may have fields that aren't exposed over gRPC.
+	DiscardUnknown: true,
+}
+
+type rpcRequest struct {
+	Version string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	ID      uint32          `json:"id"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpc.Error      `json:"error"`
+}
+
+// forwarder calls the JSON-RPC APIs of the node on behalf of gRPC requests.
+//
+// Requests are passed to the same handler as the requests to the HTTP server,
+// so they are authorized, rate limited and served the same way.
+type forwarder struct {
+	handler http.Handler
+}
+
+// call calls [method] of the API at [endpoint], which is relative to the
+// base URL of the HTTP server. The fields of [args] and [reply] are mapped to
+// the parameters and the result of the JSON-RPC method by their json names.
+func (f *forwarder) call(ctx context.Context, endpoint, method string, args, reply proto.Message) error {
+	params, err := protojson.Marshal(args)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "couldn't marshal arguments: %s", err)
+	}
+	body, err := json.Marshal(&rpcRequest{
+		Version: "2.0",
+		Method:  method,
+		Params:  params,
+		ID:      1,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "couldn't marshal request: %s", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+endpoint, bytes.NewReader(body))
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "couldn't create request: %s", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(authorizationKey); len(values) > 0 {
+			req.Header.Set("Authorization", values[0])
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		// The rate limiter identifies clients by their address.
+		if p.Addr != nil {
+			req.RemoteAddr = p.Addr.String()
+		}
+		// Clients with a verified certificate are authorized by its roles.
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			state := tlsInfo.State
+			req.TLS = &state
+		}
+	}
+
+	w := newResponseWriter()
+	f.handler.ServeHTTP(w, req)
+	if w.code != http.StatusOK {
+		return status.Error(httpStatusCode(w.code), strings.TrimSpace(w.body.String()))
+	}
+
+	resp := rpcResponse{}
+	if err := json.Unmarshal(w.body.Bytes(), &resp); err != nil {
+		return status.Errorf(codes.Internal, "couldn't parse response: %s", err)
+	}
+	if resp.Error != nil {
+		return status.Error(rpcErrorCode(resp.Error.Code), resp.Error.Message)
+	}
+	if len(resp.Result) == 0 || string(resp.Result) == "null" {
+		return nil
+	}
+	if err := unmarshalOptions.Unmarshal(resp.Result, reply); err != nil {
+		return status.Errorf(codes.Internal, "couldn't parse result: %s", err)
+	}
+	return nil
+}
+
+// httpStatusCode returns the gRPC status code of a failed HTTP request
+func httpStatusCode(code int) codes.Code {
+	switch code {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Unknown
+	}
+}
+
+// rpcErrorCode returns the gRPC status code of a JSON-RPC error
+func rpcErrorCode(code rpc.ErrorCode) codes.Code {
+	switch code {
+	case rpc.E_INVALID_REQ, rpc.E_BAD_PARAMS:
+		return codes.InvalidArgument
+	case rpc.E_NO_METHOD:
+		return codes.Unimplemented
+	case rpc.E_PARSE, rpc.E_INTERNAL:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
+
+// responseWriter buffers the response of a forwarded request
+type responseWriter struct {
+	header http.Header
+	code   int
+	body   bytes.Buffer
+}
+
+func newResponseWriter() *responseWriter {
+	return &responseWriter{
+		header: make(http.Header),
+		code:   http.StatusOK,
+	}
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	w.code = code
+}
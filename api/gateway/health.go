@@ -0,0 +1,41 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gateway
+
+import (
+	"context"
+
+	healthpb "github.com/coinflect/coinflectchain/proto/pb/health"
+)
+
+const healthEndpoint = "/health"
+
+var _ healthpb.HealthServer = (*healthServer)(nil)
+
+// healthServer serves the health API
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+	*forwarder
+}
+
+func (s *healthServer) Readiness(ctx context.Context, req *healthpb.HealthRequest) (*healthpb.HealthResponse, error) {
+	reply := &healthpb.HealthResponse{}
+	return reply, s.call(ctx, healthEndpoint, "health.readiness", req, reply)
+}
+
+func (s *healthServer) Health(ctx context.Context, req *healthpb.HealthRequest) (*healthpb.HealthResponse, error) {
+	reply := &healthpb.HealthResponse{}
+	return reply, s.call(ctx, healthEndpoint, "health.health", req, reply)
+}
+
+func (s *healthServer) Liveness(ctx context.Context, req *healthpb.HealthRequest) (*healthpb.HealthResponse, error) {
+	reply := &healthpb.HealthResponse{}
+	return reply, s.call(ctx, healthEndpoint, "health.liveness", req, reply)
+}
+
+func (s *healthServer) History(ctx context.Context, req *healthpb.HistoryRequest) (*healthpb.HistoryResponse, error) {
+	reply := &healthpb.HistoryResponse{}
+	return reply, s.call(ctx, healthEndpoint, "health.history", req, reply)
+}
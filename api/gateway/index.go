@@ -0,0 +1,157 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gateway
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	indexpb "github.com/coinflect/coinflectchain/proto/pb/index"
+)
+
+var _ indexpb.IndexServer = (*indexServer)(nil)
+
+// indexServer serves the index API
+type indexServer struct {
+	indexpb.UnimplementedIndexServer
+	*forwarder
+}
+
+// indexEndpoint returns the endpoint of the [indexType] index of [chain]. The
+// chain and index type of the requests only select the endpoint, so they
+// aren't passed to the JSON-RPC methods.
+func indexEndpoint(chain, indexType string) (string, error) {
+	if chain == "" || strings.Contains(chain, "/") {
+		return "", status.Errorf(codes.InvalidArgument, "invalid chain %q", chain)
+	}
+	switch indexType {
+	case "block", "vtx", "tx":
+		return "/index/" + chain + "/" + indexType, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "invalid index type %q", indexType)
+	}
+}
+
+func (s *indexServer) GetLastAccepted(ctx context.Context, req *indexpb.GetLastAcceptedRequest) (*indexpb.Container, error) {
+	endpoint, err := indexEndpoint(req.Chain, req.IndexType)
+	if err != nil {
+		return nil, err
+	}
+	args := &indexpb.GetLastAcceptedRequest{
+		Encoding: req.Encoding,
+	}
+	reply := &indexpb.Container{}
+	return reply, s.call(ctx, endpoint, "index.getLastAccepted", args, reply)
+}
+
+func (s *indexServer) GetContainerByIndex(ctx context.Context, req *indexpb.GetContainerByIndexRequest) (*indexpb.Container, error) {
+	endpoint, err := indexEndpoint(req.Chain, req.IndexType)
+	if err != nil {
+		return nil, err
+	}
+	args := &indexpb.GetContainerByIndexRequest{
+		Index:    req.Index,
+		Encoding: req.Encoding,
+	}
+	reply := &indexpb.Container{}
+	return reply, s.call(ctx, endpoint, "index.getContainerByIndex", args, reply)
+}
+
+func (s *indexServer) GetContainerRange(ctx context.Context, req *indexpb.GetContainerRangeRequest) (*indexpb.GetContainerRangeResponse, error) {
+	endpoint, err := indexEndpoint(req.Chain, req.IndexType)
+	if err != nil {
+		return nil, err
+	}
+	args := &indexpb.GetContainerRangeRequest{
+		StartIndex: req.StartIndex,
+		NumToFetch: req.NumToFetch,
+		Encoding:   req.Encoding,
+	}
+	reply := &indexpb.GetContainerRangeResponse{}
+	return reply, s.call(ctx, endpoint, "index.getContainerRange", args, reply)
+}
+
+func (s *indexServer) GetIndex(ctx context.Context, req *indexpb.GetIndexRequest) (*indexpb.GetIndexResponse, error) {
+	endpoint, err := indexEndpoint(req.Chain, req.IndexType)
+	if err != nil {
+		return nil, err
+	}
+	args := &indexpb.GetIndexRequest{
+		Id: req.Id,
+	}
+	reply := &indexpb.GetIndexResponse{}
+	return reply, s.call(ctx, endpoint, "index.getIndex", args, reply)
+}
+
+func (s *indexServer) IsAccepted(ctx context.Context, req *indexpb.IsAcceptedRequest) (*indexpb.IsAcceptedResponse, error) {
+	endpoint, err := indexEndpoint(req.Chain, req.IndexType)
+	if err != nil {
+		return nil, err
+	}
+	args := &indexpb.IsAcceptedRequest{
+		Id: req.Id,
+	}
+	reply := &indexpb.IsAcceptedResponse{}
+	return reply, s.call(ctx, endpoint, "index.isAccepted", args, reply)
+}
+
+func (s *indexServer) GetContainerByID(ctx context.Context, req *indexpb.GetContainerByIDRequest) (*indexpb.Container, error) {
+	endpoint, err := indexEndpoint(req.Chain, req.IndexType)
+	if err != nil {
+		return nil, err
+	}
+	args := &indexpb.GetContainerByIDRequest{
+		Id:       req.Id,
+		Encoding: req.Encoding,
+	}
+	reply := &indexpb.Container{}
+	return reply, s.call(ctx, endpoint, "index.getContainerByID", args, reply)
+}
+
+func (s *indexServer) GetContainerRangeReverse(ctx context.Context, req *indexpb.GetContainerRangeRequest) (*indexpb.GetContainerRangeResponse, error) {
+	endpoint, err := indexEndpoint(req.Chain, req.IndexType)
+	if err != nil {
+		return nil, err
+	}
+	args := &indexpb.GetContainerRangeRequest{
+		StartIndex: req.StartIndex,
+		NumToFetch: req.NumToFetch,
+		Encoding:   req.Encoding,
+	}
+	reply := &indexpb.GetContainerRangeResponse{}
+	return reply, s.call(ctx, endpoint, "index.getContainerRangeReverse", args, reply)
+}
+
+func (s *indexServer) GetContainersByTime(ctx context.Context, req *indexpb.GetContainersByTimeRequest) (*indexpb.GetContainersByTimeResponse, error) {
+	endpoint, err := indexEndpoint(req.Chain, req.IndexType)
+	if err != nil {
+		return nil, err
+	}
+	args := &indexpb.GetContainersByTimeRequest{
+		StartTime:  req.StartTime,
+		EndTime:    req.EndTime,
+		Cursor:     req.Cursor,
+		NumToFetch: req.NumToFetch,
+		Encoding:   req.Encoding,
+	}
+	reply := &indexpb.GetContainersByTimeResponse{}
+	return reply, s.call(ctx, endpoint, "index.getContainersByTime", args, reply)
+}
+
+func (s *indexServer) GetContainerByTime(ctx context.Context, req *indexpb.GetContainerByTimeRequest) (*indexpb.Container, error) {
+	endpoint, err := indexEndpoint(req.Chain, req.IndexType)
+	if err != nil {
+		return nil, err
+	}
+	args := &indexpb.GetContainerByTimeRequest{
+		Time:     req.Time,
+		Encoding: req.Encoding,
+	}
+	reply := &indexpb.Container{}
+	return reply, s.call(ctx, endpoint, "index.getContainerByTime", args, reply)
+}
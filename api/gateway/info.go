@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gateway
+
+import (
+	"context"
+
+	infopb "github.com/coinflect/coinflectchain/proto/pb/info"
+)
+
+const infoEndpoint = "/info"
+
+var _ infopb.InfoServer = (*infoServer)(nil)
+
+// infoServer serves the info API
+type infoServer struct {
+	infopb.UnimplementedInfoServer
+	*forwarder
+}
+
+func (s *infoServer) GetNodeVersion(ctx context.Context, req *infopb.GetNodeVersionRequest) (*infopb.GetNodeVersionResponse, error) {
+	reply := &infopb.GetNodeVersionResponse{}
+	return reply, s.call(ctx, infoEndpoint, "info.getNodeVersion", req, reply)
+}
+
+func (s *infoServer) GetNodeID(ctx context.Context, req *infopb.GetNodeIDRequest) (*infopb.GetNodeIDResponse, error) {
+	reply := &infopb.GetNodeIDResponse{}
+	return reply, s.call(ctx, infoEndpoint, "info.getNodeID", req, reply)
+}
+
+func (s *infoServer) GetNodeIP(ctx context.Context, req *infopb.GetNodeIPRequest) (*infopb.GetNodeIPResponse, error) {
+	reply := &infopb.GetNodeIPResponse{}
+	return reply, s.call(ctx, infoEndpoint, "info.getNodeIP", req, reply)
+}
+
+func (s *infoServer) GetNetworkID(ctx context.Context, req *infopb.GetNetworkIDRequest) (*infopb.GetNetworkIDResponse, error) {
+	reply := &infopb.GetNetworkIDResponse{}
+	return reply, s.call(ctx, infoEndpoint, "info.getNetworkID", req, reply)
+}
+
+func (s *infoServer) GetNetworkName(ctx context.Context, req *infopb.GetNetworkNameRequest) (*infopb.GetNetworkNameResponse, error) {
+	reply := &infopb.GetNetworkNameResponse{}
+	return reply, s.call(ctx, infoEndpoint, "info.getNetworkName", req, reply)
+}
+
+func (s *infoServer) GetBlockchainID(ctx context.Context, req *infopb.GetBlockchainIDRequest) (*infopb.GetBlockchainIDResponse, error) {
+	reply := &infopb.GetBlockchainIDResponse{}
+	return reply, s.call(ctx, infoEndpoint, "info.getBlockchainID", req, reply)
+}
+
+func (s *infoServer) IsBootstrapped(ctx context.Context, req *infopb.IsBootstrappedRequest) (*infopb.IsBootstrappedResponse, error) {
+	reply := &infopb.IsBootstrappedResponse{}
+	return reply, s.call(ctx, infoEndpoint, "info.isBootstrapped", req, reply)
+}
+
+func (s *infoServer) Uptime(ctx context.Context, req *infopb.UptimeRequest) (*infopb.UptimeResponse, error) {
+	reply := &infopb.UptimeResponse{}
+	return reply, s.call(ctx, infoEndpoint, "info.uptime", req, reply)
+}
+
+func (s *infoServer) GetTxFee(ctx context.Context, req *infopb.GetTxFeeRequest) (*infopb.GetTxFeeResponse, error) {
+	reply := &infopb.GetTxFeeResponse{}
+	return reply, s.call(ctx, infoEndpoint, "info.getTxFee", req, reply)
+}
+
+func (s *infoServer) Peers(ctx context.Context, req *infopb.PeersRequest) (*infopb.PeersResponse, error) {
+	reply := &infopb.PeersResponse{}
+	return reply, s.call(ctx, infoEndpoint, "info.peers", req, reply)
+}
+
+func (s *infoServer) GetVMs(ctx context.Context, req *infopb.GetVMsRequest) (*infopb.GetVMsResponse, error) {
+	reply := &infopb.GetVMsResponse{}
+	return reply, s.call(ctx, infoEndpoint, "info.getVMs", req, reply)
+}
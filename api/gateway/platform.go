@@ -0,0 +1,141 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gateway
+
+import (
+	"context"
+
+	platformpb "github.com/coinflect/coinflectchain/proto/pb/platform"
+)
+
+const platformEndpoint = "/bc/P"
+
+var _ platformpb.PlatformServer = (*platformServer)(nil)
+
+// platformServer serves the read-only methods of the P-chain's API
+type platformServer struct {
+	platformpb.UnimplementedPlatformServer
+	*forwarder
+}
+
+func (s *platformServer) GetHeight(ctx context.Context, req *platformpb.GetHeightRequest) (*platformpb.GetHeightResponse, error) {
+	reply := &platformpb.GetHeightResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getHeight", req, reply)
+}
+
+func (s *platformServer) GetBalance(ctx context.Context, req *platformpb.GetBalanceRequest) (*platformpb.GetBalanceResponse, error) {
+	reply := &platformpb.GetBalanceResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getBalance", req, reply)
+}
+
+func (s *platformServer) GetCurrentSupply(ctx context.Context, req *platformpb.GetCurrentSupplyRequest) (*platformpb.GetCurrentSupplyResponse, error) {
+	reply := &platformpb.GetCurrentSupplyResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getCurrentSupply", req, reply)
+}
+
+func (s *platformServer) GetTimestamp(ctx context.Context, req *platformpb.GetTimestampRequest) (*platformpb.GetTimestampResponse, error) {
+	reply := &platformpb.GetTimestampResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getTimestamp", req, reply)
+}
+
+func (s *platformServer) GetTx(ctx context.Context, req *platformpb.GetTxRequest) (*platformpb.GetTxResponse, error) {
+	reply := &platformpb.GetTxResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getTx", req, reply)
+}
+
+func (s *platformServer) GetTxStatus(ctx context.Context, req *platformpb.GetTxStatusRequest) (*platformpb.GetTxStatusResponse, error) {
+	reply := &platformpb.GetTxStatusResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getTxStatus", req, reply)
+}
+
+func (s *platformServer) GetBlock(ctx context.Context, req *platformpb.GetBlockRequest) (*platformpb.GetBlockResponse, error) {
+	reply := &platformpb.GetBlockResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getBlock", req, reply)
+}
+
+func (s *platformServer) GetBlockchainStatus(ctx context.Context, req *platformpb.GetBlockchainStatusRequest) (*platformpb.GetBlockchainStatusResponse, error) {
+	reply := &platformpb.GetBlockchainStatusResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getBlockchainStatus", req, reply)
+}
+
+func (s *platformServer) GetUTXOs(ctx context.Context, req *platformpb.GetUTXOsRequest) (*platformpb.GetUTXOsResponse, error) {
+	reply := &platformpb.GetUTXOsResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getUTXOs", req, reply)
+}
+
+func (s *platformServer) GetSubnets(ctx context.Context, req *platformpb.GetSubnetsRequest) (*platformpb.GetSubnetsResponse, error) {
+	reply := &platformpb.GetSubnetsResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getSubnets", req, reply)
+}
+
+func (s *platformServer) GetStakingAssetID(ctx context.Context, req *platformpb.GetStakingAssetIDRequest) (*platformpb.GetStakingAssetIDResponse, error) {
+	reply := &platformpb.GetStakingAssetIDResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getStakingAssetID", req, reply)
+}
+
+func (s *platformServer) GetCurrentValidators(ctx context.Context, req *platformpb.GetCurrentValidatorsRequest) (*platformpb.GetCurrentValidatorsResponse, error) {
+	reply := &platformpb.GetCurrentValidatorsResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getCurrentValidators", req, reply)
+}
+
+func (s *platformServer) GetPendingValidators(ctx context.Context, req *platformpb.GetPendingValidatorsRequest) (*platformpb.GetPendingValidatorsResponse, error) {
+	reply := &platformpb.GetPendingValidatorsResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getPendingValidators", req, reply)
+}
+
+func (s *platformServer) SampleValidators(ctx context.Context, req *platformpb.SampleValidatorsRequest) (*platformpb.SampleValidatorsResponse, error) {
+	reply := &platformpb.SampleValidatorsResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.sampleValidators", req, reply)
+}
+
+func (s *platformServer) ValidatedBy(ctx context.Context, req *platformpb.ValidatedByRequest) (*platformpb.ValidatedByResponse, error) {
+	reply := &platformpb.ValidatedByResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.validatedBy", req, reply)
+}
+
+func (s *platformServer) Validates(ctx context.Context, req *platformpb.ValidatesRequest) (*platformpb.ValidatesResponse, error) {
+	reply := &platformpb.ValidatesResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.validates", req, reply)
+}
+
+func (s *platformServer) GetBlockchains(ctx context.Context, req *platformpb.GetBlockchainsRequest) (*platformpb.GetBlockchainsResponse, error) {
+	reply := &platformpb.GetBlockchainsResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getBlockchains", req, reply)
+}
+
+func (s *platformServer) GetStake(ctx context.Context, req *platformpb.GetStakeRequest) (*platformpb.GetStakeResponse, error) {
+	reply := &platformpb.GetStakeResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getStake", req, reply)
+}
+
+func (s *platformServer) GetMinStake(ctx context.Context, req *platformpb.GetMinStakeRequest) (*platformpb.GetMinStakeResponse, error) {
+	reply := &platformpb.GetMinStakeResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getMinStake", req, reply)
+}
+
+func (s *platformServer) GetTotalStake(ctx context.Context, req *platformpb.GetTotalStakeRequest) (*platformpb.GetTotalStakeResponse, error) {
+	reply := &platformpb.GetTotalStakeResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getTotalStake", req, reply)
+}
+
+func (s *platformServer) GetMaxStakeAmount(ctx context.Context, req *platformpb.GetMaxStakeAmountRequest) (*platformpb.GetMaxStakeAmountResponse, error) {
+	reply := &platformpb.GetMaxStakeAmountResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getMaxStakeAmount", req, reply)
+}
+
+func (s *platformServer) GetRewardUTXOs(ctx context.Context, req *platformpb.GetRewardUTXOsRequest) (*platformpb.GetRewardUTXOsResponse, error) {
+	reply := &platformpb.GetRewardUTXOsResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getRewardUTXOs", req, reply)
+}
+
+func (s *platformServer) GetAddressTxs(ctx context.Context, req *platformpb.GetAddressTxsRequest) (*platformpb.GetAddressTxsResponse, error) {
+	reply := &platformpb.GetAddressTxsResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getAddressTxs", req, reply)
+}
+
+func (s *platformServer) GetValidatorsAt(ctx context.Context, req *platformpb.GetValidatorsAtRequest) (*platformpb.GetValidatorsAtResponse, error) {
+	reply := &platformpb.GetValidatorsAtResponse{}
+	return reply, s.call(ctx, platformEndpoint, "platform.getValidatorsAt", req, reply)
+}
@@ -0,0 +1,164 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gateway
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/coinflect/coinflectchain/utils/logging"
+
+	avmpb "github.com/coinflect/coinflectchain/proto/pb/avm"
+	healthpb "github.com/coinflect/coinflectchain/proto/pb/health"
+	indexpb "github.com/coinflect/coinflectchain/proto/pb/index"
+	infopb "github.com/coinflect/coinflectchain/proto/pb/info"
+	platformpb "github.com/coinflect/coinflectchain/proto/pb/platform"
+)
+
+var (
+	_ Server = (*server)(nil)
+
+	errInvalidClientCAs = errors.New("couldn't parse any PEM encoded client CA certificate")
+	errShutdown         = errors.New("gRPC server has been shut down")
+)
+
+// Server serves the info, health and index APIs and the read-only methods of
+// the P-chain and X-chain APIs over gRPC.
+//
+// Every gRPC call is forwarded to the handler of the HTTP server as a JSON-RPC
+// request, so the calls share the authorization, the rate limits and the
+// implementation of the JSON-RPC APIs. If auth tokens are required, they must
+// be passed in the "authorization" metadata as "Bearer <token>". Clients
+// that present a certificate signed by a client CA are authorized by the
+// certificate, as they are by the HTTP server.
+type Server interface {
+	// Dispatch starts the gRPC server
+	Dispatch() error
+	// DispatchTLS starts the gRPC server with the provided TLS certificate.
+	// If [clientCABytes] is non-empty, client certificates signed by one of
+	// the PEM encoded CAs are verified and passed on to the HTTP handler.
+	DispatchTLS(certBytes, keyBytes, clientCABytes []byte) error
+	// Shutdown stops the gRPC server
+	Shutdown()
+}
+
+type server struct {
+	log        logging.Logger
+	listenHost string
+	listenPort uint16
+	forwarder  *forwarder
+
+	lock     sync.Mutex
+	shutdown bool
+	srv      *grpc.Server
+}
+
+// New returns a gRPC server that listens on [host]:[port] and forwards its
+// calls to [handler].
+func New(log logging.Logger, host string, port uint16, handler http.Handler) Server {
+	return &server{
+		log:        log,
+		listenHost: host,
+		listenPort: port,
+		forwarder:  &forwarder{handler: handler},
+	}
+}
+
+func (s *server) Dispatch() error {
+	listenAddress := fmt.Sprintf("%s:%d", s.listenHost, s.listenPort)
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("gRPC API server listening",
+		zap.String("address", listener.Addr().String()),
+	)
+	return s.serve(listener)
+}
+
+func (s *server) DispatchTLS(certBytes, keyBytes, clientCABytes []byte) error {
+	config, err := newTLSConfig(certBytes, keyBytes, clientCABytes)
+	if err != nil {
+		return err
+	}
+
+	listenAddress := fmt.Sprintf("%s:%d", s.listenHost, s.listenPort)
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("gRPC API server listening with TLS",
+		zap.String("address", listener.Addr().String()),
+	)
+	// The handshake is done by the gRPC server, rather than by the listener,
+	// so that the connection state of each call is available to the
+	// forwarder.
+	return s.serve(listener, grpc.Creds(credentials.NewTLS(config)))
+}
+
+func newTLSConfig(certBytes, keyBytes, clientCABytes []byte) (*tls.Config, error) {
+	cert, err := tls.X509KeyPair(certBytes, keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+	if len(clientCABytes) > 0 {
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(clientCABytes) {
+			return nil, errInvalidClientCAs
+		}
+		// Clients without a certificate are still accepted so that they can
+		// authenticate with an auth token.
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+		config.ClientCAs = clientCAs
+	}
+	return config, nil
+}
+
+// serve registers the APIs on a new gRPC server and serves [listener] with it
+func (s *server) serve(listener net.Listener, opts ...grpc.ServerOption) error {
+	s.lock.Lock()
+	if s.shutdown {
+		s.lock.Unlock()
+		_ = listener.Close()
+		return errShutdown
+	}
+	srv := grpc.NewServer(opts...)
+	infopb.RegisterInfoServer(srv, &infoServer{forwarder: s.forwarder})
+	healthpb.RegisterHealthServer(srv, &healthServer{forwarder: s.forwarder})
+	indexpb.RegisterIndexServer(srv, &indexServer{forwarder: s.forwarder})
+	platformpb.RegisterPlatformServer(srv, &platformServer{forwarder: s.forwarder})
+	avmpb.RegisterAVMServer(srv, &avmServer{forwarder: s.forwarder})
+	s.srv = srv
+	s.lock.Unlock()
+
+	return srv.Serve(listener)
+}
+
+func (s *server) Shutdown() {
+	s.lock.Lock()
+	s.shutdown = true
+	srv := s.srv
+	s.lock.Unlock()
+
+	if srv != nil {
+		srv.GracefulStop()
+	}
+}
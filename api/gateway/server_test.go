@@ -0,0 +1,559 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/coinflect/coinflectchain/api"
+	"github.com/coinflect/coinflectchain/api/health"
+	"github.com/coinflect/coinflectchain/api/info"
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/indexer"
+	"github.com/coinflect/coinflectchain/network/peer"
+	"github.com/coinflect/coinflectchain/staking"
+	"github.com/coinflect/coinflectchain/utils/formatting"
+	"github.com/coinflect/coinflectchain/utils/logging"
+	"github.com/coinflect/coinflectchain/vms/avm"
+	"github.com/coinflect/coinflectchain/vms/platformvm"
+
+	avmpb "github.com/coinflect/coinflectchain/proto/pb/avm"
+	healthpb "github.com/coinflect/coinflectchain/proto/pb/health"
+	indexpb "github.com/coinflect/coinflectchain/proto/pb/index"
+	infopb "github.com/coinflect/coinflectchain/proto/pb/info"
+	platformpb "github.com/coinflect/coinflectchain/proto/pb/platform"
+	cjson "github.com/coinflect/coinflectchain/utils/json"
+)
+
+// forwardedRequest is a JSON-RPC request received by the test handler
+type forwardedRequest struct {
+	path          string
+	authorization string
+	remoteAddr    string
+	method        string
+	params        map[string]interface{}
+}
+
+// newTestConn returns a connection to a gateway whose requests are served by
+// [handler].
+func newTestConn(t *testing.T, handler http.Handler) *grpc.ClientConn {
+	listener := bufconn.Listen(1024 * 1024)
+	s := New(logging.NoLog{}, "", 0, handler).(*server)
+	go func() {
+		_ = s.serve(listener)
+	}()
+	t.Cleanup(s.Shutdown)
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+// newTestHandler returns a handler that records the requests it receives and
+// replies with [response].
+func newTestHandler(t *testing.T, code int, response string, requests chan<- forwardedRequest) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var req struct {
+			Method string                 `json:"method"`
+			Params map[string]interface{} `json:"params"`
+		}
+		require.NoError(t, json.Unmarshal(body, &req))
+		requests <- forwardedRequest{
+			path:          r.URL.Path,
+			authorization: r.Header.Get("Authorization"),
+			remoteAddr:    r.RemoteAddr,
+			method:        req.Method,
+			params:        req.Params,
+		}
+
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte(response))
+	})
+}
+
+func TestForward(t *testing.T) {
+	require := require.New(t)
+
+	requests := make(chan forwardedRequest, 1)
+	handler := newTestHandler(t, http.StatusOK, `{"jsonrpc":"2.0","id":1,"result":{"version":"coinflect/1.0.0","rpcProtocolVersion":"19","vmVersions":{"avm":"v1.0.0"},"unknownField":true}}`, requests)
+	client := infopb.NewInfoClient(newTestConn(t, handler))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), authorizationKey, "Bearer token")
+	reply, err := client.GetNodeVersion(ctx, &infopb.GetNodeVersionRequest{})
+	require.NoError(err)
+	require.Equal("coinflect/1.0.0", reply.Version)
+	require.Equal(uint32(19), reply.RpcProtocolVersion)
+	require.Equal(map[string]string{"avm": "v1.0.0"}, reply.VmVersions)
+
+	req := <-requests
+	require.Equal("/ext/info", req.path)
+	require.Equal("Bearer token", req.authorization)
+	require.NotEmpty(req.remoteAddr)
+	require.Equal("info.getNodeVersion", req.method)
+	require.Empty(req.params)
+}
+
+func TestForwardClientCertificate(t *testing.T) {
+	require := require.New(t)
+
+	serverCertBytes, serverKeyBytes, err := staking.NewCertAndKeyBytes()
+	require.NoError(err)
+	clientCertBytes, clientKeyBytes, err := staking.NewCertAndKeyBytes()
+	require.NoError(err)
+	clientCert, err := tls.X509KeyPair(clientCertBytes, clientKeyBytes)
+	require.NoError(err)
+
+	// The client certificate is self-signed, so it is its own CA.
+	config, err := newTLSConfig(serverCertBytes, serverKeyBytes, clientCertBytes)
+	require.NoError(err)
+
+	verifiedChains := make(chan [][]*x509.Certificate, 1)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NotNil(r.TLS)
+		verifiedChains <- r.TLS.VerifiedChains
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"version":"coinflect/1.0.0"}}`))
+	})
+
+	listener := bufconn.Listen(1024 * 1024)
+	s := New(logging.NoLog{}, "", 0, handler).(*server)
+	go func() {
+		_ = s.serve(listener, grpc.Creds(credentials.NewTLS(config)))
+	}()
+	t.Cleanup(s.Shutdown)
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			Certificates:       []tls.Certificate{clientCert},
+			InsecureSkipVerify: true, //#nosec G402
+		})),
+	)
+	require.NoError(err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	_, err = infopb.NewInfoClient(conn).GetNodeVersion(context.Background(), &infopb.GetNodeVersionRequest{})
+	require.NoError(err)
+
+	chains := <-verifiedChains
+	require.NotEmpty(chains)
+	require.Equal(clientCert.Certificate[0], chains[0][0].Raw)
+}
+
+func TestNewTLSConfigInvalidClientCAs(t *testing.T) {
+	certBytes, keyBytes, err := staking.NewCertAndKeyBytes()
+	require.NoError(t, err)
+
+	_, err = newTLSConfig(certBytes, keyBytes, []byte("not a certificate"))
+	require.ErrorIs(t, err, errInvalidClientCAs)
+}
+
+func TestForwardParams(t *testing.T) {
+	require := require.New(t)
+
+	requests := make(chan forwardedRequest, 1)
+	handler := newTestHandler(t, http.StatusOK, `{"jsonrpc":"2.0","id":1,"result":{"balance":"5","balances":{"asset":"5"},"utxoIDs":[{"txID":"tx","outputIndex":1}]}}`, requests)
+	client := platformpb.NewPlatformClient(newTestConn(t, handler))
+
+	reply, err := client.GetBalance(context.Background(), &platformpb.GetBalanceRequest{
+		Addresses: []string{"P-local1"},
+	})
+	require.NoError(err)
+	require.Equal(uint64(5), reply.Balance)
+	require.Equal(map[string]uint64{"asset": 5}, reply.Balances)
+	require.Len(reply.UtxoIds, 1)
+	require.Equal("tx", reply.UtxoIds[0].TxId)
+	require.Equal(uint32(1), reply.UtxoIds[0].OutputIndex)
+
+	req := <-requests
+	require.Equal("/ext/bc/P", req.path)
+	require.Empty(req.authorization)
+	require.Equal("platform.getBalance", req.method)
+	require.Equal(map[string]interface{}{"addresses": []interface{}{"P-local1"}}, req.params)
+}
+
+func TestForwardHealth(t *testing.T) {
+	require := require.New(t)
+
+	requests := make(chan forwardedRequest, 1)
+	handler := newTestHandler(t, http.StatusOK, `{"jsonrpc":"2.0","id":1,"result":{"checks":{"network":{"message":{"connectedPeers":3},"timestamp":"2022-01-01T00:00:00Z","duration":1000}},"healthy":true}}`, requests)
+	client := healthpb.NewHealthClient(newTestConn(t, handler))
+
+	reply, err := client.Health(context.Background(), &healthpb.HealthRequest{})
+	require.NoError(err)
+	require.True(reply.Healthy)
+	require.Contains(reply.Checks, "network")
+
+	check := reply.Checks["network"]
+	require.Equal(map[string]interface{}{"connectedPeers": float64(3)}, check.Message.AsInterface())
+	require.Empty(check.Error)
+	require.Equal("2022-01-01T00:00:00Z", check.Timestamp)
+	require.Equal(int64(1000), check.Duration)
+
+	req := <-requests
+	require.Equal("/ext/health", req.path)
+	require.Equal("health.health", req.method)
+}
+
+func TestForwardIndex(t *testing.T) {
+	require := require.New(t)
+
+	requests := make(chan forwardedRequest, 1)
+	handler := newTestHandler(t, http.StatusOK, `{"jsonrpc":"2.0","id":1,"result":{"id":"container","bytes":"0x00","encoding":"hex","index":"2"}}`, requests)
+	client := indexpb.NewIndexClient(newTestConn(t, handler))
+
+	reply, err := client.GetContainerByIndex(context.Background(), &indexpb.GetContainerByIndexRequest{
+		Chain:     "X",
+		IndexType: "tx",
+		Index:     2,
+	})
+	require.NoError(err)
+	require.Equal("container", reply.Id)
+	require.Equal(uint64(2), reply.Index)
+
+	// The chain and the index type select the endpoint and aren't passed to
+	// the JSON-RPC method.
+	req := <-requests
+	require.Equal("/ext/index/X/tx", req.path)
+	require.Equal("index.getContainerByIndex", req.method)
+	require.Equal(map[string]interface{}{"index": "2"}, req.params)
+
+	invalidRequests := []*indexpb.GetContainerByIndexRequest{
+		{IndexType: "tx"},
+		{Chain: "X/../../admin", IndexType: "tx"},
+		{Chain: "X", IndexType: "utxo"},
+	}
+	for _, invalidRequest := range invalidRequests {
+		_, err := client.GetContainerByIndex(context.Background(), invalidRequest)
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	}
+}
+
+func TestForwardErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		code         int
+		response     string
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:         "unauthorized",
+			code:         http.StatusUnauthorized,
+			response:     "token is invalid\n",
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "token is invalid",
+		},
+		{
+			name:         "rate limited",
+			code:         http.StatusTooManyRequests,
+			response:     "too many requests",
+			expectedCode: codes.ResourceExhausted,
+			expectedMsg:  "too many requests",
+		},
+		{
+			name:         "no method",
+			code:         http.StatusOK,
+			response:     `{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`,
+			expectedCode: codes.Unimplemented,
+			expectedMsg:  "method not found",
+		},
+		{
+			name:         "server error",
+			code:         http.StatusOK,
+			response:     `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"not bootstrapped"}}`,
+			expectedCode: codes.Unknown,
+			expectedMsg:  "not bootstrapped",
+		},
+		{
+			name:         "invalid result",
+			code:         http.StatusOK,
+			response:     `{"jsonrpc":"2.0","id":1,"result":{"healthy":"yes"}}`,
+			expectedCode: codes.Internal,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requests := make(chan forwardedRequest, 1)
+			handler := newTestHandler(t, test.code, test.response, requests)
+			client := healthpb.NewHealthClient(newTestConn(t, handler))
+
+			_, err := client.Health(context.Background(), &healthpb.HealthRequest{})
+			s, ok := status.FromError(err)
+			require.True(t, ok)
+			require.Equal(t, test.expectedCode, s.Code())
+			if test.expectedMsg != "" {
+				require.Equal(t, test.expectedMsg, s.Message())
+			}
+		})
+	}
+}
+
+// TestMessagesMatchJSONRPC verifies that the gRPC requests are parsed as the
+// arguments of the JSON-RPC methods they are forwarded to, and that the
+// results of the JSON-RPC methods are parsed as the gRPC responses, without
+// dropping any field.
+func TestMessagesMatchJSONRPC(t *testing.T) {
+	id := ids.GenerateTestID()
+	nodeID := ids.GenerateTestNodeID()
+	height := uint64(5)
+	errorMessage := "failed"
+
+	requests := []struct {
+		name string
+		req  proto.Message
+		args interface{}
+	}{
+		{
+			name: "platform.getUTXOs",
+			req: &platformpb.GetUTXOsRequest{
+				Addresses:   []string{"P-local1"},
+				SourceChain: "X",
+				Limit:       1,
+				StartIndex:  &platformpb.UTXOIndex{Address: "P-local1", Utxo: id.String()},
+				Encoding:    "hex",
+			},
+			args: &api.GetUTXOsArgs{},
+		},
+		{
+			name: "platform.getSubnets",
+			req:  &platformpb.GetSubnetsRequest{Ids: []string{id.String()}},
+			args: &platformvm.GetSubnetsArgs{},
+		},
+		{
+			name: "platform.getCurrentValidators",
+			req:  &platformpb.GetCurrentValidatorsRequest{SubnetId: id.String(), NodeIds: []string{nodeID.String()}},
+			args: &platformvm.GetCurrentValidatorsArgs{},
+		},
+		{
+			name: "platform.sampleValidators",
+			req:  &platformpb.SampleValidatorsRequest{Size: 2, SubnetId: id.String()},
+			args: &platformvm.SampleValidatorsArgs{},
+		},
+		{
+			name: "platform.getStake",
+			req:  &platformpb.GetStakeRequest{Addresses: []string{"P-local1"}, Encoding: "hex"},
+			args: &platformvm.GetStakeArgs{},
+		},
+		{
+			name: "platform.getMaxStakeAmount",
+			req:  &platformpb.GetMaxStakeAmountRequest{SubnetId: id.String(), NodeId: nodeID.String(), StartTime: 1, EndTime: 2},
+			args: &platformvm.GetMaxStakeAmountArgs{},
+		},
+		{
+			name: "platform.getRewardUTXOs",
+			req:  &platformpb.GetRewardUTXOsRequest{TxId: id.String(), Encoding: "hex"},
+			args: &api.GetTxArgs{},
+		},
+		{
+			name: "platform.getAddressTxs",
+			req:  &platformpb.GetAddressTxsRequest{Address: "P-local1", Cursor: 1, PageSize: 2, AssetId: id.String()},
+			args: &platformvm.GetAddressTxsArgs{},
+		},
+		{
+			name: "platform.getValidatorsAt",
+			req:  &platformpb.GetValidatorsAtRequest{Height: 1, SubnetId: id.String()},
+			args: &platformvm.GetValidatorsAtArgs{},
+		},
+		{
+			name: "avm.getBalanceAt",
+			req:  &avmpb.GetBalanceAtRequest{Address: "X-local1", AssetId: id.String(), Height: &height},
+			args: &avm.GetBalanceAtArgs{},
+		},
+		{
+			name: "avm.getBalanceHistory",
+			req:  &avmpb.GetBalanceHistoryRequest{Address: "X-local1", Cursor: 1, PageSize: 2, AssetId: id.String()},
+			args: &avm.GetBalanceHistoryArgs{},
+		},
+		{
+			name: "info.peers",
+			req:  &infopb.PeersRequest{NodeIds: []string{nodeID.String()}},
+			args: &info.PeersArgs{},
+		},
+		{
+			name: "index.getContainersByTime",
+			req: &indexpb.GetContainersByTimeRequest{
+				StartTime:  "2022-01-01T00:00:00Z",
+				EndTime:    "2022-01-02T00:00:00Z",
+				Cursor:     "cursor",
+				NumToFetch: 1,
+				Encoding:   "hex",
+			},
+			args: &indexer.GetContainersByTimeArgs{},
+		},
+	}
+	for _, test := range requests {
+		t.Run(test.name, func(t *testing.T) {
+			params, err := protojson.Marshal(test.req)
+			require.NoError(t, err)
+			decoder := json.NewDecoder(bytes.NewReader(params))
+			decoder.DisallowUnknownFields()
+			require.NoError(t, decoder.Decode(test.args))
+		})
+	}
+
+	replies := []struct {
+		name  string
+		reply interface{}
+		resp  proto.Message
+	}{
+		{
+			name: "platform.getUTXOs",
+			reply: &api.GetUTXOsReply{
+				NumFetched: 1,
+				UTXOs:      []string{"0x00"},
+				EndIndex:   api.Index{Address: "P-local1", UTXO: id.String()},
+				Encoding:   formatting.Hex,
+			},
+			resp: &platformpb.GetUTXOsResponse{},
+		},
+		{
+			name: "platform.getSubnets",
+			reply: &platformvm.GetSubnetsResponse{Subnets: []platformvm.APISubnet{{
+				ID:          id,
+				ControlKeys: []string{"P-local1"},
+				Threshold:   1,
+			}}},
+			resp: &platformpb.GetSubnetsResponse{},
+		},
+		{
+			name: "platform.getPendingValidators",
+			reply: &platformvm.GetPendingValidatorsReply{
+				Validators: []interface{}{map[string]interface{}{"nodeID": nodeID}},
+				Delegators: []interface{}{},
+			},
+			resp: &platformpb.GetPendingValidatorsResponse{},
+		},
+		{
+			name:  "platform.getBlockchains",
+			reply: &platformvm.GetBlockchainsResponse{Blockchains: []platformvm.APIBlockchain{{ID: id, Name: "X", SubnetID: id, VMID: id}}},
+			resp:  &platformpb.GetBlockchainsResponse{},
+		},
+		{
+			name: "platform.getStake",
+			reply: &platformvm.GetStakeReply{
+				Staked:   1,
+				Stakeds:  map[ids.ID]cjson.Uint64{id: 1},
+				Outputs:  []string{"0x00"},
+				Encoding: formatting.Hex,
+			},
+			resp: &platformpb.GetStakeResponse{},
+		},
+		{
+			name:  "platform.getMinStake",
+			reply: &platformvm.GetMinStakeReply{MinValidatorStake: 1, MinDelegatorStake: 2},
+			resp:  &platformpb.GetMinStakeResponse{},
+		},
+		{
+			name:  "platform.getRewardUTXOs",
+			reply: &platformvm.GetRewardUTXOsReply{NumFetched: 1, UTXOs: []string{"0x00"}, Encoding: formatting.Hex},
+			resp:  &platformpb.GetRewardUTXOsResponse{},
+		},
+		{
+			name:  "platform.getValidatorsAt",
+			reply: &platformvm.GetValidatorsAtReply{Validators: map[ids.NodeID]uint64{nodeID: 1}},
+			resp:  &platformpb.GetValidatorsAtResponse{},
+		},
+		{
+			name: "avm.getBalanceHistory",
+			reply: &avm.GetBalanceHistoryReply{
+				Changes: []avm.BalanceChange{{TxID: id, Height: 1, Timestamp: 2, Received: 3, Sent: 4, Balance: 5}},
+				Cursor:  1,
+			},
+			resp: &avmpb.GetBalanceHistoryResponse{},
+		},
+		{
+			name: "info.peers",
+			reply: &info.PeersReply{
+				NumPeers: 1,
+				Peers: []info.Peer{{
+					Info: peer.Info{
+						IP:             "127.0.0.1:9651",
+						PublicIP:       "127.0.0.1:9651",
+						ID:             nodeID,
+						Version:        "coinflect/1.0.0",
+						LastSent:       time.Unix(1, 0),
+						LastReceived:   time.Unix(2, 0),
+						ObservedUptime: 100,
+						TrackedSubnets: []ids.ID{id},
+					},
+					Benched: []ids.ID{id},
+				}},
+			},
+			resp: &infopb.PeersResponse{},
+		},
+		{
+			name:  "info.getVMs",
+			reply: &info.GetVMsReply{VMs: map[ids.ID][]string{id: {"avm"}}},
+			resp:  &infopb.GetVMsResponse{},
+		},
+		{
+			name: "health.history",
+			reply: &health.History{Health: map[string][]health.Transition{
+				"network": {{Timestamp: time.Unix(1, 0), Healthy: false, Error: &errorMessage}},
+			}},
+			resp: &healthpb.HistoryResponse{},
+		},
+		{
+			name: "index.getContainersByTime",
+			reply: &indexer.GetContainersByTimeResponse{
+				Containers: []indexer.FormattedContainer{{
+					ID:        id,
+					Bytes:     "0x00",
+					Timestamp: time.Unix(1, 0),
+					Encoding:  formatting.Hex,
+					Index:     1,
+				}},
+				NextCursor: "cursor",
+			},
+			resp: &indexpb.GetContainersByTimeResponse{},
+		},
+	}
+	for _, test := range replies {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := json.Marshal(test.reply)
+			require.NoError(t, err)
+			require.NoError(t, protojson.Unmarshal(result, test.resp))
+		})
+	}
+}
@@ -21,6 +21,8 @@ type Client interface {
 	Health(context.Context, ...rpc.Option) (*APIHealthReply, error)
 	// Liveness returns if the node is in need of a restart
 	Liveness(context.Context, ...rpc.Option) (*APIHealthReply, error)
+	// History returns the most recent transitions of the checks
+	History(context.Context, ...rpc.Option) (*History, error)
 	// AwaitHealthy queries the Health endpoint with a pause of [interval]
 	// in between checks and returns early if Health returns healthy
 	AwaitHealthy(ctx context.Context, freq time.Duration, options ...rpc.Option) (bool, error)
@@ -56,6 +58,12 @@ func (c *client) Liveness(ctx context.Context, options ...rpc.Option) (*APIHealt
 	return res, err
 }
 
+func (c *client) History(ctx context.Context, options ...rpc.Option) (*History, error) {
+	res := &History{}
+	err := c.requester.SendRequest(ctx, "health.history", struct{}{}, res, options...)
+	return res, err
+}
+
 func (c *client) AwaitHealthy(ctx context.Context, freq time.Duration, options ...rpc.Option) (bool, error) {
 	ticker := time.NewTicker(freq)
 	defer ticker.Stop()
@@ -9,32 +9,49 @@ import (
 
 	stdjson "encoding/json"
 
-	"github.com/gorilla/rpc/v2"
-
+	"github.com/coinflect/coinflectchain/api/openrpc"
 	"github.com/coinflect/coinflectchain/utils/json"
 	"github.com/coinflect/coinflectchain/utils/logging"
 )
 
+var _ http.Handler = (*GetAndPostHandler)(nil)
+
+// GetAndPostHandler serves jsonrpc POST requests with the embedded server and
+// GET requests with [get]. The embedded server is exposed so that the handler
+// is still recognized as a jsonrpc server, for example to support batches.
+//
+// Because GET requests report the health of the node, the OpenRPC document of
+// the health API isn't returned by GET requests to the handler. It is returned
+// by the "rpc.discover" method, and by the handler returned by
+// DocumentHandler, which is meant to be served on a separate endpoint.
+type GetAndPostHandler struct {
+	*openrpc.Server
+	get http.Handler
+}
+
+// If a GET request is sent, we respond with a 200 if the node is healthy or
+// a 503 if the node isn't healthy.
+func (h *GetAndPostHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.Server.ServeHTTP(w, r)
+		return
+	}
+
+	h.get.ServeHTTP(w, r)
+}
+
 // NewGetAndPostHandler returns a health handler that supports GET and jsonrpc
 // POST requests.
-func NewGetAndPostHandler(log logging.Logger, reporter Reporter) (http.Handler, error) {
-	newServer := rpc.NewServer()
+func NewGetAndPostHandler(log logging.Logger, reporter Reporter) (*GetAndPostHandler, error) {
+	newServer := openrpc.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
 
-	getHandler := NewGetHandler(reporter.Health)
-
-	// If a GET request is sent, we respond with a 200 if the node is healthy or
-	// a 503 if the node isn't healthy.
-	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			newServer.ServeHTTP(w, r)
-			return
-		}
-
-		getHandler.ServeHTTP(w, r)
-	})
+	handler := &GetAndPostHandler{
+		Server: newServer,
+		get:    NewGetHandler(reporter.Health),
+	}
 
 	err := newServer.RegisterService(
 		&Service{
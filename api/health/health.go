@@ -6,6 +6,9 @@ package health
 
 import (
 	"context"
+	"errors"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -15,7 +18,15 @@ import (
 	"github.com/coinflect/coinflectchain/utils/logging"
 )
 
-var _ Health = (*health)(nil)
+// MaintenanceCheck is the name of the readiness check that fails while the node
+// is in maintenance mode
+const MaintenanceCheck = "maintenance"
+
+var (
+	_ Health = (*health)(nil)
+
+	errMaintenanceMode = errors.New("node is in maintenance mode")
+)
 
 // Health defines the full health service interface for registering, reporting
 // and refreshing health checks.
@@ -23,15 +34,24 @@ type Health interface {
 	Registerer
 	Reporter
 
+	// SetMaintenanceMode marks the node as not ready while [enabled], so that
+	// load balancers stop routing requests to it. The health and the liveness
+	// of the node, and its participation in consensus, aren't affected.
+	SetMaintenanceMode(enabled bool, reason string)
+
 	Start(ctx context.Context, freq time.Duration)
 	Stop()
 }
 
 // Registerer defines how to register new components to check the health of.
+//
+// A check may name the checks it depends on, among the readiness, health and
+// liveness checks. When a check fails, the failing checks it depends on are
+// reported as the root causes of its failure.
 type Registerer interface {
-	RegisterReadinessCheck(name string, checker Checker) error
-	RegisterHealthCheck(name string, checker Checker) error
-	RegisterLivenessCheck(name string, checker Checker) error
+	RegisterReadinessCheck(name string, checker Checker, dependencies ...string) error
+	RegisterHealthCheck(name string, checker Checker, dependencies ...string) error
+	RegisterLivenessCheck(name string, checker Checker, dependencies ...string) error
 }
 
 // Reporter returns the current health status.
@@ -39,6 +59,8 @@ type Reporter interface {
 	Readiness() (map[string]Result, bool)
 	Health() (map[string]Result, bool)
 	Liveness() (map[string]Result, bool)
+	// History returns the most recent transitions of the checks
+	History() History
 }
 
 type health struct {
@@ -46,6 +68,11 @@ type health struct {
 	readiness *worker
 	health    *worker
 	liveness  *worker
+
+	maintenanceLock sync.RWMutex
+	// maintenance is the result of [MaintenanceCheck], or nil if the node
+	// isn't in maintenance mode
+	maintenance *Result
 }
 
 func New(log logging.Logger, registerer prometheus.Registerer) (Health, error) {
@@ -68,20 +95,28 @@ func New(log logging.Logger, registerer prometheus.Registerer) (Health, error) {
 	}, err
 }
 
-func (h *health) RegisterReadinessCheck(name string, checker Checker) error {
-	return h.readiness.RegisterMonotonicCheck(name, checker)
+func (h *health) RegisterReadinessCheck(name string, checker Checker, dependencies ...string) error {
+	return h.readiness.RegisterMonotonicCheck(name, checker, dependencies...)
 }
 
-func (h *health) RegisterHealthCheck(name string, checker Checker) error {
-	return h.health.RegisterCheck(name, checker)
+func (h *health) RegisterHealthCheck(name string, checker Checker, dependencies ...string) error {
+	return h.health.RegisterCheck(name, checker, dependencies...)
 }
 
-func (h *health) RegisterLivenessCheck(name string, checker Checker) error {
-	return h.liveness.RegisterCheck(name, checker)
+func (h *health) RegisterLivenessCheck(name string, checker Checker, dependencies ...string) error {
+	return h.liveness.RegisterCheck(name, checker, dependencies...)
 }
 
 func (h *health) Readiness() (map[string]Result, bool) {
-	results, healthy := h.readiness.Results()
+	results, healthy := h.results(h.readiness)
+
+	h.maintenanceLock.RLock()
+	if h.maintenance != nil {
+		results[MaintenanceCheck] = *h.maintenance
+		healthy = false
+	}
+	h.maintenanceLock.RUnlock()
+
 	if !healthy {
 		h.log.Warn("failing readiness check",
 			zap.Reflect("reason", results),
@@ -91,7 +126,7 @@ func (h *health) Readiness() (map[string]Result, bool) {
 }
 
 func (h *health) Health() (map[string]Result, bool) {
-	results, healthy := h.health.Results()
+	results, healthy := h.results(h.health)
 	if !healthy {
 		h.log.Warn("failing health check",
 			zap.Reflect("reason", results),
@@ -101,7 +136,7 @@ func (h *health) Health() (map[string]Result, bool) {
 }
 
 func (h *health) Liveness() (map[string]Result, bool) {
-	results, healthy := h.liveness.Results()
+	results, healthy := h.results(h.liveness)
 	if !healthy {
 		h.log.Warn("failing liveness check",
 			zap.Reflect("reason", results),
@@ -110,6 +145,52 @@ func (h *health) Liveness() (map[string]Result, bool) {
 	return results, healthy
 }
 
+func (h *health) History() History {
+	return History{
+		Readiness: h.readiness.History(),
+		Health:    h.health.History(),
+		Liveness:  h.liveness.History(),
+	}
+}
+
+func (h *health) SetMaintenanceMode(enabled bool, reason string) {
+	h.maintenanceLock.Lock()
+	defer h.maintenanceLock.Unlock()
+
+	if enabled == (h.maintenance != nil) {
+		return
+	}
+
+	now := time.Now()
+	if !enabled {
+		h.log.Info("leaving maintenance mode")
+		h.maintenance = nil
+		h.readiness.AddTransition(MaintenanceCheck, Transition{
+			Timestamp: now,
+			Healthy:   true,
+		})
+		return
+	}
+
+	h.log.Info("entering maintenance mode",
+		zap.String("reason", reason),
+	)
+	errString := errMaintenanceMode.Error()
+	h.maintenance = &Result{
+		Details: map[string]interface{}{
+			"reason": reason,
+		},
+		Error:              &errString,
+		Timestamp:          now,
+		TimeOfFirstFailure: &now,
+	}
+	h.readiness.AddTransition(MaintenanceCheck, Transition{
+		Timestamp: now,
+		Healthy:   false,
+		Error:     &errString,
+	})
+}
+
 func (h *health) Start(ctx context.Context, freq time.Duration) {
 	h.readiness.Start(ctx, freq)
 	h.health.Start(ctx, freq)
@@ -121,3 +202,74 @@ func (h *health) Stop() {
 	h.health.Stop()
 	h.liveness.Stop()
 }
+
+// results returns the results of the checks of [w]. The root causes of the
+// failing checks are searched among the checks of [w] first, and then among
+// the readiness, health and liveness checks.
+func (h *health) results(w *worker) (map[string]Result, bool) {
+	results, healthy := w.Results()
+	if healthy {
+		return results, healthy
+	}
+
+	readinessResults, _ := h.readiness.Results()
+	healthResults, _ := h.health.Results()
+	livenessResults, _ := h.liveness.Results()
+	lookup := func(name string) (Result, bool) {
+		for _, checks := range []map[string]Result{results, readinessResults, healthResults, livenessResults} {
+			if result, ok := checks[name]; ok {
+				return result, true
+			}
+		}
+		return Result{}, false
+	}
+
+	for name, result := range results {
+		if result.Error == nil || len(result.Dependencies) == 0 {
+			continue
+		}
+
+		causes := make(map[string]struct{})
+		addRootCauses(causes, result, lookup, map[string]struct{}{name: {}})
+		if len(causes) == 0 {
+			continue
+		}
+		result.RootCauses = make([]string, 0, len(causes))
+		for cause := range causes {
+			result.RootCauses = append(result.RootCauses, cause)
+		}
+		sort.Strings(result.RootCauses)
+		results[name] = result
+	}
+	return results, healthy
+}
+
+// addRootCauses adds to [causes] the failing dependencies of [result], direct
+// or transitive, whose own dependencies are passing. [path] contains the checks
+// that depend on [result], so that cyclic dependencies are ignored. Returns
+// true if any dependency of [result] is failing.
+func addRootCauses(
+	causes map[string]struct{},
+	result Result,
+	lookup func(string) (Result, bool),
+	path map[string]struct{},
+) bool {
+	failing := false
+	for _, dependency := range result.Dependencies {
+		if _, ok := path[dependency]; ok {
+			continue
+		}
+		dependencyResult, ok := lookup(dependency)
+		if !ok || dependencyResult.Error == nil {
+			continue
+		}
+		failing = true
+
+		path[dependency] = struct{}{}
+		if !addRootCauses(causes, dependencyResult, lookup, path) {
+			causes[dependency] = struct{}{}
+		}
+		delete(path, dependency)
+	}
+	return failing
+}
@@ -275,3 +275,139 @@ func TestDeadlockRegression(t *testing.T) {
 
 	awaitHealthy(h, true)
 }
+
+func TestHistory(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		shouldCheckErr utils.AtomicBool
+		checkErr       = errors.New("unhealthy")
+	)
+	check := CheckerFunc(func(context.Context) (interface{}, error) {
+		if shouldCheckErr.GetValue() {
+			return nil, checkErr
+		}
+		return "", nil
+	})
+
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	require.NoError(err)
+	w := h.(*health).health
+
+	err = h.RegisterHealthCheck("check", check)
+	require.NoError(err)
+	require.Empty(h.History().Health)
+
+	// The first result is recorded, but not the results that don't change the
+	// state of the check.
+	w.runChecks(context.Background())
+	w.runChecks(context.Background())
+	history := h.History().Health["check"]
+	require.Len(history, 1)
+	require.True(history[0].Healthy)
+	require.Nil(history[0].Error)
+
+	shouldCheckErr.SetValue(true)
+	w.runChecks(context.Background())
+	w.runChecks(context.Background())
+	history = h.History().Health["check"]
+	require.Len(history, 2)
+	require.False(history[1].Healthy)
+	require.Equal(checkErr.Error(), *history[1].Error)
+
+	// Only the most recent transitions are kept
+	for i := 0; i < maxHistorySize; i++ {
+		shouldCheckErr.SetValue(!shouldCheckErr.GetValue())
+		w.runChecks(context.Background())
+	}
+	history = h.History().Health["check"]
+	require.Len(history, maxHistorySize)
+	require.True(history[0].Healthy)
+	require.False(history[maxHistorySize-1].Healthy)
+}
+
+func TestRootCauses(t *testing.T) {
+	require := require.New(t)
+
+	checkErr := errors.New("unhealthy")
+	failingCheck := CheckerFunc(func(context.Context) (interface{}, error) {
+		return nil, checkErr
+	})
+	passingCheck := CheckerFunc(func(context.Context) (interface{}, error) {
+		return "", nil
+	})
+
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	require.NoError(err)
+
+	require.NoError(h.RegisterHealthCheck("network", failingCheck))
+	require.NoError(h.RegisterHealthCheck("database", passingCheck))
+	require.NoError(h.RegisterHealthCheck("chain", failingCheck, "network", "database"))
+	require.NoError(h.RegisterHealthCheck("cycle1", failingCheck, "cycle2"))
+	require.NoError(h.RegisterHealthCheck("cycle2", failingCheck, "cycle1"))
+	require.NoError(h.RegisterReadinessCheck("bootstrapped", failingCheck, "chain", "database", "unknown"))
+
+	h.(*health).readiness.runChecks(context.Background())
+	h.(*health).health.runChecks(context.Background())
+
+	// Readiness checks may depend on health checks
+	readinessResult, readiness := h.Readiness()
+	require.False(readiness)
+	result := readinessResult["bootstrapped"]
+	require.Equal([]string{"chain", "database", "unknown"}, result.Dependencies)
+	require.Equal([]string{"network"}, result.RootCauses)
+
+	healthResult, health := h.Health()
+	require.False(health)
+	require.Equal([]string{"network"}, healthResult["chain"].RootCauses)
+	require.Empty(healthResult["network"].RootCauses)
+	require.Equal([]string{"cycle2"}, healthResult["cycle1"].RootCauses)
+	require.Equal([]string{"cycle1"}, healthResult["cycle2"].RootCauses)
+}
+
+func TestMaintenanceMode(t *testing.T) {
+	require := require.New(t)
+
+	check := CheckerFunc(func(context.Context) (interface{}, error) {
+		return "", nil
+	})
+
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	require.NoError(err)
+
+	require.NoError(h.RegisterReadinessCheck("check", check))
+	require.NoError(h.RegisterHealthCheck("check", check))
+	h.(*health).readiness.runChecks(context.Background())
+	h.(*health).health.runChecks(context.Background())
+
+	_, readiness := h.Readiness()
+	require.True(readiness)
+
+	h.SetMaintenanceMode(true, "upgrade")
+
+	readinessResult, readiness := h.Readiness()
+	require.False(readiness)
+	require.Len(readinessResult, 2)
+	result := readinessResult[MaintenanceCheck]
+	require.Equal(map[string]interface{}{"reason": "upgrade"}, result.Details)
+	require.Equal(errMaintenanceMode.Error(), *result.Error)
+
+	// The node stays healthy while it's in maintenance mode
+	_, health := h.Health()
+	require.True(health)
+
+	// Enabling the maintenance mode again doesn't change its state
+	h.SetMaintenanceMode(true, "other")
+	readinessResult, _ = h.Readiness()
+	require.Equal(map[string]interface{}{"reason": "upgrade"}, readinessResult[MaintenanceCheck].Details)
+
+	h.SetMaintenanceMode(false, "")
+	readinessResult, readiness = h.Readiness()
+	require.True(readiness)
+	require.NotContains(readinessResult, MaintenanceCheck)
+
+	history := h.History().Readiness[MaintenanceCheck]
+	require.Len(history, 2)
+	require.False(history[0].Healthy)
+	require.True(history[1].Healthy)
+}
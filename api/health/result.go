@@ -38,4 +38,33 @@ type Result struct {
 
 	// TimeOfFirstFailure of the HealthCheck,
 	TimeOfFirstFailure *time.Time `json:"timeOfFirstFailure,omitempty"`
+
+	// Dependencies are the checks that must pass for this HealthCheck to
+	// pass.
+	Dependencies []string `json:"dependencies,omitempty"`
+
+	// RootCauses of the failure of the HealthCheck. These are the failing
+	// checks that this HealthCheck depends on, directly or transitively, whose
+	// own dependencies are passing.
+	RootCauses []string `json:"rootCauses,omitempty"`
+}
+
+// Transition is a change of the state of a HealthCheck
+type Transition struct {
+	// Timestamp of the HealthCheck that changed the state
+	Timestamp time.Time `json:"timestamp"`
+
+	// Healthy is true if the HealthCheck started passing and false if it
+	// started failing.
+	Healthy bool `json:"healthy"`
+
+	// Error returned by the HealthCheck if it started failing
+	Error *string `json:"error,omitempty"`
+}
+
+// History contains the most recent transitions of the checks, by check name
+type History struct {
+	Readiness map[string][]Transition `json:"readiness"`
+	Health    map[string][]Transition `json:"health"`
+	Liveness  map[string][]Transition `json:"liveness"`
 }
@@ -41,3 +41,11 @@ func (s *Service) Liveness(_ *http.Request, _ *struct{}, reply *APIHealthReply)
 	reply.Checks, reply.Healthy = s.health.Liveness()
 	return nil
 }
+
+// History returns the most recent transitions of the readiness, health and
+// liveness checks
+func (s *Service) History(_ *http.Request, _ *struct{}, reply *History) error {
+	s.log.Debug("Health.history called")
+	*reply = s.health.History()
+	return nil
+}
@@ -6,12 +6,18 @@ package health
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	stdjson "encoding/json"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/coinflect/coinflectchain/api/openrpc"
 	"github.com/coinflect/coinflectchain/utils/logging"
 )
 
@@ -112,4 +118,51 @@ func TestServiceResponses(t *testing.T) {
 		require.Zero(result.ContiguousFailures)
 		require.True(reply.Healthy)
 	}
+	{
+		reply := History{}
+		err = s.History(nil, nil, &reply)
+		require.NoError(err)
+
+		require.Len(reply.Readiness["check"], 1)
+		require.True(reply.Readiness["check"][0].Healthy)
+		require.Len(reply.Health["check"], 1)
+		require.Len(reply.Liveness["check"], 1)
+	}
+}
+
+func TestGetAndPostHandler(t *testing.T) {
+	require := require.New(t)
+
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	require.NoError(err)
+	handler, err := NewGetAndPostHandler(logging.NoLog{}, h)
+	require.NoError(err)
+
+	// GET requests report the health of the node.
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/ext/health", nil))
+	require.Equal(http.StatusOK, rr.Code)
+	reply := APIHealthReply{}
+	require.NoError(stdjson.Unmarshal(rr.Body.Bytes(), &reply))
+	require.True(reply.Healthy)
+
+	// The OpenRPC document is served by the document handler and by the
+	// "rpc.discover" method.
+	rr = httptest.NewRecorder()
+	handler.DocumentHandler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/ext/health/openrpc", nil))
+	require.Equal(http.StatusOK, rr.Code)
+	doc := openrpc.Document{}
+	require.NoError(stdjson.Unmarshal(rr.Body.Bytes(), &doc))
+	require.Equal("health", doc.Info.Title)
+
+	req := httptest.NewRequest(http.MethodPost, "/ext/health", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"rpc.discover","params":{}}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(http.StatusOK, rr.Code)
+	var resp struct {
+		Result openrpc.Document `json:"result"`
+	}
+	require.NoError(stdjson.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Equal(doc, resp.Result)
 }
@@ -16,6 +16,9 @@ import (
 	"github.com/coinflect/coinflectchain/utils"
 )
 
+// maxHistorySize is the number of transitions kept in the history of a check
+const maxHistorySize = 32
+
 var errDuplicateCheck = errors.New("duplicated check")
 
 type worker struct {
@@ -23,8 +26,10 @@ type worker struct {
 	checksLock sync.RWMutex
 	checks     map[string]Checker
 
-	resultsLock sync.RWMutex
-	results     map[string]Result
+	resultsLock  sync.RWMutex
+	results      map[string]Result
+	dependencies map[string][]string
+	history      map[string][]Transition
 
 	startOnce sync.Once
 	closeOnce sync.Once
@@ -34,14 +39,18 @@ type worker struct {
 func newWorker(namespace string, registerer prometheus.Registerer) (*worker, error) {
 	metrics, err := newMetrics(namespace, registerer)
 	return &worker{
-		metrics: metrics,
-		checks:  make(map[string]Checker),
-		results: make(map[string]Result),
-		closer:  make(chan struct{}),
+		metrics:      metrics,
+		checks:       make(map[string]Checker),
+		results:      make(map[string]Result),
+		dependencies: make(map[string][]string),
+		history:      make(map[string][]Transition),
+		closer:       make(chan struct{}),
 	}, err
 }
 
-func (w *worker) RegisterCheck(name string, checker Checker) error {
+// RegisterCheck adds the check [name]. The check is expected to fail while any
+// of the checks named by [dependencies] fail.
+func (w *worker) RegisterCheck(name string, checker Checker, dependencies ...string) error {
 	w.checksLock.Lock()
 	defer w.checksLock.Unlock()
 
@@ -54,13 +63,16 @@ func (w *worker) RegisterCheck(name string, checker Checker) error {
 
 	w.checks[name] = checker
 	w.results[name] = notYetRunResult
+	if len(dependencies) > 0 {
+		w.dependencies[name] = append([]string(nil), dependencies...)
+	}
 
 	// Whenever a new check is added - it is failing
 	w.metrics.failingChecks.Inc()
 	return nil
 }
 
-func (w *worker) RegisterMonotonicCheck(name string, checker Checker) error {
+func (w *worker) RegisterMonotonicCheck(name string, checker Checker, dependencies ...string) error {
 	var result utils.AtomicInterface
 	return w.RegisterCheck(name, CheckerFunc(func(ctx context.Context) (interface{}, error) {
 		details := result.GetValue()
@@ -73,7 +85,7 @@ func (w *worker) RegisterMonotonicCheck(name string, checker Checker) error {
 			result.SetValue(details)
 		}
 		return details, err
-	}))
+	}), dependencies...)
 }
 
 func (w *worker) Results() (map[string]Result, bool) {
@@ -83,12 +95,43 @@ func (w *worker) Results() (map[string]Result, bool) {
 	results := make(map[string]Result, len(w.results))
 	healthy := true
 	for name, result := range w.results {
+		result.Dependencies = w.dependencies[name]
 		results[name] = result
 		healthy = healthy && result.Error == nil
 	}
 	return results, healthy
 }
 
+// History returns the most recent transitions of the checks
+func (w *worker) History() map[string][]Transition {
+	w.resultsLock.RLock()
+	defer w.resultsLock.RUnlock()
+
+	history := make(map[string][]Transition, len(w.history))
+	for name, transitions := range w.history {
+		history[name] = append([]Transition(nil), transitions...)
+	}
+	return history
+}
+
+// AddTransition records [transition] in the history of the check [name]
+func (w *worker) AddTransition(name string, transition Transition) {
+	w.resultsLock.Lock()
+	defer w.resultsLock.Unlock()
+
+	w.addTransition(name, transition)
+}
+
+// Assumes [w.resultsLock] is held
+func (w *worker) addTransition(name string, transition Transition) {
+	history := w.history[name]
+	if len(history) == maxHistorySize {
+		copy(history, history[1:])
+		history = history[:len(history)-1]
+	}
+	w.history[name] = append(history, transition)
+}
+
 func (w *worker) Start(ctx context.Context, freq time.Duration) {
 	w.startOnce.Do(func() {
 		go func() {
@@ -172,4 +215,15 @@ func (w *worker) runCheck(ctx context.Context, wg *sync.WaitGroup, name string,
 		w.metrics.failingChecks.Dec()
 	}
 	w.results[name] = result
+
+	// The first result of a check is recorded even if it's failing, like the
+	// result it replaces.
+	healthy := err == nil
+	if prevResult.Timestamp.IsZero() || healthy != (prevResult.Error == nil) {
+		w.addTransition(name, Transition{
+			Timestamp: end,
+			Healthy:   healthy,
+			Error:     result.Error,
+		})
+	}
 }
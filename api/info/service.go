@@ -9,8 +9,7 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/gorilla/rpc/v2"
-
+	"github.com/coinflect/coinflectchain/api/openrpc"
 	"github.com/coinflect/coinflectchain/chains"
 	"github.com/coinflect/coinflectchain/ids"
 	"github.com/coinflect/coinflectchain/network"
@@ -72,7 +71,7 @@ func NewService(
 	validators validators.Set,
 	benchlist benchlist.Manager,
 ) (*common.HTTPHandler, error) {
-	newServer := rpc.NewServer()
+	newServer := openrpc.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
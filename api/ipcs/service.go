@@ -7,11 +7,10 @@ package ipcs
 import (
 	"net/http"
 
-	"github.com/gorilla/rpc/v2"
-
 	"go.uber.org/zap"
 
 	"github.com/coinflect/coinflectchain/api"
+	"github.com/coinflect/coinflectchain/api/openrpc"
 	"github.com/coinflect/coinflectchain/api/server"
 	"github.com/coinflect/coinflectchain/chains"
 	"github.com/coinflect/coinflectchain/ids"
@@ -39,7 +38,7 @@ func NewService(log logging.Logger, chainManager chains.Manager, httpServer serv
 		ipcs: ipcs,
 	}
 
-	newServer := rpc.NewServer()
+	newServer := openrpc.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -0,0 +1,159 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package openrpc
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Version of the OpenRPC specification that the documents follow
+const Version = "1.2.6"
+
+var (
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	requestType = reflect.TypeOf((*http.Request)(nil))
+)
+
+// Document is an OpenRPC document that describes the methods of JSON-RPC
+// services.
+type Document struct {
+	OpenRPC    string     `json:"openrpc"`
+	Info       Info       `json:"info"`
+	Methods    []*Method  `json:"methods"`
+	Components Components `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Method is a JSON-RPC method. Its parameters are passed by name, as the
+// fields of a single JSON object.
+type Method struct {
+	Name           string               `json:"name"`
+	ParamStructure string               `json:"paramStructure"`
+	Params         []*ContentDescriptor `json:"params"`
+	Result         *ContentDescriptor   `json:"result"`
+}
+
+// ContentDescriptor describes a parameter or the result of a method
+type ContentDescriptor struct {
+	Name   string  `json:"name"`
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// describer builds the OpenRPC document of gorilla/rpc services
+type describer struct {
+	generator *schemaGenerator
+	methods   []*Method
+}
+
+func newDescriber() *describer {
+	return &describer{
+		generator: newSchemaGenerator(),
+	}
+}
+
+// addService describes the methods of [receiver] that gorilla/rpc registers
+// as the methods of the service [name]. Methods are called as
+// "[name].[method]", where the method's name starts with a lowercase letter.
+func (d *describer) addService(receiver interface{}, name string) {
+	receiverType := reflect.TypeOf(receiver)
+	for i := 0; i < receiverType.NumMethod(); i++ {
+		method := receiverType.Method(i)
+		argsType, replyType, ok := rpcMethodTypes(method)
+		if !ok {
+			continue
+		}
+
+		params := []*ContentDescriptor{}
+		if argsType.Kind() == reflect.Struct {
+			for _, p := range d.generator.properties(argsType) {
+				params = append(params, &ContentDescriptor{
+					Name:   p.name,
+					Schema: p.schema,
+				})
+			}
+		} else {
+			params = append(params, &ContentDescriptor{
+				Name:   "args",
+				Schema: d.generator.schema(argsType),
+			})
+		}
+
+		r, size := utf8.DecodeRuneInString(method.Name)
+		d.methods = append(d.methods, &Method{
+			Name:           name + "." + string(unicode.ToLower(r)) + method.Name[size:],
+			ParamStructure: "by-name",
+			Params:         params,
+			Result: &ContentDescriptor{
+				Name:   "result",
+				Schema: d.generator.schema(replyType),
+			},
+		})
+	}
+	sort.Slice(d.methods, func(i, j int) bool {
+		return d.methods[i].Name < d.methods[j].Name
+	})
+}
+
+// document returns the OpenRPC document of the services added so far. The
+// document isn't modified when more services are added.
+func (d *describer) document(title, version string) *Document {
+	schemas := make(map[string]*Schema, len(d.generator.schemas))
+	for name, schema := range d.generator.schemas {
+		schemas[name] = schema
+	}
+	return &Document{
+		OpenRPC: Version,
+		Info: Info{
+			Title:   title,
+			Version: version,
+		},
+		Methods: append([]*Method(nil), d.methods...),
+		Components: Components{
+			Schemas: schemas,
+		},
+	}
+}
+
+// rpcMethodTypes returns the types of the arguments and of the reply of
+// [method], if gorilla/rpc registers it as a JSON-RPC method. That is, if it
+// has the signature:
+//
+//	func (*http.Request, *Args, *Reply) error
+func rpcMethodTypes(method reflect.Method) (reflect.Type, reflect.Type, bool) {
+	methodType := method.Type
+	if method.PkgPath != "" || methodType.NumIn() != 4 || methodType.NumOut() != 1 {
+		return nil, nil, false
+	}
+	argsType, replyType := methodType.In(2), methodType.In(3)
+	if methodType.In(1) != requestType ||
+		argsType.Kind() != reflect.Ptr ||
+		replyType.Kind() != reflect.Ptr ||
+		!isExportedOrBuiltin(argsType) ||
+		!isExportedOrBuiltin(replyType) ||
+		methodType.Out(0) != errorType {
+		return nil, nil, false
+	}
+	return argsType.Elem(), replyType.Elem(), true
+}
+
+func isExportedOrBuiltin(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	r, _ := utf8.DecodeRuneInString(t.Name())
+	return unicode.IsUpper(r) || t.PkgPath() == ""
+}
@@ -0,0 +1,201 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package openrpc
+
+import (
+	"encoding"
+	"encoding/json"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const schemasRef = "#/components/schemas/"
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+
+	// invalidNameChars matches the characters that can't be part of the name
+	// of a component
+	invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+)
+
+// Schema is a JSON Schema
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// property is a field of the JSON representation of a struct
+type property struct {
+	name   string
+	schema *Schema
+}
+
+// schemaGenerator describes Go types by the JSON Schema of the values that
+// encoding/json marshals them to. Named structs are described once in
+// [schemas] and referenced by the other schemas.
+type schemaGenerator struct {
+	schemas map[string]*Schema
+	// names of the structs in [schemas]
+	names map[reflect.Type]string
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+func (g *schemaGenerator) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// Types that marshal themselves are usually marshalled as strings, as
+	// the IDs, encodings and quoted numbers of the APIs are.
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case implements(t, textMarshalerType):
+		return &Schema{Type: "string"}
+	case implements(t, jsonMarshalerType):
+		if t.Kind() == reflect.Struct {
+			return &Schema{}
+		}
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 && !implements(t.Elem(), jsonMarshalerType) && !implements(t.Elem(), textMarshalerType) {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return &Schema{Ref: schemasRef + g.structName(t)}
+	default:
+		// Interfaces may hold any value
+		return &Schema{}
+	}
+}
+
+// structName returns the name of the named struct [t] in [g.schemas], adding
+// its schema if it wasn't described yet.
+func (g *schemaGenerator) structName(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	baseName := invalidNameChars.ReplaceAllString(path.Base(t.PkgPath())+"."+t.Name(), "_")
+	name := baseName
+	for i := 2; g.schemas[name] != nil; i++ {
+		name = baseName + "_" + strconv.Itoa(i)
+	}
+
+	// The name is reserved before the fields are described so that recursive
+	// types reference it.
+	g.names[t] = name
+	g.schemas[name] = &Schema{}
+	*g.schemas[name] = *g.structSchema(t)
+	return name
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) *Schema {
+	properties := g.properties(t)
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema, len(properties)),
+	}
+	for _, p := range properties {
+		schema.Properties[p.name] = p.schema
+	}
+	return schema
+}
+
+// properties returns the fields of the JSON representation of the struct [t],
+// in the order of their declaration. The fields of embedded structs are
+// promoted, unless they are hidden by the fields of [t].
+func (g *schemaGenerator) properties(t reflect.Type) []property {
+	var (
+		properties []property
+		embedded   []reflect.Type
+		names      = make(map[string]bool)
+	)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			embedded = append(embedded, fieldType)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema := g.schema(field.Type)
+		if strings.Contains(options, "string") {
+			switch schema.Type {
+			case "boolean", "integer", "number":
+				// Quoted by the ",string" option
+				schema = &Schema{Type: "string"}
+			}
+		}
+		properties = append(properties, property{name: name, schema: schema})
+		names[name] = true
+	}
+	for _, embeddedType := range embedded {
+		for _, p := range g.properties(embeddedType) {
+			if !names[p.name] {
+				properties = append(properties, p)
+				names[p.name] = true
+			}
+		}
+	}
+	return properties
+}
+
+// implements returns true if [t] or a pointer to [t] implements [iface]
+func implements(t reflect.Type, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package openrpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/rpc/v2"
+
+	"github.com/coinflect/coinflectchain/version"
+)
+
+// discoverServiceName is the name of the service of the "rpc.discover"
+// method, which the OpenRPC specification reserves for returning the
+// document of the server.
+const discoverServiceName = "rpc"
+
+var _ http.Handler = (*Server)(nil)
+
+// Server is a gorilla/rpc server that describes its services with an OpenRPC
+// document. The document is returned by the "rpc.discover" method and by GET
+// requests to the server's endpoint, so that clients can be generated from it.
+// Endpoints that serve GET requests differently, such as the health API, serve
+// the document on a separate endpoint with DocumentHandler.
+type Server struct {
+	*rpc.Server
+
+	lock      sync.RWMutex
+	describer *describer
+	services  []string
+}
+
+// NewServer returns a new Server
+func NewServer() *Server {
+	s := &Server{
+		Server:    rpc.NewServer(),
+		describer: newDescriber(),
+	}
+	// This can't fail, as the discover service has a method of the required
+	// type and is the first service to be registered.
+	_ = s.Server.RegisterService(&discoverService{server: s}, discoverServiceName)
+	return s
+}
+
+// RegisterService registers the methods of [receiver] as the methods of the
+// service [name] and adds them to the document of the server.
+func (s *Server) RegisterService(receiver interface{}, name string) error {
+	if err := s.Server.RegisterService(receiver, name); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.describer.addService(receiver, name)
+	s.services = append(s.services, name)
+	return nil
+}
+
+// Document returns the OpenRPC document of the registered services
+func (s *Server) Document() *Document {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.describer.document(strings.Join(s.services, ", "), version.Current.String())
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.Server.ServeHTTP(w, r)
+		return
+	}
+
+	s.serveDocument(w)
+}
+
+// DocumentHandler returns a handler that replies to every request with the
+// document of the server. It is meant for servers whose GET requests are
+// handled by another handler, so that the document can be served on a
+// separate endpoint.
+func (s *Server) DocumentHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		s.serveDocument(w)
+	})
+}
+
+func (s *Server) serveDocument(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(s.Document())
+}
+
+// discoverService serves the "rpc.discover" method
+type discoverService struct {
+	server *Server
+}
+
+// Discover returns the OpenRPC document of the server
+func (d *discoverService) Discover(_ *http.Request, _ *struct{}, reply *Document) error {
+	*reply = *d.server.Document()
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package openrpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	stdjson "encoding/json"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/utils/json"
+)
+
+type Pagination struct {
+	Limit json.Uint32 `json:"limit"`
+}
+
+type GetThingsArgs struct {
+	Pagination
+	IDs      []ids.ID          `json:"ids"`
+	Owner    *string           `json:"owner,omitempty"`
+	Tags     map[string]string `json:"tags"`
+	Verbose  bool              `json:"verbose,string"`
+	Ignored  string            `json:"-"`
+	internal string
+}
+
+type Thing struct {
+	ID       ids.ID      `json:"id"`
+	Amount   json.Uint64 `json:"amount"`
+	Bytes    []byte      `json:"bytes"`
+	Time     time.Time   `json:"time"`
+	Children []*Thing    `json:"children"`
+	Details  interface{} `json:"details"`
+}
+
+type GetThingsReply struct {
+	Things []Thing `json:"things"`
+}
+
+type ThingService struct{}
+
+func (*ThingService) GetThings(_ *http.Request, _ *GetThingsArgs, reply *GetThingsReply) error {
+	reply.Things = []Thing{}
+	return nil
+}
+
+func (*ThingService) Ping(_ *http.Request, _ *struct{}, reply *string) error {
+	*reply = "pong"
+	return nil
+}
+
+// NotAMethod doesn't have the signature of a JSON-RPC method
+func (*ThingService) NotAMethod() {}
+
+func newTestServer(t *testing.T) *Server {
+	s := NewServer()
+	s.RegisterCodec(json.NewCodec(), "application/json")
+	require.NoError(t, s.RegisterService(&ThingService{}, "things"))
+	return s
+}
+
+func TestDocument(t *testing.T) {
+	require := require.New(t)
+
+	doc := newTestServer(t).Document()
+	require.Equal(Version, doc.OpenRPC)
+	require.Equal("things", doc.Info.Title)
+	require.Len(doc.Methods, 2)
+
+	getThings := doc.Methods[0]
+	require.Equal("things.getThings", getThings.Name)
+	require.Equal("by-name", getThings.ParamStructure)
+
+	params := make([]string, len(getThings.Params))
+	for i, param := range getThings.Params {
+		params[i] = param.Name
+	}
+	// Embedded fields are promoted after the fields of the struct
+	require.Equal([]string{"ids", "owner", "tags", "verbose", "limit"}, params)
+	require.Equal(&Schema{Type: "array", Items: &Schema{Type: "string"}}, getThings.Params[0].Schema)
+	require.Equal(&Schema{Type: "string"}, getThings.Params[1].Schema)
+	require.Equal(&Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}}, getThings.Params[2].Schema)
+	require.Equal(&Schema{Type: "string"}, getThings.Params[3].Schema)
+	require.Equal(&Schema{Type: "string"}, getThings.Params[4].Schema)
+
+	require.Equal(&Schema{Ref: schemasRef + "openrpc.GetThingsReply"}, getThings.Result.Schema)
+	require.Equal(&Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"things": {Type: "array", Items: &Schema{Ref: schemasRef + "openrpc.Thing"}},
+		},
+	}, doc.Components.Schemas["openrpc.GetThingsReply"])
+	require.Equal(&Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"id":       {Type: "string"},
+			"amount":   {Type: "string"},
+			"bytes":    {Type: "string", Format: "byte"},
+			"time":     {Type: "string", Format: "date-time"},
+			"children": {Type: "array", Items: &Schema{Ref: schemasRef + "openrpc.Thing"}},
+			"details":  {},
+		},
+	}, doc.Components.Schemas["openrpc.Thing"])
+
+	ping := doc.Methods[1]
+	require.Equal("things.ping", ping.Name)
+	require.Empty(ping.Params)
+	require.Equal(&Schema{Type: "string"}, ping.Result.Schema)
+}
+
+func TestServeDocument(t *testing.T) {
+	require := require.New(t)
+
+	s := newTestServer(t)
+	expectedDoc, err := stdjson.Marshal(s.Document())
+	require.NoError(err)
+
+	// The document is returned for GET requests
+	req := httptest.NewRequest(http.MethodGet, "/ext/things", nil)
+	rr := httptest.NewRecorder()
+	s.ServeHTTP(rr, req)
+	require.Equal(http.StatusOK, rr.Code)
+	require.JSONEq(string(expectedDoc), rr.Body.String())
+
+	// and by the document handler
+	req = httptest.NewRequest(http.MethodGet, "/ext/things/openrpc", nil)
+	rr = httptest.NewRecorder()
+	s.DocumentHandler().ServeHTTP(rr, req)
+	require.Equal(http.StatusOK, rr.Code)
+	require.JSONEq(string(expectedDoc), rr.Body.String())
+
+	// and by the "rpc.discover" method
+	req = httptest.NewRequest(http.MethodPost, "/ext/things", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"rpc.discover","params":{}}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr = httptest.NewRecorder()
+	s.ServeHTTP(rr, req)
+	require.Equal(http.StatusOK, rr.Code)
+
+	var resp struct {
+		Result stdjson.RawMessage `json:"result"`
+	}
+	require.NoError(stdjson.Unmarshal(rr.Body.Bytes(), &resp))
+	require.JSONEq(string(expectedDoc), string(resp.Result))
+
+	// The registered services are still served
+	req = httptest.NewRequest(http.MethodPost, "/ext/things", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"things.ping","params":{}}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr = httptest.NewRecorder()
+	s.ServeHTTP(rr, req)
+	require.Equal(http.StatusOK, rr.Code)
+	require.Contains(rr.Body.String(), `"pong"`)
+}
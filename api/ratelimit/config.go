@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DefaultMaxBuckets is the default number of token buckets that are tracked.
+const DefaultMaxBuckets = 65536
+
+var (
+	errNegativeRate    = errors.New("rate can't be negative")
+	errInvalidBurst    = errors.New("burst must be positive if rate is positive")
+	errNegativeBuckets = errors.New("max buckets can't be negative")
+)
+
+// Limit is a token bucket that refills at [Rate] tokens per second and holds
+// at most [Burst] tokens. Every API call consumes a token.
+type Limit struct {
+	// Rate is the number of calls per second that a client may make. If 0,
+	// calls aren't limited.
+	Rate float64 `json:"rate"`
+	// Burst is the number of calls that a client may make at once.
+	Burst int `json:"burst"`
+}
+
+func (l Limit) Verify() error {
+	switch {
+	case l.Rate < 0:
+		return errNegativeRate
+	case l.Rate > 0 && l.Burst <= 0:
+		return errInvalidBurst
+	default:
+		return nil
+	}
+}
+
+type Config struct {
+	// Default is the limit of every endpoint that isn't in [Endpoints]
+	Default Limit `json:"default"`
+	// Endpoints maps an endpoint's path, such as "/ext/bc/X", to its limit
+	Endpoints map[string]Limit `json:"endpoints"`
+	// Methods maps a JSON-RPC method, such as "avm.getUTXOs", to its limit.
+	// Calls to the method consume a token from the method's bucket in
+	// addition to the endpoint's bucket.
+	Methods map[string]Limit `json:"methods"`
+	// MaxBuckets is the maximum number of token buckets that are tracked. If
+	// exceeded, the least recently used bucket is dropped. If 0,
+	// [DefaultMaxBuckets] is used.
+	MaxBuckets int `json:"maxBuckets"`
+}
+
+// Enabled returns true if any API calls are limited.
+func (c *Config) Enabled() bool {
+	if c.Default.Rate > 0 {
+		return true
+	}
+	for _, limit := range c.Endpoints {
+		if limit.Rate > 0 {
+			return true
+		}
+	}
+	for _, limit := range c.Methods {
+		if limit.Rate > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Config) Verify() error {
+	if err := c.Default.Verify(); err != nil {
+		return fmt.Errorf("invalid default limit: %w", err)
+	}
+	for endpoint, limit := range c.Endpoints {
+		if err := limit.Verify(); err != nil {
+			return fmt.Errorf("invalid limit for endpoint %q: %w", endpoint, err)
+		}
+	}
+	for method, limit := range c.Methods {
+		if err := limit.Verify(); err != nil {
+			return fmt.Errorf("invalid limit for method %q: %w", method, err)
+		}
+	}
+	if c.MaxBuckets < 0 {
+		return errNegativeBuckets
+	}
+	return nil
+}
@@ -0,0 +1,278 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package ratelimit limits the rate of API calls that each client may make.
+package ratelimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"golang.org/x/time/rate"
+
+	rpc "github.com/gorilla/rpc/v2/json2"
+
+	"github.com/coinflect/coinflectchain/api/auth"
+	"github.com/coinflect/coinflectchain/cache"
+	"github.com/coinflect/coinflectchain/utils/timer/mockable"
+)
+
+const (
+	retryAfterHeaderKey = "Retry-After"
+
+	// defaultLimitName is the name of the default limit in the metrics
+	defaultLimitName = "default"
+
+	// maxBodySize is the largest request body that is parsed to find the
+	// JSON-RPC methods that are called. If method limits are configured,
+	// larger bodies are rejected.
+	maxBodySize = 1024 * 1024 // 1 MiB
+)
+
+var errBodyTooLarge = errors.New("request body too large")
+
+// Limiter rejects API calls once a client exceeds the rate of calls it is
+// allowed to make.
+//
+// Clients whose auth token was verified before the calls are passed to the
+// Limiter are identified by the API key or ID of their token (see
+// auth.Client). Other clients are identified by the subject of the client
+// certificate verified during the TLS handshake, if they presented one, or by
+// their IP address.
+type Limiter struct {
+	config Config
+	clock  mockable.Clock
+
+	// lock ensures that a single bucket is created per key
+	lock    sync.Mutex
+	buckets cache.LRU
+
+	rejected *prometheus.CounterVec
+}
+
+// New returns a new Limiter that enforces the limits of [config]. The calls
+// that are rejected are reported under [namespace].
+func New(
+	config Config,
+	namespace string,
+	registerer prometheus.Registerer,
+) (*Limiter, error) {
+	maxBuckets := config.MaxBuckets
+	if maxBuckets == 0 {
+		maxBuckets = DefaultMaxBuckets
+	}
+	l := &Limiter{
+		config:  config,
+		buckets: cache.LRU{Size: maxBuckets},
+		rejected: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "rate_limited",
+				Help:      "# of API calls that were rejected because a rate limit was exceeded",
+			},
+			[]string{"limit"},
+		),
+	}
+	return l, registerer.Register(l.rejected)
+}
+
+// WrapHandler wraps an http.Handler. Before passing a request to the provided
+// handler, a token is taken from every bucket that limits the request. If any
+// bucket is empty, the request is rejected with http.StatusTooManyRequests. If
+// method limits are configured, requests whose body is too large to find the
+// methods they call are rejected with http.StatusRequestEntityTooLarge.
+func (l *Limiter) WrapHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		names, limits, err := l.limits(r)
+		if err != nil {
+			writeErrorResponse(w, http.StatusRequestEntityTooLarge, err.Error())
+			return
+		}
+		if len(names) == 0 {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		client := l.clientKey(r)
+		now := l.clock.Time()
+		reservations := make([]*rate.Reservation, len(names))
+		var (
+			delay       time.Duration
+			limitedName string
+		)
+		for i, name := range names {
+			reservations[i] = l.bucket(client, name, limits[i]).ReserveN(now, 1)
+			if d := reservations[i].DelayFrom(now); d > delay {
+				delay = d
+				limitedName = name
+			}
+		}
+		if delay == 0 {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		// The call is rejected, so the tokens that were taken are returned.
+		// Reservations of the same bucket must be cancelled in the reverse
+		// order that they were made for the tokens to be fully restored.
+		for i := len(reservations) - 1; i >= 0; i-- {
+			reservations[i].CancelAt(now)
+		}
+		l.rejected.WithLabelValues(limitedName).Inc()
+		retryAfter := int(math.Ceil(delay.Seconds()))
+		w.Header().Set(retryAfterHeaderKey, strconv.Itoa(retryAfter))
+		writeErrorResponse(w, http.StatusTooManyRequests, "rate limit exceeded")
+	})
+}
+
+// limits returns the names and limits of the buckets that limit [r].
+func (l *Limiter) limits(r *http.Request) ([]string, []Limit, error) {
+	var (
+		names  []string
+		limits []Limit
+	)
+	endpoint := strings.TrimSuffix(r.URL.Path, "/")
+	if limit, ok := l.config.Endpoints[endpoint]; ok {
+		if limit.Rate > 0 {
+			names = append(names, endpoint)
+			limits = append(limits, limit)
+		}
+	} else if l.config.Default.Rate > 0 {
+		names = append(names, defaultLimitName)
+		limits = append(limits, l.config.Default)
+	}
+
+	if len(l.config.Methods) == 0 {
+		return names, limits, nil
+	}
+	methods, err := l.methods(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, method := range methods {
+		if limit, ok := l.config.Methods[method]; ok && limit.Rate > 0 {
+			names = append(names, method)
+			limits = append(limits, limit)
+		}
+	}
+	return names, limits, nil
+}
+
+// methods returns the JSON-RPC methods called by [r]. The body of [r] is
+// replaced so that it can still be read by the handler. Bodies that can't be
+// parsed call no methods, since the handler rejects them anyway.
+func (*Limiter) methods(r *http.Request) ([]string, error) {
+	if r.Method != http.MethodPost || r.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: io.MultiReader(bytes.NewReader(body), r.Body),
+		Closer: r.Body,
+	}
+	if err != nil {
+		return nil, nil
+	}
+	if len(body) > maxBodySize {
+		return nil, errBodyTooLarge
+	}
+
+	type request struct {
+		Method string `json:"method"`
+	}
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var requests []request
+		if err := json.Unmarshal(body, &requests); err != nil {
+			return nil, nil
+		}
+		methods := make([]string, len(requests))
+		for i, req := range requests {
+			methods[i] = req.Method
+		}
+		return methods, nil
+	}
+
+	var req request
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, nil
+	}
+	return []string{req.Method}, nil
+}
+
+// clientKey returns the identifier of the client that made [r].
+func (*Limiter) clientKey(r *http.Request) string {
+	if client, ok := auth.Client(r.Context()); ok {
+		return client
+	}
+
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+		return "cert:" + r.TLS.VerifiedChains[0][0].Subject.String()
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// bucket returns the bucket named [name] of [client], creating it if needed.
+func (l *Limiter) bucket(client, name string, limit Limit) *rate.Limiter {
+	key := client + "\x00" + name
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if bucket, ok := l.buckets.Get(key); ok {
+		return bucket.(*rate.Limiter)
+	}
+	bucket := rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)
+	l.buckets.Put(key, bucket)
+	return bucket
+}
+
+type responseErr struct {
+	Code    rpc.ErrorCode `json:"code"`
+	Message string        `json:"message"`
+}
+
+type responseBody struct {
+	Version string      `json:"jsonrpc"`
+	Err     responseErr `json:"error"`
+	ID      uint8       `json:"id"`
+}
+
+// Write a JSON-RPC formatted response saying that the API call was rejected
+// with [message]. The response has header [code].
+// Errors while writing are ignored.
+func writeErrorResponse(w http.ResponseWriter, code int, message string) {
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	// There isn't anything to do with the returned error, so it is dropped.
+	_ = json.NewEncoder(w).Encode(responseBody{
+		Version: rpc.Version,
+		Err: responseErr{
+			Code:    rpc.E_SERVER,
+			Message: message,
+		},
+		ID: 1,
+	})
+}
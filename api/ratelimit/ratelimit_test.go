@@ -0,0 +1,228 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ratelimit
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/api/auth"
+)
+
+// Always returns 200 (http.StatusOK)
+var dummyHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+func newLimiter(t *testing.T, config Config) *Limiter {
+	require.NoError(t, config.Verify())
+	l, err := New(config, "", prometheus.NewRegistry())
+	require.NoError(t, err)
+	l.clock.Set(time.Unix(0, 0))
+	return l
+}
+
+func serve(h http.Handler, remoteAddr, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.RemoteAddr = remoteAddr
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestEndpointLimit(t *testing.T) {
+	require := require.New(t)
+
+	l := newLimiter(t, Config{
+		Default: Limit{Rate: 1, Burst: 2},
+		Endpoints: map[string]Limit{
+			"/ext/info": {},
+		},
+	})
+	h := l.WrapHandler(dummyHandler)
+
+	require.Equal(http.StatusOK, serve(h, "1.2.3.4:1", "/ext/bc/X", "").Code)
+	require.Equal(http.StatusOK, serve(h, "1.2.3.4:2", "/ext/bc/X", "").Code)
+
+	rr := serve(h, "1.2.3.4:3", "/ext/bc/X", "")
+	require.Equal(http.StatusTooManyRequests, rr.Code)
+	require.Equal("1", rr.Header().Get(retryAfterHeaderKey))
+	require.Contains(rr.Body.String(), "rate limit exceeded")
+	require.Equal(float64(1), testutil.ToFloat64(l.rejected.WithLabelValues(defaultLimitName)))
+
+	// Other clients have their own buckets.
+	require.Equal(http.StatusOK, serve(h, "5.6.7.8:1", "/ext/bc/X", "").Code)
+
+	// Endpoints without a rate aren't limited.
+	for i := 0; i < 5; i++ {
+		require.Equal(http.StatusOK, serve(h, "1.2.3.4:1", "/ext/info/", "").Code)
+	}
+
+	// Rejected calls don't consume tokens, so the bucket refills in a second.
+	l.clock.Set(l.clock.Time().Add(time.Second))
+	require.Equal(http.StatusOK, serve(h, "1.2.3.4:1", "/ext/bc/X", "").Code)
+	require.Equal(http.StatusTooManyRequests, serve(h, "1.2.3.4:1", "/ext/bc/X", "").Code)
+}
+
+func TestMethodLimit(t *testing.T) {
+	require := require.New(t)
+
+	l := newLimiter(t, Config{
+		Methods: map[string]Limit{
+			"avm.getUTXOs": {Rate: 0.1, Burst: 1},
+		},
+	})
+
+	var bodies []string
+	h := l.WrapHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(err)
+		bodies = append(bodies, string(body))
+	}))
+
+	getUTXOs := `{"jsonrpc":"2.0","id":1,"method":"avm.getUTXOs","params":{}}`
+	getBalance := `{"jsonrpc":"2.0","id":1,"method":"avm.getBalance","params":{}}`
+	require.Equal(http.StatusOK, serve(h, "1.2.3.4:1", "/ext/bc/X", getUTXOs).Code)
+	require.Equal(http.StatusOK, serve(h, "1.2.3.4:1", "/ext/bc/X", getBalance).Code)
+
+	rr := serve(h, "1.2.3.4:1", "/ext/bc/X", getUTXOs)
+	require.Equal(http.StatusTooManyRequests, rr.Code)
+	require.Equal("10", rr.Header().Get(retryAfterHeaderKey))
+	require.Equal(float64(1), testutil.ToFloat64(l.rejected.WithLabelValues("avm.getUTXOs")))
+
+	// Every call of a batch consumes a token.
+	batch := "[" + getBalance + "," + getUTXOs + "]"
+	require.Equal(http.StatusTooManyRequests, serve(h, "5.6.7.8:1", "/ext/bc/X", "["+getUTXOs+","+getUTXOs+"]").Code)
+	require.Equal(http.StatusOK, serve(h, "5.6.7.8:1", "/ext/bc/X", batch).Code)
+
+	// The handler reads the complete body.
+	require.Equal([]string{getUTXOs, getBalance, batch}, bodies)
+
+	// Bodies too large to find their methods are rejected.
+	large := `{"jsonrpc":"2.0","id":1,"method":"avm.getUTXOs","params":"` + strings.Repeat("a", maxBodySize) + `"}`
+	rr = serve(h, "9.9.9.9:1", "/ext/bc/X", large)
+	require.Equal(http.StatusRequestEntityTooLarge, rr.Code)
+	require.Contains(rr.Body.String(), errBodyTooLarge.Error())
+	require.Len(bodies, 3)
+}
+
+func TestLargeBodyWithoutMethodLimits(t *testing.T) {
+	require := require.New(t)
+
+	l := newLimiter(t, Config{
+		Default: Limit{Rate: 1, Burst: 1},
+	})
+	h := l.WrapHandler(dummyHandler)
+
+	large := strings.Repeat("a", maxBodySize+1)
+	require.Equal(http.StatusOK, serve(h, "1.2.3.4:1", "/ext/bc/X", large).Code)
+}
+
+func TestKeyByVerifiedToken(t *testing.T) {
+	require := require.New(t)
+
+	l := newLimiter(t, Config{
+		Default: Limit{Rate: 1, Burst: 1},
+	})
+	h := l.WrapHandler(dummyHandler)
+
+	serveWithClient := func(client string) int {
+		req := httptest.NewRequest(http.MethodGet, "/ext/info", nil)
+		req.RemoteAddr = "1.2.3.4:1"
+		req = req.WithContext(auth.WithClient(req.Context(), client))
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	// Calls from the same IP are limited separately for each verified token.
+	require.Equal(http.StatusOK, serveWithClient("sub:alice"))
+	require.Equal(http.StatusOK, serveWithClient("sub:bob"))
+	require.Equal(http.StatusTooManyRequests, serveWithClient("sub:alice"))
+
+	// Calls without a verified token are limited by IP, even if they carry
+	// an unverified one.
+	req := httptest.NewRequest(http.MethodGet, "/ext/info", nil)
+	req.RemoteAddr = "1.2.3.4:1"
+	req.Header.Set("Authorization", "Bearer unverified")
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	require.Equal(http.StatusOK, rr.Code)
+	require.Equal(http.StatusTooManyRequests, serve(h, "1.2.3.4:1", "/ext/info", "").Code)
+}
+
+func TestKeyByCertificate(t *testing.T) {
+	require := require.New(t)
+
+	l := newLimiter(t, Config{
+		Default: Limit{Rate: 1, Burst: 1},
+	})
+	h := l.WrapHandler(dummyHandler)
+
+	serveWithCertificate := func(commonName string) int {
+		req := httptest.NewRequest(http.MethodGet, "/ext/info", nil)
+		req.RemoteAddr = "1.2.3.4:1"
+		req.TLS = &tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{
+				{Subject: pkix.Name{CommonName: commonName}},
+			}},
+		}
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	// Calls from the same IP are limited separately for each certificate.
+	require.Equal(http.StatusOK, serveWithCertificate("alice"))
+	require.Equal(http.StatusOK, serveWithCertificate("bob"))
+	require.Equal(http.StatusTooManyRequests, serveWithCertificate("alice"))
+
+	// Calls without a certificate are limited by IP.
+	require.Equal(http.StatusOK, serve(h, "1.2.3.4:1", "/ext/info", "").Code)
+	require.Equal(http.StatusTooManyRequests, serve(h, "1.2.3.4:1", "/ext/info", "").Code)
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		expectedErr error
+	}{
+		{
+			name:   "valid",
+			config: Config{Default: Limit{Rate: 1, Burst: 1}},
+		},
+		{
+			name:        "negative rate",
+			config:      Config{Endpoints: map[string]Limit{"/ext/info": {Rate: -1}}},
+			expectedErr: errNegativeRate,
+		},
+		{
+			name:        "no burst",
+			config:      Config{Methods: map[string]Limit{"info.peers": {Rate: 1}}},
+			expectedErr: errInvalidBurst,
+		},
+		{
+			name:        "negative max buckets",
+			config:      Config{MaxBuckets: -1},
+			expectedErr: errNegativeBuckets,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, test.config.Verify(), test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	rpc "github.com/gorilla/rpc/v2/json2"
+
+	"github.com/coinflect/coinflectchain/utils/units"
+)
+
+// maxBatchBodySize is the largest batch that is read into memory to be split
+// into its requests. Larger batches are refused.
+const maxBatchBodySize = 16 * units.MiB
+
+// rpcServer is implemented by gorilla/rpc servers, which serve a single
+// JSON-RPC request per HTTP request. Only these servers are wrapped with
+// [batchMiddleware], as other handlers, such as the ones of the C-chain, may
+// handle batches themselves or may not speak JSON-RPC at all.
+type rpcServer interface {
+	http.Handler
+	RegisterService(receiver interface{}, name string) error
+}
+
+// batchMiddleware wraps a handler so that it accepts JSON-RPC 2.0 batches of
+// at most [maxBatchSize] requests. Every request of a batch is passed to the
+// handler on its own, so that the failure of a request doesn't affect the
+// other requests of the batch. The responses are returned in a single array.
+// If [maxBatchSize] is 0 or less, every batch is rejected.
+func batchMiddleware(h http.Handler, maxBatchSize int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Body == nil {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxBatchBodySize+1))
+		if err != nil {
+			writeRPCError(w, http.StatusBadRequest, rpc.E_PARSE, err.Error())
+			return
+		}
+		trimmedBody := bytes.TrimSpace(body)
+		if len(trimmedBody) == 0 || trimmedBody[0] != '[' {
+			// Requests that aren't batches are passed on unchanged, including
+			// the part of the body that wasn't read.
+			r.Body = struct {
+				io.Reader
+				io.Closer
+			}{
+				Reader: io.MultiReader(bytes.NewReader(body), r.Body),
+				Closer: r.Body,
+			}
+			h.ServeHTTP(w, r)
+			return
+		}
+		if len(body) > maxBatchBodySize {
+			writeRPCError(w, http.StatusRequestEntityTooLarge, rpc.E_INVALID_REQ, fmt.Sprintf("batch exceeds the limit of %d bytes", maxBatchBodySize))
+			return
+		}
+
+		var requests []json.RawMessage
+		if err := json.Unmarshal(trimmedBody, &requests); err != nil {
+			writeRPCError(w, http.StatusOK, rpc.E_PARSE, fmt.Sprintf("couldn't parse batch: %s", err))
+			return
+		}
+		switch {
+		case maxBatchSize <= 0:
+			writeRPCError(w, http.StatusOK, rpc.E_INVALID_REQ, "batches aren't accepted")
+			return
+		case len(requests) == 0:
+			writeRPCError(w, http.StatusOK, rpc.E_INVALID_REQ, "empty batch")
+			return
+		case len(requests) > maxBatchSize:
+			writeRPCError(w, http.StatusOK, rpc.E_INVALID_REQ, fmt.Sprintf("batch has %d requests which exceeds the limit of %d", len(requests), maxBatchSize))
+			return
+		}
+
+		responses := make([]json.RawMessage, 0, len(requests))
+		for _, request := range requests {
+			if response := serveBatchRequest(h, r, request); response != nil {
+				responses = append(responses, response)
+			}
+		}
+
+		// If every request was a notification, nothing is returned.
+		if len(responses) == 0 {
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		// There isn't anything to do with the returned error, so it is dropped.
+		_ = json.NewEncoder(w).Encode(responses)
+	})
+}
+
+// serveBatchRequest passes [request] to [h] as if it was the body of [r]. The
+// response to [request] is returned, or nil if [request] is a notification.
+func serveBatchRequest(h http.Handler, r *http.Request, request json.RawMessage) json.RawMessage {
+	var header struct {
+		ID json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(request, &header); err != nil {
+		return newRPCErrorResponse(nil, rpc.E_INVALID_REQ, fmt.Sprintf("invalid request: %s", err))
+	}
+
+	req := r.Clone(r.Context())
+	req.Body = io.NopCloser(bytes.NewReader(request))
+	req.ContentLength = int64(len(request))
+	req.Header.Del("Content-Length")
+	// The responses are combined, so they must not be compressed individually.
+	req.Header.Del("Accept-Encoding")
+
+	w := newBufferedResponseWriter()
+	h.ServeHTTP(w, req)
+
+	// Notifications don't have a response, even if they failed.
+	if len(header.ID) == 0 || string(header.ID) == "null" {
+		return nil
+	}
+	response := bytes.TrimSpace(w.body.Bytes())
+	if len(response) > 0 && response[0] == '{' && json.Valid(response) {
+		return response
+	}
+
+	// The handler didn't reply with a JSON-RPC response, for example because
+	// the chain isn't done bootstrapping, so the reply is converted into an
+	// error.
+	message := string(response)
+	if message == "" {
+		message = http.StatusText(w.status)
+	}
+	return newRPCErrorResponse(header.ID, rpc.E_SERVER, message)
+}
+
+type rpcError struct {
+	Code    rpc.ErrorCode `json:"code"`
+	Message string        `json:"message"`
+}
+
+type rpcErrorResponse struct {
+	Version string          `json:"jsonrpc"`
+	Err     rpcError        `json:"error"`
+	ID      json.RawMessage `json:"id"`
+}
+
+func newRPCErrorResponse(id json.RawMessage, code rpc.ErrorCode, message string) json.RawMessage {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	// Marshalling can't fail because every field is marshallable.
+	response, _ := json.Marshal(rpcErrorResponse{
+		Version: rpc.Version,
+		Err: rpcError{
+			Code:    code,
+			Message: message,
+		},
+		ID: id,
+	})
+	return response
+}
+
+// Write a JSON-RPC formatted error response that isn't associated with a
+// request. Errors while writing are ignored.
+func writeRPCError(w http.ResponseWriter, status int, code rpc.ErrorCode, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(newRPCErrorResponse(nil, code, message))
+}
+
+// bufferedResponseWriter holds the response to a request of a batch.
+type bufferedResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newBufferedResponseWriter() *bufferedResponseWriter {
+	return &bufferedResponseWriter{
+		header: make(http.Header),
+		status: http.StatusOK,
+	}
+}
+
+func (w *bufferedResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
@@ -0,0 +1,278 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/rpc/v2"
+
+	"github.com/stretchr/testify/require"
+
+	rpcjson "github.com/gorilla/rpc/v2/json2"
+
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/snow/engine/common"
+	"github.com/coinflect/coinflectchain/utils/logging"
+)
+
+var errEcho = errors.New("echo failed")
+
+type EchoService struct{}
+
+type EchoArgs struct {
+	Message string `json:"message"`
+}
+
+type EchoReply struct {
+	Message string `json:"message"`
+}
+
+func (*EchoService) Echo(_ *http.Request, args *EchoArgs, reply *EchoReply) error {
+	if args.Message == "" {
+		return errEcho
+	}
+	reply.Message = args.Message
+	return nil
+}
+
+func newBatchTestHandler(t *testing.T, maxBatchSize int) http.Handler {
+	s := rpc.NewServer()
+	s.RegisterCodec(rpcjson.NewCodec(), "application/json")
+	require.NoError(t, s.RegisterService(&EchoService{}, "echo"))
+	return batchMiddleware(s, maxBatchSize)
+}
+
+func serveBatch(h http.Handler, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	return rr
+}
+
+type batchTestResponse struct {
+	ID     json.RawMessage `json:"id"`
+	Result *EchoReply      `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+func TestBatch(t *testing.T) {
+	require := require.New(t)
+
+	h := newBatchTestHandler(t, 10)
+	rr := serveBatch(h, `[
+		{"jsonrpc":"2.0","id":1,"method":"echo.Echo","params":{"message":"hello"}},
+		{"jsonrpc":"2.0","id":"two","method":"echo.Echo","params":{"message":""}},
+		{"jsonrpc":"2.0","method":"echo.Echo","params":{"message":"notification"}},
+		{"jsonrpc":"2.0","id":3,"method":"echo.Unknown","params":{}}
+	]`)
+	require.Equal(http.StatusOK, rr.Code)
+
+	var responses []batchTestResponse
+	require.NoError(json.Unmarshal(rr.Body.Bytes(), &responses))
+
+	// The notification doesn't have a response.
+	require.Len(responses, 3)
+
+	require.Equal("1", string(responses[0].ID))
+	require.Nil(responses[0].Error)
+	require.Equal("hello", responses[0].Result.Message)
+
+	// A failed request doesn't affect the other requests.
+	require.Equal(`"two"`, string(responses[1].ID))
+	require.Nil(responses[1].Result)
+	require.Equal(errEcho.Error(), responses[1].Error.Message)
+
+	require.Equal("3", string(responses[2].ID))
+	require.Nil(responses[2].Result)
+	require.NotNil(responses[2].Error)
+}
+
+func TestBatchSingleRequest(t *testing.T) {
+	require := require.New(t)
+
+	h := newBatchTestHandler(t, 10)
+	rr := serveBatch(h, `{"jsonrpc":"2.0","id":1,"method":"echo.Echo","params":{"message":"hello"}}`)
+	require.Equal(http.StatusOK, rr.Code)
+
+	var response batchTestResponse
+	require.NoError(json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Equal("hello", response.Result.Message)
+}
+
+func TestBatchOnlyNotifications(t *testing.T) {
+	require := require.New(t)
+
+	h := newBatchTestHandler(t, 10)
+	rr := serveBatch(h, `[{"jsonrpc":"2.0","method":"echo.Echo","params":{"message":"hello"}}]`)
+	require.Equal(http.StatusOK, rr.Code)
+	require.Empty(rr.Body.String())
+}
+
+func TestBatchNonJSONResponse(t *testing.T) {
+	require := require.New(t)
+
+	h := batchMiddleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "API call rejected because chain is not done bootstrapping", http.StatusServiceUnavailable)
+	}), 10)
+	rr := serveBatch(h, `[{"jsonrpc":"2.0","id":1,"method":"echo.Echo","params":{}}]`)
+	require.Equal(http.StatusOK, rr.Code)
+
+	var responses []batchTestResponse
+	require.NoError(json.Unmarshal(rr.Body.Bytes(), &responses))
+	require.Len(responses, 1)
+	require.Equal("1", string(responses[0].ID))
+	require.Equal(rpcjson.E_SERVER, responses[0].Error.Code)
+	require.Equal("API call rejected because chain is not done bootstrapping", responses[0].Error.Message)
+}
+
+func TestInvalidBatch(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		expectedCode rpcjson.ErrorCode
+	}{
+		{
+			name:         "empty batch",
+			body:         `[]`,
+			expectedCode: rpcjson.E_INVALID_REQ,
+		},
+		{
+			name:         "too many requests",
+			body:         `[{"id":1},{"id":2},{"id":3}]`,
+			expectedCode: rpcjson.E_INVALID_REQ,
+		},
+		{
+			name:         "invalid json",
+			body:         `[{"id":1},`,
+			expectedCode: rpcjson.E_PARSE,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			h := newBatchTestHandler(t, 2)
+			rr := serveBatch(h, test.body)
+			require.Equal(http.StatusOK, rr.Code)
+
+			var response batchTestResponse
+			require.NoError(json.Unmarshal(rr.Body.Bytes(), &response))
+			require.Equal("null", string(response.ID))
+			require.Equal(test.expectedCode, response.Error.Code)
+		})
+	}
+}
+
+func TestBatchDisabled(t *testing.T) {
+	require := require.New(t)
+
+	h := newBatchTestHandler(t, 0)
+	rr := serveBatch(h, `[{"jsonrpc":"2.0","id":1,"method":"echo.Echo","params":{"message":"hello"}}]`)
+	require.Equal(http.StatusOK, rr.Code)
+
+	var response batchTestResponse
+	require.NoError(json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Equal("null", string(response.ID))
+	require.Equal(rpcjson.E_INVALID_REQ, response.Error.Code)
+
+	// Requests that aren't batches are still served.
+	rr = serveBatch(h, `{"jsonrpc":"2.0","id":1,"method":"echo.Echo","params":{"message":"hello"}}`)
+	require.Equal(http.StatusOK, rr.Code)
+	require.NoError(json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Equal("hello", response.Result.Message)
+}
+
+func TestBatchTooLarge(t *testing.T) {
+	require := require.New(t)
+
+	h := newBatchTestHandler(t, 10)
+	message := strings.Repeat("a", maxBatchBodySize)
+	rr := serveBatch(h, `[{"jsonrpc":"2.0","id":1,"method":"echo.Echo","params":{"message":"`+message+`"}}]`)
+	require.Equal(http.StatusRequestEntityTooLarge, rr.Code)
+
+	var response batchTestResponse
+	require.NoError(json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Equal(rpcjson.E_INVALID_REQ, response.Error.Code)
+
+	// Requests that aren't batches aren't limited by the middleware.
+	rr = serveBatch(h, `{"jsonrpc":"2.0","id":1,"method":"echo.Echo","params":{"message":"`+message+`"}}`)
+	require.Equal(http.StatusOK, rr.Code)
+	require.NoError(json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Equal(message, response.Result.Message)
+}
+
+func TestBatchOnlyRPCServerRoutes(t *testing.T) {
+	require := require.New(t)
+
+	s := New()
+	s.Initialize(
+		logging.NoLog{},
+		nil,
+		"127.0.0.1",
+		0,
+		nil,
+		time.Second,
+		10,
+		ids.EmptyNodeID,
+		false,
+		nil,
+	)
+
+	rpcServer := rpc.NewServer()
+	rpcServer.RegisterCodec(rpcjson.NewCodec(), "application/json")
+	require.NoError(rpcServer.RegisterService(&EchoService{}, "echo"))
+	require.NoError(s.AddRoute(
+		&common.HTTPHandler{LockOptions: common.NoLock, Handler: rpcServer},
+		&sync.RWMutex{},
+		"echo",
+		"",
+	))
+
+	// Handlers that aren't gorilla/rpc servers receive batches unchanged.
+	var receivedBody string
+	require.NoError(s.AddRoute(
+		&common.HTTPHandler{
+			LockOptions: common.NoLock,
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(err)
+				receivedBody = string(body)
+			}),
+		},
+		&sync.RWMutex{},
+		"eth",
+		"",
+	))
+
+	body := `[{"jsonrpc":"2.0","id":1,"method":"echo.Echo","params":{"message":"hello"}}]`
+	post := func(url string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := post("/ext/echo")
+	require.Equal(http.StatusOK, rr.Code)
+	var responses []batchTestResponse
+	require.NoError(json.Unmarshal(rr.Body.Bytes(), &responses))
+	require.Len(responses, 1)
+	require.Equal("hello", responses[0].Result.Message)
+
+	rr = post("/ext/eth")
+	require.Equal(http.StatusOK, rr.Code)
+	require.Equal(body, receivedBody)
+}
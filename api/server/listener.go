@@ -0,0 +1,184 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+const (
+	TCPNetwork  = "tcp"
+	UnixNetwork = "unix"
+)
+
+var (
+	errUnknownNetwork   = errors.New("unknown network")
+	errNoAddress        = errors.New("no address")
+	errInvalidPerms     = errors.New("invalid socket permissions")
+	errPublicNoAuth     = errors.New("TCP listeners that don't require auth must listen on a loopback address")
+	errAddressNotSocket = errors.New("address exists and isn't a socket")
+)
+
+// Listener is an additional address that the API server accepts requests on
+type Listener struct {
+	// Network of the listener, either [TCPNetwork] or [UnixNetwork]
+	Network string
+	// Address is the host:port of TCP listeners and the path of the socket
+	// file of Unix domain socket listeners
+	Address string
+	// Perms of the socket file of Unix domain socket listeners. Clients need
+	// write permission to connect to the socket. Formatted in JSON as an
+	// octal string, e.g. "0600".
+	Perms os.FileMode
+	// RequireAuth is false if the requests received on this listener skip the
+	// [AuthWrapper]s passed to Initialize
+	RequireAuth bool
+}
+
+type listenerJSON struct {
+	Network     string `json:"network"`
+	Address     string `json:"address"`
+	Perms       string `json:"perms,omitempty"`
+	RequireAuth bool   `json:"requireAuth"`
+}
+
+func (l Listener) MarshalJSON() ([]byte, error) {
+	listener := listenerJSON{
+		Network:     l.Network,
+		Address:     l.Address,
+		RequireAuth: l.RequireAuth,
+	}
+	if l.Perms != 0 {
+		listener.Perms = fmt.Sprintf("%#o", uint32(l.Perms))
+	}
+	return json.Marshal(listener)
+}
+
+func (l *Listener) UnmarshalJSON(b []byte) error {
+	listener := listenerJSON{}
+	if err := json.Unmarshal(b, &listener); err != nil {
+		return err
+	}
+	var perms uint64
+	if listener.Perms != "" {
+		var err error
+		perms, err = strconv.ParseUint(listener.Perms, 8, 32)
+		if err != nil {
+			return fmt.Errorf("%w %q: %s", errInvalidPerms, listener.Perms, err)
+		}
+	}
+	*l = Listener{
+		Network:     listener.Network,
+		Address:     listener.Address,
+		Perms:       os.FileMode(perms),
+		RequireAuth: listener.RequireAuth,
+	}
+	return nil
+}
+
+// Verify returns an error if [l] can't be listened on. TCP listeners that
+// skip the [AuthWrapper]s must only be reachable from this host.
+func (l *Listener) Verify() error {
+	switch {
+	case l.Network != TCPNetwork && l.Network != UnixNetwork:
+		return fmt.Errorf("%w: %q", errUnknownNetwork, l.Network)
+	case l.Address == "":
+		return errNoAddress
+	case l.Network == UnixNetwork && (l.Perms == 0 || l.Perms&^os.ModePerm != 0):
+		return fmt.Errorf("%w: %#o", errInvalidPerms, uint32(l.Perms))
+	case l.Network == TCPNetwork && !l.RequireAuth && !isLoopback(l.Address):
+		return fmt.Errorf("%w: %q", errPublicNoAuth, l.Address)
+	default:
+		return nil
+	}
+}
+
+// isLoopback returns true if the host of [address] only resolves to loopback
+// addresses.
+func isLoopback(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// listen opens [l]'s address
+func (l *Listener) listen() (net.Listener, error) {
+	switch l.Network {
+	case TCPNetwork:
+		return net.Listen(TCPNetwork, l.Address)
+	case UnixNetwork:
+		return l.listenUnix()
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownNetwork, l.Network)
+	}
+}
+
+// listenUnix binds the socket inside a directory that only this process can
+// access and moves it to [l.Address] once its permissions are set, so that it
+// is never reachable with the default permissions.
+func (l *Listener) listenUnix() (net.Listener, error) {
+	// A socket file left behind by a previous run that didn't shut down
+	// cleanly is replaced, but other files never are.
+	if info, err := os.Lstat(l.Address); err == nil && info.Mode()&os.ModeSocket == 0 {
+		return nil, fmt.Errorf("%w: %q", errAddressNotSocket, l.Address)
+	}
+
+	dir, err := os.MkdirTemp(filepath.Dir(l.Address), ".sock")
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create directory for socket %q: %w", l.Address, err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "s")
+	listener, err := net.ListenUnix(UnixNetwork, &net.UnixAddr{Name: path, Net: UnixNetwork})
+	if err != nil {
+		return nil, err
+	}
+	// The socket is removed from [l.Address] instead when closed
+	listener.SetUnlinkOnClose(false)
+	if err := os.Chmod(path, l.Perms); err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("couldn't set the permissions of socket %q: %w", l.Address, err)
+	}
+	if err := os.Rename(path, l.Address); err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("couldn't move socket to %q: %w", l.Address, err)
+	}
+	return &unixListener{
+		UnixListener: listener,
+		path:         l.Address,
+	}, nil
+}
+
+// unixListener removes its socket file when closed
+type unixListener struct {
+	*net.UnixListener
+	path string
+}
+
+// Addr returns the address of the socket, rather than the path it was bound to
+func (l *unixListener) Addr() net.Addr {
+	return &net.UnixAddr{Name: l.path, Net: UnixNetwork}
+}
+
+func (l *unixListener) Close() error {
+	err := l.UnixListener.Close()
+	if rmErr := os.Remove(l.path); err == nil && !errors.Is(rmErr, os.ErrNotExist) {
+		err = rmErr
+	}
+	return err
+}
@@ -0,0 +1,164 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenUnix(t *testing.T) {
+	require := require.New(t)
+
+	l := Listener{
+		Network: UnixNetwork,
+		Address: filepath.Join(t.TempDir(), "api.sock"),
+		Perms:   0o600,
+	}
+
+	// A socket that was left behind is replaced
+	stale, err := net.Listen(UnixNetwork, l.Address)
+	require.NoError(err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(stale.Close())
+
+	listener, err := l.listen()
+	require.NoError(err)
+
+	info, err := os.Stat(l.Address)
+	require.NoError(err)
+	require.Equal(os.FileMode(0o600), info.Mode().Perm())
+	require.Equal(l.Address, listener.Addr().String())
+
+	// The directory the socket was bound in is removed
+	entries, err := os.ReadDir(filepath.Dir(l.Address))
+	require.NoError(err)
+	require.Len(entries, 1)
+
+	conn, err := net.Dial(UnixNetwork, l.Address)
+	require.NoError(err)
+	require.NoError(conn.Close())
+
+	// Closing the listener removes the socket
+	require.NoError(listener.Close())
+	_, err = os.Stat(l.Address)
+	require.True(errors.Is(err, os.ErrNotExist))
+}
+
+func TestListenUnixNotSocket(t *testing.T) {
+	require := require.New(t)
+
+	l := Listener{
+		Network: UnixNetwork,
+		Address: filepath.Join(t.TempDir(), "api.sock"),
+		Perms:   0o600,
+	}
+	require.NoError(os.WriteFile(l.Address, nil, 0o600))
+
+	// Files that aren't sockets are never removed
+	_, err := l.listen()
+	require.ErrorIs(err, errAddressNotSocket)
+	_, err = os.Stat(l.Address)
+	require.NoError(err)
+}
+
+func TestListenUnknownNetwork(t *testing.T) {
+	l := Listener{
+		Network: "udp",
+		Address: "127.0.0.1:0",
+	}
+	_, err := l.listen()
+	require.ErrorIs(t, err, errUnknownNetwork)
+}
+
+func TestListenerVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		listener    Listener
+		expectedErr error
+	}{
+		{
+			name:     "tcp",
+			listener: Listener{Network: TCPNetwork, Address: "127.0.0.1:9652"},
+		},
+		{
+			name:     "tcp without auth on localhost",
+			listener: Listener{Network: TCPNetwork, Address: "localhost:9652"},
+		},
+		{
+			name:     "tcp with auth",
+			listener: Listener{Network: TCPNetwork, Address: "0.0.0.0:9652", RequireAuth: true},
+		},
+		{
+			name:        "public tcp without auth",
+			listener:    Listener{Network: TCPNetwork, Address: "0.0.0.0:9652"},
+			expectedErr: errPublicNoAuth,
+		},
+		{
+			name:        "tcp without auth or host",
+			listener:    Listener{Network: TCPNetwork, Address: ":9652"},
+			expectedErr: errPublicNoAuth,
+		},
+		{
+			name:     "unix",
+			listener: Listener{Network: UnixNetwork, Address: "/tmp/api.sock", Perms: 0o660},
+		},
+		{
+			name:        "no perms",
+			listener:    Listener{Network: UnixNetwork, Address: "/tmp/api.sock"},
+			expectedErr: errInvalidPerms,
+		},
+		{
+			name:        "unknown network",
+			listener:    Listener{Network: "udp", Address: "127.0.0.1:9652"},
+			expectedErr: errUnknownNetwork,
+		},
+		{
+			name:        "no address",
+			listener:    Listener{Network: TCPNetwork},
+			expectedErr: errNoAddress,
+		},
+		{
+			name:        "invalid perms",
+			listener:    Listener{Network: UnixNetwork, Address: "/tmp/api.sock", Perms: os.ModeSetuid | 0o600},
+			expectedErr: errInvalidPerms,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, test.listener.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestListenerJSON(t *testing.T) {
+	require := require.New(t)
+
+	l := Listener{
+		Network:     UnixNetwork,
+		Address:     "/tmp/api.sock",
+		Perms:       0o660,
+		RequireAuth: true,
+	}
+	b, err := json.Marshal(l)
+	require.NoError(err)
+	require.JSONEq(`{"network":"unix","address":"/tmp/api.sock","perms":"0660","requireAuth":true}`, string(b))
+
+	var parsed Listener
+	require.NoError(json.Unmarshal(b, &parsed))
+	require.Equal(l, parsed)
+
+	// Perms must be octal strings
+	err = json.Unmarshal([]byte(`{"network":"unix","address":"/tmp/api.sock","perms":"0800"}`), &parsed)
+	require.ErrorIs(err, errInvalidPerms)
+	err = json.Unmarshal([]byte(`{"network":"unix","address":"/tmp/api.sock","perms":416}`), &parsed)
+	require.Error(err)
+}
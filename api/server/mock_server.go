@@ -9,6 +9,7 @@
 package server
 
 import (
+	http "net/http"
 	reflect "reflect"
 	sync "sync"
 	time "time"
@@ -123,34 +124,76 @@ func (mr *MockServerMockRecorder) Dispatch() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dispatch", reflect.TypeOf((*MockServer)(nil).Dispatch))
 }
 
+// DispatchListener mocks base method.
+func (m *MockServer) DispatchListener(arg0 Listener) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DispatchListener", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DispatchListener indicates an expected call of DispatchListener.
+func (mr *MockServerMockRecorder) DispatchListener(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchListener", reflect.TypeOf((*MockServer)(nil).DispatchListener), arg0)
+}
+
+// DispatchListenerTLS mocks base method.
+func (m *MockServer) DispatchListenerTLS(arg0 Listener, arg1, arg2, arg3 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DispatchListenerTLS", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DispatchListenerTLS indicates an expected call of DispatchListenerTLS.
+func (mr *MockServerMockRecorder) DispatchListenerTLS(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchListenerTLS", reflect.TypeOf((*MockServer)(nil).DispatchListenerTLS), arg0, arg1, arg2, arg3)
+}
+
 // DispatchTLS mocks base method.
-func (m *MockServer) DispatchTLS(arg0, arg1 []byte) error {
+func (m *MockServer) DispatchTLS(arg0, arg1, arg2 []byte) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DispatchTLS", arg0, arg1)
+	ret := m.ctrl.Call(m, "DispatchTLS", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DispatchTLS indicates an expected call of DispatchTLS.
-func (mr *MockServerMockRecorder) DispatchTLS(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockServerMockRecorder) DispatchTLS(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchTLS", reflect.TypeOf((*MockServer)(nil).DispatchTLS), arg0, arg1, arg2)
+}
+
+// Handler mocks base method.
+func (m *MockServer) Handler() http.Handler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Handler")
+	ret0, _ := ret[0].(http.Handler)
+	return ret0
+}
+
+// Handler indicates an expected call of Handler.
+func (mr *MockServerMockRecorder) Handler() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchTLS", reflect.TypeOf((*MockServer)(nil).DispatchTLS), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handler", reflect.TypeOf((*MockServer)(nil).Handler))
 }
 
 // Initialize mocks base method.
-func (m *MockServer) Initialize(arg0 logging.Logger, arg1 logging.Factory, arg2 string, arg3 uint16, arg4 []string, arg5 time.Duration, arg6 ids.NodeID, arg7 bool, arg8 trace.Tracer, arg9 ...Wrapper) {
+func (m *MockServer) Initialize(arg0 logging.Logger, arg1 logging.Factory, arg2 string, arg3 uint16, arg4 []string, arg5 time.Duration, arg6 int, arg7 ids.NodeID, arg8 bool, arg9 trace.Tracer, arg10 ...Wrapper) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8}
-	for _, a := range arg9 {
+	varargs := []interface{}{arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9}
+	for _, a := range arg10 {
 		varargs = append(varargs, a)
 	}
 	m.ctrl.Call(m, "Initialize", varargs...)
 }
 
 // Initialize indicates an expected call of Initialize.
-func (mr *MockServerMockRecorder) Initialize(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8 interface{}, arg9 ...interface{}) *gomock.Call {
+func (mr *MockServerMockRecorder) Initialize(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9 interface{}, arg10 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8}, arg9...)
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9}, arg10...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Initialize", reflect.TypeOf((*MockServer)(nil).Initialize), varargs...)
 }
 
@@ -7,6 +7,7 @@ package server
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net"
@@ -39,6 +40,7 @@ const (
 
 var (
 	errUnknownLockOption = errors.New("invalid lock options")
+	errInvalidClientCAs  = errors.New("couldn't parse any PEM encoded client CA certificate")
 
 	_ PathAdder = readPathAdder{}
 	_ Server    = (*server)(nil)
@@ -73,6 +75,7 @@ type Server interface {
 		port uint16,
 		allowedOrigins []string,
 		shutdownTimeout time.Duration,
+		maxBatchSize int,
 		nodeID ids.NodeID,
 		tracingEnabled bool,
 		tracer trace.Tracer,
@@ -80,8 +83,24 @@ type Server interface {
 	)
 	// Dispatch starts the API server
 	Dispatch() error
-	// DispatchTLS starts the API server with the provided TLS certificate
-	DispatchTLS(certBytes, keyBytes []byte) error
+	// DispatchTLS starts the API server with the provided TLS certificate.
+	// HTTP/2 is negotiated with the clients that support it. If
+	// [clientCABytes] isn't empty, clients may present a certificate issued by
+	// one of the PEM encoded CAs it contains, which is verified during the
+	// handshake.
+	DispatchTLS(certBytes, keyBytes, clientCABytes []byte) error
+	// DispatchListener starts serving the API on [listener]. It can be called
+	// concurrently with Dispatch or DispatchTLS to accept requests on multiple
+	// addresses.
+	DispatchListener(listener Listener) error
+	// DispatchListenerTLS starts serving the API on [listener] as
+	// DispatchListener does. TCP listeners are served with the provided TLS
+	// certificate, as by DispatchTLS. Unix domain socket listeners, which are
+	// only reachable from this host, are served without TLS.
+	DispatchListenerTLS(listener Listener, certBytes, keyBytes, clientCABytes []byte) error
+	// Handler returns the handler of the API server's requests, which includes
+	// the wrappers passed to Initialize
+	Handler() http.Handler
 	// RegisterChain registers the API endpoints associated with this chain. That is,
 	// add <route, handler> pairs to server so that API calls can be made to the VM.
 	// This method runs in a goroutine to avoid a deadlock in the event that the caller
@@ -101,19 +120,28 @@ type server struct {
 	factory logging.Factory
 	// points the the router handlers
 	handler http.Handler
+	// points the the router handlers, skipping the [AuthWrapper]s
+	noAuthHandler http.Handler
 	// Listens for HTTP traffic on this address
 	listenHost string
 	listenPort uint16
 
 	shutdownTimeout time.Duration
 
+	// The maximum number of requests in a JSON-RPC batch. If 0, batches are
+	// rejected.
+	maxBatchSize int
+
 	tracingEnabled bool
 	tracer         trace.Tracer
 
 	// Maps endpoints to handlers
 	router *router
 
-	srv *http.Server
+	// Servers of the listeners that are dispatched
+	srvsLock     sync.Mutex
+	srvs         []*http.Server
+	shuttingDown bool
 }
 
 // New returns an instance of a Server.
@@ -128,6 +156,7 @@ func (s *server) Initialize(
 	port uint16,
 	allowedOrigins []string,
 	shutdownTimeout time.Duration,
+	maxBatchSize int,
 	nodeID ids.NodeID,
 	tracingEnabled bool,
 	tracer trace.Tracer,
@@ -138,12 +167,14 @@ func (s *server) Initialize(
 	s.listenHost = host
 	s.listenPort = port
 	s.shutdownTimeout = shutdownTimeout
+	s.maxBatchSize = maxBatchSize
 	s.tracingEnabled = tracingEnabled
 	s.tracer = tracer
 	s.router = newRouter()
 
 	s.log.Info("API created",
 		zap.Strings("allowedOrigins", allowedOrigins),
+		zap.Int("maxBatchSize", maxBatchSize),
 	)
 
 	corsHandler := cors.New(cors.Options{
@@ -159,8 +190,12 @@ func (s *server) Initialize(
 		},
 	)
 
+	s.noAuthHandler = s.handler
 	for _, wrapper := range wrappers {
 		s.handler = wrapper.WrapHandler(s.handler)
+		if _, ok := wrapper.(AuthWrapper); !ok {
+			s.noAuthHandler = wrapper.WrapHandler(s.noAuthHandler)
+		}
 	}
 }
 
@@ -183,23 +218,15 @@ func (s *server) Dispatch() error {
 		)
 	}
 
-	s.srv = &http.Server{
-		Handler:           s.handler,
-		ReadHeaderTimeout: readHeaderTimeout,
-	}
-	return s.srv.Serve(listener)
+	return s.serve(listener, s.handler)
 }
 
-func (s *server) DispatchTLS(certBytes, keyBytes []byte) error {
+func (s *server) DispatchTLS(certBytes, keyBytes, clientCABytes []byte) error {
 	listenAddress := fmt.Sprintf("%s:%d", s.listenHost, s.listenPort)
-	cert, err := tls.X509KeyPair(certBytes, keyBytes)
+	config, err := newTLSConfig(certBytes, keyBytes, clientCABytes)
 	if err != nil {
 		return err
 	}
-	config := &tls.Config{
-		MinVersion:   tls.VersionTLS12,
-		Certificates: []tls.Certificate{cert},
-	}
 
 	listener, err := tls.Listen("tcp", listenAddress, config)
 	if err != nil {
@@ -218,12 +245,102 @@ func (s *server) DispatchTLS(certBytes, keyBytes []byte) error {
 		)
 	}
 
-	s.srv = &http.Server{
-		Addr:              listenAddress,
-		Handler:           s.handler,
+	return s.serve(listener, s.handler)
+}
+
+func (s *server) DispatchListener(l Listener) error {
+	listener, err := l.listen()
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("HTTP API server listening",
+		zap.String("network", l.Network),
+		zap.String("address", listener.Addr().String()),
+		zap.Bool("requireAuth", l.RequireAuth),
+	)
+	return s.serve(listener, s.listenerHandler(l))
+}
+
+func (s *server) DispatchListenerTLS(l Listener, certBytes, keyBytes, clientCABytes []byte) error {
+	if l.Network != TCPNetwork {
+		return s.DispatchListener(l)
+	}
+
+	config, err := newTLSConfig(certBytes, keyBytes, clientCABytes)
+	if err != nil {
+		return err
+	}
+	listener, err := l.listen()
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("HTTPS API server listening",
+		zap.String("network", l.Network),
+		zap.String("address", listener.Addr().String()),
+		zap.Bool("requireAuth", l.RequireAuth),
+	)
+	return s.serve(tls.NewListener(listener, config), s.listenerHandler(l))
+}
+
+// listenerHandler returns the handler of the requests received on [l]
+func (s *server) listenerHandler(l Listener) http.Handler {
+	if l.RequireAuth {
+		return s.handler
+	}
+	return s.noAuthHandler
+}
+
+// newTLSConfig returns the TLS config of a listener serving the provided
+// certificate. HTTP/2 is negotiated with the clients that support it. If
+// [clientCABytes] isn't empty, clients may present a certificate issued by one
+// of the PEM encoded CAs it contains.
+func newTLSConfig(certBytes, keyBytes, clientCABytes []byte) (*tls.Config, error) {
+	cert, err := tls.X509KeyPair(certBytes, keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+	if len(clientCABytes) > 0 {
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(clientCABytes) {
+			return nil, errInvalidClientCAs
+		}
+		// Clients without a certificate are still accepted so that they can
+		// authenticate with an auth token.
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+		config.ClientCAs = clientCAs
+	}
+	return config, nil
+}
+
+// serve handles the requests received on [listener] with [handler] until the
+// server is shutdown
+func (s *server) serve(listener net.Listener, handler http.Handler) error {
+	srv := &http.Server{
+		Handler:           handler,
 		ReadHeaderTimeout: readHeaderTimeout,
 	}
-	return s.srv.Serve(listener)
+
+	s.srvsLock.Lock()
+	if s.shuttingDown {
+		s.srvsLock.Unlock()
+		_ = listener.Close()
+		return http.ErrServerClosed
+	}
+	s.srvs = append(s.srvs, srv)
+	s.srvsLock.Unlock()
+
+	return srv.Serve(listener)
+}
+
+func (s *server) Handler() http.Handler {
+	return s.handler
 }
 
 func (s *server) RegisterChain(chainName string, engine common.Engine) {
@@ -280,6 +397,7 @@ func (s *server) addChainRoute(chainName string, handler *common.HTTPHandler, ct
 		zap.String("url", url),
 		zap.String("endpoint", endpoint),
 	)
+	_, batchable := handler.Handler.(rpcServer)
 	if s.tracingEnabled {
 		handler = &common.HTTPHandler{
 			LockOptions: handler.LockOptions,
@@ -299,6 +417,9 @@ func (s *server) addChainRoute(chainName string, handler *common.HTTPHandler, ct
 	}
 	// Apply middleware to reject calls to the handler before the chain finishes bootstrapping
 	h = rejectMiddleware(h, ctx)
+	if batchable {
+		h = batchMiddleware(h, s.maxBatchSize)
+	}
 	return s.router.AddRouter(url, endpoint, h)
 }
 
@@ -319,6 +440,7 @@ func (s *server) addRoute(handler *common.HTTPHandler, lock *sync.RWMutex, base,
 		zap.String("endpoint", endpoint),
 	)
 
+	_, batchable := handler.Handler.(rpcServer)
 	if s.tracingEnabled {
 		handler = &common.HTTPHandler{
 			LockOptions: handler.LockOptions,
@@ -337,6 +459,9 @@ func (s *server) addRoute(handler *common.HTTPHandler, lock *sync.RWMutex, base,
 	if err != nil {
 		return err
 	}
+	if batchable {
+		h = batchMiddleware(h, s.maxBatchSize)
+	}
 	return s.router.AddRouter(url, endpoint, h)
 }
 
@@ -414,17 +539,34 @@ func (s *server) AddAliasesWithReadLock(endpoint string, aliases ...string) erro
 }
 
 func (s *server) Shutdown() error {
-	if s.srv == nil {
-		return nil
-	}
+	s.srvsLock.Lock()
+	s.shuttingDown = true
+	srvs := s.srvs
+	s.srvsLock.Unlock()
 
+	// The listeners share the shutdown timeout, so the servers are shutdown
+	// concurrently.
 	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
-	err := s.srv.Shutdown(ctx)
-	cancel()
+	defer cancel()
 
-	// If shutdown times out, make sure the server is still shutdown.
-	_ = s.srv.Close()
-	return err
+	errs := make(chan error, len(srvs))
+	for _, srv := range srvs {
+		go func(srv *http.Server) {
+			err := srv.Shutdown(ctx)
+
+			// If shutdown times out, make sure the server is still shutdown.
+			_ = srv.Close()
+			errs <- err
+		}(srv)
+	}
+
+	var firstErr error
+	for range srvs {
+		if err := <-errs; err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
 }
 
 type readPathAdder struct {
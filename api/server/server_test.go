@@ -0,0 +1,200 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/staking"
+	"github.com/coinflect/coinflectchain/utils/logging"
+)
+
+// rejectWrapper rejects every request
+type rejectWrapper struct{}
+
+func (rejectWrapper) WrapHandler(http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+}
+
+// headerWrapper sets a header on every response
+type headerWrapper struct{}
+
+func (headerWrapper) WrapHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("wrapped", "true")
+		h.ServeHTTP(w, r)
+	})
+}
+
+func unixClient(path string) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, UnixNetwork, path)
+			},
+		},
+	}
+}
+
+func TestDispatchListenerAuth(t *testing.T) {
+	require := require.New(t)
+
+	s := New()
+	s.Initialize(
+		logging.NoLog{},
+		nil,
+		"127.0.0.1",
+		0,
+		nil,
+		time.Second,
+		0,
+		ids.EmptyNodeID,
+		false,
+		nil,
+		headerWrapper{},
+		AuthWrapper{Wrapper: rejectWrapper{}},
+	)
+
+	dir := t.TempDir()
+	listeners := []Listener{
+		{
+			Network: UnixNetwork,
+			Address: filepath.Join(dir, "noauth.sock"),
+			Perms:   0o600,
+		},
+		{
+			Network:     UnixNetwork,
+			Address:     filepath.Join(dir, "auth.sock"),
+			Perms:       0o600,
+			RequireAuth: true,
+		},
+	}
+	errs := make(chan error, len(listeners))
+	for _, l := range listeners {
+		go func(l Listener) {
+			errs <- s.DispatchListener(l)
+		}(l)
+	}
+
+	get := func(path string) *http.Response {
+		var (
+			resp *http.Response
+			err  error
+		)
+		require.Eventually(func() bool {
+			resp, err = unixClient(path).Get("http://unix/ext/unknown")
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+		require.NoError(resp.Body.Close())
+		return resp
+	}
+
+	// The other wrappers are still applied when auth is skipped
+	resp := get(listeners[0].Address)
+	require.Equal(http.StatusNotFound, resp.StatusCode)
+	require.Equal("true", resp.Header.Get("wrapped"))
+
+	resp = get(listeners[1].Address)
+	require.Equal(http.StatusUnauthorized, resp.StatusCode)
+
+	require.NoError(s.Shutdown())
+	for range listeners {
+		require.ErrorIs(<-errs, http.ErrServerClosed)
+	}
+
+	// Listeners dispatched after shutdown don't serve requests
+	require.ErrorIs(s.DispatchListener(listeners[0]), http.ErrServerClosed)
+}
+
+func TestDispatchTLSInvalidClientCAs(t *testing.T) {
+	require := require.New(t)
+
+	certBytes, keyBytes, err := staking.NewCertAndKeyBytes()
+	require.NoError(err)
+
+	s := New()
+	s.Initialize(
+		logging.NoLog{},
+		nil,
+		"127.0.0.1",
+		0,
+		nil,
+		time.Second,
+		0,
+		ids.EmptyNodeID,
+		false,
+		nil,
+	)
+	err = s.DispatchTLS(certBytes, keyBytes, []byte("not a certificate"))
+	require.ErrorIs(err, errInvalidClientCAs)
+}
+
+func TestDispatchListenerTLS(t *testing.T) {
+	require := require.New(t)
+
+	certBytes, keyBytes, err := staking.NewCertAndKeyBytes()
+	require.NoError(err)
+
+	s := New()
+	s.Initialize(
+		logging.NoLog{},
+		nil,
+		"127.0.0.1",
+		0,
+		nil,
+		time.Second,
+		0,
+		ids.EmptyNodeID,
+		false,
+		nil,
+		headerWrapper{},
+	)
+
+	// Find a free port for the listener
+	freeListener, err := net.Listen(TCPNetwork, "127.0.0.1:0")
+	require.NoError(err)
+	l := Listener{
+		Network: TCPNetwork,
+		Address: freeListener.Addr().String(),
+	}
+	require.NoError(freeListener.Close())
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- s.DispatchListenerTLS(l, certBytes, keyBytes, nil)
+	}()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: true, //#nosec G402
+			},
+		},
+	}
+	var resp *http.Response
+	require.Eventually(func() bool {
+		resp, err = client.Get("https://" + l.Address + "/ext/unknown")
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(resp.Body.Close())
+	require.Equal(http.StatusNotFound, resp.StatusCode)
+	require.Equal("true", resp.Header.Get("wrapped"))
+	require.NotNil(resp.TLS)
+
+	require.NoError(s.Shutdown())
+	require.ErrorIs(<-errs, http.ErrServerClosed)
+}
@@ -12,3 +12,9 @@ type Wrapper interface {
 	// WrapHandler wraps an http.Handler.
 	WrapHandler(h http.Handler) http.Handler
 }
+
+// AuthWrapper marks a Wrapper that authorizes requests. It isn't applied to
+// the requests received on listeners that don't require auth.
+type AuthWrapper struct {
+	Wrapper
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package dbcmd implements the offline database commands that are run with
+// "coinflectchain db <command>". The node must not be running while these
+// commands operate on its database.
+package dbcmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/leveldb"
+	"github.com/coinflect/coinflectchain/database/lsmdb"
+	"github.com/coinflect/coinflectchain/database/manager"
+	"github.com/coinflect/coinflectchain/utils/logging"
+	"github.com/coinflect/coinflectchain/version"
+)
+
+// Name is the first argument that selects the database commands.
+const Name = "db"
+
+var (
+	errMissingCommand = errors.New("missing command")
+	errUnknownCommand = errors.New("unknown command")
+	errUnknownDBType  = errors.New("unknown database type")
+
+	commands = map[string]func(args []string) error{
+		inspectCommand: runInspect,
+		migrateCommand: runMigrate,
+		restoreCommand: runRestore,
+	}
+)
+
+// Run executes the database command described by [args]. The first element of
+// [args] is the name of the command.
+func Run(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w, expected one of {%s}", errMissingCommand, commandNames())
+	}
+	run, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("%w %q, expected one of {%s}", errUnknownCommand, args[0], commandNames())
+	}
+	return run(args[1:])
+}
+
+func commandNames() string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func newLogger() logging.Logger {
+	return logging.NewLogger(
+		"",
+		logging.NewWrappedCore(logging.Info, os.Stdout, logging.Plain.ConsoleEncoder()),
+	)
+}
+
+func readConfigFile(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+	return os.ReadFile(path)
+}
+
+// readOnlyConfig returns [dbConfig] with the database opened read-only, so
+// that the commands never modify the databases they only read from.
+func readOnlyConfig(dbConfig []byte) ([]byte, error) {
+	parsedConfig := map[string]interface{}{}
+	if len(dbConfig) > 0 {
+		if err := json.Unmarshal(dbConfig, &parsedConfig); err != nil {
+			return nil, fmt.Errorf("failed to parse db config: %w", err)
+		}
+	}
+	parsedConfig["readOnly"] = true
+	return json.Marshal(parsedConfig)
+}
+
+// openManager opens every versioned database of type [dbType] in [dbDir]. The
+// directory is expected to have the layout used by the node, with one
+// sub-directory per database version.
+func openManager(
+	dbType string,
+	dbDir string,
+	dbConfig []byte,
+	log logging.Logger,
+	namespace string,
+	reg prometheus.Registerer,
+) (manager.Manager, error) {
+	switch dbType {
+	case leveldb.Name:
+		return manager.NewLevelDB(dbDir, dbConfig, log, version.CurrentDatabase, namespace, reg)
+	case lsmdb.Name:
+		return manager.NewLSMDB(dbDir, dbConfig, log, version.CurrentDatabase, namespace, reg)
+	default:
+		return nil, fmt.Errorf(
+			"%w %q, expected one of {%s, %s}",
+			errUnknownDBType,
+			dbType,
+			leveldb.Name,
+			lsmdb.Name,
+		)
+	}
+}
+
+// openDatabase opens a single database of type [dbType] at [path].
+func openDatabase(
+	dbType string,
+	path string,
+	dbConfig []byte,
+	log logging.Logger,
+	namespace string,
+	reg prometheus.Registerer,
+) (database.Database, error) {
+	switch dbType {
+	case leveldb.Name:
+		return leveldb.New(path, dbConfig, log, namespace, reg)
+	case lsmdb.Name:
+		return lsmdb.New(path, dbConfig, log, namespace, reg)
+	default:
+		return nil, fmt.Errorf(
+			"%w %q, expected one of {%s, %s}",
+			errUnknownDBType,
+			dbType,
+			leveldb.Name,
+			lsmdb.Name,
+		)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dbcmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/spf13/pflag"
+
+	"github.com/coinflect/coinflectchain/database/inspect"
+	"github.com/coinflect/coinflectchain/database/leveldb"
+	"github.com/coinflect/coinflectchain/genesis"
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/utils/constants"
+	"github.com/coinflect/coinflectchain/utils/logging"
+	"github.com/coinflect/coinflectchain/utils/perms"
+)
+
+const (
+	inspectCommand = "inspect"
+
+	outputFileKey = "output-file"
+)
+
+// inspectOutput is the JSON document written by the inspect command.
+type inspectOutput struct {
+	Databases []inspectedDatabase `json:"databases"`
+}
+
+type inspectedDatabase struct {
+	Version string `json:"version"`
+	// Chains maps the name of every chain that was found to its ID.
+	Chains map[string]ids.ID `json:"chains"`
+	*inspect.Report
+}
+
+// runInspect reports the number and size of the keys of every namespace of
+// every versioned database in a database directory.
+func runInspect(args []string) error {
+	fs := pflag.NewFlagSet(Name+" "+inspectCommand, pflag.ContinueOnError)
+	dbDir := fs.String(dbDirKey, "", "Path to the network directory of the database to inspect, e.g. ~/.coinflectchain/db/mainnet")
+	dbType := fs.String(dbTypeKey, leveldb.Name, "Database type of the database")
+	dbConfigFile := fs.String(dbConfigFileKey, "", "Path to the database config file")
+	networkName := fs.String(networkIDKey, "", "Network of the database. If set, chains are named by their aliases")
+	genesisFile := fs.String(genesisFileKey, "", "Path to the genesis file of the network. Required to name the chains of custom networks")
+	outputFile := fs.String(outputFileKey, "", "Path to write the report to. Defaults to stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *dbDir == "" {
+		return fmt.Errorf("%w: --%s", errMissingDBDir, dbDirKey)
+	}
+
+	aliases := map[ids.ID]string{
+		constants.PlatformChainID: "P",
+	}
+	if *networkName != "" {
+		networkID, err := constants.NetworkID(*networkName)
+		if err != nil {
+			return err
+		}
+		genesisBytes, err := loadGenesis(networkID, *genesisFile)
+		if err != nil {
+			return fmt.Errorf("couldn't load genesis: %w", err)
+		}
+		_, chainAliases, err := genesis.Aliases(genesisBytes)
+		if err != nil {
+			return err
+		}
+		for chainID, chainAlias := range chainAliases {
+			aliases[chainID] = chainAlias[0]
+		}
+	}
+
+	dbConfig, err := readConfigFile(*dbConfigFile)
+	if err != nil {
+		return fmt.Errorf("couldn't read database config: %w", err)
+	}
+
+	// The report is written to stdout by default, so nothing is logged.
+	dbManager, err := openManager(*dbType, *dbDir, dbConfig, logging.NoLog{}, "inspect_db", prometheus.NewRegistry())
+	if err != nil {
+		return fmt.Errorf("couldn't open database: %w", err)
+	}
+	defer dbManager.Close()
+
+	output := inspectOutput{}
+	for _, db := range dbManager.GetDatabases() {
+		chainIDs, err := inspect.DiscoverChains(db.Database)
+		if err != nil {
+			return fmt.Errorf("couldn't discover chains of database %s: %w", db.Version, err)
+		}
+		chains := make(map[string]ids.ID, len(chainIDs))
+		for _, chainID := range chainIDs {
+			name, ok := aliases[chainID]
+			if !ok {
+				name = chainID.String()
+			}
+			chains[name] = chainID
+		}
+
+		report, err := inspect.Database(db.Database, inspect.NewNodeResolver(chainIDs, aliases))
+		if err != nil {
+			return fmt.Errorf("couldn't inspect database %s: %w", db.Version, err)
+		}
+		output.Databases = append(output.Databases, inspectedDatabase{
+			Version: db.Version.String(),
+			Chains:  chains,
+			Report:  report,
+		})
+	}
+
+	var w io.Writer = os.Stdout
+	if *outputFile != "" {
+		f, err := os.OpenFile(*outputFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perms.ReadWrite)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(output)
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dbcmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/spf13/pflag"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/leveldb"
+	"github.com/coinflect/coinflectchain/database/migrate"
+	"github.com/coinflect/coinflectchain/utils/perms"
+	"github.com/coinflect/coinflectchain/version"
+)
+
+const (
+	migrateCommand = "migrate"
+
+	srcDBDirKey        = "src-db-dir"
+	srcDBTypeKey       = "src-db-type"
+	srcDBConfigFileKey = "src-db-config-file"
+	dstDBDirKey        = "dst-db-dir"
+	dstDBTypeKey       = "dst-db-type"
+	dstDBConfigFileKey = "dst-db-config-file"
+	batchSizeKey       = "batch-size"
+	prefixLengthKey    = "prefix-length"
+)
+
+var (
+	errMissingDBDir     = errors.New("missing database directory")
+	errSameDBDir        = errors.New("source and destination database directories must differ")
+	errInvalidBatchSize = errors.New("batch size must be positive")
+)
+
+// runMigrate copies every versioned database in the source directory into the
+// destination directory, possibly changing the database type. The progress of
+// the migration is persisted in the destination directory so that an
+// interrupted migration can be resumed by re-running the same command. The
+// source databases are opened read-only.
+func runMigrate(args []string) error {
+	fs := pflag.NewFlagSet(Name+" "+migrateCommand, pflag.ContinueOnError)
+	srcDir := fs.String(srcDBDirKey, "", "Path to the network directory of the database to migrate from, e.g. ~/.coinflectchain/db/mainnet")
+	srcType := fs.String(srcDBTypeKey, leveldb.Name, "Database type of the source database")
+	srcConfigFile := fs.String(srcDBConfigFileKey, "", "Path to the config file of the source database")
+	dstDir := fs.String(dstDBDirKey, "", "Path to the network directory of the database to migrate to")
+	dstType := fs.String(dstDBTypeKey, leveldb.Name, "Database type of the destination database")
+	dstConfigFile := fs.String(dstDBConfigFileKey, "", "Path to the config file of the destination database")
+	batchSize := fs.Int(batchSizeKey, migrate.DefaultBatchSize, "Number of bytes to write to the destination database per batch")
+	prefixLen := fs.Int(prefixLengthKey, migrate.DefaultPrefixLen, "Number of leading key bytes used to group keys when verifying the migration")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	switch {
+	case *srcDir == "":
+		return fmt.Errorf("%w: --%s", errMissingDBDir, srcDBDirKey)
+	case *dstDir == "":
+		return fmt.Errorf("%w: --%s", errMissingDBDir, dstDBDirKey)
+	case filepath.Clean(*srcDir) == filepath.Clean(*dstDir):
+		return errSameDBDir
+	case *batchSize <= 0:
+		return errInvalidBatchSize
+	}
+
+	srcConfig, err := readConfigFile(*srcConfigFile)
+	if err != nil {
+		return fmt.Errorf("couldn't read source database config: %w", err)
+	}
+	srcConfig, err = readOnlyConfig(srcConfig)
+	if err != nil {
+		return fmt.Errorf("couldn't read source database config: %w", err)
+	}
+	dstConfig, err := readConfigFile(*dstConfigFile)
+	if err != nil {
+		return fmt.Errorf("couldn't read destination database config: %w", err)
+	}
+
+	log := newLogger()
+
+	srcManager, err := openManager(*srcType, *srcDir, srcConfig, log, "src_db", prometheus.NewRegistry())
+	if err != nil {
+		return fmt.Errorf("couldn't open source database: %w", err)
+	}
+	defer srcManager.Close()
+
+	if err := os.MkdirAll(*dstDir, perms.ReadWriteExecute); err != nil {
+		return err
+	}
+	openDst := func(v *version.Semantic) (database.Database, error) {
+		namespace := "dst_db_" + strings.ReplaceAll(v.String(), ".", "_")
+		return openDatabase(
+			*dstType,
+			filepath.Join(*dstDir, v.String()),
+			dstConfig,
+			log,
+			namespace,
+			prometheus.NewRegistry(),
+		)
+	}
+
+	return migrate.Manager(
+		log,
+		srcManager,
+		openDst,
+		*dstDir,
+		migrate.Config{
+			BatchSize: *batchSize,
+			PrefixLen: *prefixLen,
+		},
+	)
+}
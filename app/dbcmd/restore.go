@@ -0,0 +1,171 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dbcmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/spf13/pflag"
+
+	"go.uber.org/zap"
+
+	"github.com/coinflect/coinflectchain/database/backup"
+	"github.com/coinflect/coinflectchain/database/leveldb"
+	"github.com/coinflect/coinflectchain/genesis"
+	"github.com/coinflect/coinflectchain/utils/constants"
+	"github.com/coinflect/coinflectchain/utils/hashing"
+	"github.com/coinflect/coinflectchain/utils/perms"
+	"github.com/coinflect/coinflectchain/version"
+)
+
+const (
+	restoreCommand = "restore"
+
+	archiveKey      = "archive"
+	dbDirKey        = "db-dir"
+	dbTypeKey       = "db-type"
+	dbConfigFileKey = "db-config-file"
+	networkIDKey    = "network-id"
+	genesisFileKey  = "genesis-file"
+
+	// restoringSuffix is appended to the directory an archive is restored
+	// into. The database manager ignores the directory until the restore
+	// completes and it is renamed to the database version.
+	restoringSuffix = ".restoring"
+)
+
+var (
+	errMissingArchive   = errors.New("missing archive")
+	errDBVersionExists  = errors.New("database version already exists")
+	errInvalidDBVersion = errors.New("invalid database version")
+)
+
+// runRestore unpacks an archive created by the admin.backupDatabase API into
+// a database directory. The archive is only unpacked if it was created for the
+// same network and genesis as the node it is being restored for.
+func runRestore(args []string) error {
+	fs := pflag.NewFlagSet(Name+" "+restoreCommand, pflag.ContinueOnError)
+	archivePath := fs.String(archiveKey, "", "Path to the archive created by admin.backupDatabase")
+	dbDir := fs.String(dbDirKey, "", "Path to the database directory of the node, e.g. ~/.coinflectchain/db")
+	dbType := fs.String(dbTypeKey, leveldb.Name, "Database type to restore the archive into")
+	dbConfigFile := fs.String(dbConfigFileKey, "", "Path to the database config file")
+	networkName := fs.String(networkIDKey, constants.MainnetName, "Network the archive is expected to belong to")
+	genesisFile := fs.String(genesisFileKey, "", "Path to the genesis file of the network. Required for custom networks")
+	batchSize := fs.Int(batchSizeKey, backup.DefaultBatchSize, "Number of bytes to write to the database per batch")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	switch {
+	case *archivePath == "":
+		return fmt.Errorf("%w: --%s", errMissingArchive, archiveKey)
+	case *dbDir == "":
+		return fmt.Errorf("%w: --%s", errMissingDBDir, dbDirKey)
+	case *batchSize <= 0:
+		return errInvalidBatchSize
+	}
+
+	networkID, err := constants.NetworkID(*networkName)
+	if err != nil {
+		return err
+	}
+	genesisBytes, err := loadGenesis(networkID, *genesisFile)
+	if err != nil {
+		return fmt.Errorf("couldn't load genesis: %w", err)
+	}
+	genesisID := hashing.ComputeHash256Array(genesisBytes)
+	dbConfig, err := readConfigFile(*dbConfigFile)
+	if err != nil {
+		return fmt.Errorf("couldn't read database config: %w", err)
+	}
+
+	f, err := os.Open(*archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r, err := backup.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("couldn't read archive: %w", err)
+	}
+	defer r.Close()
+
+	header := r.Header()
+	if err := header.Verify(networkID, genesisID); err != nil {
+		return err
+	}
+	dbVersion, err := version.Parse(header.DatabaseVersion)
+	if err != nil {
+		return fmt.Errorf("%w %q: %s", errInvalidDBVersion, header.DatabaseVersion, err)
+	}
+
+	networkDir := filepath.Join(*dbDir, constants.NetworkName(networkID))
+	versionDir := filepath.Join(networkDir, dbVersion.String())
+	if _, err := os.Stat(versionDir); err == nil {
+		return fmt.Errorf("%w: %s", errDBVersionExists, versionDir)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	// Remove any leftovers of a previously interrupted restore.
+	restoringDir := versionDir + restoringSuffix
+	if err := os.RemoveAll(restoringDir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(networkDir, perms.ReadWriteExecute); err != nil {
+		return err
+	}
+
+	log := newLogger()
+	log.Info("restoring database",
+		zap.String("archive", *archivePath),
+		zap.String("version", dbVersion.String()),
+		zap.Time("timestamp", header.Timestamp),
+	)
+
+	db, err := openDatabase(*dbType, restoringDir, dbConfig, log, "restore_db", prometheus.NewRegistry())
+	if err != nil {
+		return fmt.Errorf("couldn't open database: %w", err)
+	}
+	result, err := r.Restore(db, *batchSize)
+	if closeErr := db.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.RemoveAll(restoringDir)
+		return fmt.Errorf("couldn't restore archive: %w", err)
+	}
+	if err := os.Rename(restoringDir, versionDir); err != nil {
+		return err
+	}
+
+	log.Info("restored database",
+		zap.String("path", versionDir),
+		zap.Uint64("numKeys", result.NumKeys),
+		zap.Stringer("checksum", result.Checksum),
+	)
+	return nil
+}
+
+// loadGenesis returns the genesis bytes of the network. If [genesisFile] is
+// empty, the genesis of a standard network is used.
+func loadGenesis(networkID uint32, genesisFile string) ([]byte, error) {
+	var (
+		genesisBytes []byte
+		err          error
+	)
+	if genesisFile != "" {
+		genesisBytes, _, err = genesis.FromFile(networkID, genesisFile)
+	} else {
+		genesisBytes, _, err = genesis.FromConfig(genesis.GetConfig(networkID))
+	}
+	return genesisBytes, err
+}
@@ -22,7 +22,9 @@ import (
 	"github.com/coinflect/coinflectchain/api/metrics"
 	"github.com/coinflect/coinflectchain/api/server"
 	"github.com/coinflect/coinflectchain/chains/atomic"
+	"github.com/coinflect/coinflectchain/database/cachedb"
 	"github.com/coinflect/coinflectchain/database/prefixdb"
+	"github.com/coinflect/coinflectchain/database/versiondb"
 	"github.com/coinflect/coinflectchain/ids"
 	"github.com/coinflect/coinflectchain/message"
 	"github.com/coinflect/coinflectchain/network"
@@ -164,6 +166,7 @@ type ManagerConfig struct {
 	DecisionAcceptorGroup       snow.AcceptorGroup
 	ConsensusAcceptorGroup      snow.AcceptorGroup
 	DBManager                   dbManager.Manager
+	DBCache                     *cachedb.Cache             // If non-nil, the values read by chains are cached here
 	MsgCreator                  message.OutboundMsgBuilder // message creator, shared with network
 	Router                      router.Router              // Routes incoming messages to the appropriate chain
 	Net                         network.Network            // Sends consensus messages to other validators
@@ -580,7 +583,19 @@ func (m *manager) createCoinflectChain(
 	if err != nil {
 		return nil, err
 	}
+	if m.DBCache != nil {
+		meterDBManager, err = meterDBManager.NewCacheDBManager("db_cache", ctx.Registerer, m.DBCache)
+		if err != nil {
+			return nil, err
+		}
+	}
 	prefixDBManager := meterDBManager.NewPrefixDBManager(ctx.ChainID[:])
+	if ctx.SubnetID != constants.PrimaryNetworkID {
+		prefixDBManager, err = m.newQuotaDBManager(ctx, prefixDBManager)
+		if err != nil {
+			return nil, err
+		}
+	}
 	vmDBManager := prefixDBManager.NewPrefixDBManager([]byte("vm"))
 
 	db := prefixDBManager.Current()
@@ -588,6 +603,12 @@ func (m *manager) createCoinflectChain(
 	vertexBootstrappingDB := prefixdb.New([]byte("vertex_bs"), db.Database)
 	txBootstrappingDB := prefixdb.New([]byte("tx_bs"), db.Database)
 
+	// The journal must recover any interrupted commit of the job queues
+	// before they're loaded.
+	bootstrappingJournal, err := versiondb.NewJournal(db.Database)
+	if err != nil {
+		return nil, err
+	}
 	vtxBlocker, err := queue.NewWithMissing(vertexBootstrappingDB, "vtx", ctx.Registerer)
 	if err != nil {
 		return nil, err
@@ -720,6 +741,7 @@ func (m *manager) createCoinflectChain(
 		AllGetsServer: avaGetHandler,
 		VtxBlocked:    vtxBlocker,
 		TxBlocked:     txBlocker,
+		Journal:       bootstrappingJournal,
 		Manager:       vtxManager,
 		VM:            vm,
 	}
@@ -770,7 +792,7 @@ func (m *manager) createCoinflectChain(
 	// Register health check for this chain
 	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
 
-	if err := m.Health.RegisterHealthCheck(chainAlias, handler); err != nil {
+	if err := m.Health.RegisterHealthCheck(chainAlias, handler, "network", "database"); err != nil {
 		return nil, fmt.Errorf("couldn't add health check for chain %s: %w", chainAlias, err)
 	}
 
@@ -800,7 +822,19 @@ func (m *manager) createSnowmanChain(
 	if err != nil {
 		return nil, err
 	}
+	if m.DBCache != nil {
+		meterDBManager, err = meterDBManager.NewCacheDBManager("db_cache", ctx.Registerer, m.DBCache)
+		if err != nil {
+			return nil, err
+		}
+	}
 	prefixDBManager := meterDBManager.NewPrefixDBManager(ctx.ChainID[:])
+	if ctx.SubnetID != constants.PrimaryNetworkID {
+		prefixDBManager, err = m.newQuotaDBManager(ctx, prefixDBManager)
+		if err != nil {
+			return nil, err
+		}
+	}
 	vmDBManager := prefixDBManager.NewPrefixDBManager([]byte("vm"))
 
 	db := prefixDBManager.Current()
@@ -1047,7 +1081,7 @@ func (m *manager) createSnowmanChain(
 	handler.SetStateSyncer(stateSyncer)
 
 	// Register health checks
-	if err := m.Health.RegisterHealthCheck(chainAlias, handler); err != nil {
+	if err := m.Health.RegisterHealthCheck(chainAlias, handler, "network", "database"); err != nil {
 		return nil, fmt.Errorf("couldn't add health check for chain %s: %w", chainAlias, err)
 	}
 
@@ -1058,6 +1092,27 @@ func (m *manager) createSnowmanChain(
 	}, nil
 }
 
+// newQuotaDBManager wraps the database of a chain that isn't in the primary
+// network so that the bytes stored by the chain are limited by the quota of
+// its subnet. The usage of the database is reported as a health check.
+func (m *manager) newQuotaDBManager(ctx *snow.ConsensusContext, chainDBManager dbManager.Manager) (dbManager.Manager, error) {
+	var quota uint64
+	if subnetConfig, ok := m.SubnetConfigs[ctx.SubnetID]; ok {
+		quota = subnetConfig.DatabaseQuota
+	}
+	quotaDBManager, err := chainDBManager.NewQuotaDBManager("db_quota", ctx.Registerer, quota)
+	if err != nil {
+		return nil, err
+	}
+
+	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
+	healthCheckName := fmt.Sprintf("%s-database", chainAlias)
+	if err := m.Health.RegisterHealthCheck(healthCheckName, quotaDBManager.Current().Database); err != nil {
+		return nil, fmt.Errorf("couldn't add database health check for chain %s: %w", chainAlias, err)
+	}
+	return quotaDBManager, nil
+}
+
 func (m *manager) SubnetID(chainID ids.ID) (ids.ID, error) {
 	m.chainsLock.Lock()
 	defer m.chainsLock.Unlock()
@@ -1101,10 +1156,11 @@ func (m *manager) registerBootstrappedHealthChecks() error {
 		}
 		return subnetIDs, nil
 	})
-	if err := m.Health.RegisterReadinessCheck("bootstrapped", bootstrappedCheck); err != nil {
+	// Bootstrapping requires connections to peers
+	if err := m.Health.RegisterReadinessCheck("bootstrapped", bootstrappedCheck, "network"); err != nil {
 		return fmt.Errorf("couldn't register bootstrapped readiness check: %w", err)
 	}
-	if err := m.Health.RegisterHealthCheck("bootstrapped", bootstrappedCheck); err != nil {
+	if err := m.Health.RegisterHealthCheck("bootstrapped", bootstrappedCheck, "network"); err != nil {
 		return fmt.Errorf("couldn't register bootstrapped health check: %w", err)
 	}
 	return nil
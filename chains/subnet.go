@@ -38,6 +38,11 @@ type SubnetConfig struct {
 	// building a snowman++ block.
 	// TODO: Remove this flag once all VMs throttle their own block production.
 	ProposerMinBlockDelay time.Duration `json:"proposerMinBlockDelay" yaml:"proposerMinBlockDelay"`
+
+	// DatabaseQuota is the maximum number of bytes that each of this Subnet's
+	// Chains may store. Writes that exceed the quota are refused. If 0, writes
+	// are never refused.
+	DatabaseQuota uint64 `json:"databaseQuota" yaml:"databaseQuota"`
 }
 
 type subnet struct {
@@ -20,6 +20,8 @@ import (
 
 	"github.com/spf13/viper"
 
+	"github.com/coinflect/coinflectchain/api/ratelimit"
+	"github.com/coinflect/coinflectchain/api/server"
 	"github.com/coinflect/coinflectchain/app/runner"
 	"github.com/coinflect/coinflectchain/chains"
 	"github.com/coinflect/coinflectchain/genesis"
@@ -30,6 +32,7 @@ import (
 	"github.com/coinflect/coinflectchain/network/dialer"
 	"github.com/coinflect/coinflectchain/network/throttling"
 	"github.com/coinflect/coinflectchain/node"
+	"github.com/coinflect/coinflectchain/sinks"
 	"github.com/coinflect/coinflectchain/snow/consensus/coinflect"
 	"github.com/coinflect/coinflectchain/snow/consensus/snowball"
 	"github.com/coinflect/coinflectchain/snow/networking/benchlist"
@@ -66,6 +69,9 @@ var (
 	errInvalidStakerWeights          = errors.New("staking weights must be positive")
 	errStakingDisableOnPublicNetwork = errors.New("staking disabled on public network")
 	errAuthPasswordTooWeak           = errors.New("API auth password is not strong enough")
+	errInvalidSocketPerms            = errors.New("invalid socket permissions")
+	errClientCAWithoutTLS            = fmt.Errorf("%s can't be set unless %s is true", HTTPSClientCAFileKey, HTTPSEnabledKey)
+	errClientCAWithoutAuth           = fmt.Errorf("%s can't be set unless %s is true", HTTPSClientCAFileKey, APIAuthRequiredKey)
 	errInvalidUptimeRequirement      = errors.New("uptime requirement must be in the range [0, 1]")
 	errMinValidatorStakeAboveMax     = errors.New("minimum validator stake can't be greater than maximum validator stake")
 	errInvalidDelegationFee          = errors.New("delegation fee must be in the range [0, 1,000,000]")
@@ -192,10 +198,104 @@ func getAPIAuthConfig(v *viper.Viper) (node.APIAuthConfig, error) {
 	if !password.SufficientlyStrong(config.APIAuthPassword, password.OK) {
 		return node.APIAuthConfig{}, errAuthPasswordTooWeak
 	}
+
+	var (
+		certRolesBytes []byte
+		err            error
+	)
+	if v.IsSet(APIAuthCertRolesContentKey) {
+		certRolesContent := v.GetString(APIAuthCertRolesContentKey)
+		certRolesBytes, err = base64.StdEncoding.DecodeString(certRolesContent)
+		if err != nil {
+			return node.APIAuthConfig{}, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	} else if v.IsSet(APIAuthCertRolesFileKey) {
+		path := GetExpandedArg(v, APIAuthCertRolesFileKey)
+		certRolesBytes, err = os.ReadFile(path)
+		if err != nil {
+			return node.APIAuthConfig{}, err
+		}
+	}
+	if len(certRolesBytes) > 0 {
+		if err := json.Unmarshal(certRolesBytes, &config.APICertRoles); err != nil {
+			return node.APIAuthConfig{}, fmt.Errorf("couldn't parse the roles of client certificates: %w", err)
+		}
+		if err := config.APICertRoles.Verify(); err != nil {
+			return node.APIAuthConfig{}, err
+		}
+	}
+	return config, nil
+}
+
+func getAPIRateLimitConfig(v *viper.Viper) (ratelimit.Config, error) {
+	config := ratelimit.Config{
+		Default: ratelimit.Limit{
+			Rate:  v.GetFloat64(APIRateLimitRateKey),
+			Burst: v.GetInt(APIRateLimitBurstKey),
+		},
+	}
+
+	var (
+		configBytes []byte
+		err         error
+	)
+	if v.IsSet(APIRateLimitContentKey) {
+		rateLimitContent := v.GetString(APIRateLimitContentKey)
+		configBytes, err = base64.StdEncoding.DecodeString(rateLimitContent)
+		if err != nil {
+			return ratelimit.Config{}, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	} else if v.IsSet(APIRateLimitFileKey) {
+		path := GetExpandedArg(v, APIRateLimitFileKey)
+		configBytes, err = os.ReadFile(path)
+		if err != nil {
+			return ratelimit.Config{}, err
+		}
+	}
+	if len(configBytes) > 0 {
+		if err := json.Unmarshal(configBytes, &config); err != nil {
+			return ratelimit.Config{}, fmt.Errorf("couldn't parse API rate limits: %w", err)
+		}
+	}
+
+	if err := config.Verify(); err != nil {
+		return ratelimit.Config{}, fmt.Errorf("invalid API rate limits: %w", err)
+	}
 	return config, nil
 }
 
-func getIPCConfig(v *viper.Viper) node.IPCConfig {
+func getAcceptorSinksConfig(v *viper.Viper) (sinks.Config, error) {
+	var (
+		config      sinks.Config
+		configBytes []byte
+		err         error
+	)
+	if v.IsSet(AcceptorSinksContentKey) {
+		sinksContent := v.GetString(AcceptorSinksContentKey)
+		configBytes, err = base64.StdEncoding.DecodeString(sinksContent)
+		if err != nil {
+			return sinks.Config{}, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	} else if v.IsSet(AcceptorSinksFileKey) {
+		path := GetExpandedArg(v, AcceptorSinksFileKey)
+		configBytes, err = os.ReadFile(path)
+		if err != nil {
+			return sinks.Config{}, err
+		}
+	}
+	if len(configBytes) > 0 {
+		if err := json.Unmarshal(configBytes, &config); err != nil {
+			return sinks.Config{}, fmt.Errorf("couldn't parse acceptor sinks: %w", err)
+		}
+	}
+
+	if err := config.Verify(); err != nil {
+		return sinks.Config{}, fmt.Errorf("invalid acceptor sinks: %w", err)
+	}
+	return config, nil
+}
+
+func getIPCConfig(v *viper.Viper) (node.IPCConfig, error) {
 	config := node.IPCConfig{
 		IPCAPIEnabled: v.GetBool(IpcAPIEnabledKey),
 		IPCPath:       ipcs.DefaultBaseURL,
@@ -206,14 +306,18 @@ func getIPCConfig(v *viper.Viper) node.IPCConfig {
 	if v.IsSet(IpcsPathKey) {
 		config.IPCPath = GetExpandedArg(v, IpcsPathKey)
 	}
-	return config
+
+	var err error
+	config.AcceptorSinksConfig, err = getAcceptorSinksConfig(v)
+	return config, err
 }
 
 func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 	var (
-		httpsKey  []byte
-		httpsCert []byte
-		err       error
+		httpsKey      []byte
+		httpsCert     []byte
+		httpsClientCA []byte
+		err           error
 	)
 	switch {
 	case v.IsSet(HTTPSKeyContentKey):
@@ -243,35 +347,119 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 		}
 	}
 
+	switch {
+	case v.IsSet(HTTPSClientCAContentKey):
+		rawContent := v.GetString(HTTPSClientCAContentKey)
+		httpsClientCA, err = base64.StdEncoding.DecodeString(rawContent)
+		if err != nil {
+			return node.HTTPConfig{}, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.IsSet(HTTPSClientCAFileKey):
+		httpsClientCAFilepath := GetExpandedArg(v, HTTPSClientCAFileKey)
+		if httpsClientCA, err = os.ReadFile(filepath.Clean(httpsClientCAFilepath)); err != nil {
+			return node.HTTPConfig{}, err
+		}
+	}
+	if len(httpsClientCA) > 0 && !v.GetBool(HTTPSEnabledKey) {
+		return node.HTTPConfig{}, errClientCAWithoutTLS
+	}
+	// Client certificates are only checked when authorization is required, so
+	// they would be silently ignored otherwise.
+	if len(httpsClientCA) > 0 && !v.GetBool(APIAuthRequiredKey) {
+		return node.HTTPConfig{}, errClientCAWithoutAuth
+	}
+
 	config := node.HTTPConfig{
 		APIConfig: node.APIConfig{
 			APIIndexerConfig: node.APIIndexerConfig{
 				IndexAPIEnabled:      v.GetBool(IndexEnabledKey),
 				IndexAllowIncomplete: v.GetBool(IndexAllowIncompleteKey),
+				IndexBackfillEnabled: v.GetBool(IndexBackfillEnabledKey),
 			},
 			AdminAPIEnabled:    v.GetBool(AdminAPIEnabledKey),
 			InfoAPIEnabled:     v.GetBool(InfoAPIEnabledKey),
 			KeystoreAPIEnabled: v.GetBool(KeystoreAPIEnabledKey),
 			MetricsAPIEnabled:  v.GetBool(MetricsAPIEnabledKey),
 			HealthAPIEnabled:   v.GetBool(HealthAPIEnabledKey),
+			EventsAPIEnabled:   v.GetBool(EventsAPIEnabledKey),
 		},
 		HTTPHost:          v.GetString(HTTPHostKey),
 		HTTPPort:          uint16(v.GetUint(HTTPPortKey)),
+		HTTPGRPCPort:      uint16(v.GetUint(HTTPGRPCPortKey)),
 		HTTPSEnabled:      v.GetBool(HTTPSEnabledKey),
 		HTTPSKey:          httpsKey,
 		HTTPSCert:         httpsCert,
+		HTTPSClientCA:     httpsClientCA,
 		APIAllowedOrigins: v.GetStringSlice(HTTPAllowedOrigins),
+		APIMaxBatchSize:   v.GetInt(HTTPMaxBatchSizeKey),
 
 		ShutdownTimeout: v.GetDuration(HTTPShutdownTimeoutKey),
 		ShutdownWait:    v.GetDuration(HTTPShutdownWaitKey),
 	}
 
+	if v.GetString(HTTPUnixSocketPathKey) != "" {
+		perms := os.FileMode(v.GetUint(HTTPUnixSocketPermsKey))
+		if perms == 0 || perms&^os.ModePerm != 0 {
+			return node.HTTPConfig{}, fmt.Errorf("%w: %o", errInvalidSocketPerms, perms)
+		}
+		config.HTTPListeners = append(config.HTTPListeners, server.Listener{
+			Network:     server.UnixNetwork,
+			Address:     GetExpandedArg(v, HTTPUnixSocketPathKey),
+			Perms:       perms,
+			RequireAuth: v.GetBool(HTTPUnixSocketAuthRequiredKey),
+		})
+	}
+
+	listeners, err := getHTTPListeners(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+	config.HTTPListeners = append(config.HTTPListeners, listeners...)
+
 	config.APIAuthConfig, err = getAPIAuthConfig(v)
 	if err != nil {
 		return node.HTTPConfig{}, err
 	}
-	config.IPCConfig = getIPCConfig(v)
-	return config, nil
+	config.APIRateLimitConfig, err = getAPIRateLimitConfig(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+	config.IPCConfig, err = getIPCConfig(v)
+	return config, err
+}
+
+func getHTTPListeners(v *viper.Viper) ([]server.Listener, error) {
+	var (
+		listenersBytes []byte
+		err            error
+	)
+	if v.IsSet(HTTPListenersContentKey) {
+		listenersContent := v.GetString(HTTPListenersContentKey)
+		listenersBytes, err = base64.StdEncoding.DecodeString(listenersContent)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	} else if v.IsSet(HTTPListenersFileKey) {
+		path := GetExpandedArg(v, HTTPListenersFileKey)
+		listenersBytes, err = os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(listenersBytes) == 0 {
+		return nil, nil
+	}
+
+	var listeners []server.Listener
+	if err := json.Unmarshal(listenersBytes, &listeners); err != nil {
+		return nil, fmt.Errorf("couldn't parse HTTP listeners: %w", err)
+	}
+	for i := range listeners {
+		if err := listeners[i].Verify(); err != nil {
+			return nil, fmt.Errorf("invalid HTTP listener %d: %w", i, err)
+		}
+	}
+	return listeners, nil
 }
 
 func getRouterHealthConfig(v *viper.Viper, halflife time.Duration) (router.HealthConfig, error) {
@@ -912,7 +1100,10 @@ func getDatabaseConfig(v *viper.Viper, networkID uint32) (node.DatabaseConfig, e
 			GetExpandedArg(v, DBPathKey),
 			constants.NetworkName(networkID),
 		),
-		Config: configBytes,
+		Config:        configBytes,
+		CacheSize:     v.GetUint64(DBCacheSizeKey),
+		BackupDir:     GetExpandedArg(v, DBBackupDirKey),
+		MasterKeyPath: GetExpandedArg(v, DBMasterKeyFileKey),
 	}, nil
 }
 
@@ -1159,6 +1350,7 @@ func getDefaultSubnetConfig(v *viper.Viper) chains.SubnetConfig {
 		ValidatorOnly:         false,
 		GossipConfig:          getGossipConfig(v),
 		ProposerMinBlockDelay: proposervm.DefaultMinBlockDelay,
+		DatabaseQuota:         v.GetUint64(DBSubnetChainQuotaKey),
 	}
 }
 
@@ -18,6 +18,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/coinflect/coinflectchain/api/server"
 	"github.com/coinflect/coinflectchain/chains"
 	"github.com/coinflect/coinflectchain/ids"
 )
@@ -587,6 +588,165 @@ func TestCalcMinConnectedStake(t *testing.T) {
 	require.Equal(t, defaultExpectedMinStake, minStake)
 }
 
+func TestGetHTTPConfigUnixSocket(t *testing.T) {
+	tests := map[string]struct {
+		path              string
+		perms             string
+		expectedListeners []server.Listener
+		expectedErr       error
+	}{
+		"no socket": {
+			expectedListeners: nil,
+		},
+		"socket": {
+			path:  "/tmp/api.sock",
+			perms: "0660",
+			expectedListeners: []server.Listener{{
+				Network: server.UnixNetwork,
+				Address: "/tmp/api.sock",
+				Perms:   0o660,
+			}},
+		},
+		"invalid perms": {
+			path:        "/tmp/api.sock",
+			perms:       "01777",
+			expectedErr: errInvalidSocketPerms,
+		},
+		"no perms": {
+			path:        "/tmp/api.sock",
+			perms:       "0",
+			expectedErr: errInvalidSocketPerms,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			v.Set(HTTPUnixSocketPathKey, test.path)
+			if test.perms != "" {
+				v.Set(HTTPUnixSocketPermsKey, test.perms)
+			}
+
+			config, err := getHTTPConfig(v)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				require.Equal(test.expectedListeners, config.HTTPListeners)
+			}
+		})
+	}
+}
+
+func TestGetHTTPConfigClientCA(t *testing.T) {
+	tests := map[string]struct {
+		httpsEnabled bool
+		expectedErr  error
+	}{
+		"without TLS": {
+			httpsEnabled: false,
+			expectedErr:  errClientCAWithoutTLS,
+		},
+		"without auth": {
+			httpsEnabled: true,
+			expectedErr:  errClientCAWithoutAuth,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := setupViperFlags()
+			v.Set(HTTPSClientCAContentKey, base64.StdEncoding.EncodeToString([]byte("ca")))
+			v.Set(HTTPSEnabledKey, test.httpsEnabled)
+
+			_, err := getHTTPConfig(v)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestGetHTTPConfigListeners(t *testing.T) {
+	const listenersJSON = `[
+		{"network":"tcp","address":"127.0.0.1:9653","requireAuth":true},
+		{"network":"unix","address":"/tmp/admin.sock","perms":"0600"}
+	]`
+	expectedListeners := []server.Listener{
+		{
+			Network:     server.TCPNetwork,
+			Address:     "127.0.0.1:9653",
+			RequireAuth: true,
+		},
+		{
+			Network: server.UnixNetwork,
+			Address: "/tmp/admin.sock",
+			Perms:   0o600,
+		},
+	}
+
+	tests := map[string]struct {
+		content           string
+		fileContent       string
+		expectedListeners []server.Listener
+		expectErr         bool
+	}{
+		"no listeners": {
+			expectedListeners: nil,
+		},
+		"content": {
+			content:           listenersJSON,
+			expectedListeners: expectedListeners,
+		},
+		"file": {
+			fileContent:       listenersJSON,
+			expectedListeners: expectedListeners,
+		},
+		"malformed": {
+			content:   `{"network":"tcp"}`,
+			expectErr: true,
+		},
+		"unknown network": {
+			content:   `[{"network":"udp","address":"127.0.0.1:9653"}]`,
+			expectErr: true,
+		},
+		"missing address": {
+			content:   `[{"network":"tcp"}]`,
+			expectErr: true,
+		},
+		"decimal perms": {
+			content:   `[{"network":"unix","address":"/tmp/admin.sock","perms":384}]`,
+			expectErr: true,
+		},
+		"public listener without auth": {
+			content:   `[{"network":"tcp","address":"0.0.0.0:9653"}]`,
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			if test.content != "" {
+				v.Set(HTTPListenersContentKey, base64.StdEncoding.EncodeToString([]byte(test.content)))
+			}
+			if test.fileContent != "" {
+				path := filepath.Join(t.TempDir(), "listeners.json")
+				require.NoError(os.WriteFile(path, []byte(test.fileContent), 0o600))
+				v.Set(HTTPListenersFileKey, path)
+			}
+
+			config, err := getHTTPConfig(v)
+			if test.expectErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			require.Equal(test.expectedListeners, config.HTTPListeners)
+		})
+	}
+}
+
 // setups config json file and writes content
 func setupConfigJSON(t *testing.T, rootPath string, value string) string {
 	configFilePath := filepath.Join(rootPath, "config.json")
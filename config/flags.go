@@ -18,6 +18,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/coinflect/coinflectchain/database/leveldb"
+	"github.com/coinflect/coinflectchain/database/lsmdb"
 	"github.com/coinflect/coinflectchain/database/memdb"
 	"github.com/coinflect/coinflectchain/genesis"
 	"github.com/coinflect/coinflectchain/trace"
@@ -40,6 +41,7 @@ var (
 	defaultDBDir                = filepath.Join(defaultUnexpandedDataDir, "db")
 	defaultLogDir               = filepath.Join(defaultUnexpandedDataDir, "logs")
 	defaultProfileDir           = filepath.Join(defaultUnexpandedDataDir, "profiles")
+	defaultDBBackupDir          = filepath.Join(defaultUnexpandedDataDir, "backups")
 	defaultStakingPath          = filepath.Join(defaultUnexpandedDataDir, "staking")
 	defaultStakingTLSKeyPath    = filepath.Join(defaultStakingPath, "staker.key")
 	defaultStakingCertPath      = filepath.Join(defaultStakingPath, "staker.crt")
@@ -114,10 +116,14 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Uint64(AddSubnetDelegatorFeeKey, genesis.LocalParams.AddSubnetDelegatorFee, "Transaction fee, in nCFLT, for transactions that add new subnet delegators")
 
 	// Database
-	fs.String(DBTypeKey, leveldb.Name, fmt.Sprintf("Database type to use. Should be one of {%s, %s}", leveldb.Name, memdb.Name))
+	fs.String(DBTypeKey, leveldb.Name, fmt.Sprintf("Database type to use. Should be one of {%s, %s, %s}", leveldb.Name, lsmdb.Name, memdb.Name))
 	fs.String(DBPathKey, defaultDBDir, "Path to database directory")
 	fs.String(DBConfigFileKey, "", fmt.Sprintf("Path to database config file. Ignored if %s is specified", DBConfigContentKey))
 	fs.String(DBConfigContentKey, "", "Specifies base64 encoded database config content")
+	fs.Uint64(DBCacheSizeKey, 0, "Maximum number of bytes of database values to cache in memory, shared by all chains. If 0, database reads aren't cached")
+	fs.Uint64(DBSubnetChainQuotaKey, 0, "Default maximum number of bytes that each chain of a subnet may store. Writes that exceed the quota are refused. Doesn't apply to the primary network. If 0, writes are never refused")
+	fs.String(DBBackupDirKey, defaultDBBackupDir, "Path to the directory that database backups created with admin.backupDatabase are written to")
+	fs.String(DBMasterKeyFileKey, "", "Path to the hex encoded master key that the database is encrypted with. A new key is generated if the file doesn't exist. Must be set when the database is created, because existing values aren't encrypted. If empty, the database isn't encrypted")
 
 	// Logging
 	fs.String(LogsDirKey, defaultLogDir, "Logging directory for Coinflect")
@@ -212,19 +218,34 @@ func addNodeFlags(fs *flag.FlagSet) {
 	// HTTP APIs
 	fs.String(HTTPHostKey, "127.0.0.1", "Address of the HTTP server")
 	fs.Uint(HTTPPortKey, DefaultHTTPPort, "Port of the HTTP server")
+	fs.Uint(HTTPGRPCPortKey, 0, "Port of the gRPC server that serves the Info, Health and Index APIs and the P-Chain and X-Chain read APIs. It uses the HTTP server's host, TLS settings and authorization. If 0, the gRPC server isn't started")
 	fs.Bool(HTTPSEnabledKey, false, "Upgrade the HTTP server to HTTPs")
 	fs.String(HTTPSKeyFileKey, "", fmt.Sprintf("TLS private key file for the HTTPs server. Ignored if %s is specified", HTTPSKeyContentKey))
 	fs.String(HTTPSKeyContentKey, "", "Specifies base64 encoded TLS private key for the HTTPs server")
 	fs.String(HTTPSCertFileKey, "", fmt.Sprintf("TLS certificate file for the HTTPs server. Ignored if %s is specified", HTTPSCertContentKey))
 	fs.String(HTTPSCertContentKey, "", "Specifies base64 encoded TLS certificate for the HTTPs server")
+	fs.String(HTTPSClientCAFileKey, "", fmt.Sprintf("PEM file of the CAs that issue the client certificates accepted by the HTTPs server. Clients that present a certificate are authorized with the roles of its subject in %s instead of an auth token. Requires %s. Ignored if %s is specified", APIAuthCertRolesFileKey, APIAuthRequiredKey, HTTPSClientCAContentKey))
+	fs.String(HTTPSClientCAContentKey, "", "Specifies base64 encoded PEM CAs that issue the client certificates accepted by the HTTPs server")
 	fs.String(HTTPAllowedOrigins, "*", "Origins to allow on the HTTP port. Defaults to * which allows all origins. Example: https://*.coinflect.com")
+	fs.Int(HTTPMaxBatchSizeKey, 100, "Maximum number of requests in a JSON-RPC batch. If 0, JSON-RPC batches are rejected")
 	fs.Duration(HTTPShutdownWaitKey, 0, "Duration to wait after receiving SIGTERM or SIGINT before initiating shutdown. The /health endpoint will return unhealthy during this duration")
 	fs.Duration(HTTPShutdownTimeoutKey, 10*time.Second, "Maximum duration to wait for existing connections to complete during node shutdown")
+	fs.String(HTTPUnixSocketPathKey, "", "Path of a Unix domain socket that the HTTP server also listens on, so that local processes can call the APIs without opening a port. If empty, the socket isn't created")
+	fs.Uint(HTTPUnixSocketPermsKey, 0o600, "Permissions of the HTTP server's Unix domain socket, in octal. Clients need write permission to connect")
+	fs.Bool(HTTPUnixSocketAuthRequiredKey, false, fmt.Sprintf("Require authorization tokens on the HTTP server's Unix domain socket. Ignored if %s is false", APIAuthRequiredKey))
+	fs.String(HTTPListenersFileKey, "", fmt.Sprintf("Path to a JSON file that specifies a list of additional listeners of the HTTP server, each with a network (tcp or unix), an address, the permissions of the socket file of unix listeners as an octal string (e.g. \"0600\") and whether authorization tokens are required. TCP listeners are served with TLS if %s is set and must listen on a loopback address unless they require authorization tokens. Ignored if %s is specified", HTTPSEnabledKey, HTTPListenersContentKey))
+	fs.String(HTTPListenersContentKey, "", "Specifies base64 encoded additional listeners of the HTTP server")
 	fs.Bool(APIAuthRequiredKey, false, "Require authorization token to call HTTP APIs")
 	fs.String(APIAuthPasswordFileKey, "",
 		fmt.Sprintf("Password file used to initially create/validate API authorization tokens. Ignored if %s is specified. Leading and trailing whitespace is removed from the password. Can be changed via API call",
 			APIAuthPasswordKey))
 	fs.String(APIAuthPasswordKey, "", "Specifies password for API authorization tokens")
+	fs.String(APIAuthCertRolesFileKey, "", fmt.Sprintf(`Path to a JSON file that maps the subjects of client certificates to the roles they are authorized with, e.g. {"CN=automation,O=Coinflect": ["read-only"]}. Ignored if %s is specified`, APIAuthCertRolesContentKey))
+	fs.String(APIAuthCertRolesContentKey, "", "Specifies base64 encoded roles of the subjects of client certificates")
+	fs.Float64(APIRateLimitRateKey, 0, "Number of API calls per second that each client may make to each endpoint. Clients are identified by their auth token if authorization is required, by their verified client certificate if they presented one, or by their IP otherwise. If 0, API calls aren't rate limited")
+	fs.Int(APIRateLimitBurstKey, 100, "Number of API calls that each client may make at once to each endpoint")
+	fs.String(APIRateLimitFileKey, "", fmt.Sprintf("Path to a JSON file that specifies rate limits for specific endpoints and JSON-RPC methods. Ignored if %s is specified", APIRateLimitContentKey))
+	fs.String(APIRateLimitContentKey, "", "Specifies base64 encoded rate limits for specific endpoints and JSON-RPC methods")
 
 	// Enable/Disable APIs
 	fs.Bool(AdminAPIEnabledKey, false, "If true, this node exposes the Admin API")
@@ -232,6 +253,7 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Bool(KeystoreAPIEnabledKey, true, "If true, this node exposes the Keystore API")
 	fs.Bool(MetricsAPIEnabledKey, true, "If true, this node exposes the Metrics API")
 	fs.Bool(HealthAPIEnabledKey, true, "If true, this node exposes the Health API")
+	fs.Bool(EventsAPIEnabledKey, false, "If true, this node exposes the Events API, which publishes accepted containers, transaction status changes and validator set changes over WebSocket")
 	fs.Bool(IpcAPIEnabledKey, false, "If true, IPCs can be opened")
 
 	// Health Checks
@@ -321,9 +343,14 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.String(IpcsChainIDsKey, "", "Comma separated list of chain ids to add to the IPC engine. Example: 11111111111111111111111111111111LpoYY,4R5p2RXDGLqaifZE4hHWH9owe34pfoBULn1DrQTWivjg8o4aH")
 	fs.String(IpcsPathKey, "", "The directory (Unix) or named pipe name prefix (Windows) for IPC sockets")
 
+	// Acceptor sinks
+	fs.String(AcceptorSinksFileKey, "", fmt.Sprintf("Path to a JSON file that specifies the sinks that accepted containers are delivered to. Ignored if %s is specified", AcceptorSinksContentKey))
+	fs.String(AcceptorSinksContentKey, "", "Specifies base64 encoded sinks that accepted containers are delivered to")
+
 	// Indexer
 	fs.Bool(IndexEnabledKey, false, "If true, index all accepted containers and transactions and expose them via an API")
 	fs.Bool(IndexAllowIncompleteKey, false, "If true, allow running the node in such a way that could cause an index to miss transactions. Ignored if index is disabled")
+	fs.Bool(IndexBackfillEnabledKey, false, "If true, add the containers missing from an incomplete index from the history of its chain. Ignored if index is disabled")
 
 	// Config Directories
 	fs.String(ChainConfigDirKey, defaultChainConfigDir, fmt.Sprintf("Chain specific configurations parent directory. Ignored if %s is specified", ChainConfigContentKey))
@@ -38,6 +38,10 @@ const (
 	DBPathKey                                          = "db-dir"
 	DBConfigFileKey                                    = "db-config-file"
 	DBConfigContentKey                                 = "db-config-file-content"
+	DBCacheSizeKey                                     = "db-cache-size"
+	DBSubnetChainQuotaKey                              = "db-subnet-chain-quota"
+	DBBackupDirKey                                     = "db-backup-dir"
+	DBMasterKeyFileKey                                 = "db-master-key-file"
 	PublicIPKey                                        = "public-ip"
 	PublicIPResolutionFreqKey                          = "public-ip-resolution-frequency"
 	PublicIPResolutionServiceKey                       = "public-ip-resolution-service"
@@ -47,17 +51,32 @@ const (
 	OutboundConnectionTimeoutKey                       = "outbound-connection-timeout"
 	HTTPHostKey                                        = "http-host"
 	HTTPPortKey                                        = "http-port"
+	HTTPGRPCPortKey                                    = "http-grpc-port"
 	HTTPSEnabledKey                                    = "http-tls-enabled"
 	HTTPSKeyFileKey                                    = "http-tls-key-file"
 	HTTPSKeyContentKey                                 = "http-tls-key-file-content"
 	HTTPSCertFileKey                                   = "http-tls-cert-file"
 	HTTPSCertContentKey                                = "http-tls-cert-file-content"
+	HTTPSClientCAFileKey                               = "http-tls-client-ca-file"
+	HTTPSClientCAContentKey                            = "http-tls-client-ca-file-content"
 	HTTPAllowedOrigins                                 = "http-allowed-origins"
+	HTTPMaxBatchSizeKey                                = "http-max-batch-size"
 	HTTPShutdownTimeoutKey                             = "http-shutdown-timeout"
 	HTTPShutdownWaitKey                                = "http-shutdown-wait"
+	HTTPUnixSocketPathKey                              = "http-unix-socket-path"
+	HTTPUnixSocketPermsKey                             = "http-unix-socket-perms"
+	HTTPUnixSocketAuthRequiredKey                      = "http-unix-socket-auth-required"
+	HTTPListenersFileKey                               = "http-listeners-file"
+	HTTPListenersContentKey                            = "http-listeners-file-content"
 	APIAuthRequiredKey                                 = "api-auth-required"
 	APIAuthPasswordKey                                 = "api-auth-password"
 	APIAuthPasswordFileKey                             = "api-auth-password-file"
+	APIAuthCertRolesFileKey                            = "api-auth-cert-roles-file"
+	APIAuthCertRolesContentKey                         = "api-auth-cert-roles-file-content"
+	APIRateLimitRateKey                                = "api-rate-limit-rate"
+	APIRateLimitBurstKey                               = "api-rate-limit-burst"
+	APIRateLimitFileKey                                = "api-rate-limit-file"
+	APIRateLimitContentKey                             = "api-rate-limit-file-content"
 	StateSyncIPsKey                                    = "state-sync-ips"
 	StateSyncIDsKey                                    = "state-sync-ids"
 	BootstrapIPsKey                                    = "bootstrap-ips"
@@ -133,9 +152,12 @@ const (
 	KeystoreAPIEnabledKey                              = "api-keystore-enabled"
 	MetricsAPIEnabledKey                               = "api-metrics-enabled"
 	HealthAPIEnabledKey                                = "api-health-enabled"
+	EventsAPIEnabledKey                                = "api-events-enabled"
 	IpcAPIEnabledKey                                   = "api-ipcs-enabled"
 	IpcsChainIDsKey                                    = "ipcs-chain-ids"
 	IpcsPathKey                                        = "ipcs-path"
+	AcceptorSinksFileKey                               = "acceptor-sinks-file"
+	AcceptorSinksContentKey                            = "acceptor-sinks-file-content"
 	MeterVMsEnabledKey                                 = "meter-vms-enabled"
 	ConsensusGossipFrequencyKey                        = "consensus-gossip-frequency"
 	ConsensusGossipAcceptedFrontierValidatorSizeKey    = "consensus-accepted-frontier-gossip-validator-size"
@@ -152,6 +174,7 @@ const (
 	FdLimitKey                                         = "fd-limit"
 	IndexEnabledKey                                    = "index-enabled"
 	IndexAllowIncompleteKey                            = "index-allow-incomplete"
+	IndexBackfillEnabledKey                            = "index-backfill-enabled"
 	RouterHealthMaxDropRateKey                         = "router-health-max-drop-rate"
 	RouterHealthMaxOutstandingRequestsKey              = "router-health-max-outstanding-requests"
 	HealthCheckFreqKey                                 = "health-check-frequency"
@@ -0,0 +1,442 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package backup implements a compressed and checksummed archive of the
+// contents of a database.
+//
+// An archive is a gzip stream containing:
+//
+//	magic | header length | header | records... | end marker | count | checksum
+//
+// Every record is a key/value pair encoded as a record marker followed by the
+// uvarint length prefixed key and value. The checksum is the sha256 hash of
+// every uncompressed byte that precedes it.
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"time"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/utils/perms"
+	"github.com/coinflect/coinflectchain/utils/units"
+)
+
+const (
+	// FormatVersion is the version of the archive format written by Write.
+	FormatVersion = 1
+
+	// DefaultBatchSize is the number of bytes written to the database in each
+	// batch when restoring an archive.
+	DefaultBatchSize = 4 * units.MiB
+
+	// maxHeaderLen bounds the size of the header that is read from an
+	// untrusted archive.
+	maxHeaderLen = 64 * units.KiB
+	// maxEntryLen bounds the size of keys and values that are read from an
+	// untrusted archive.
+	maxEntryLen = 256 * units.MiB
+
+	tmpFileSuffix = ".tmp"
+
+	// progressInterval is the number of keys written between progress reports
+	// and cancellation checks.
+	progressInterval = 1024
+
+	recordMarker byte = 1
+	endMarker    byte = 0
+)
+
+var (
+	magic = []byte("CFLTDBBK")
+
+	errInvalidMagic        = errors.New("not a database backup")
+	errUnsupportedVersion  = errors.New("unsupported backup format version")
+	errHeaderTooLarge      = errors.New("backup header is too large")
+	errEntryTooLarge       = errors.New("backup entry is too large")
+	errUnexpectedMarker    = errors.New("unexpected marker")
+	errCountMismatch       = errors.New("backup key count mismatch")
+	errChecksumMismatch    = errors.New("backup checksum mismatch")
+	errTrailingData        = errors.New("unexpected data after backup trailer")
+	errNetworkIDMismatch   = errors.New("backup network ID mismatch")
+	errGenesisIDMismatch   = errors.New("backup genesis ID mismatch")
+	errDestinationNotEmpty = errors.New("destination database is not empty")
+	errMissingDBVersion    = errors.New("backup is missing the database version")
+	errFileExists          = errors.New("backup file already exists")
+)
+
+// Header describes the database that an archive was created from.
+type Header struct {
+	// FormatVersion is the version of the archive format.
+	FormatVersion uint32 `json:"formatVersion"`
+	// NetworkID is the ID of the network the database belongs to.
+	NetworkID uint32 `json:"networkID"`
+	// GenesisID is the hash of the genesis bytes of the network.
+	GenesisID ids.ID `json:"genesisID"`
+	// DatabaseVersion is the version of the database that was backed up.
+	DatabaseVersion string `json:"databaseVersion"`
+	// Timestamp is the time the backup was started at.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Verify returns an error if the archive wasn't created for the network
+// identified by [networkID] and [genesisID].
+func (h *Header) Verify(networkID uint32, genesisID ids.ID) error {
+	if h.NetworkID != networkID {
+		return fmt.Errorf("%w: expected %d but archive has %d", errNetworkIDMismatch, networkID, h.NetworkID)
+	}
+	if h.GenesisID != genesisID {
+		return fmt.Errorf("%w: expected %s but archive has %s", errGenesisIDMismatch, genesisID, h.GenesisID)
+	}
+	if h.DatabaseVersion == "" {
+		return errMissingDBVersion
+	}
+	return nil
+}
+
+// Result summarizes the contents of an archive.
+type Result struct {
+	// NumKeys is the number of key/value pairs in the archive.
+	NumKeys uint64 `json:"numKeys"`
+	// Checksum is the checksum that was written to the archive.
+	Checksum ids.ID `json:"checksum"`
+}
+
+// Write writes an archive of every key in [db] to [w]. [db] should be a
+// snapshot so that the archive is consistent if the database is being written
+// to concurrently. If [onProgress] isn't nil, it is periodically called with
+// the number of keys written so far. Write returns early if [ctx] is done.
+func Write(
+	ctx context.Context,
+	w io.Writer,
+	header Header,
+	db database.Iteratee,
+	onProgress func(numKeys uint64),
+) (Result, error) {
+	header.FormatVersion = FormatVersion
+	headerBytes, err := json.Marshal(header)
+	if err != nil {
+		return Result{}, err
+	}
+
+	zw := gzip.NewWriter(w)
+	bw := bufio.NewWriter(zw)
+	h := sha256.New()
+	out := io.MultiWriter(bw, h)
+
+	var buf [binary.MaxVarintLen64]byte
+	writeUvarint := func(v uint64) error {
+		n := binary.PutUvarint(buf[:], v)
+		_, err := out.Write(buf[:n])
+		return err
+	}
+	writeBytes := func(b []byte) error {
+		if err := writeUvarint(uint64(len(b))); err != nil {
+			return err
+		}
+		_, err := out.Write(b)
+		return err
+	}
+
+	if _, err := out.Write(magic); err != nil {
+		return Result{}, err
+	}
+	if err := writeBytes(headerBytes); err != nil {
+		return Result{}, err
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	var numKeys uint64
+	for it.Next() {
+		if _, err := out.Write([]byte{recordMarker}); err != nil {
+			return Result{}, err
+		}
+		if err := writeBytes(it.Key()); err != nil {
+			return Result{}, err
+		}
+		if err := writeBytes(it.Value()); err != nil {
+			return Result{}, err
+		}
+		numKeys++
+
+		if numKeys%progressInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return Result{}, err
+			}
+			if onProgress != nil {
+				onProgress(numKeys)
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return Result{}, err
+	}
+
+	if _, err := out.Write([]byte{endMarker}); err != nil {
+		return Result{}, err
+	}
+	if err := writeUvarint(numKeys); err != nil {
+		return Result{}, err
+	}
+
+	if onProgress != nil {
+		onProgress(numKeys)
+	}
+
+	result := Result{NumKeys: numKeys}
+	copy(result.Checksum[:], h.Sum(nil))
+	if _, err := bw.Write(result.Checksum[:]); err != nil {
+		return Result{}, err
+	}
+	if err := bw.Flush(); err != nil {
+		return Result{}, err
+	}
+	return result, zw.Close()
+}
+
+// hashingReader hashes every byte that is read from it.
+type hashingReader struct {
+	r *bufio.Reader
+	h hash.Hash
+}
+
+func (r *hashingReader) ReadByte() (byte, error) {
+	b, err := r.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	_, _ = r.h.Write([]byte{b})
+	return b, nil
+}
+
+func (r *hashingReader) readFull(b []byte) error {
+	if _, err := io.ReadFull(r.r, b); err != nil {
+		return err
+	}
+	_, _ = r.h.Write(b)
+	return nil
+}
+
+// Reader reads an archive written by Write.
+type Reader struct {
+	zr     *gzip.Reader
+	r      *hashingReader
+	header Header
+}
+
+// NewReader reads and parses the header of the archive in [r]. The records of
+// the archive are only read by Restore, so the header can be validated before
+// anything is written.
+func NewReader(r io.Reader) (*Reader, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidMagic, err)
+	}
+	reader := &Reader{
+		zr: zr,
+		r: &hashingReader{
+			r: bufio.NewReader(zr),
+			h: sha256.New(),
+		},
+	}
+
+	gotMagic := make([]byte, len(magic))
+	if err := reader.readFull(gotMagic); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(gotMagic, magic) {
+		return nil, errInvalidMagic
+	}
+
+	headerBytes, err := reader.readBytes(maxHeaderLen, errHeaderTooLarge)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(headerBytes, &reader.header); err != nil {
+		return nil, fmt.Errorf("failed to parse backup header: %w", err)
+	}
+	if reader.header.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("%w: %d", errUnsupportedVersion, reader.header.FormatVersion)
+	}
+	return reader, nil
+}
+
+// Header returns the header of the archive.
+func (r *Reader) Header() Header {
+	return r.header
+}
+
+// Restore writes every record of the archive into [db], which must be empty,
+// and verifies the checksum of the archive. If an error is returned, [db] may
+// contain a partial copy of the archive and should be discarded.
+func (r *Reader) Restore(db database.Database, batchSize int) (Result, error) {
+	isEmpty, err := database.IsEmpty(db)
+	if err != nil {
+		return Result{}, err
+	}
+	if !isEmpty {
+		return Result{}, errDestinationNotEmpty
+	}
+
+	var (
+		batch   = db.NewBatch()
+		numKeys uint64
+	)
+	for {
+		marker, err := r.readByte()
+		if err != nil {
+			return Result{}, err
+		}
+		if marker == endMarker {
+			break
+		}
+		if marker != recordMarker {
+			return Result{}, fmt.Errorf("%w: %d", errUnexpectedMarker, marker)
+		}
+
+		key, err := r.readBytes(maxEntryLen, errEntryTooLarge)
+		if err != nil {
+			return Result{}, err
+		}
+		value, err := r.readBytes(maxEntryLen, errEntryTooLarge)
+		if err != nil {
+			return Result{}, err
+		}
+		if err := batch.Put(key, value); err != nil {
+			return Result{}, err
+		}
+		numKeys++
+
+		if batch.Size() < batchSize {
+			continue
+		}
+		if err := batch.Write(); err != nil {
+			return Result{}, err
+		}
+		batch.Reset()
+	}
+	if err := batch.Write(); err != nil {
+		return Result{}, err
+	}
+
+	expectedNumKeys, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return Result{}, wrapEOF(err)
+	}
+	if expectedNumKeys != numKeys {
+		return Result{}, fmt.Errorf("%w: trailer has %d but read %d", errCountMismatch, expectedNumKeys, numKeys)
+	}
+
+	result := Result{NumKeys: numKeys}
+	copy(result.Checksum[:], r.r.h.Sum(nil))
+
+	var expectedChecksum ids.ID
+	if _, err := io.ReadFull(r.r.r, expectedChecksum[:]); err != nil {
+		return Result{}, wrapEOF(err)
+	}
+	if expectedChecksum != result.Checksum {
+		return Result{}, fmt.Errorf("%w: trailer has %s but computed %s", errChecksumMismatch, expectedChecksum, result.Checksum)
+	}
+	if _, err := r.r.r.ReadByte(); err != io.EOF {
+		if err == nil {
+			err = errTrailingData
+		}
+		return Result{}, err
+	}
+	return result, nil
+}
+
+// Close releases the resources held by the reader. It doesn't close the
+// underlying reader.
+func (r *Reader) Close() error {
+	return r.zr.Close()
+}
+
+func (r *Reader) readByte() (byte, error) {
+	b, err := r.r.ReadByte()
+	return b, wrapEOF(err)
+}
+
+func (r *Reader) readFull(b []byte) error {
+	return wrapEOF(r.r.readFull(b))
+}
+
+func (r *Reader) readBytes(maxLen uint64, errTooLarge error) ([]byte, error) {
+	length, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return nil, wrapEOF(err)
+	}
+	if length > maxLen {
+		return nil, fmt.Errorf("%w: %d > %d", errTooLarge, length, maxLen)
+	}
+	b := make([]byte, length)
+	return b, r.readFull(b)
+}
+
+// wrapEOF reports an archive that ends before its trailer as truncated.
+func wrapEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// CheckPath returns an error if an archive can't be written to [path] by
+// WriteFile because a file already exists there.
+func CheckPath(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%w: %s", errFileExists, path)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// WriteFile writes an archive of every key in [db] to a new file at [path].
+// The archive is written to a temporary file that is only moved to [path]
+// once it is complete, so [path] never contains a partial archive. See Write
+// for [ctx] and [onProgress].
+func WriteFile(
+	ctx context.Context,
+	path string,
+	header Header,
+	db database.Iteratee,
+	onProgress func(numKeys uint64),
+) (Result, error) {
+	if err := CheckPath(path); err != nil {
+		return Result{}, err
+	}
+
+	tmpPath := path + tmpFileSuffix
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perms.ReadWrite)
+	if err != nil {
+		return Result{}, err
+	}
+	result, err := Write(ctx, f, header, db, onProgress)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return Result{}, err
+	}
+	return result, os.Rename(tmpPath, path)
+}
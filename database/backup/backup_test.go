@@ -0,0 +1,262 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/memdb"
+	"github.com/coinflect/coinflectchain/ids"
+)
+
+var testHeader = Header{
+	NetworkID:       12345,
+	GenesisID:       ids.ID{1, 2, 3},
+	DatabaseVersion: "v1.0.0",
+	Timestamp:       time.Unix(1000, 0).UTC(),
+}
+
+func populate(t *testing.T, db database.KeyValueWriter, numKeys int) {
+	for i := 0; i < numKeys; i++ {
+		key := []byte(fmt.Sprintf("key-%05d", i))
+		value := bytes.Repeat([]byte{byte(i)}, i%64)
+		require.NoError(t, db.Put(key, value))
+	}
+}
+
+func writeArchive(t *testing.T, db database.Database) ([]byte, Result) {
+	snapshot, err := database.NewSnapshot(db)
+	require.NoError(t, err)
+	defer snapshot.Release()
+
+	buf := &bytes.Buffer{}
+	result, err := Write(context.Background(), buf, testHeader, snapshot, nil)
+	require.NoError(t, err)
+	return buf.Bytes(), result
+}
+
+func TestWriteRestore(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	populate(t, src, 1000)
+	archive, written := writeArchive(t, src)
+	require.Equal(uint64(1000), written.NumKeys)
+
+	r, err := NewReader(bytes.NewReader(archive))
+	require.NoError(err)
+	defer r.Close()
+
+	header := r.Header()
+	require.Equal(uint32(FormatVersion), header.FormatVersion)
+	require.NoError(header.Verify(testHeader.NetworkID, testHeader.GenesisID))
+	require.Equal(testHeader.DatabaseVersion, header.DatabaseVersion)
+	require.True(testHeader.Timestamp.Equal(header.Timestamp))
+
+	dst := memdb.New()
+	restored, err := r.Restore(dst, 128)
+	require.NoError(err)
+	require.Equal(written, restored)
+
+	it := src.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		value, err := dst.Get(it.Key())
+		require.NoError(err)
+		require.Equal(it.Value(), value)
+	}
+	require.NoError(it.Error())
+
+	count, err := database.Count(dst)
+	require.NoError(err)
+	require.Equal(1000, count)
+}
+
+func TestWriteIsConsistent(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	populate(t, src, 100)
+
+	snapshot, err := database.NewSnapshot(src)
+	require.NoError(err)
+	defer snapshot.Release()
+
+	// Writes made after the snapshot must not be included in the archive.
+	populate(t, src, 200)
+
+	buf := &bytes.Buffer{}
+	result, err := Write(context.Background(), buf, testHeader, snapshot, nil)
+	require.NoError(err)
+	require.Equal(uint64(100), result.NumKeys)
+}
+
+func TestVerifyHeader(t *testing.T) {
+	require := require.New(t)
+
+	header := testHeader
+	require.NoError(header.Verify(testHeader.NetworkID, testHeader.GenesisID))
+	require.ErrorIs(header.Verify(testHeader.NetworkID+1, testHeader.GenesisID), errNetworkIDMismatch)
+	require.ErrorIs(header.Verify(testHeader.NetworkID, ids.GenerateTestID()), errGenesisIDMismatch)
+
+	header.DatabaseVersion = ""
+	require.ErrorIs(header.Verify(testHeader.NetworkID, testHeader.GenesisID), errMissingDBVersion)
+}
+
+func TestRestoreNonEmptyDestination(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	populate(t, src, 10)
+	archive, _ := writeArchive(t, src)
+
+	r, err := NewReader(bytes.NewReader(archive))
+	require.NoError(err)
+	defer r.Close()
+
+	dst := memdb.New()
+	require.NoError(dst.Put([]byte("key"), nil))
+	_, err = r.Restore(dst, DefaultBatchSize)
+	require.ErrorIs(err, errDestinationNotEmpty)
+}
+
+func TestRestoreDetectsCorruption(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	populate(t, src, 100)
+	archive, _ := writeArchive(t, src)
+
+	// Flip a byte of a value inside the uncompressed stream and re-compress
+	// it, so that only the checksum can detect the modification.
+	zr, err := gzip.NewReader(bytes.NewReader(archive))
+	require.NoError(err)
+	raw, err := io.ReadAll(zr)
+	require.NoError(err)
+	raw[len(raw)/2] ^= 0xff
+
+	corrupted := &bytes.Buffer{}
+	zw := gzip.NewWriter(corrupted)
+	_, err = zw.Write(raw)
+	require.NoError(err)
+	require.NoError(zw.Close())
+
+	r, err := NewReader(corrupted)
+	require.NoError(err)
+	defer r.Close()
+
+	_, err = r.Restore(memdb.New(), DefaultBatchSize)
+	require.Error(err)
+}
+
+func TestRestoreTruncated(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	populate(t, src, 100)
+	archive, _ := writeArchive(t, src)
+
+	zr, err := gzip.NewReader(bytes.NewReader(archive))
+	require.NoError(err)
+	raw, err := io.ReadAll(zr)
+	require.NoError(err)
+
+	truncated := &bytes.Buffer{}
+	zw := gzip.NewWriter(truncated)
+	_, err = zw.Write(raw[:len(raw)-10])
+	require.NoError(err)
+	require.NoError(zw.Close())
+
+	r, err := NewReader(truncated)
+	require.NoError(err)
+	defer r.Close()
+
+	_, err = r.Restore(memdb.New(), DefaultBatchSize)
+	require.ErrorIs(err, io.ErrUnexpectedEOF)
+}
+
+func TestNewReaderInvalidArchive(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewReader(bytes.NewReader([]byte("not an archive")))
+	require.ErrorIs(err, errInvalidMagic)
+
+	buf := &bytes.Buffer{}
+	zw := gzip.NewWriter(buf)
+	_, err = zw.Write([]byte("NOTMAGIC and some more bytes"))
+	require.NoError(err)
+	require.NoError(zw.Close())
+
+	_, err = NewReader(buf)
+	require.ErrorIs(err, errInvalidMagic)
+}
+
+func TestWriteFile(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	populate(t, src, 100)
+
+	path := filepath.Join(t.TempDir(), "backup.gz")
+	written, err := WriteFile(context.Background(), path, testHeader, src, nil)
+	require.NoError(err)
+
+	_, err = os.Stat(path + tmpFileSuffix)
+	require.ErrorIs(err, os.ErrNotExist)
+
+	// Existing archives must not be overwritten.
+	_, err = WriteFile(context.Background(), path, testHeader, src, nil)
+	require.ErrorIs(err, errFileExists)
+
+	f, err := os.Open(path)
+	require.NoError(err)
+	defer f.Close()
+
+	r, err := NewReader(f)
+	require.NoError(err)
+	defer r.Close()
+
+	restored, err := r.Restore(memdb.New(), DefaultBatchSize)
+	require.NoError(err)
+	require.Equal(written, restored)
+}
+
+func TestWriteFileProgressAndCancel(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	populate(t, src, 3*progressInterval)
+
+	var reported []uint64
+	onProgress := func(numKeys uint64) {
+		reported = append(reported, numKeys)
+	}
+	path := filepath.Join(t.TempDir(), "backup.gz")
+	_, err := WriteFile(context.Background(), path, testHeader, src, onProgress)
+	require.NoError(err)
+	require.Equal([]uint64{progressInterval, 2 * progressInterval, 3 * progressInterval, 3 * progressInterval}, reported)
+
+	// A cancelled backup leaves no archive behind.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	path = filepath.Join(t.TempDir(), "backup.gz")
+	_, err = WriteFile(ctx, path, testHeader, src, nil)
+	require.ErrorIs(err, context.Canceled)
+	_, err = os.Stat(path)
+	require.ErrorIs(err, os.ErrNotExist)
+	_, err = os.Stat(path + tmpFileSuffix)
+	require.ErrorIs(err, os.ErrNotExist)
+}
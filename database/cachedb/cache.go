@@ -0,0 +1,188 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cachedb
+
+import (
+	"container/list"
+	"encoding/binary"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/coinflect/coinflectchain/utils/wrappers"
+)
+
+// entryOverhead is an approximation of the number of bytes used to track an
+// entry in the cache, in addition to its key and value.
+const entryOverhead = 128
+
+// Cache is a least recently used cache of database values that is bounded by
+// the number of bytes it holds. A single Cache can be shared by many
+// databases, so that the memory used for caching is bounded for all of them.
+type Cache struct {
+	lock sync.Mutex
+
+	maxSize   int
+	size      int
+	entryMap  map[string]*list.Element
+	entryList *list.List
+	nextID    uint64
+
+	sizeMetric      prometheus.Gauge
+	numEntries      prometheus.Gauge
+	evictionsMetric prometheus.Counter
+}
+
+type entry struct {
+	key   string
+	value []byte
+	// If exists is false, the key is known to not be in the database.
+	exists bool
+}
+
+func (e *entry) size() int {
+	return len(e.key) + len(e.value) + entryOverhead
+}
+
+// namespace separates the entries of the databases that share a cache.
+type namespace struct {
+	// id is prefixed to every key of the namespace. Assigning a new id drops
+	// every entry of the namespace.
+	id uint64
+	// writes is incremented every time that an entry of the namespace is
+	// invalidated. A value read from the database is only cached if no entry
+	// was invalidated while it was being read.
+	writes uint64
+}
+
+// NewCache returns a cache that holds at most [maxSize] bytes.
+func NewCache(maxSize int, metricsNamespace string, registerer prometheus.Registerer) (*Cache, error) {
+	c := &Cache{
+		maxSize:   maxSize,
+		entryMap:  make(map[string]*list.Element),
+		entryList: list.New(),
+		sizeMetric: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "size",
+			Help:      "bytes held by the cache",
+		}),
+		numEntries: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "entries",
+			Help:      "# of entries in the cache",
+		}),
+		evictionsMetric: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "evictions",
+			Help:      "# of entries evicted to stay within the size of the cache",
+		}),
+	}
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(c.sizeMetric),
+		registerer.Register(c.numEntries),
+		registerer.Register(c.evictionsMetric),
+	)
+	return c, errs.Err
+}
+
+func (c *Cache) newNamespace() *namespace {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	ns := &namespace{id: c.nextID}
+	c.nextID++
+	return ns
+}
+
+// get returns the cached value of [key] in [ns] and if the key is in the
+// database. If the key isn't cached, the current number of invalidations of
+// [ns] is returned so that the value can be cached by [put] later.
+func (c *Cache) get(ns *namespace, key []byte) ([]byte, bool, bool, uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	elem, ok := c.entryMap[cacheKey(ns, key)]
+	if !ok {
+		return nil, false, false, ns.writes
+	}
+	c.entryList.MoveToBack(elem)
+	e := elem.Value.(*entry)
+	return e.value, e.exists, true, 0
+}
+
+// put caches [value] for [key] in [ns], unless an entry of [ns] was
+// invalidated since [writes] was returned by [get].
+func (c *Cache) put(ns *namespace, writes uint64, key, value []byte, exists bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if ns.writes != writes {
+		return
+	}
+
+	e := &entry{
+		key:    cacheKey(ns, key),
+		value:  value,
+		exists: exists,
+	}
+	size := e.size()
+	if size > c.maxSize {
+		return
+	}
+	if elem, ok := c.entryMap[e.key]; ok {
+		c.remove(elem)
+	}
+	for c.size+size > c.maxSize {
+		c.remove(c.entryList.Front())
+		c.evictionsMetric.Inc()
+	}
+	c.entryMap[e.key] = c.entryList.PushBack(e)
+	c.size += size
+	c.updateMetrics()
+}
+
+// evict drops the cached values of [keys] in [ns].
+func (c *Cache) evict(ns *namespace, keys ...[]byte) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	ns.writes++
+	for _, key := range keys {
+		if elem, ok := c.entryMap[cacheKey(ns, key)]; ok {
+			c.remove(elem)
+		}
+	}
+	c.updateMetrics()
+}
+
+// evictAll drops every cached value in [ns]. The entries are left in the
+// cache until they are evicted to make room for newer entries.
+func (c *Cache) evictAll(ns *namespace) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	ns.writes++
+	ns.id = c.nextID
+	c.nextID++
+}
+
+func (c *Cache) remove(elem *list.Element) {
+	e := c.entryList.Remove(elem).(*entry)
+	delete(c.entryMap, e.key)
+	c.size -= e.size()
+}
+
+func (c *Cache) updateMetrics() {
+	c.sizeMetric.Set(float64(c.size))
+	c.numEntries.Set(float64(len(c.entryMap)))
+}
+
+func cacheKey(ns *namespace, key []byte) string {
+	b := make([]byte, wrappers.LongLen+len(key))
+	binary.BigEndian.PutUint64(b, ns.id)
+	copy(b[wrappers.LongLen:], key)
+	return string(b)
+}
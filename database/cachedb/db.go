@@ -0,0 +1,224 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package cachedb implements a database that caches the values read from an
+// underlying database in a cache that may be shared with other databases.
+package cachedb
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/utils"
+	"github.com/coinflect/coinflectchain/utils/wrappers"
+)
+
+var (
+	_ database.Database     = (*Database)(nil)
+	_ database.Snapshotter  = (*Database)(nil)
+	_ database.RangeDeleter = (*Database)(nil)
+	_ database.Batch        = (*batch)(nil)
+	_ database.RangeDeleter = (*batch)(nil)
+)
+
+// Database caches the values and the absence of keys that are read from the
+// underlying database. Writes are passed through to the underlying database
+// and invalidate the cached values of the written keys.
+//
+// Writes to the underlying database that aren't made through this Database
+// aren't noticed, so the underlying database must only be written to through
+// this Database. Multiple Databases may share a Cache as long as they don't
+// wrap overlapping databases.
+type Database struct {
+	db    database.Database
+	cache *Cache
+	ns    *namespace
+
+	hit, miss prometheus.Counter
+}
+
+// New returns a new database that caches its values in [cache]. The hits and
+// misses of the cache are reported under [metricsNamespace].
+func New(
+	metricsNamespace string,
+	registerer prometheus.Registerer,
+	cache *Cache,
+	db database.Database,
+) (*Database, error) {
+	cacheDB := &Database{
+		db:    db,
+		cache: cache,
+		ns:    cache.newNamespace(),
+		hit: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "hit",
+			Help:      "# of reads that were served from the cache",
+		}),
+		miss: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "miss",
+			Help:      "# of reads that were served from the underlying database",
+		}),
+	}
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(cacheDB.hit),
+		registerer.Register(cacheDB.miss),
+	)
+	return cacheDB, errs.Err
+}
+
+// Has only caches keys that aren't in the database, as the value of a key
+// that is in the database isn't read.
+func (db *Database) Has(key []byte) (bool, error) {
+	_, exists, cached, writes := db.cache.get(db.ns, key)
+	if cached {
+		db.hit.Inc()
+		return exists, nil
+	}
+	db.miss.Inc()
+
+	has, err := db.db.Has(key)
+	if err == nil && !has {
+		db.cache.put(db.ns, writes, key, nil, false)
+	}
+	return has, err
+}
+
+func (db *Database) Get(key []byte) ([]byte, error) {
+	value, exists, cached, writes := db.cache.get(db.ns, key)
+	if cached {
+		db.hit.Inc()
+		if !exists {
+			return nil, database.ErrNotFound
+		}
+		return utils.CopyBytes(value), nil
+	}
+	db.miss.Inc()
+
+	value, err := db.db.Get(key)
+	switch err {
+	case nil:
+		db.cache.put(db.ns, writes, key, utils.CopyBytes(value), true)
+	case database.ErrNotFound:
+		db.cache.put(db.ns, writes, key, nil, false)
+	}
+	return value, err
+}
+
+func (db *Database) Put(key, value []byte) error {
+	err := db.db.Put(key, value)
+	db.cache.evict(db.ns, key)
+	return err
+}
+
+func (db *Database) Delete(key []byte) error {
+	err := db.db.Delete(key)
+	db.cache.evict(db.ns, key)
+	return err
+}
+
+// DeleteRange removes every key in [start, limit) from the underlying database
+// and drops every cached value.
+func (db *Database) DeleteRange(start, limit []byte) error {
+	err := database.DeleteRange(db.db, start, limit)
+	db.cache.evictAll(db.ns)
+	return err
+}
+
+func (db *Database) NewBatch() database.Batch {
+	return &batch{
+		Batch: db.db.NewBatch(),
+		db:    db,
+	}
+}
+
+func (db *Database) NewIterator() database.Iterator {
+	return db.db.NewIterator()
+}
+
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.db.NewIteratorWithStart(start)
+}
+
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.db.NewIteratorWithPrefix(prefix)
+}
+
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return db.db.NewIteratorWithStartAndPrefix(start, prefix)
+}
+
+// NewSnapshot returns a snapshot of the underlying database. Reads from the
+// snapshot aren't cached.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	return database.NewSnapshot(db.db)
+}
+
+func (db *Database) Compact(start, limit []byte) error {
+	return db.db.Compact(start, limit)
+}
+
+// Close closes the underlying database and drops every cached value.
+func (db *Database) Close() error {
+	db.cache.evictAll(db.ns)
+	return db.db.Close()
+}
+
+func (db *Database) HealthCheck(ctx context.Context) (interface{}, error) {
+	return db.db.HealthCheck(ctx)
+}
+
+type batch struct {
+	database.Batch
+
+	db *Database
+	// keys that are written by the batch
+	keys [][]byte
+	// deletesRange is true if the batch deletes a range of keys
+	deletesRange bool
+}
+
+func (b *batch) Put(key, value []byte) error {
+	b.keys = append(b.keys, utils.CopyBytes(key))
+	return b.Batch.Put(key, value)
+}
+
+func (b *batch) Delete(key []byte) error {
+	b.keys = append(b.keys, utils.CopyBytes(key))
+	return b.Batch.Delete(key)
+}
+
+func (b *batch) DeleteRange(start, limit []byte) error {
+	b.deletesRange = true
+	return database.DeleteRange(b.Batch, start, limit)
+}
+
+func (b *batch) Write() error {
+	err := b.Batch.Write()
+	if b.deletesRange {
+		b.db.cache.evictAll(b.db.ns)
+	} else {
+		b.db.cache.evict(b.db.ns, b.keys...)
+	}
+	return err
+}
+
+func (b *batch) Reset() {
+	if cap(b.keys) > len(b.keys)*database.MaxExcessCapacityFactor {
+		b.keys = make([][]byte, 0, cap(b.keys)/database.CapacityReductionFactor)
+	} else {
+		b.keys = b.keys[:0]
+	}
+	b.deletesRange = false
+	b.Batch.Reset()
+}
+
+// Inner returns itself, so that writes made through the inner batch still
+// invalidate the cache.
+func (b *batch) Inner() database.Batch {
+	return b
+}
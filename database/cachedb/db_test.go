@@ -0,0 +1,190 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cachedb
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/memdb"
+	"github.com/coinflect/coinflectchain/utils/units"
+)
+
+func newDB(t testing.TB, cache *Cache, db database.Database) *Database {
+	if cache == nil {
+		var err error
+		cache, err = NewCache(units.MiB, "", prometheus.NewRegistry())
+		require.NoError(t, err)
+	}
+	cacheDB, err := New("", prometheus.NewRegistry(), cache, db)
+	require.NoError(t, err)
+	return cacheDB
+}
+
+func TestInterface(t *testing.T) {
+	for _, test := range database.Tests {
+		test(t, newDB(t, nil, memdb.New()))
+	}
+}
+
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		test(t, newDB(t, nil, memdb.New()))
+	}
+}
+
+func TestDeleteRangeInterface(t *testing.T) {
+	for _, test := range database.DeleteRangeTests {
+		test(t, newDB(t, nil, memdb.New()))
+	}
+}
+
+func FuzzInterface(f *testing.F) {
+	for _, test := range database.FuzzTests {
+		test(f, newDB(f, nil, memdb.New()))
+	}
+}
+
+func BenchmarkInterface(b *testing.B) {
+	for _, size := range database.BenchmarkSizes {
+		keys, values := database.SetupBenchmark(b, size[0], size[1], size[2])
+		for _, bench := range database.Benchmarks {
+			bench(b, newDB(b, nil, memdb.New()), "cachedb", keys, values)
+		}
+	}
+}
+
+func TestCachedReads(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := newDB(t, nil, baseDB)
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+
+	value, err := db.Get([]byte("key"))
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+	require.Equal(float64(1), testutil.ToFloat64(db.miss))
+
+	// Modifying the returned value doesn't modify the cached value.
+	value[0] = 'V'
+
+	// The value is served from the cache, even though the underlying database
+	// was modified without going through [db].
+	require.NoError(baseDB.Put([]byte("key"), []byte("other")))
+	value, err = db.Get([]byte("key"))
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+	require.Equal(float64(1), testutil.ToFloat64(db.hit))
+
+	// Writes invalidate the cached value.
+	require.NoError(db.Put([]byte("key"), []byte("new value")))
+	value, err = db.Get([]byte("key"))
+	require.NoError(err)
+	require.Equal([]byte("new value"), value)
+
+	require.NoError(db.Delete([]byte("key")))
+	_, err = db.Get([]byte("key"))
+	require.Equal(database.ErrNotFound, err)
+}
+
+func TestNegativeCaching(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := newDB(t, nil, baseDB)
+
+	has, err := db.Has([]byte("key"))
+	require.NoError(err)
+	require.False(has)
+
+	// The absence of the key is served from the cache.
+	require.NoError(baseDB.Put([]byte("key"), []byte("value")))
+	has, err = db.Has([]byte("key"))
+	require.NoError(err)
+	require.False(has)
+	_, err = db.Get([]byte("key"))
+	require.Equal(database.ErrNotFound, err)
+	require.Equal(float64(2), testutil.ToFloat64(db.hit))
+
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+	has, err = db.Has([]byte("key"))
+	require.NoError(err)
+	require.True(has)
+}
+
+func TestBatchInvalidation(t *testing.T) {
+	require := require.New(t)
+
+	db := newDB(t, nil, memdb.New())
+	require.NoError(db.Put([]byte("key1"), []byte("value1")))
+	require.NoError(db.Put([]byte("key2"), []byte("value2")))
+	_, err := db.Get([]byte("key1"))
+	require.NoError(err)
+	_, err = db.Get([]byte("key2"))
+	require.NoError(err)
+
+	batch := db.NewBatch()
+	require.NoError(batch.Put([]byte("key1"), []byte("new value1")))
+
+	// Writes replayed into the inner batch invalidate the cache as well.
+	otherBatch := db.NewBatch()
+	require.NoError(otherBatch.Delete([]byte("key2")))
+	require.NoError(otherBatch.Replay(batch.Inner()))
+	require.NoError(batch.Inner().Write())
+
+	value, err := db.Get([]byte("key1"))
+	require.NoError(err)
+	require.Equal([]byte("new value1"), value)
+	_, err = db.Get([]byte("key2"))
+	require.Equal(database.ErrNotFound, err)
+
+	batch.Reset()
+	require.NoError(database.DeleteRange(batch, nil, nil))
+	require.NoError(batch.Write())
+	_, err = db.Get([]byte("key1"))
+	require.Equal(database.ErrNotFound, err)
+}
+
+func TestSharedCache(t *testing.T) {
+	require := require.New(t)
+
+	value := make([]byte, 100)
+	entrySize := len(value) + entryOverhead + 8 + 1
+	cache, err := NewCache(3*entrySize, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	// Both databases use the same keys, which are cached separately.
+	db1 := newDB(t, cache, memdb.New())
+	db2 := newDB(t, cache, memdb.New())
+	require.NoError(db1.Put([]byte{0}, value))
+	require.NoError(db2.Put([]byte{0}, nil))
+
+	_, err = db1.Get([]byte{0})
+	require.NoError(err)
+	value, err = db2.Get([]byte{0})
+	require.NoError(err)
+	require.Empty(value)
+	require.Equal(float64(2), testutil.ToFloat64(cache.numEntries))
+
+	// The budget is shared, so the least recently used entry is evicted.
+	require.NoError(db2.Put([]byte{1}, make([]byte, 100)))
+	require.NoError(db2.Put([]byte{2}, make([]byte, 100)))
+	_, err = db2.Get([]byte{1})
+	require.NoError(err)
+	_, err = db2.Get([]byte{2})
+	require.NoError(err)
+	require.Equal(float64(1), testutil.ToFloat64(cache.evictionsMetric))
+	require.LessOrEqual(testutil.ToFloat64(cache.sizeMetric), float64(3*entrySize))
+
+	_, err = db1.Get([]byte{0})
+	require.NoError(err)
+	require.Equal(float64(2), testutil.ToFloat64(db1.miss))
+}
@@ -13,8 +13,11 @@ import (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
+	_ database.Database     = (*Database)(nil)
+	_ database.Snapshotter  = (*Database)(nil)
+	_ database.RangeDeleter = (*Database)(nil)
+	_ database.Batch        = (*batch)(nil)
+	_ database.RangeDeleter = (*batch)(nil)
 )
 
 // CorruptableDB is a wrapper around Database
@@ -68,6 +71,20 @@ func (db *Database) Delete(key []byte) error {
 	return db.handleError(db.Database.Delete(key))
 }
 
+// DeleteRange removes every key in [start, limit) from the underlying database
+func (db *Database) DeleteRange(start, limit []byte) error {
+	if err := db.corrupted(); err != nil {
+		return err
+	}
+	err := database.DeleteRange(db.Database, start, limit)
+	if err == database.ErrRangeDeletionNotSupported {
+		// The underlying database not supporting range deletion doesn't
+		// indicate corruption.
+		return err
+	}
+	return db.handleError(err)
+}
+
 func (db *Database) Compact(start []byte, limit []byte) error {
 	return db.handleError(db.Database.Compact(start, limit))
 }
@@ -90,6 +107,20 @@ func (db *Database) NewBatch() database.Batch {
 	}
 }
 
+// NewSnapshot returns a snapshot of the underlying database
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	if err := db.corrupted(); err != nil {
+		return nil, err
+	}
+	snapshot, err := database.NewSnapshot(db.Database)
+	if err == database.ErrSnapshotsNotSupported {
+		// The underlying database not supporting snapshots doesn't indicate
+		// corruption.
+		return nil, err
+	}
+	return snapshot, db.handleError(err)
+}
+
 func (db *Database) corrupted() error {
 	db.errorLock.RLock()
 	defer db.errorLock.RUnlock()
@@ -99,9 +130,9 @@ func (db *Database) corrupted() error {
 
 func (db *Database) handleError(err error) error {
 	switch err {
-	case nil, database.ErrNotFound, database.ErrClosed:
-	// If we get an error other than "not found" or "closed", disallow future
-	// database operations to avoid possible corruption
+	case nil, database.ErrNotFound, database.ErrClosed, database.ErrQuotaExceeded:
+	// If we get an error other than "not found", "closed" or "quota exceeded",
+	// disallow future database operations to avoid possible corruption
 	default:
 		db.errorLock.Lock()
 		defer db.errorLock.Unlock()
@@ -121,6 +152,11 @@ type batch struct {
 	db *Database
 }
 
+// DeleteRange deletes every key in [start, limit) during writing
+func (b *batch) DeleteRange(start, limit []byte) error {
+	return database.DeleteRange(b.Batch, start, limit)
+}
+
 // Write flushes any accumulated data to disk.
 func (b *batch) Write() error {
 	if err := b.db.corrupted(); err != nil {
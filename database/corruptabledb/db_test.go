@@ -23,6 +23,22 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		baseDB := memdb.New()
+		db := New(baseDB)
+		test(t, db)
+	}
+}
+
+func TestDeleteRangeInterface(t *testing.T) {
+	for _, test := range database.DeleteRangeTests {
+		baseDB := memdb.New()
+		db := New(baseDB)
+		test(t, db)
+	}
+}
+
 func FuzzInterface(f *testing.F) {
 	for _, test := range database.FuzzTests {
 		baseDB := memdb.New()
@@ -50,6 +66,9 @@ func TestCorruption(t *testing.T) {
 		"corrupted delete": func(db database.Database) error {
 			return db.Delete(key)
 		},
+		"corrupted delete range": func(db database.Database) error {
+			return database.DeleteRange(db, nil, nil)
+		},
 		"corrupted batch": func(db database.Database) error {
 			corruptableBatch := db.NewBatch()
 			require.NotNil(t, corruptableBatch)
@@ -0,0 +1,435 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package crashdb implements a database that simulates losing power, so that
+// tests can verify that the state left in the underlying database after a
+// crash is consistent.
+package crashdb
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/nodb"
+	"github.com/coinflect/coinflectchain/utils"
+)
+
+var (
+	_ database.Database     = (*Database)(nil)
+	_ database.RangeDeleter = (*Database)(nil)
+	_ database.Batch        = (*batch)(nil)
+	_ database.RangeDeleter = (*batch)(nil)
+	_ database.Iterator     = (*iterator)(nil)
+
+	// ErrCrashed is returned by every operation after the database crashed.
+	ErrCrashed = errors.New("crashed")
+)
+
+// Database passes every operation through to the underlying database until
+// it crashes. A crash is triggered after a configured number of writes.
+//
+// Every Put, Delete and DeleteRange is a write, and so is every batch. Like
+// the databases it simulates, a batch is written atomically: a crash never
+// happens while it's being written, and an unsynced batch is lost as a whole.
+// When the database crashes, the most recent writes that hadn't been synced
+// yet are undone in the underlying database, after which the underlying
+// database holds the state that would be found after a restart.
+type Database struct {
+	lock sync.RWMutex
+	db   database.Database
+
+	crashAfter int
+	unsynced   int
+
+	writes  int
+	undo    []undoLog
+	crashed bool
+	closed  bool
+}
+
+// undoLog restores the values that were overwritten by a write when it's
+// applied in reverse order.
+type undoLog []keyValue
+
+type keyValue struct {
+	key    []byte
+	value  []byte
+	delete bool
+
+	// If deleteRange is true, every key in [key, limit) is deleted.
+	deleteRange bool
+	limit       []byte
+}
+
+// New returns a database that crashes after the [crashAfter]th write to [db].
+// If [crashAfter] is 0, the database only crashes when Crash is called. When
+// the database crashes, the last [unsynced] writes are lost.
+func New(db database.Database, crashAfter, unsynced int) *Database {
+	return &Database{
+		db:         db,
+		crashAfter: crashAfter,
+		unsynced:   unsynced,
+	}
+}
+
+// Writes returns the number of writes that were made to the underlying
+// database, including the writes that were lost in a crash.
+func (db *Database) Writes() int {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	return db.writes
+}
+
+// Crashed returns true if the database crashed.
+func (db *Database) Crashed() bool {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	return db.crashed
+}
+
+// Crash simulates losing power immediately.
+func (db *Database) Crash() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.crashed {
+		return nil
+	}
+	return db.crash()
+}
+
+// crash undoes the unsynced writes. Assumes [db.lock] is held.
+func (db *Database) crash() error {
+	db.crashed = true
+	for i := len(db.undo) - 1; i >= 0; i-- {
+		for j := len(db.undo[i]) - 1; j >= 0; j-- {
+			kv := db.undo[i][j]
+			var err error
+			if kv.delete {
+				err = db.db.Delete(kv.key)
+			} else {
+				err = db.db.Put(kv.key, kv.value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	db.undo = nil
+	return nil
+}
+
+// err returns the error to report if the database can't be used anymore.
+// Assumes [db.lock] is held.
+func (db *Database) err() error {
+	switch {
+	case db.crashed:
+		return ErrCrashed
+	case db.closed:
+		return database.ErrClosed
+	default:
+		return nil
+	}
+}
+
+func (db *Database) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if err := db.err(); err != nil {
+		return false, err
+	}
+	return db.db.Has(key)
+}
+
+func (db *Database) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if err := db.err(); err != nil {
+		return nil, err
+	}
+	return db.db.Get(key)
+}
+
+func (db *Database) Put(key, value []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	return db.write(keyValue{key: key, value: value})
+}
+
+func (db *Database) Delete(key []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	return db.write(keyValue{key: key, delete: true})
+}
+
+// DeleteRange removes every key in [start, limit) from the underlying database
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	return db.write(keyValue{key: start, deleteRange: true, limit: limit})
+}
+
+// write applies [kvs] to the underlying database as a single write and
+// crashes if it was the last write before the configured crash. Assumes
+// [db.lock] is held.
+func (db *Database) write(kvs ...keyValue) error {
+	if err := db.err(); err != nil {
+		return err
+	}
+
+	var undo undoLog
+	for _, kv := range kvs {
+		kvUndo, err := db.undoLog(kv)
+		if err != nil {
+			return err
+		}
+		undo = append(undo, kvUndo...)
+
+		switch {
+		case kv.deleteRange:
+			err = database.DeleteRange(db.db, kv.key, kv.limit)
+		case kv.delete:
+			err = db.db.Delete(kv.key)
+		default:
+			err = db.db.Put(kv.key, kv.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	db.writes++
+	if db.unsynced > 0 {
+		if len(db.undo) == db.unsynced {
+			copy(db.undo, db.undo[1:])
+			db.undo = db.undo[:len(db.undo)-1]
+		}
+		db.undo = append(db.undo, undo)
+	}
+	if db.writes != db.crashAfter {
+		return nil
+	}
+	if err := db.crash(); err != nil {
+		return err
+	}
+	return ErrCrashed
+}
+
+// undoLog returns the writes that restore the values that [kv] overwrites.
+// Assumes [db.lock] is held.
+func (db *Database) undoLog(kv keyValue) (undoLog, error) {
+	if db.unsynced == 0 {
+		return nil, nil
+	}
+	if !kv.deleteRange {
+		prev, err := db.restore(kv.key)
+		return undoLog{prev}, err
+	}
+
+	var undo undoLog
+	it := db.db.NewIteratorWithStart(kv.key)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if !database.InRange(key, kv.key, kv.limit) {
+			break
+		}
+		undo = append(undo, keyValue{
+			key:   utils.CopyBytes(key),
+			value: utils.CopyBytes(it.Value()),
+		})
+	}
+	return undo, it.Error()
+}
+
+// restore returns the write that restores the current value of [key].
+func (db *Database) restore(key []byte) (keyValue, error) {
+	value, err := db.db.Get(key)
+	switch err {
+	case nil:
+		return keyValue{key: utils.CopyBytes(key), value: value}, nil
+	case database.ErrNotFound:
+		return keyValue{key: utils.CopyBytes(key), delete: true}, nil
+	default:
+		return keyValue{}, err
+	}
+}
+
+func (db *Database) NewBatch() database.Batch {
+	return &batch{db: db}
+}
+
+func (db *Database) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if err := db.err(); err != nil {
+		return &nodb.Iterator{Err: err}
+	}
+	return &iterator{
+		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       db,
+	}
+}
+
+func (db *Database) Compact(start, limit []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if err := db.err(); err != nil {
+		return err
+	}
+	return db.db.Compact(start, limit)
+}
+
+// Close doesn't close the underlying database, so that it can be inspected
+// after a crash.
+func (db *Database) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if err := db.err(); err != nil {
+		return err
+	}
+	db.closed = true
+	return nil
+}
+
+func (db *Database) HealthCheck(ctx context.Context) (interface{}, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if err := db.err(); err != nil {
+		return nil, err
+	}
+	return db.db.HealthCheck(ctx)
+}
+
+type batch struct {
+	db     *Database
+	writes []keyValue
+	size   int
+}
+
+func (b *batch) Put(key, value []byte) error {
+	b.writes = append(b.writes, keyValue{
+		key:   utils.CopyBytes(key),
+		value: utils.CopyBytes(value),
+	})
+	b.size += len(key) + len(value)
+	return nil
+}
+
+func (b *batch) Delete(key []byte) error {
+	b.writes = append(b.writes, keyValue{
+		key:    utils.CopyBytes(key),
+		delete: true,
+	})
+	b.size += len(key)
+	return nil
+}
+
+func (b *batch) DeleteRange(start, limit []byte) error {
+	b.writes = append(b.writes, keyValue{
+		key:         utils.CopyBytes(start),
+		deleteRange: true,
+		limit:       utils.CopyBytes(limit),
+	})
+	b.size += len(start) + len(limit)
+	return nil
+}
+
+func (b *batch) Size() int {
+	return b.size
+}
+
+// Write applies the operations of the batch as a single write, so a crash
+// either keeps or loses all of them.
+func (b *batch) Write() error {
+	b.db.lock.Lock()
+	defer b.db.lock.Unlock()
+
+	if len(b.writes) == 0 {
+		return b.db.err()
+	}
+	return b.db.write(b.writes...)
+}
+
+func (b *batch) Reset() {
+	if cap(b.writes) > len(b.writes)*database.MaxExcessCapacityFactor {
+		b.writes = make([]keyValue, 0, cap(b.writes)/database.CapacityReductionFactor)
+	} else {
+		b.writes = b.writes[:0]
+	}
+	b.size = 0
+}
+
+func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
+	for _, kv := range b.writes {
+		var err error
+		switch {
+		case kv.deleteRange:
+			err = database.DeleteRange(w, kv.key, kv.limit)
+		case kv.delete:
+			err = w.Delete(kv.key)
+		default:
+			err = w.Put(kv.key, kv.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *batch) Inner() database.Batch {
+	return b
+}
+
+type iterator struct {
+	database.Iterator
+	db *Database
+
+	err error
+}
+
+func (it *iterator) Next() bool {
+	it.db.lock.RLock()
+	err := it.db.err()
+	it.db.lock.RUnlock()
+	if err != nil {
+		it.err = err
+		return false
+	}
+	return it.Iterator.Next()
+}
+
+func (it *iterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.Iterator.Error()
+}
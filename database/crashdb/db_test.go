@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package crashdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/memdb"
+)
+
+func TestInterface(t *testing.T) {
+	for _, test := range database.Tests {
+		test(t, New(memdb.New(), 0, 0))
+	}
+}
+
+func TestDeleteRangeInterface(t *testing.T) {
+	for _, test := range database.DeleteRangeTests {
+		test(t, New(memdb.New(), 0, 2))
+	}
+}
+
+func FuzzInterface(f *testing.F) {
+	for _, test := range database.FuzzTests {
+		test(f, New(memdb.New(), 0, 0))
+	}
+}
+
+func TestCrashAfterWrites(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	require.NoError(baseDB.Put([]byte("a"), []byte("old")))
+	require.NoError(baseDB.Put([]byte("c"), []byte("old")))
+
+	db := New(baseDB, 4, 2)
+	require.NoError(db.Put([]byte("a"), []byte("new")))
+	require.NoError(db.Delete([]byte("c")))
+	require.NoError(db.Put([]byte("b"), []byte("new")))
+
+	batch := db.NewBatch()
+	require.NoError(batch.Put([]byte("d"), []byte("new")))
+	require.NoError(batch.Put([]byte("a"), []byte("newer")))
+	require.NoError(batch.Put([]byte("e"), []byte("new")))
+	require.ErrorIs(batch.Write(), ErrCrashed)
+
+	require.True(db.Crashed())
+	require.Equal(4, db.Writes())
+	_, err := db.Get([]byte("a"))
+	require.ErrorIs(err, ErrCrashed)
+
+	// The last 2 writes were lost, including the whole batch.
+	value, err := baseDB.Get([]byte("a"))
+	require.NoError(err)
+	require.Equal([]byte("new"), value)
+	has, err := baseDB.Has([]byte("c"))
+	require.NoError(err)
+	require.False(has)
+	has, err = baseDB.Has([]byte("b"))
+	require.NoError(err)
+	require.False(has)
+	has, err = baseDB.Has([]byte("d"))
+	require.NoError(err)
+	require.False(has)
+	has, err = baseDB.Has([]byte("e"))
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestCrashUndoesDeleteRange(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	require.NoError(baseDB.Put([]byte("a"), []byte("a")))
+	require.NoError(baseDB.Put([]byte("b"), []byte("b")))
+	require.NoError(baseDB.Put([]byte("c"), []byte("c")))
+
+	db := New(baseDB, 0, 1)
+	require.NoError(db.DeleteRange([]byte("a"), []byte("c")))
+	require.NoError(db.Crash())
+	require.ErrorIs(db.Put([]byte("d"), nil), ErrCrashed)
+
+	count, err := database.Count(baseDB)
+	require.NoError(err)
+	require.Equal(3, count)
+}
@@ -82,6 +82,50 @@ type Compacter interface {
 	Compact(start []byte, limit []byte) error
 }
 
+// Snapshot is a read-only view of a backing data store as of the moment the
+// snapshot was created. Writes made to the data store after the snapshot was
+// created are not visible through the snapshot.
+//
+// A snapshot must be released after use. A snapshot is safe for concurrent use.
+type Snapshot interface {
+	KeyValueReader
+	Iteratee
+
+	// Release releases the resources held by the snapshot. Reading from the
+	// snapshot after it has been released, or after the data store has been
+	// closed, returns [ErrClosed].
+	//
+	// Release can be called multiple times.
+	Release()
+}
+
+// Snapshotter wraps the NewSnapshot method of a backing data store.
+//
+// Snapshotter is optional. Databases that wrap another database implement it
+// by snapshotting the wrapped database, and return [ErrSnapshotsNotSupported]
+// if the wrapped database doesn't support snapshots.
+type Snapshotter interface {
+	// NewSnapshot returns a read-only view of the data store's current
+	// contents.
+	NewSnapshot() (Snapshot, error)
+}
+
+// RangeDeleter wraps the DeleteRange method of a backing data store or batch.
+//
+// RangeDeleter is optional. Databases that wrap another database implement it
+// by translating the range for the wrapped database, and return
+// [ErrRangeDeletionNotSupported] if the wrapped database doesn't support range
+// deletion.
+type RangeDeleter interface {
+	// DeleteRange removes every key in [start, limit). An empty [start]
+	// starts at the first key and an empty [limit] has no upper bound.
+	//
+	// When called on a batch, the range is deleted when the batch is written
+	// and only affects the operations that were added to the batch before
+	// it.
+	DeleteRange(start, limit []byte) error
+}
+
 // Database contains all the methods required to allow handling different
 // key-value data stores backing the database.
 type Database interface {
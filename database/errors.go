@@ -10,4 +10,8 @@ import "errors"
 var (
 	ErrClosed   = errors.New("closed")
 	ErrNotFound = errors.New("not found")
+
+	ErrSnapshotsNotSupported     = errors.New("snapshots not supported")
+	ErrRangeDeletionNotSupported = errors.New("range deletion not supported")
+	ErrQuotaExceeded             = errors.New("quota exceeded")
 )
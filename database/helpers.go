@@ -5,6 +5,7 @@
 package database
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -150,11 +151,17 @@ func Size(db Iteratee) (int, error) {
 
 	size := 0
 	for iterator.Next() {
-		size += len(iterator.Key()) + len(iterator.Value()) + kvPairOverhead
+		size += EntrySize(iterator.Key(), iterator.Value())
 	}
 	return size, iterator.Error()
 }
 
+// EntrySize returns the estimated number of bytes used to store [key] and
+// [value] in a database.
+func EntrySize(key, value []byte) int {
+	return len(key) + len(value) + kvPairOverhead
+}
+
 func IsEmpty(db Iteratee) (bool, error) {
 	iterator := db.NewIterator()
 	defer iterator.Release()
@@ -178,3 +185,64 @@ func ClearPrefix(readerDB Iteratee, deleterDB KeyValueDeleter, prefix []byte) er
 	}
 	return iterator.Error()
 }
+
+// NewSnapshot returns a snapshot of [db]. If [db] doesn't implement
+// Snapshotter, [ErrSnapshotsNotSupported] is returned.
+func NewSnapshot(db interface{}) (Snapshot, error) {
+	snapshotter, ok := db.(Snapshotter)
+	if !ok {
+		return nil, ErrSnapshotsNotSupported
+	}
+	return snapshotter.NewSnapshot()
+}
+
+// DeleteRange removes every key in [start, limit) from [db]. If [db] doesn't
+// implement RangeDeleter, [ErrRangeDeletionNotSupported] is returned.
+func DeleteRange(db interface{}, start, limit []byte) error {
+	rangeDeleter, ok := db.(RangeDeleter)
+	if !ok {
+		return ErrRangeDeletionNotSupported
+	}
+	return rangeDeleter.DeleteRange(start, limit)
+}
+
+// PruneRange removes every key in [start, limit) from [db]. If [db] doesn't
+// support range deletion, the keys are deleted with batches of at most
+// [batchSize] bytes.
+func PruneRange(db Database, start, limit []byte, batchSize int) error {
+	err := DeleteRange(db, start, limit)
+	if err != ErrRangeDeletionNotSupported {
+		return err
+	}
+
+	iterator := db.NewIteratorWithStart(start)
+	defer iterator.Release()
+
+	batch := db.NewBatch()
+	for iterator.Next() {
+		key := iterator.Key()
+		if !InRange(key, start, limit) {
+			break
+		}
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+		if batch.Size() < batchSize {
+			continue
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+	}
+	if err := iterator.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// InRange returns true if [key] is in [start, limit). An empty [limit] has no
+// upper bound.
+func InRange(key, start, limit []byte) bool {
+	return bytes.Compare(key, start) >= 0 && (len(limit) == 0 || bytes.Compare(key, limit) < 0)
+}
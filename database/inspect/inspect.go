@@ -0,0 +1,209 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package inspect reports how the keys of a database are distributed across
+// the namespaces created by prefixdb.
+package inspect
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/bits"
+	"sort"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/utils/hashing"
+)
+
+const (
+	// UnprefixedName is the namespace of keys that are too short to have been
+	// written through a prefixdb.
+	UnprefixedName = "unprefixed"
+	// unknownName is the namespace of keys whose prefix isn't known. Unknown
+	// keys are grouped by their first [hashing.HashLen] bytes.
+	unknownName = "unknown/"
+)
+
+// Bucket counts the entries whose size is at most MaxSize bytes and larger
+// than the MaxSize of the previous bucket.
+type Bucket struct {
+	MaxSize uint64 `json:"maxSize"`
+	Count   uint64 `json:"count"`
+}
+
+// Histogram groups sizes into buckets with power of two upper bounds.
+type Histogram struct {
+	// buckets[i] counts sizes in (2^(i-1), 2^i], buckets[0] counts empty
+	// entries and entries of a single byte.
+	buckets [65]uint64
+}
+
+func (h *Histogram) add(size int) {
+	i := 0
+	if size > 1 {
+		i = bits.Len64(uint64(size - 1))
+	}
+	h.buckets[i]++
+}
+
+// Buckets returns the non-empty buckets of the histogram, ordered by size.
+func (h *Histogram) Buckets() []Bucket {
+	buckets := []Bucket{}
+	for i, count := range h.buckets {
+		if count == 0 {
+			continue
+		}
+		buckets = append(buckets, Bucket{
+			MaxSize: 1 << i,
+			Count:   count,
+		})
+	}
+	return buckets
+}
+
+// MarshalJSON marshals the non-empty buckets of the histogram.
+func (h *Histogram) MarshalJSON() ([]byte, error) {
+	return json.Marshal(h.Buckets())
+}
+
+// Stats describes the keys of a namespace.
+type Stats struct {
+	// Name identifies the namespace, e.g. "chain/X/vm/utxo".
+	Name string `json:"name"`
+	// Prefix is the hex encoded prefix that every key of the namespace
+	// starts with.
+	Prefix     string     `json:"prefix"`
+	NumKeys    uint64     `json:"numKeys"`
+	KeyBytes   uint64     `json:"keyBytes"`
+	ValueBytes uint64     `json:"valueBytes"`
+	KeySizes   *Histogram `json:"keySizes"`
+	ValueSizes *Histogram `json:"valueSizes"`
+}
+
+func newStats(name string, prefix []byte) *Stats {
+	return &Stats{
+		Name:       name,
+		Prefix:     hex.EncodeToString(prefix),
+		KeySizes:   &Histogram{},
+		ValueSizes: &Histogram{},
+	}
+}
+
+func (s *Stats) add(key, value []byte) {
+	s.NumKeys++
+	s.KeyBytes += uint64(len(key))
+	s.ValueBytes += uint64(len(value))
+	s.KeySizes.add(len(key))
+	s.ValueSizes.add(len(value))
+}
+
+// Size returns the total number of key and value bytes in the namespace.
+func (s *Stats) Size() uint64 {
+	return s.KeyBytes + s.ValueBytes
+}
+
+// Report describes the keys of a database.
+type Report struct {
+	NumKeys    uint64 `json:"numKeys"`
+	KeyBytes   uint64 `json:"keyBytes"`
+	ValueBytes uint64 `json:"valueBytes"`
+	// Namespaces is ordered from the largest namespace to the smallest.
+	Namespaces []*Stats `json:"namespaces"`
+}
+
+// Resolver maps keys to the most specific known namespace that contains them.
+type Resolver struct {
+	names map[string]string
+	// lengths of the known prefixes, from the longest to the shortest.
+	lengths []int
+}
+
+// NewResolver returns a resolver that doesn't know of any namespace.
+func NewResolver() *Resolver {
+	return &Resolver{
+		names: make(map[string]string),
+	}
+}
+
+// Add names the keys that start with [prefix].
+func (r *Resolver) Add(name string, prefix []byte) {
+	if _, ok := r.names[string(prefix)]; !ok {
+		r.addLength(len(prefix))
+	}
+	r.names[string(prefix)] = name
+}
+
+func (r *Resolver) addLength(length int) {
+	i := sort.Search(len(r.lengths), func(i int) bool {
+		return r.lengths[i] <= length
+	})
+	if i < len(r.lengths) && r.lengths[i] == length {
+		return
+	}
+	r.lengths = append(r.lengths, 0)
+	copy(r.lengths[i+1:], r.lengths[i:])
+	r.lengths[i] = length
+}
+
+// Resolve returns the name and prefix of the namespace that contains [key].
+func (r *Resolver) Resolve(key []byte) (string, []byte) {
+	for _, length := range r.lengths {
+		if length > len(key) {
+			continue
+		}
+		if name, ok := r.names[string(key[:length])]; ok {
+			return name, key[:length]
+		}
+	}
+	if len(key) < hashing.HashLen {
+		return UnprefixedName, nil
+	}
+	prefix := key[:hashing.HashLen]
+	return unknownName + hex.EncodeToString(prefix), prefix
+}
+
+// Database iterates over every key in [db] and groups the keys by the
+// namespaces known by [r].
+func Database(db database.Iteratee, r *Resolver) (*Report, error) {
+	var (
+		report     = &Report{}
+		namespaces = make(map[string]*Stats)
+	)
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		value := it.Value()
+
+		report.NumKeys++
+		report.KeyBytes += uint64(len(key))
+		report.ValueBytes += uint64(len(value))
+
+		name, prefix := r.Resolve(key)
+		stats, ok := namespaces[name]
+		if !ok {
+			stats = newStats(name, prefix)
+			namespaces[name] = stats
+		}
+		stats.add(key, value)
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	report.Namespaces = make([]*Stats, 0, len(namespaces))
+	for _, stats := range namespaces {
+		report.Namespaces = append(report.Namespaces, stats)
+	}
+	sort.Slice(report.Namespaces, func(i, j int) bool {
+		si, sj := report.Namespaces[i], report.Namespaces[j]
+		if si.Size() != sj.Size() {
+			return si.Size() > sj.Size()
+		}
+		return si.Name < sj.Name
+	})
+	return report, nil
+}
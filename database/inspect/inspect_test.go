@@ -0,0 +1,156 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package inspect
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/linkeddb"
+	"github.com/coinflect/coinflectchain/database/memdb"
+	"github.com/coinflect/coinflectchain/database/prefixdb"
+	"github.com/coinflect/coinflectchain/database/versiondb"
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/utils/constants"
+)
+
+// vmStateDB creates the database that the VM of [chainID] builds its state on,
+// the same way that the chain manager and the VMs do.
+func vmStateDB(db database.Database, chainID ids.ID) *versiondb.Database {
+	chainDB := prefixdb.New(chainID[:], db)
+	vmDB := prefixdb.New([]byte("vm"), chainDB)
+	return versiondb.New(vmDB)
+}
+
+func namespaces(report *Report) map[string]*Stats {
+	m := make(map[string]*Stats, len(report.Namespaces))
+	for _, stats := range report.Namespaces {
+		m[stats.Name] = stats
+	}
+	return m
+}
+
+func TestHistogram(t *testing.T) {
+	require := require.New(t)
+
+	h := &Histogram{}
+	for _, size := range []int{0, 1, 2, 3, 4, 5, 1024} {
+		h.add(size)
+	}
+	require.Equal(
+		[]Bucket{
+			{MaxSize: 1, Count: 2},
+			{MaxSize: 2, Count: 1},
+			{MaxSize: 4, Count: 2},
+			{MaxSize: 8, Count: 1},
+			{MaxSize: 1024, Count: 1},
+		},
+		h.Buckets(),
+	)
+}
+
+func TestResolverLongestMatch(t *testing.T) {
+	require := require.New(t)
+
+	r := NewResolver()
+	r.Add("a", []byte("aa"))
+	r.Add("b", []byte("aabb"))
+
+	name, prefix := r.Resolve([]byte("aabbcc"))
+	require.Equal("b", name)
+	require.Equal([]byte("aabb"), prefix)
+
+	name, prefix = r.Resolve([]byte("aacc"))
+	require.Equal("a", name)
+	require.Equal([]byte("aa"), prefix)
+
+	name, prefix = r.Resolve([]byte("cc"))
+	require.Equal(UnprefixedName, name)
+	require.Nil(prefix)
+
+	key := make([]byte, 40)
+	name, prefix = r.Resolve(key)
+	require.Equal(unknownName+"0000000000000000000000000000000000000000000000000000000000000000", name)
+	require.Len(prefix, 32)
+}
+
+func TestDatabase(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	xChainID := ids.GenerateTestID()
+	subnetID := ids.GenerateTestID()
+	subnetChainID := ids.GenerateTestID()
+
+	// P-chain state
+	pState := vmStateDB(db, constants.PlatformChainID)
+	subnets := linkeddb.NewDefault(prefixdb.New([]byte("subnet"), pState))
+	require.NoError(subnets.Put(subnetID[:], nil))
+	chains := prefixdb.New([]byte("chain"), pState)
+	primaryChains := linkeddb.NewDefault(prefixdb.New(constants.PrimaryNetworkID[:], chains))
+	require.NoError(primaryChains.Put(xChainID[:], nil))
+	subnetChains := linkeddb.NewDefault(prefixdb.New(subnetID[:], chains))
+	require.NoError(subnetChains.Put(subnetChainID[:], nil))
+	current := prefixdb.New([]byte("current"), prefixdb.New([]byte("validators"), pState))
+	require.NoError(prefixdb.New([]byte("validator"), current).Put([]byte("validator"), []byte("value")))
+	addressTxs := prefixdb.New([]byte("addressTxs"), pState)
+	require.NoError(addressTxs.Put([]byte("address"), nil))
+	require.NoError(pState.Commit())
+
+	// X-chain state
+	xState := vmStateDB(db, xChainID)
+	utxos := prefixdb.New([]byte("utxo"), prefixdb.New([]byte("utxo"), xState))
+	for i := 0; i < 10; i++ {
+		require.NoError(utxos.Put([]byte{byte(i)}, make([]byte, 100)))
+	}
+	require.NoError(xState.Commit())
+
+	// proposervm state of the subnet chain
+	subnetVMDB := prefixdb.New([]byte("vm"), prefixdb.New(subnetChainID[:], db))
+	proposerDB := versiondb.New(prefixdb.New([]byte("proposervm"), subnetVMDB))
+	require.NoError(prefixdb.New([]byte("block"), proposerDB).Put([]byte("block"), nil))
+	require.NoError(proposerDB.Commit())
+
+	// node state
+	require.NoError(prefixdb.New([]byte("keystore"), db).Put([]byte("user"), nil))
+	require.NoError(db.Put([]byte("genesisID"), nil))
+
+	chainIDs, err := DiscoverChains(db)
+	require.NoError(err)
+	require.ElementsMatch([]ids.ID{constants.PlatformChainID, xChainID, subnetChainID}, chainIDs)
+
+	r := NewNodeResolver(chainIDs, map[ids.ID]string{
+		constants.PlatformChainID: "P",
+		xChainID:                  "X",
+	})
+	report, err := Database(db, r)
+	require.NoError(err)
+
+	count, err := database.Count(db)
+	require.NoError(err)
+	require.Equal(uint64(count), report.NumKeys)
+
+	stats := namespaces(report)
+	require.Contains(stats, "chain/P/vm/validators/current/validator")
+	require.Contains(stats, "chain/P/vm/subnet")
+	require.Contains(stats, "chain/P/vm/addressTxs")
+	require.Contains(stats, "keystore")
+	require.Contains(stats, UnprefixedName)
+	require.Contains(stats, "chain/"+subnetChainID.String()+"/vm/proposervm/block")
+
+	xUTXOs := stats["chain/X/vm/utxo/utxo"]
+	require.NotNil(xUTXOs)
+	require.Equal(uint64(10), xUTXOs.NumKeys)
+	require.Equal(uint64(1000), xUTXOs.ValueBytes)
+
+	// The largest namespace is reported first.
+	require.Equal(xUTXOs, report.Namespaces[0])
+
+	_, err = json.Marshal(report)
+	require.NoError(err)
+}
@@ -0,0 +1,207 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package inspect
+
+import (
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/linkeddb"
+	"github.com/coinflect/coinflectchain/database/prefixdb"
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/utils/constants"
+	"github.com/coinflect/coinflectchain/utils/hashing"
+)
+
+// namespace describes a prefixdb that is created by the node.
+type namespace struct {
+	name   string
+	prefix []byte
+	// flatten is true if the prefixdb is created directly on top of the
+	// parent prefixdb, in which case prefixdb replaces the parent prefix with
+	// the hash of the parent prefix and [prefix]. Otherwise the hash of
+	// [prefix] is added after the parent prefix, e.g. when the parent
+	// prefixdb is wrapped by a versiondb.
+	flatten  bool
+	children []namespace
+}
+
+// prefixes returns the bytes written before the prefixdb of the namespace and
+// the prefix of the prefixdb. Every key of the namespace starts with both.
+func (n *namespace) prefixes(parentBase, parentDBPrefix []byte) ([]byte, []byte) {
+	if n.flatten {
+		dbPrefix := make([]byte, 0, len(parentDBPrefix)+len(n.prefix))
+		dbPrefix = append(dbPrefix, parentDBPrefix...)
+		dbPrefix = append(dbPrefix, n.prefix...)
+		return parentBase, hashing.ComputeHash256(dbPrefix)
+	}
+	base := make([]byte, 0, len(parentBase)+len(parentDBPrefix))
+	base = append(base, parentBase...)
+	base = append(base, parentDBPrefix...)
+	return base, hashing.ComputeHash256(n.prefix)
+}
+
+func (n *namespace) add(r *Resolver, parentName string, parentBase, parentDBPrefix []byte) {
+	name := n.name
+	if parentName != "" {
+		name = parentName + "/" + n.name
+	}
+	base, dbPrefix := n.prefixes(parentBase, parentDBPrefix)
+	prefix := make([]byte, 0, len(base)+len(dbPrefix))
+	prefix = append(prefix, base...)
+	prefix = append(prefix, dbPrefix...)
+	r.Add(name, prefix)
+	for i := range n.children {
+		n.children[i].add(r, name, base, dbPrefix)
+	}
+}
+
+func nested(name string, children ...namespace) namespace {
+	return namespace{
+		name:     name,
+		prefix:   []byte(name),
+		children: children,
+	}
+}
+
+func flat(name string, children ...namespace) namespace {
+	return namespace{
+		name:     name,
+		prefix:   []byte(name),
+		flatten:  true,
+		children: children,
+	}
+}
+
+var (
+	// utxoState mirrors the prefixes of vms/components/cflt.
+	utxoState = []namespace{
+		flat("utxo"),
+		flat("index"),
+	}
+
+	// stakers mirrors the staker prefixes of vms/platformvm/state.
+	stakers = []namespace{
+		flat("validator"),
+		flat("delegator"),
+		flat("subnetValidator"),
+		flat("subnetDelegator"),
+	}
+
+	// vmNamespaces are the prefixes used by the VMs inside of the "vm"
+	// namespace of a chain. The VM of a chain isn't known, so the prefixes of
+	// every VM are checked.
+	vmNamespaces = []namespace{
+		// vms/proposervm
+		flat("proposervm",
+			nested("chain"),
+			nested("block"),
+			nested("height",
+				flat("height"),
+				flat("metadata"),
+			),
+		),
+
+		// vms/platformvm/state
+		nested("block"),
+		nested("validators",
+			flat("current", stakers...),
+			flat("pending", stakers...),
+			flat("validatorDiffs"),
+		),
+		nested("tx"),
+		nested("rewardUTXOs"),
+		nested("utxo", utxoState...),
+		nested("subnet"),
+		nested("transformedSubnet"),
+		nested("supply"),
+		nested("chain"),
+		nested("singleton"),
+		nested("addressTxs"),
+
+		// vms/avm/states
+		nested("status"),
+	}
+
+	// chainNamespaces are the prefixes used by chains/manager.
+	chainNamespaces = []namespace{
+		flat("vm", vmNamespaces...),
+		flat("bs"),
+		flat("vertex"),
+		flat("vertex_bs"),
+		flat("tx_bs"),
+		flat("journal"),
+	}
+
+	// nodeNamespaces are the prefixes used by node.Node.
+	nodeNamespaces = []namespace{
+		{
+			name:   "indexer",
+			prefix: []byte{0x00},
+		},
+		nested("shared memory"),
+		nested("keystore"),
+	}
+)
+
+// NewNodeResolver returns a resolver that knows the namespaces created by the
+// node and by the chains in [chainIDs]. Chains are named by their alias in
+// [aliases] if one is provided.
+func NewNodeResolver(chainIDs []ids.ID, aliases map[ids.ID]string) *Resolver {
+	r := NewResolver()
+	for i := range nodeNamespaces {
+		nodeNamespaces[i].add(r, "", nil, nil)
+	}
+	for _, chainID := range chainIDs {
+		name, ok := aliases[chainID]
+		if !ok {
+			name = chainID.String()
+		}
+		chain := namespace{
+			name:     name,
+			prefix:   chainID[:],
+			children: chainNamespaces,
+		}
+		chain.add(r, "chain", nil, nil)
+	}
+	return r
+}
+
+// DiscoverChains returns the IDs of the P-chain and of every chain that the
+// P-chain in [db] knows about.
+func DiscoverChains(db database.Database) ([]ids.ID, error) {
+	vmDB := prefixdb.New([]byte("vm"), prefixdb.New(constants.PlatformChainID[:], db))
+
+	subnetIDs := []ids.ID{constants.PrimaryNetworkID}
+	subnetDB := linkeddb.NewDefault(prefixdb.NewNested([]byte("subnet"), vmDB))
+	subnetIDs, err := appendIDs(subnetIDs, subnetDB)
+	if err != nil {
+		return nil, err
+	}
+
+	chainIDs := []ids.ID{constants.PlatformChainID}
+	chainDB := prefixdb.NewNested([]byte("chain"), vmDB)
+	for _, subnetID := range subnetIDs {
+		subnetChainDB := linkeddb.NewDefault(prefixdb.New(subnetID[:], chainDB))
+		chainIDs, err = appendIDs(chainIDs, subnetChainDB)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return chainIDs, nil
+}
+
+// appendIDs appends the keys of [db] to [dst].
+func appendIDs(dst []ids.ID, db linkeddb.LinkedDB) ([]ids.ID, error) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		id, err := ids.ToID(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		dst = append(dst, id)
+	}
+	return dst, it.Error()
+}
@@ -65,9 +65,13 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iter)(nil)
+	_ database.Database     = (*Database)(nil)
+	_ database.Snapshotter  = (*Database)(nil)
+	_ database.RangeDeleter = (*Database)(nil)
+	_ database.Batch        = (*batch)(nil)
+	_ database.RangeDeleter = (*batch)(nil)
+	_ database.Iterator     = (*iter)(nil)
+	_ database.Snapshot     = (*snapshot)(nil)
 )
 
 // Database is a persistent key-value store. Apart from basic data storage
@@ -179,6 +183,12 @@ type config struct {
 	// The default value is infinity.
 	MaxManifestFileSize int64 `json:"maxManifestFileSize"`
 
+	// ReadOnly opens an existing database without modifying any of its
+	// files. Writes to the database fail.
+	//
+	// The default is false.
+	ReadOnly bool `json:"readOnly"`
+
 	// MetricUpdateFrequency is the frequency to poll LevelDB metrics.
 	// If <= 0, LevelDB metrics aren't polled.
 	MetricUpdateFrequency time.Duration `json:"metricUpdateFrequency"`
@@ -222,8 +232,10 @@ func New(file string, configBytes []byte, log logging.Logger, namespace string,
 		WriteBuffer:                   parsedConfig.WriteBuffer,
 		Filter:                        filter.NewBloomFilter(parsedConfig.FilterBitsPerKey),
 		MaxManifestFileSize:           parsedConfig.MaxManifestFileSize,
+		ReadOnly:                      parsedConfig.ReadOnly,
+		ErrorIfMissing:                parsedConfig.ReadOnly,
 	})
-	if _, corrupted := err.(*errors.ErrCorrupted); corrupted {
+	if _, corrupted := err.(*errors.ErrCorrupted); corrupted && !parsedConfig.ReadOnly {
 		db, err = leveldb.RecoverFile(file, nil)
 	}
 	if err != nil {
@@ -290,6 +302,19 @@ func (db *Database) Delete(key []byte) error {
 	return updateError(db.DB.Delete(key, nil))
 }
 
+// DeleteRange removes every key in [start, limit).
+//
+// LevelDB doesn't support range tombstones, so the deletion is run through a
+// batch: the keys in the range are read from a snapshot and deleted with a
+// single atomic write.
+func (db *Database) DeleteRange(start, limit []byte) error {
+	b := &batch{db: db}
+	if err := b.DeleteRange(start, limit); err != nil {
+		return err
+	}
+	return b.Write()
+}
+
 // NewBatch creates a write/delete-only buffer that is atomically committed to
 // the database when write is called
 func (db *Database) NewBatch() database.Batch {
@@ -326,16 +351,24 @@ func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
 // over the database starting at start and ignoring keys that do not start with
 // the provided prefix
 func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
-	iterRange := util.BytesPrefix(prefix)
-	if bytes.Compare(start, prefix) == 1 {
-		iterRange.Start = start
-	}
 	return &iter{
 		db:       db,
-		Iterator: db.DB.NewIterator(iterRange, nil),
+		Iterator: db.DB.NewIterator(startAndPrefixRange(start, prefix), nil),
 	}
 }
 
+// NewSnapshot returns a read-only view of the current state of the database
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	s, err := db.DB.GetSnapshot()
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return &snapshot{
+		db:       db,
+		Snapshot: s,
+	}, nil
+}
+
 // This comment is basically copy pasted from the underlying levelDB library:
 
 // Compact the underlying DB for the given key range.
@@ -372,6 +405,27 @@ type batch struct {
 	leveldb.Batch
 	db   *Database
 	size int
+
+	// ranges that were deleted in the batch, in the order they were deleted.
+	ranges []deletedRange
+}
+
+// deletedRange is a range of keys that was deleted in a batch after the first
+// [index] records of the batch.
+type deletedRange struct {
+	start, limit []byte
+	index        int
+}
+
+// covers returns true if [key] is deleted by a range that was deleted after
+// the record at [index].
+func (b *batch) covers(key []byte, index int) bool {
+	for i := len(b.ranges) - 1; i >= 0 && b.ranges[i].index > index; i-- {
+		if database.InRange(key, b.ranges[i].start, b.ranges[i].limit) {
+			return true
+		}
+	}
+	return false
 }
 
 // Put the value into the batch for later writing
@@ -388,30 +442,103 @@ func (b *batch) Delete(key []byte) error {
 	return nil
 }
 
+// DeleteRange deletes every key in [start, limit) during writing
+func (b *batch) DeleteRange(start, limit []byte) error {
+	b.ranges = append(b.ranges, deletedRange{
+		start: utils.CopyBytes(start),
+		limit: utils.CopyBytes(limit),
+		index: b.Batch.Len(),
+	})
+	b.size += len(start) + len(limit) + levelDBByteOverhead
+	return nil
+}
+
 // Size retrieves the amount of data queued up for writing.
 func (b *batch) Size() int {
 	return b.size
 }
 
 // Write flushes any accumulated data to disk.
+//
+// LevelDB batches can't contain range deletions. If ranges were deleted, the
+// keys in the ranges are read from a snapshot and deleted first, followed by
+// the records that weren't added before a deleted range that contains them.
+// Keys that are written into a deleted range by other writers while the batch
+// is being written may not be deleted.
 func (b *batch) Write() error {
-	return updateError(b.db.DB.Write(&b.Batch, nil))
+	if len(b.ranges) == 0 {
+		return updateError(b.db.DB.Write(&b.Batch, nil))
+	}
+
+	snapshot, err := b.db.DB.GetSnapshot()
+	if err != nil {
+		return updateError(err)
+	}
+	defer snapshot.Release()
+
+	expanded := new(leveldb.Batch)
+	for _, r := range b.ranges {
+		it := snapshot.NewIterator(keyRange(r.start, r.limit), nil)
+		for it.Next() {
+			expanded.Delete(it.Key())
+		}
+		it.Release()
+		if err := it.Error(); err != nil {
+			return updateError(err)
+		}
+	}
+
+	replay := &rangeReplayer{batch: b}
+	replay.put = func(key, value []byte) error {
+		if !b.covers(key, replay.index) {
+			expanded.Put(key, value)
+		}
+		return nil
+	}
+	replay.delete = func(key []byte) error {
+		if !b.covers(key, replay.index) {
+			expanded.Delete(key)
+		}
+		return nil
+	}
+	if err := b.Batch.Replay(replay); err != nil {
+		return err
+	}
+	return updateError(b.db.DB.Write(expanded, nil))
 }
 
 // Reset resets the batch for reuse.
 func (b *batch) Reset() {
 	b.Batch.Reset()
 	b.size = 0
+	b.ranges = nil
 }
 
 // Replay the batch contents.
 func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
-	replay := &replayer{writerDeleter: w}
+	if len(b.ranges) == 0 {
+		replay := &replayer{writerDeleter: w}
+		if err := b.Batch.Replay(replay); err != nil {
+			// Never actually returns an error, because Replay just returns nil
+			return err
+		}
+		return replay.err
+	}
+
+	replay := &rangeReplayer{
+		batch:       b,
+		put:         w.Put,
+		delete:      w.Delete,
+		deleteRange: func(start, limit []byte) error { return database.DeleteRange(w, start, limit) },
+	}
 	if err := b.Batch.Replay(replay); err != nil {
-		// Never actually returns an error, because Replay just returns nil
 		return err
 	}
-	return replay.err
+	if replay.err != nil {
+		return replay.err
+	}
+	// Replay the ranges that were deleted after the last record.
+	return replay.replayRanges(b.Batch.Len())
 }
 
 // Inner returns itself
@@ -438,6 +565,97 @@ func (r *replayer) Delete(key []byte) {
 	r.err = r.writerDeleter.Delete(key)
 }
 
+// rangeReplayer replays the records of a batch while keeping track of the
+// index of the record being replayed. If [deleteRange] is set, the deleted
+// ranges are replayed in order with the records.
+type rangeReplayer struct {
+	batch       *batch
+	put         func(key, value []byte) error
+	delete      func(key []byte) error
+	deleteRange func(start, limit []byte) error
+
+	index     int
+	nextRange int
+	err       error
+}
+
+// replayRanges replays the ranges that were deleted before the record at
+// [index].
+func (r *rangeReplayer) replayRanges(index int) error {
+	if r.deleteRange == nil {
+		return nil
+	}
+	for ; r.nextRange < len(r.batch.ranges) && r.batch.ranges[r.nextRange].index <= index; r.nextRange++ {
+		dr := r.batch.ranges[r.nextRange]
+		if err := r.deleteRange(dr.start, dr.limit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *rangeReplayer) Put(key, value []byte) {
+	if r.err != nil {
+		return
+	}
+	if r.err = r.replayRanges(r.index); r.err == nil {
+		r.err = r.put(key, value)
+	}
+	r.index++
+}
+
+func (r *rangeReplayer) Delete(key []byte) {
+	if r.err != nil {
+		return
+	}
+	if r.err = r.replayRanges(r.index); r.err == nil {
+		r.err = r.delete(key)
+	}
+	r.index++
+}
+
+// snapshot is a wrapper around a levelDB snapshot to convert its errors and
+// iterators.
+type snapshot struct {
+	db *Database
+	*leveldb.Snapshot
+}
+
+// Has returns if the key was set in the database when the snapshot was taken
+func (s *snapshot) Has(key []byte) (bool, error) {
+	has, err := s.Snapshot.Has(key, nil)
+	return has, updateError(err)
+}
+
+// Get returns the value the key mapped to in the database when the snapshot
+// was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	value, err := s.Snapshot.Get(key, nil)
+	return value, updateError(err)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the snapshot starting at start and ignoring keys that do not start with
+// the provided prefix
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(startAndPrefixRange(start, prefix), nil),
+	}
+}
+
 type iter struct {
 	db *Database
 	iterator.Iterator
@@ -481,9 +699,28 @@ func (it *iter) Value() []byte {
 	return it.val
 }
 
+// startAndPrefixRange returns the range of keys that start with [prefix] and
+// are >= [start]
+func startAndPrefixRange(start, prefix []byte) *util.Range {
+	iterRange := util.BytesPrefix(prefix)
+	if bytes.Compare(start, prefix) == 1 {
+		iterRange.Start = start
+	}
+	return iterRange
+}
+
+// keyRange returns the range of keys in [start, limit). An empty [limit] has
+// no upper bound.
+func keyRange(start, limit []byte) *util.Range {
+	if len(limit) == 0 {
+		limit = nil
+	}
+	return &util.Range{Start: start, Limit: limit}
+}
+
 func updateError(err error) error {
 	switch err {
-	case leveldb.ErrClosed:
+	case leveldb.ErrClosed, leveldb.ErrSnapshotReleased:
 		return database.ErrClosed
 	case leveldb.ErrNotFound:
 		return database.ErrNotFound
@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package lsmdb
+
+import (
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/utils"
+)
+
+type op struct {
+	kind  kind
+	key   []byte
+	value []byte
+}
+
+// batch buffers writes until they are atomically applied to the database.
+type batch struct {
+	db   *Database
+	ops  []op
+	size int
+}
+
+// Put the value into the batch for later writing
+func (b *batch) Put(key, value []byte) error {
+	b.ops = append(b.ops, op{
+		kind:  kindPut,
+		key:   utils.CopyBytes(key),
+		value: utils.CopyBytes(value),
+	})
+	b.size += len(key) + len(value) + byteOverhead
+	return nil
+}
+
+// Delete the key during writing
+func (b *batch) Delete(key []byte) error {
+	b.ops = append(b.ops, op{
+		kind: kindDelete,
+		key:  utils.CopyBytes(key),
+	})
+	b.size += len(key) + byteOverhead
+	return nil
+}
+
+// Size retrieves the amount of data queued up for writing.
+func (b *batch) Size() int {
+	return b.size
+}
+
+// Write flushes any accumulated data to disk.
+func (b *batch) Write() error {
+	return b.db.write(b.ops)
+}
+
+// Reset resets the batch for reuse.
+func (b *batch) Reset() {
+	if cap(b.ops) > len(b.ops)*database.MaxExcessCapacityFactor {
+		b.ops = make([]op, 0, cap(b.ops)/database.CapacityReductionFactor)
+	} else {
+		b.ops = b.ops[:0]
+	}
+	b.size = 0
+}
+
+// Replay the batch contents.
+func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
+	for _, o := range b.ops {
+		if o.kind == kindDelete {
+			if err := w.Delete(o.key); err != nil {
+				return err
+			}
+		} else if err := w.Put(o.key, o.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Inner returns itself
+func (b *batch) Inner() database.Batch {
+	return b
+}
@@ -0,0 +1,473 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package lsmdb
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/coinflect/coinflectchain/database"
+)
+
+var errCompactionAborted = errors.New("compaction aborted")
+
+// compaction describes the merging of [inputs] from [level] and [level]+1 into
+// new tables in [level]+1.
+type compaction struct {
+	level int
+	// inputs contains the tables being compacted from [level] and [level]+1.
+	// Level 0 inputs are ordered from newest to oldest, all other inputs are
+	// sorted by key.
+	inputs [2][]*table
+	// dropTombstones is true if no level below the output level may contain
+	// the keys being compacted, so deletions no longer need to be recorded.
+	dropTombstones bool
+}
+
+// runFlushes flushes immutable memtables into level 0 as they become
+// available.
+func (db *Database) runFlushes() {
+	defer db.closeWg.Done()
+
+	for {
+		db.lock.Lock()
+		for !db.closed.GetValue() && db.bgErr == nil && len(db.imm) == 0 {
+			db.cond.Wait()
+		}
+		done := db.closed.GetValue() || db.bgErr != nil
+		db.lock.Unlock()
+		if done {
+			return
+		}
+
+		db.flushLock.Lock()
+		err := db.flushOldest()
+		db.flushLock.Unlock()
+		if err != nil {
+			db.reportBackgroundError("flush", err)
+		}
+	}
+}
+
+// runCompactions compacts levels that have exceeded their size limits.
+func (db *Database) runCompactions() {
+	defer db.closeWg.Done()
+
+	for {
+		db.lock.Lock()
+		for !db.closed.GetValue() && db.bgErr == nil && !db.needsCompaction() {
+			db.cond.Wait()
+		}
+		done := db.closed.GetValue() || db.bgErr != nil
+		db.lock.Unlock()
+		if done {
+			return
+		}
+
+		// The compaction must be picked while holding [compactionLock] to
+		// ensure that its inputs aren't concurrently compacted by a manual
+		// compaction.
+		db.compactionLock.Lock()
+		db.lock.Lock()
+		c := db.pickCompaction()
+		db.lock.Unlock()
+
+		var err error
+		if c != nil {
+			err = db.runCompaction(c)
+		}
+		db.compactionLock.Unlock()
+		if err != nil {
+			db.reportBackgroundError("compaction", err)
+		}
+	}
+}
+
+func (db *Database) reportBackgroundError(operation string, err error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed.GetValue() {
+		return
+	}
+	db.log.Error("background operation failed",
+		zap.String("operation", operation),
+		zap.Error(err),
+	)
+	db.bgErr = err
+	db.cond.Broadcast()
+}
+
+// flushAll flushes every immutable memtable into level 0.
+func (db *Database) flushAll() error {
+	db.flushLock.Lock()
+	defer db.flushLock.Unlock()
+
+	for {
+		db.lock.RLock()
+		numImm := len(db.imm)
+		db.lock.RUnlock()
+		if numImm == 0 {
+			return nil
+		}
+		if err := db.flushOldest(); err != nil {
+			return err
+		}
+	}
+}
+
+// flushOldest writes the oldest immutable memtable into a level 0 table.
+//
+// Assumes [flushLock] is held.
+func (db *Database) flushOldest() error {
+	db.lock.RLock()
+	if db.closed.GetValue() {
+		db.lock.RUnlock()
+		return database.ErrClosed
+	}
+	if len(db.imm) == 0 {
+		db.lock.RUnlock()
+		return nil
+	}
+	mem := db.imm[len(db.imm)-1]
+	db.lock.RUnlock()
+
+	startTime := time.Now()
+	t, err := db.writeLevel0Table(mem)
+	if err != nil {
+		return err
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	// The log of the next oldest memtable is now the oldest log that must be
+	// replayed on restart.
+	nextLogNum := db.mem.logNum
+	if len(db.imm) > 1 {
+		nextLogNum = db.imm[len(db.imm)-2].logNum
+	}
+
+	var added [numLevels][]*table
+	if t != nil {
+		added[0] = []*table{t}
+	}
+	newVersion := db.current.edit(nil, added)
+	if err := db.installVersion(newVersion, nextLogNum); err != nil {
+		if t != nil {
+			t.markObsolete()
+		}
+		newVersion.unref()
+		return err
+	}
+	db.imm = db.imm[:len(db.imm)-1]
+	db.cond.Broadcast()
+
+	atomic.AddUint64(&db.stats.memCompactions, 1)
+	atomic.AddInt64(&db.stats.levelDurations[0], int64(time.Since(startTime)))
+	if t != nil {
+		atomic.AddUint64(&db.stats.levelWrites[0], t.Size)
+	}
+	_ = os.Remove(logFileName(db.dir, mem.logNum))
+	return nil
+}
+
+// writeLevel0Table writes the contents of [mem] into a new table. If [mem] is
+// empty, no table is written and nil is returned.
+func (db *Database) writeLevel0Table(mem *memtable) (*table, error) {
+	tables, err := db.writeTables(mem.newIterator(nil, math.MaxUint64), false, math.MaxUint64)
+	if err != nil || len(tables) == 0 {
+		return nil, err
+	}
+	return tables[0], nil
+}
+
+// writeTables writes the entries reported by [it] into new tables. Tables are
+// split once they exceed [targetSize] bytes. If [dropTombstones] is true,
+// deletions are not written. [it] is released by this function.
+func (db *Database) writeTables(it internalIterator, dropTombstones bool, targetSize uint64) ([]*table, error) {
+	defer it.release()
+
+	var (
+		tables []*table
+		writer *tableWriter
+		num    uint64
+	)
+	abandon := func() {
+		if writer != nil {
+			writer.abandon()
+			_ = os.Remove(tableFileName(db.dir, num))
+		}
+		for _, t := range tables {
+			t.ref()
+			t.markObsolete()
+			t.unref()
+		}
+	}
+	finish := func() error {
+		meta, err := writer.finish(num)
+		if err != nil {
+			_ = os.Remove(tableFileName(db.dir, num))
+			writer = nil
+			return err
+		}
+		writer = nil
+		atomic.AddUint64(&db.stats.tableWrite, meta.Size)
+		t, err := openTable(tableFileName(db.dir, num), meta, db.blockCache, &db.stats)
+		if err != nil {
+			_ = os.Remove(tableFileName(db.dir, num))
+			return err
+		}
+		tables = append(tables, t)
+		return nil
+	}
+
+	for it.next() {
+		if db.closed.GetValue() {
+			abandon()
+			return nil, errCompactionAborted
+		}
+		if dropTombstones && it.kind() == kindDelete {
+			continue
+		}
+		if writer == nil {
+			db.lock.Lock()
+			num = db.newFileNum()
+			db.lock.Unlock()
+
+			var err error
+			writer, err = newTableWriter(tableFileName(db.dir, num), db.config.BlockSize, db.config.FilterBitsPerKey)
+			if err != nil {
+				abandon()
+				return nil, err
+			}
+		}
+		if err := writer.add(it.key(), it.kind(), it.value()); err != nil {
+			abandon()
+			return nil, err
+		}
+		if writer.estimatedSize() >= targetSize {
+			if err := finish(); err != nil {
+				abandon()
+				return nil, err
+			}
+		}
+	}
+	if err := it.error(); err != nil {
+		abandon()
+		return nil, err
+	}
+	if writer != nil {
+		if err := finish(); err != nil {
+			abandon()
+			return nil, err
+		}
+	}
+	return tables, nil
+}
+
+// needsCompaction returns true if a level has exceeded its size limit.
+//
+// Assumes [lock] is held.
+func (db *Database) needsCompaction() bool {
+	v := db.current
+	if len(v.levels[0]) >= db.config.L0CompactionTrigger {
+		return true
+	}
+	for level := 1; level < numLevels-1; level++ {
+		if v.levelSize(level) > db.maxBytesForLevel(level) {
+			return true
+		}
+	}
+	return false
+}
+
+// pickCompaction returns the next compaction that should be performed, or nil
+// if no compaction is needed.
+//
+// Assumes [lock] is held.
+func (db *Database) pickCompaction() *compaction {
+	v := db.current
+	if len(v.levels[0]) >= db.config.L0CompactionTrigger {
+		return db.newCompaction(v, 0, v.levels[0])
+	}
+
+	for level := 1; level < numLevels-1; level++ {
+		if v.levelSize(level) <= db.maxBytesForLevel(level) {
+			continue
+		}
+
+		// Rotate through the key space of the level.
+		tables := v.levels[level]
+		input := tables[0]
+		for _, t := range tables {
+			if bytes.Compare(t.Smallest, db.compactPointers[level]) > 0 {
+				input = t
+				break
+			}
+		}
+		db.compactPointers[level] = input.Largest
+		return db.newCompaction(v, level, []*table{input})
+	}
+	return nil
+}
+
+// newCompaction returns a compaction of [inputs] from [level] along with all
+// the overlapping tables in the next level.
+//
+// Assumes [lock] is held.
+func (db *Database) newCompaction(v *version, level int, inputs []*table) *compaction {
+	start, limit := keyRange(inputs)
+	c := &compaction{
+		level:          level,
+		inputs:         [2][]*table{inputs, v.overlapping(level+1, start, limit)},
+		dropTombstones: true,
+	}
+
+	start, limit = keyRange(append(c.inputs[0], c.inputs[1]...))
+	for deeperLevel := level + 2; deeperLevel < numLevels; deeperLevel++ {
+		if len(v.overlapping(deeperLevel, start, limit)) > 0 {
+			c.dropTombstones = false
+			break
+		}
+	}
+	return c
+}
+
+// runCompaction performs [c] and installs the resulting version.
+//
+// Assumes [compactionLock] is held.
+func (db *Database) runCompaction(c *compaction) error {
+	startTime := time.Now()
+
+	var (
+		iterators []internalIterator
+		readBytes uint64
+	)
+	if c.level == 0 {
+		for _, t := range c.inputs[0] {
+			iterators = append(iterators, t.newIterator(nil))
+		}
+	} else {
+		iterators = append(iterators, newLevelIterator(c.inputs[0], nil))
+	}
+	iterators = append(iterators, newLevelIterator(c.inputs[1], nil))
+	for _, inputs := range c.inputs {
+		for _, t := range inputs {
+			readBytes += t.Size
+		}
+	}
+
+	outputs, err := db.writeTables(newMergingIterator(iterators), c.dropTombstones, uint64(db.config.TargetFileSize))
+	if err != nil {
+		return err
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	deleted := make(map[uint64]struct{}, len(c.inputs[0])+len(c.inputs[1]))
+	for _, inputs := range c.inputs {
+		for _, t := range inputs {
+			deleted[t.Num] = struct{}{}
+		}
+	}
+	var added [numLevels][]*table
+	added[c.level+1] = outputs
+
+	newVersion := db.current.edit(deleted, added)
+	logNum := db.mem.logNum
+	if len(db.imm) > 0 {
+		logNum = db.imm[len(db.imm)-1].logNum
+	}
+	if err := db.installVersion(newVersion, logNum); err != nil {
+		for _, t := range outputs {
+			t.markObsolete()
+		}
+		newVersion.unref()
+		return err
+	}
+	for _, inputs := range c.inputs {
+		for _, t := range inputs {
+			t.markObsolete()
+		}
+	}
+	db.cond.Broadcast()
+
+	if c.level == 0 {
+		atomic.AddUint64(&db.stats.level0Compactions, 1)
+	} else {
+		atomic.AddUint64(&db.stats.nonLevel0Compactions, 1)
+	}
+	outputLevel := c.level + 1
+	atomic.AddInt64(&db.stats.levelDurations[outputLevel], int64(time.Since(startTime)))
+	atomic.AddUint64(&db.stats.levelReads[outputLevel], readBytes)
+	for _, t := range outputs {
+		atomic.AddUint64(&db.stats.levelWrites[outputLevel], t.Size)
+	}
+	return nil
+}
+
+// compactRange compacts all the tables that overlap [start, limit] down into
+// the deepest populated level.
+func (db *Database) compactRange(start, limit []byte) error {
+	db.compactionLock.Lock()
+	defer db.compactionLock.Unlock()
+
+	for level := 0; level < numLevels-1; level++ {
+		db.lock.Lock()
+		if db.closed.GetValue() {
+			db.lock.Unlock()
+			return database.ErrClosed
+		}
+		v := db.current
+		deepestLevel := v.deepestLevel()
+		if level > 0 && level >= deepestLevel {
+			db.lock.Unlock()
+			return nil
+		}
+
+		var inputs []*table
+		if level == 0 {
+			// Level 0 tables may overlap, so they must all be compacted
+			// together to ensure that newer versions of keys aren't shadowed
+			// by older versions.
+			inputs = v.levels[0]
+		} else {
+			inputs = v.overlapping(level, start, limit)
+		}
+		if len(inputs) == 0 {
+			db.lock.Unlock()
+			continue
+		}
+		c := db.newCompaction(v, level, inputs)
+		db.lock.Unlock()
+
+		if err := db.runCompaction(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keyRange returns the smallest and largest keys contained in [tables].
+func keyRange(tables []*table) ([]byte, []byte) {
+	var start, limit []byte
+	for i, t := range tables {
+		if i == 0 || bytes.Compare(t.Smallest, start) < 0 {
+			start = t.Smallest
+		}
+		if i == 0 || bytes.Compare(t.Largest, limit) > 0 {
+			limit = t.Largest
+		}
+	}
+	return start, limit
+}
@@ -0,0 +1,736 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package lsmdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"github.com/coinflect/coinflectchain/cache"
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/nodb"
+	"github.com/coinflect/coinflectchain/utils"
+	"github.com/coinflect/coinflectchain/utils/logging"
+	"github.com/coinflect/coinflectchain/utils/perms"
+	"github.com/coinflect/coinflectchain/utils/units"
+	"github.com/coinflect/coinflectchain/utils/wrappers"
+)
+
+const (
+	// Name is the name of this database for database switches
+	Name = "lsmdb"
+
+	// DefaultBlockCacheSize is the number of bytes to use for block caching.
+	DefaultBlockCacheSize = 12 * units.MiB
+
+	// DefaultBlockSize is the minimum number of bytes stored in each table
+	// block.
+	DefaultBlockSize = 4 * units.KiB
+
+	// DefaultWriteBufferSize is the number of bytes that are buffered in a
+	// memtable before it is flushed into a table.
+	DefaultWriteBufferSize = 6 * units.MiB
+
+	// DefaultMaxImmutableMemtables is the number of full memtables that can be
+	// waiting to be flushed before writes are delayed.
+	DefaultMaxImmutableMemtables = 2
+
+	// DefaultL0CompactionTrigger is the number of level 0 tables that will
+	// trigger a compaction into level 1.
+	DefaultL0CompactionTrigger = 4
+
+	// DefaultL0StopWritesTrigger is the number of level 0 tables that will
+	// cause writes to be delayed until a compaction completes.
+	DefaultL0StopWritesTrigger = 12
+
+	// DefaultTargetFileSize is the size that compactions will attempt to
+	// split their output tables at.
+	DefaultTargetFileSize = 2 * units.MiB
+
+	// DefaultBaseLevelSize is the maximum number of bytes stored in level 1
+	// before it is compacted into level 2.
+	DefaultBaseLevelSize = 64 * units.MiB
+
+	// DefaultLevelSizeMultiplier is the ratio between the maximum sizes of
+	// consecutive levels.
+	DefaultLevelSizeMultiplier = 10
+
+	// DefaultBitsPerKey is the number of bits to add to the bloom filter per
+	// key.
+	DefaultBitsPerKey = 10
+
+	// DefaultMetricUpdateFrequency is the frequency to poll the database
+	// metrics.
+	DefaultMetricUpdateFrequency = 10 * time.Second
+
+	// byteOverhead is the number of bytes of constant overhead that should be
+	// added to a batch size per operation.
+	byteOverhead = 8
+)
+
+var (
+	errReadOnly = errors.New("database is read-only")
+
+	_ database.Database    = (*Database)(nil)
+	_ database.Snapshotter = (*Database)(nil)
+	_ database.Batch       = (*batch)(nil)
+	_ database.Iterator    = (*iter)(nil)
+)
+
+// Database is a persistent key-value store built as a log-structured merge
+// tree. Writes are appended to a write-ahead log and buffered in a memtable.
+// Full memtables are flushed into sorted tables which are merged into
+// progressively larger levels by background compactions, so large databases
+// can keep accepting writes while compaction is ongoing.
+type Database struct {
+	dir        string
+	config     config
+	log        logging.Logger
+	blockCache *cache.LRU
+	// dirLock is held until the database is closed, so that the directory
+	// isn't opened by another process.
+	dirLock io.Closer
+
+	// writeLock serializes writers.
+	writeLock sync.Mutex
+	// flushLock is held while a memtable is being flushed into a table.
+	flushLock sync.Mutex
+	// compactionLock is held while tables are being compacted.
+	compactionLock sync.Mutex
+
+	// lock protects the fields below. cond is signaled whenever the state
+	// protected by lock changes in a way that a waiting writer or a background
+	// goroutine may care about.
+	lock sync.RWMutex
+	cond *sync.Cond
+	// mem is the memtable currently receiving writes.
+	mem *memtable
+	// imm contains the memtables waiting to be flushed, ordered from newest
+	// to oldest.
+	imm []*memtable
+	// current is the current set of tables.
+	current *version
+	// wal is the write-ahead log for [mem].
+	wal *logWriter
+	// seq is the sequence number of the last applied write.
+	seq         uint64
+	nextFileNum uint64
+	// compactPointers records, for each level, the largest key of the last
+	// table that was compacted out of the level so that compactions rotate
+	// through the key space.
+	compactPointers [numLevels][]byte
+	// bgErr is set if a background flush or compaction failed. Once set, all
+	// subsequent writes fail.
+	bgErr error
+
+	// scratch is reused by writers to encode batches. Protected by
+	// [writeLock].
+	scratch []byte
+
+	stats stats
+	// metrics is only initialized and used when [MetricUpdateFrequency] is > 0
+	// in the config
+	metrics   metrics
+	closed    utils.AtomicBool
+	closeOnce sync.Once
+	// closeCh is closed when Close() is called.
+	closeCh chan struct{}
+	// closeWg is used to wait for all goroutines created by New() to exit.
+	closeWg sync.WaitGroup
+}
+
+type config struct {
+	// BlockCacheCapacity is the number of bytes of table blocks to cache.
+	// Use -1 for zero.
+	//
+	// The default value is 12MiB.
+	BlockCacheCapacity int `json:"blockCacheCapacity"`
+	// BlockSize is the minimum uncompressed size in bytes of each table
+	// block.
+	//
+	// The default value is 4KiB.
+	BlockSize int `json:"blockSize"`
+	// WriteBuffer defines the maximum size of a memtable before it is flushed
+	// into a table.
+	//
+	// The default value is 6MiB.
+	WriteBuffer int `json:"writeBuffer"`
+	// MaxImmutableMemtables is the number of full memtables that may be
+	// waiting to be flushed before writes are delayed.
+	//
+	// The default value is 2.
+	MaxImmutableMemtables int `json:"maxImmutableMemtables"`
+	// L0CompactionTrigger defines the number of level 0 tables that will
+	// trigger a compaction.
+	//
+	// The default value is 4.
+	L0CompactionTrigger int `json:"l0CompactionTrigger"`
+	// L0StopWritesTrigger defines the number of level 0 tables that will
+	// cause writes to be delayed until the level 0 tables are compacted.
+	//
+	// The default value is 12.
+	L0StopWritesTrigger int `json:"l0StopWritesTrigger"`
+	// TargetFileSize is the size at which compaction output tables are split.
+	//
+	// The default value is 2MiB.
+	TargetFileSize int `json:"targetFileSize"`
+	// BaseLevelSize limits the total size of the tables in level 1. The limit
+	// of every subsequent level is calculated as:
+	//   BaseLevelSize * (LevelSizeMultiplier ^ (Level - 1))
+	//
+	// The default value is 64MiB.
+	BaseLevelSize int64 `json:"baseLevelSize"`
+	// LevelSizeMultiplier defines the multiplier for BaseLevelSize.
+	//
+	// The default value is 10.
+	LevelSizeMultiplier float64 `json:"levelSizeMultiplier"`
+	// FilterBitsPerKey is the number of bits per key in the bloom filter of
+	// each table.
+	//
+	// The default value is 10.
+	FilterBitsPerKey int `json:"filterBitsPerKey"`
+	// Sync causes every write to be synced to disk before it returns.
+	//
+	// The default is false.
+	Sync bool `json:"sync"`
+	// ReadOnly opens an existing database without modifying any of its
+	// files. Unflushed writes are replayed into memory, and writes to the
+	// database fail.
+	//
+	// The default is false.
+	ReadOnly bool `json:"readOnly"`
+
+	// MetricUpdateFrequency is the frequency to poll the database metrics.
+	// If <= 0, the metrics aren't polled.
+	MetricUpdateFrequency time.Duration `json:"metricUpdateFrequency"`
+}
+
+// New returns a new LSM database stored in the directory [dir].
+func New(dir string, configBytes []byte, log logging.Logger, namespace string, reg prometheus.Registerer) (database.Database, error) {
+	parsedConfig := config{
+		BlockCacheCapacity:    DefaultBlockCacheSize,
+		BlockSize:             DefaultBlockSize,
+		WriteBuffer:           DefaultWriteBufferSize,
+		MaxImmutableMemtables: DefaultMaxImmutableMemtables,
+		L0CompactionTrigger:   DefaultL0CompactionTrigger,
+		L0StopWritesTrigger:   DefaultL0StopWritesTrigger,
+		TargetFileSize:        DefaultTargetFileSize,
+		BaseLevelSize:         DefaultBaseLevelSize,
+		LevelSizeMultiplier:   DefaultLevelSizeMultiplier,
+		FilterBitsPerKey:      DefaultBitsPerKey,
+		MetricUpdateFrequency: DefaultMetricUpdateFrequency,
+	}
+	if len(configBytes) > 0 {
+		if err := json.Unmarshal(configBytes, &parsedConfig); err != nil {
+			return nil, fmt.Errorf("failed to parse db config: %w", err)
+		}
+	}
+
+	log.Info("creating lsmdb",
+		zap.Reflect("config", parsedConfig),
+	)
+
+	if !parsedConfig.ReadOnly {
+		if err := os.MkdirAll(dir, perms.ReadWriteExecute); err != nil {
+			return nil, err
+		}
+	}
+
+	dirLock, err := lockFile(filepath.Join(dir, lockFileName), parsedConfig.ReadOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	db := &Database{
+		dir:     dir,
+		config:  parsedConfig,
+		log:     log,
+		dirLock: dirLock,
+		closeCh: make(chan struct{}),
+	}
+	db.cond = sync.NewCond(&db.lock)
+	if parsedConfig.BlockCacheCapacity > 0 && parsedConfig.BlockSize > 0 {
+		db.blockCache = &cache.LRU{Size: parsedConfig.BlockCacheCapacity / parsedConfig.BlockSize}
+	}
+	if err := db.open(); err != nil {
+		// Drop any close error to report the original error
+		_ = dirLock.Close()
+		return nil, err
+	}
+
+	if parsedConfig.MetricUpdateFrequency > 0 {
+		metrics, err := newMetrics(namespace, reg)
+		if err != nil {
+			// Drop any close error to report the original error
+			_ = db.Close()
+			return nil, err
+		}
+		db.metrics = metrics
+		db.closeWg.Add(1)
+		go func() {
+			t := time.NewTicker(parsedConfig.MetricUpdateFrequency)
+			defer func() {
+				t.Stop()
+				db.closeWg.Done()
+			}()
+
+			for {
+				db.updateMetrics()
+
+				select {
+				case <-t.C:
+				case <-db.closeCh:
+					return
+				}
+			}
+		}()
+	}
+
+	if !parsedConfig.ReadOnly {
+		db.closeWg.Add(2)
+		go db.runFlushes()
+		go db.runCompactions()
+	}
+	return db, nil
+}
+
+// open loads the tables listed in the manifest, replays any unflushed
+// write-ahead logs and removes any files that are no longer referenced.
+func (db *Database) open() error {
+	if err := checkDir(db.dir, db.config.ReadOnly); err != nil {
+		return err
+	}
+	m, err := readManifest(db.dir)
+	if err != nil {
+		return err
+	}
+	db.nextFileNum = m.NextFileNum
+
+	v := &version{refs: 1}
+	for level, metas := range m.Levels {
+		for _, meta := range metas {
+			t, err := openTable(tableFileName(db.dir, meta.Num), meta, db.blockCache, &db.stats)
+			if err != nil {
+				v.unref()
+				return err
+			}
+			t.ref()
+			v.levels[level] = append(v.levels[level], t)
+		}
+	}
+	db.current = v
+
+	entries, err := os.ReadDir(db.dir)
+	if err != nil {
+		v.unref()
+		return err
+	}
+	var (
+		logNums      []uint64
+		obsoleteLogs []uint64
+		liveTables   = make(map[uint64]struct{})
+		unusedTables []uint64
+	)
+	for _, tables := range m.Levels {
+		for _, meta := range tables {
+			liveTables[meta.Num] = struct{}{}
+		}
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		ext := filepath.Ext(name)
+		num, err := strconv.ParseUint(strings.TrimSuffix(name, ext), 10, 64)
+		if err != nil {
+			continue
+		}
+		if num >= db.nextFileNum {
+			db.nextFileNum = num + 1
+		}
+		switch ext {
+		case logFileExtension:
+			if num >= m.LogNum {
+				logNums = append(logNums, num)
+			} else {
+				obsoleteLogs = append(obsoleteLogs, num)
+			}
+		case tableFileExtension:
+			if _, ok := liveTables[num]; !ok {
+				unusedTables = append(unusedTables, num)
+			}
+		}
+	}
+	sort.Slice(logNums, func(i, j int) bool { return logNums[i] < logNums[j] })
+
+	// Replay the logs into a single memtable and flush it so that the replayed
+	// logs can be removed.
+	recovered := newMemtable(0)
+	for _, logNum := range logNums {
+		err := readLog(logFileName(db.dir, logNum), func(payload []byte) error {
+			seq, ops, err := decodeBatch(payload)
+			if err != nil {
+				return err
+			}
+			for i, o := range ops {
+				recovered.add(seq+uint64(i), o.kind, o.key, o.value)
+			}
+			if last := seq + uint64(len(ops)) - 1; len(ops) > 0 && last > db.seq {
+				db.seq = last
+			}
+			return nil
+		})
+		if err != nil {
+			v.unref()
+			return fmt.Errorf("failed to replay log %d: %w", logNum, err)
+		}
+	}
+
+	if db.config.ReadOnly {
+		db.mem = recovered
+		return nil
+	}
+
+	var added [numLevels][]*table
+	if !recovered.empty() {
+		t, err := db.writeLevel0Table(recovered)
+		if err != nil {
+			v.unref()
+			return err
+		}
+		added[0] = []*table{t}
+	}
+
+	logNum := db.newFileNum()
+	wal, err := newLogWriter(logFileName(db.dir, logNum), db.config.Sync)
+	if err != nil {
+		v.unref()
+		return err
+	}
+	newVersion := v.edit(nil, added)
+	if err := db.installVersion(newVersion, logNum); err != nil {
+		_ = wal.close()
+		newVersion.unref()
+		v.unref()
+		return err
+	}
+	db.wal = wal
+	db.mem = newMemtable(logNum)
+
+	for _, num := range append(logNums, obsoleteLogs...) {
+		_ = os.Remove(logFileName(db.dir, num))
+	}
+	for _, num := range unusedTables {
+		_ = os.Remove(tableFileName(db.dir, num))
+	}
+	_ = os.Remove(filepath.Join(db.dir, manifestTmpFileName))
+	return nil
+}
+
+// Has returns if the key is set in the database
+func (db *Database) Has(key []byte) (bool, error) {
+	_, err := db.get(key)
+	switch err {
+	case nil:
+		return true, nil
+	case database.ErrNotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// Get returns the value the key maps to in the database
+func (db *Database) Get(key []byte) ([]byte, error) {
+	value, err := db.get(key)
+	if err != nil {
+		return nil, err
+	}
+	return utils.CopyBytes(value), nil
+}
+
+func (db *Database) get(key []byte) ([]byte, error) {
+	state, err := db.acquireReadState()
+	if err != nil {
+		return nil, err
+	}
+	defer state.release()
+
+	return state.get(key)
+}
+
+func foundValue(value []byte, k kind) ([]byte, error) {
+	if k == kindDelete {
+		return nil, database.ErrNotFound
+	}
+	return value, nil
+}
+
+// Put sets the value of the provided key to the provided value
+func (db *Database) Put(key []byte, value []byte) error {
+	return db.write([]op{{kind: kindPut, key: key, value: value}})
+}
+
+// Delete removes the key from the database
+func (db *Database) Delete(key []byte) error {
+	return db.write([]op{{kind: kindDelete, key: key}})
+}
+
+// NewBatch creates a write/delete-only buffer that is atomically committed to
+// the database when write is called
+func (db *Database) NewBatch() database.Batch {
+	return &batch{db: db}
+}
+
+// NewIterator creates a lexicographically ordered iterator over the database
+func (db *Database) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// database starting at the provided key
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// database ignoring keys that do not start with the provided prefix
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the database starting at start and ignoring keys that do not start with
+// the provided prefix
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	state, err := db.acquireReadState()
+	if err != nil {
+		return &nodb.Iterator{Err: err}
+	}
+	defer state.release()
+
+	return state.newIterator(db, start, prefix)
+}
+
+// Compact the underlying DB for the given key range.
+// Specifically, deleted and overwritten versions are discarded,
+// and the data is rearranged to reduce the cost of operations
+// needed to access the data. This operation should typically only
+// be invoked by users who understand the underlying implementation.
+//
+// A nil start is treated as a key before all keys in the DB.
+// And a nil limit is treated as a key after all keys in the DB.
+// Therefore if both are nil then it will compact entire DB.
+func (db *Database) Compact(start []byte, limit []byte) error {
+	if db.config.ReadOnly {
+		return errReadOnly
+	}
+
+	db.writeLock.Lock()
+	err := db.makeRoomForWrite(true)
+	db.writeLock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := db.flushAll(); err != nil {
+		return err
+	}
+	return db.compactRange(start, limit)
+}
+
+func (db *Database) Close() error {
+	db.lock.Lock()
+	if db.closed.GetValue() {
+		db.lock.Unlock()
+		return database.ErrClosed
+	}
+	db.closed.SetValue(true)
+	db.cond.Broadcast()
+	db.lock.Unlock()
+
+	db.closeOnce.Do(func() {
+		close(db.closeCh)
+	})
+	db.closeWg.Wait()
+
+	// Wait for any in-progress writes or manual compactions to finish.
+	db.writeLock.Lock()
+	defer db.writeLock.Unlock()
+	db.flushLock.Lock()
+	defer db.flushLock.Unlock()
+	db.compactionLock.Lock()
+	defer db.compactionLock.Unlock()
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	errs := wrappers.Errs{}
+	if db.wal != nil {
+		errs.Add(db.wal.close())
+	}
+	db.current.unref()
+	errs.Add(db.dirLock.Close())
+	return errs.Err
+}
+
+func (db *Database) HealthCheck(context.Context) (interface{}, error) {
+	if db.closed.GetValue() {
+		return nil, database.ErrClosed
+	}
+
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	return nil, db.bgErr
+}
+
+// write atomically applies [ops] to the database.
+func (db *Database) write(ops []op) error {
+	if db.config.ReadOnly {
+		return errReadOnly
+	}
+
+	db.writeLock.Lock()
+	defer db.writeLock.Unlock()
+
+	if err := db.makeRoomForWrite(false); err != nil {
+		return err
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+
+	db.lock.RLock()
+	mem := db.mem
+	wal := db.wal
+	seq := db.seq + 1
+	db.lock.RUnlock()
+
+	db.scratch = encodeBatch(db.scratch[:0], seq, ops)
+	if _, err := wal.write(db.scratch); err != nil {
+		return err
+	}
+	for i, o := range ops {
+		mem.add(seq+uint64(i), o.kind, o.key, o.value)
+	}
+
+	// Publish the writes to readers only once the entire batch has been
+	// applied.
+	db.lock.Lock()
+	db.seq = seq + uint64(len(ops)) - 1
+	db.lock.Unlock()
+	return nil
+}
+
+// makeRoomForWrite ensures that the current memtable has room for more writes.
+// If the memtable is full, it is rotated out to be flushed. If too many
+// memtables or level 0 tables are waiting on background work, the write is
+// delayed. If [force] is true, the memtable is rotated if it is non-empty.
+//
+// Assumes [writeLock] is held.
+func (db *Database) makeRoomForWrite(force bool) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	var stallStart time.Time
+	defer func() {
+		if !stallStart.IsZero() {
+			atomic.AddInt64(&db.stats.writeDelayDuration, int64(time.Since(stallStart)))
+			atomic.StoreInt32(&db.stats.writePaused, 0)
+		}
+	}()
+
+	for {
+		switch {
+		case db.closed.GetValue():
+			return database.ErrClosed
+		case db.bgErr != nil:
+			return db.bgErr
+		case !force && db.mem.approximateSize() < db.config.WriteBuffer:
+			return nil
+		case force && db.mem.empty():
+			return nil
+		case len(db.imm) >= db.config.MaxImmutableMemtables ||
+			len(db.current.levels[0]) >= db.config.L0StopWritesTrigger:
+			if stallStart.IsZero() {
+				stallStart = time.Now()
+				atomic.AddUint64(&db.stats.writeDelayCount, 1)
+				atomic.StoreInt32(&db.stats.writePaused, 1)
+			}
+			db.cond.Wait()
+		default:
+			logNum := db.newFileNum()
+			wal, err := newLogWriter(logFileName(db.dir, logNum), db.config.Sync)
+			if err != nil {
+				return err
+			}
+			if err := db.wal.close(); err != nil {
+				_ = wal.close()
+				return err
+			}
+			db.wal = wal
+			db.imm = append([]*memtable{db.mem}, db.imm...)
+			db.mem = newMemtable(logNum)
+			force = false
+			db.cond.Broadcast()
+		}
+	}
+}
+
+// newFileNum returns a new, unique file number.
+//
+// Assumes [lock] is held or that the database is being opened.
+func (db *Database) newFileNum() uint64 {
+	num := db.nextFileNum
+	db.nextFileNum++
+	return num
+}
+
+// installVersion persists [v] along with [logNum] to the manifest and makes it
+// the current version. The reference to [v] is transferred to the database.
+//
+// Assumes [lock] is held or that the database is being opened.
+func (db *Database) installVersion(v *version, logNum uint64) error {
+	err := writeManifest(db.dir, &manifest{
+		NextFileNum: db.nextFileNum,
+		LogNum:      logNum,
+		Levels:      v.tableMetas(),
+	})
+	if err != nil {
+		return err
+	}
+	oldVersion := db.current
+	db.current = v
+	oldVersion.unref()
+	return nil
+}
+
+// maxBytesForLevel returns the size that [level] may grow to before it is
+// compacted into the next level.
+func (db *Database) maxBytesForLevel(level int) uint64 {
+	size := float64(db.config.BaseLevelSize)
+	for ; level > 1; level-- {
+		size *= db.config.LevelSizeMultiplier
+	}
+	if size >= math.MaxUint64 {
+		return math.MaxUint64
+	}
+	return uint64(size)
+}
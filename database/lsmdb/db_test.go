@@ -0,0 +1,385 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package lsmdb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/utils/logging"
+	"github.com/coinflect/coinflectchain/utils/perms"
+)
+
+// smallConfig forces frequent flushes and compactions.
+var smallConfig = []byte(`{"writeBuffer":1024,"blockSize":128,"targetFileSize":2048,"baseLevelSize":8192,"l0CompactionTrigger":2}`)
+
+func TestInterface(t *testing.T) {
+	for _, config := range [][]byte{nil, smallConfig} {
+		for _, test := range database.Tests {
+			folder := t.TempDir()
+			db, err := New(folder, config, logging.NoLog{}, "", prometheus.NewRegistry())
+			if err != nil {
+				t.Fatalf("lsmdb.New(%q, logging.NoLog{}) errored with %s", folder, err)
+			}
+
+			defer db.Close()
+
+			test(t, db)
+
+			// The database may have been closed by the test, so we don't care if it
+			// errors here.
+			_ = db.Close()
+		}
+	}
+}
+
+func TestSnapshotInterface(t *testing.T) {
+	for _, config := range [][]byte{nil, smallConfig} {
+		for _, test := range database.SnapshotTests {
+			folder := t.TempDir()
+			db, err := New(folder, config, logging.NoLog{}, "", prometheus.NewRegistry())
+			if err != nil {
+				t.Fatalf("lsmdb.New(%q, logging.NoLog{}) errored with %s", folder, err)
+			}
+
+			defer db.Close()
+
+			test(t, db)
+
+			// The database may have been closed by the test, so we don't care if it
+			// errors here.
+			_ = db.Close()
+		}
+	}
+}
+
+func TestSnapshotSurvivesCompaction(t *testing.T) {
+	require := require.New(t)
+
+	db, err := New(t.TempDir(), smallConfig, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	defer db.Close()
+
+	const numKeys = 500
+	for i := 0; i < numKeys; i++ {
+		key := []byte(fmt.Sprintf("key-%05d", i))
+		require.NoError(db.Put(key, key))
+	}
+
+	snapshot, err := database.NewSnapshot(db)
+	require.NoError(err)
+	defer snapshot.Release()
+
+	for i := 0; i < numKeys; i++ {
+		key := []byte(fmt.Sprintf("key-%05d", i))
+		require.NoError(db.Delete(key))
+	}
+	require.NoError(db.Compact(nil, nil))
+
+	isEmpty, err := database.IsEmpty(db)
+	require.NoError(err)
+	require.True(isEmpty)
+
+	count, err := database.Count(snapshot)
+	require.NoError(err)
+	require.Equal(numKeys, count)
+
+	for i := 0; i < numKeys; i++ {
+		key := []byte(fmt.Sprintf("key-%05d", i))
+		value, err := snapshot.Get(key)
+		require.NoError(err)
+		require.Equal(key, value)
+	}
+}
+
+func TestReopen(t *testing.T) {
+	require := require.New(t)
+
+	folder := t.TempDir()
+	db, err := New(folder, smallConfig, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	const numKeys = 1000
+	for i := 0; i < numKeys; i++ {
+		key := []byte(fmt.Sprintf("key-%05d", i))
+		require.NoError(db.Put(key, key))
+	}
+	for i := 0; i < numKeys; i += 2 {
+		key := []byte(fmt.Sprintf("key-%05d", i))
+		require.NoError(db.Delete(key))
+	}
+	require.NoError(db.Close())
+
+	db, err = New(folder, smallConfig, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	defer db.Close()
+
+	for i := 0; i < numKeys; i++ {
+		key := []byte(fmt.Sprintf("key-%05d", i))
+		value, err := db.Get(key)
+		if i%2 == 0 {
+			require.Equal(database.ErrNotFound, err)
+			continue
+		}
+		require.NoError(err)
+		require.Equal(key, value)
+	}
+
+	count, err := database.Count(db)
+	require.NoError(err)
+	require.Equal(numKeys/2, count)
+}
+
+func TestCompactDropsDeletedKeys(t *testing.T) {
+	require := require.New(t)
+
+	db, err := New(t.TempDir(), smallConfig, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	defer db.Close()
+
+	const numKeys = 500
+	for i := 0; i < numKeys; i++ {
+		key := []byte(fmt.Sprintf("key-%05d", i))
+		require.NoError(db.Put(key, key))
+	}
+	for i := 0; i < numKeys; i++ {
+		key := []byte(fmt.Sprintf("key-%05d", i))
+		require.NoError(db.Delete(key))
+	}
+	require.NoError(db.Compact(nil, nil))
+
+	lsm := db.(*Database)
+	lsm.lock.RLock()
+	numTables := 0
+	for _, tables := range lsm.current.levels {
+		numTables += len(tables)
+	}
+	lsm.lock.RUnlock()
+	require.Zero(numTables)
+
+	isEmpty, err := database.IsEmpty(db)
+	require.NoError(err)
+	require.True(isEmpty)
+}
+
+func FuzzInterface(f *testing.F) {
+	for _, test := range database.FuzzTests {
+		folder := f.TempDir()
+		db, err := New(folder, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+		if err != nil {
+			require.NoError(f, err)
+		}
+
+		defer db.Close()
+
+		test(f, db)
+
+		// The database may have been closed by the test, so we don't care if it
+		// errors here.
+		_ = db.Close()
+	}
+}
+
+func BenchmarkInterface(b *testing.B) {
+	for _, size := range database.BenchmarkSizes {
+		keys, values := database.SetupBenchmark(b, size[0], size[1], size[2])
+		for _, bench := range database.Benchmarks {
+			folder := b.TempDir()
+
+			db, err := New(folder, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			bench(b, db, "lsmdb", keys, values)
+
+			// The database may have been closed by the test, so we don't care if it
+			// errors here.
+			_ = db.Close()
+		}
+	}
+}
+
+func TestRefusesForeignDirectory(t *testing.T) {
+	require := require.New(t)
+
+	// A leveldb directory uses the same log file names as lsmdb.
+	folder := t.TempDir()
+	for _, name := range []string{"CURRENT", "LOCK", "MANIFEST-000002", "000003.log"} {
+		require.NoError(os.WriteFile(filepath.Join(folder, name), []byte{1}, perms.ReadWrite))
+	}
+	_, err := New(folder, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.ErrorIs(err, errForeignFiles)
+
+	// The log wasn't removed.
+	_, err = os.Stat(filepath.Join(folder, "000003.log"))
+	require.NoError(err)
+
+	// Logs without a manifest weren't written by lsmdb either.
+	folder = t.TempDir()
+	require.NoError(os.WriteFile(filepath.Join(folder, "000003.log"), []byte{1}, perms.ReadWrite))
+	_, err = New(folder, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.ErrorIs(err, errNoManifest)
+}
+
+func TestReplayLog(t *testing.T) {
+	writeLog := func(t *testing.T, path string, numBatches int) []int64 {
+		w, err := newLogWriter(path, false)
+		require.NoError(t, err)
+		offsets := make([]int64, 0, numBatches)
+		offset := int64(0)
+		for i := 0; i < numBatches; i++ {
+			key := []byte(fmt.Sprintf("key-%05d", i))
+			n, err := w.write(encodeBatch(nil, uint64(i+1), []op{{kind: kindPut, key: key, value: key}}))
+			require.NoError(t, err)
+			offsets = append(offsets, offset)
+			offset += int64(n)
+		}
+		require.NoError(t, w.close())
+		return offsets
+	}
+	countRecords := func(path string) (int, error) {
+		count := 0
+		err := readLog(path, func([]byte) error {
+			count++
+			return nil
+		})
+		return count, err
+	}
+
+	t.Run("torn final record", func(t *testing.T) {
+		require := require.New(t)
+
+		path := filepath.Join(t.TempDir(), "000001.log")
+		writeLog(t, path, 3)
+		info, err := os.Stat(path)
+		require.NoError(err)
+		require.NoError(os.Truncate(path, info.Size()-1))
+
+		count, err := countRecords(path)
+		require.NoError(err)
+		require.Equal(2, count)
+	})
+
+	t.Run("corrupt final record", func(t *testing.T) {
+		require := require.New(t)
+
+		path := filepath.Join(t.TempDir(), "000001.log")
+		writeLog(t, path, 3)
+		corruptByte(t, path, -1)
+
+		count, err := countRecords(path)
+		require.NoError(err)
+		require.Equal(2, count)
+	})
+
+	t.Run("corrupt middle record", func(t *testing.T) {
+		require := require.New(t)
+
+		path := filepath.Join(t.TempDir(), "000001.log")
+		offsets := writeLog(t, path, 3)
+		corruptByte(t, path, offsets[1]+logRecordHeaderSize)
+
+		_, err := countRecords(path)
+		require.ErrorIs(err, errCorruptLog)
+	})
+}
+
+// corruptByte flips a byte of the file at [path]. A negative [offset] is
+// relative to the end of the file.
+func corruptByte(t *testing.T, path string, offset int64) {
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	if offset < 0 {
+		offset += int64(len(b))
+	}
+	b[offset] ^= 0xff
+	require.NoError(t, os.WriteFile(path, b, perms.ReadWrite))
+}
+
+func TestReadOnly(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	db, err := New(dir, smallConfig, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	for i := 0; i < 100; i++ {
+		key := []byte(fmt.Sprintf("key-%05d", i))
+		require.NoError(db.Put(key, key))
+	}
+	require.NoError(db.Close())
+
+	listFiles := func() map[string]int64 {
+		entries, err := os.ReadDir(dir)
+		require.NoError(err)
+		files := make(map[string]int64, len(entries))
+		for _, entry := range entries {
+			info, err := entry.Info()
+			require.NoError(err)
+			files[entry.Name()] = info.Size()
+		}
+		return files
+	}
+	files := listFiles()
+
+	db, err = New(dir, []byte(`{"readOnly":true}`), logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	for i := 0; i < 100; i++ {
+		key := []byte(fmt.Sprintf("key-%05d", i))
+		value, err := db.Get(key)
+		require.NoError(err)
+		require.Equal(key, value)
+	}
+	require.ErrorIs(db.Put([]byte("key"), nil), errReadOnly)
+	require.ErrorIs(db.Compact(nil, nil), errReadOnly)
+	require.NoError(db.Close())
+
+	// None of the files were modified
+	require.Equal(files, listFiles())
+
+	// Missing databases aren't created
+	missingDir := filepath.Join(dir, "missing")
+	_, err = New(missingDir, []byte(`{"readOnly":true}`), logging.NoLog{}, "", prometheus.NewRegistry())
+	require.Error(err)
+	_, err = os.Stat(missingDir)
+	require.ErrorIs(err, os.ErrNotExist)
+}
+
+func TestDirectoryLock(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	db, err := New(dir, smallConfig, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	// The directory can't be opened again while it's being written to
+	_, err = New(dir, smallConfig, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.ErrorIs(err, errLocked)
+	_, err = New(dir, []byte(`{"readOnly":true}`), logging.NoLog{}, "", prometheus.NewRegistry())
+	require.ErrorIs(err, errLocked)
+	require.NoError(db.Close())
+
+	// Read-only databases share the directory with each other, but not with
+	// writers
+	readOnly1, err := New(dir, []byte(`{"readOnly":true}`), logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	readOnly2, err := New(dir, []byte(`{"readOnly":true}`), logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	_, err = New(dir, smallConfig, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.ErrorIs(err, errLocked)
+	require.NoError(readOnly1.Close())
+	require.NoError(readOnly2.Close())
+
+	db, err = New(dir, smallConfig, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(db.Close())
+}
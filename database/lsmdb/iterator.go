@@ -0,0 +1,191 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package lsmdb
+
+import (
+	"bytes"
+	"sync/atomic"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/utils"
+)
+
+var (
+	_ internalIterator  = (*mergingIterator)(nil)
+	_ database.Iterator = (*iter)(nil)
+)
+
+// internalIterator iterates over a sorted run of entries. Each key is reported
+// at most once by an internalIterator. Deleted keys are reported with
+// [kindDelete] so that they can shadow older versions of the key.
+type internalIterator interface {
+	// next moves the iterator to the next entry. It returns false once the
+	// iterator is exhausted or has errored.
+	next() bool
+	key() []byte
+	kind() kind
+	value() []byte
+	error() error
+	release()
+}
+
+// mergingIterator merges the entries of multiple internal iterators. If the
+// same key is reported by multiple iterators, the entry from the iterator with
+// the smallest index is reported. This means that iterators should be provided
+// in order from the newest data to the oldest.
+type mergingIterator struct {
+	iterators   []internalIterator
+	valid       []bool
+	initialized bool
+	current     int
+	err         error
+}
+
+func newMergingIterator(iterators []internalIterator) *mergingIterator {
+	return &mergingIterator{
+		iterators: iterators,
+		valid:     make([]bool, len(iterators)),
+		current:   -1,
+	}
+}
+
+func (it *mergingIterator) next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if !it.initialized {
+		it.initialized = true
+		for i := range it.iterators {
+			it.advance(i)
+		}
+	} else if it.current >= 0 {
+		currentKey := it.iterators[it.current].key()
+		for i, iterator := range it.iterators {
+			if i != it.current && it.valid[i] && bytes.Equal(iterator.key(), currentKey) {
+				it.advance(i)
+			}
+		}
+		it.advance(it.current)
+	}
+	if it.err != nil {
+		it.current = -1
+		return false
+	}
+
+	it.current = -1
+	for i, iterator := range it.iterators {
+		if !it.valid[i] {
+			continue
+		}
+		if it.current < 0 || bytes.Compare(iterator.key(), it.iterators[it.current].key()) < 0 {
+			it.current = i
+		}
+	}
+	return it.current >= 0
+}
+
+func (it *mergingIterator) advance(i int) {
+	iterator := it.iterators[i]
+	it.valid[i] = iterator.next()
+	if !it.valid[i] {
+		if err := iterator.error(); err != nil && it.err == nil {
+			it.err = err
+		}
+	}
+}
+
+func (it *mergingIterator) key() []byte {
+	return it.iterators[it.current].key()
+}
+
+func (it *mergingIterator) kind() kind {
+	return it.iterators[it.current].kind()
+}
+
+func (it *mergingIterator) value() []byte {
+	return it.iterators[it.current].value()
+}
+
+func (it *mergingIterator) error() error {
+	return it.err
+}
+
+func (it *mergingIterator) release() {
+	for _, iterator := range it.iterators {
+		iterator.release()
+	}
+	it.current = -1
+}
+
+// iter exposes the live keys of a consistent view of the database.
+type iter struct {
+	db       *Database
+	version  *version
+	merged   *mergingIterator
+	prefix   []byte
+	released bool
+
+	key, val []byte
+	err      error
+}
+
+func (it *iter) Next() bool {
+	// Short-circuit and set an error if the underlying database has been closed.
+	if it.db.closed.GetValue() {
+		it.key = nil
+		it.val = nil
+		it.err = database.ErrClosed
+		return false
+	}
+	if it.released {
+		it.key = nil
+		it.val = nil
+		return false
+	}
+
+	for it.merged.next() {
+		key := it.merged.key()
+		if !bytes.HasPrefix(key, it.prefix) {
+			break
+		}
+		if it.merged.kind() == kindDelete {
+			continue
+		}
+		it.key = utils.CopyBytes(key)
+		it.val = utils.CopyBytes(it.merged.value())
+		return true
+	}
+	it.key = nil
+	it.val = nil
+	return false
+}
+
+func (it *iter) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.merged.error()
+}
+
+func (it *iter) Key() []byte {
+	return it.key
+}
+
+func (it *iter) Value() []byte {
+	return it.val
+}
+
+func (it *iter) Release() {
+	if it.released {
+		return
+	}
+	it.released = true
+	it.key = nil
+	it.val = nil
+	it.merged.release()
+	it.version.unref()
+	atomic.AddInt64(&it.db.stats.aliveIterators, -1)
+}
@@ -0,0 +1,15 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package lsmdb
+
+import "errors"
+
+// lockFileName is the file that is locked while the directory is open, so that
+// a database is never opened by multiple processes at once. A database that
+// is being written to holds an exclusive lock, while read-only databases share
+// the lock with each other.
+const lockFileName = "LOCK"
+
+var errLocked = errors.New("database is already open")
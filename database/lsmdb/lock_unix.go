@@ -0,0 +1,34 @@
+//go:build !windows
+// +build !windows
+
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package lsmdb
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+
+	"github.com/coinflect/coinflectchain/utils/perms"
+)
+
+// lockFile locks the file at [path] until the returned closer is closed.
+func lockFile(path string, readOnly bool) (io.Closer, error) {
+	flag, how := os.O_RDWR|os.O_CREATE, syscall.LOCK_EX
+	if readOnly {
+		flag, how = os.O_RDONLY|os.O_CREATE, syscall.LOCK_SH
+	}
+	f, err := os.OpenFile(path, flag, perms.ReadWrite)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), how|syscall.LOCK_NB); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("%w: %s", errLocked, err)
+	}
+	return f, nil
+}
@@ -0,0 +1,42 @@
+//go:build windows
+// +build windows
+
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package lsmdb
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+)
+
+// lockFile locks the file at [path] until the returned closer is closed. The
+// file is opened without sharing write access, which Windows enforces between
+// processes.
+func lockFile(path string, readOnly bool) (io.Closer, error) {
+	pathPtr, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+	access, shareMode := uint32(syscall.GENERIC_READ|syscall.GENERIC_WRITE), uint32(0)
+	if readOnly {
+		access, shareMode = syscall.GENERIC_READ, syscall.FILE_SHARE_READ
+	}
+	handle, err := syscall.CreateFile(
+		pathPtr,
+		access,
+		shareMode,
+		nil,
+		syscall.OPEN_ALWAYS,
+		syscall.FILE_ATTRIBUTE_NORMAL,
+		0,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errLocked, err)
+	}
+	return os.NewFile(uintptr(handle), path), nil
+}
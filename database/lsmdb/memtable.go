@@ -0,0 +1,225 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package lsmdb
+
+import (
+	"bytes"
+	"math/rand"
+	"sync"
+
+	"github.com/coinflect/coinflectchain/utils"
+)
+
+const (
+	maxSkipListHeight = 12
+
+	// memtableEntryOverhead is the number of bytes of overhead that is added
+	// to the size of the memtable for each inserted entry.
+	memtableEntryOverhead = 16
+)
+
+type kind byte
+
+const (
+	kindDelete kind = iota
+	kindPut
+)
+
+// node is an entry in the memtable's skip list. Nodes are never removed or
+// modified once inserted, with the exception of their forward pointers.
+type node struct {
+	key   []byte
+	seq   uint64
+	kind  kind
+	value []byte
+	next  []*node
+}
+
+// less returns true if the node should be ordered before the [key, seq] pair.
+// Entries are sorted by key in ascending order and then by sequence number in
+// descending order so that the most recent version of a key is found first.
+func (n *node) less(key []byte, seq uint64) bool {
+	switch bytes.Compare(n.key, key) {
+	case -1:
+		return true
+	case 1:
+		return false
+	default:
+		return n.seq > seq
+	}
+}
+
+// memtable is an in-memory, multi-versioned, sorted write buffer. Every write
+// is tagged with a sequence number so that readers can observe a consistent
+// view of the memtable while writes continue to be applied.
+type memtable struct {
+	lock   sync.RWMutex
+	head   *node
+	height int
+	rand   *rand.Rand
+	size   int
+
+	// logNum is the number of the write-ahead log that contains the writes
+	// applied to this memtable.
+	logNum uint64
+}
+
+func newMemtable(logNum uint64) *memtable {
+	return &memtable{
+		head:   &node{next: make([]*node, maxSkipListHeight)},
+		height: 1,
+		rand:   rand.New(rand.NewSource(int64(logNum))), // #nosec G404
+		logNum: logNum,
+	}
+}
+
+// add inserts a new version of [key]. [key] and [value] are copied.
+func (m *memtable) add(seq uint64, k kind, key, value []byte) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var prev [maxSkipListHeight]*node
+	x := m.head
+	for level := m.height - 1; level >= 0; level-- {
+		for next := x.next[level]; next != nil && next.less(key, seq); next = x.next[level] {
+			x = next
+		}
+		prev[level] = x
+	}
+
+	height := m.randomHeight()
+	if height > m.height {
+		for level := m.height; level < height; level++ {
+			prev[level] = m.head
+		}
+		m.height = height
+	}
+
+	n := &node{
+		key:   utils.CopyBytes(key),
+		seq:   seq,
+		kind:  k,
+		value: utils.CopyBytes(value),
+		next:  make([]*node, height),
+	}
+	for level := 0; level < height; level++ {
+		n.next[level] = prev[level].next[level]
+		prev[level].next[level] = n
+	}
+	m.size += len(key) + len(value) + memtableEntryOverhead
+}
+
+// get returns the most recent version of [key] that has a sequence number no
+// larger than [seq].
+func (m *memtable) get(key []byte, seq uint64) ([]byte, kind, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	n := m.seek(key, seq)
+	if n == nil || !bytes.Equal(n.key, key) {
+		return nil, kindDelete, false
+	}
+	return n.value, n.kind, true
+}
+
+// seek returns the first node that is not ordered before [key, seq].
+//
+// Assumes the lock is held.
+func (m *memtable) seek(key []byte, seq uint64) *node {
+	x := m.head
+	for level := m.height - 1; level >= 0; level-- {
+		for next := x.next[level]; next != nil && next.less(key, seq); next = x.next[level] {
+			x = next
+		}
+	}
+	return x.next[0]
+}
+
+func (m *memtable) approximateSize() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.size
+}
+
+func (m *memtable) empty() bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.head.next[0] == nil
+}
+
+// Assumes the lock is held.
+func (m *memtable) randomHeight() int {
+	height := 1
+	for height < maxSkipListHeight && m.rand.Intn(4) == 0 {
+		height++
+	}
+	return height
+}
+
+// newIterator returns an iterator over the most recent version of every key
+// that is >= [start] that was written with a sequence number <= [seq].
+func (m *memtable) newIterator(start []byte, seq uint64) *memtableIterator {
+	return &memtableIterator{
+		memtable: m,
+		start:    start,
+		seq:      seq,
+	}
+}
+
+var _ internalIterator = (*memtableIterator)(nil)
+
+type memtableIterator struct {
+	memtable    *memtable
+	start       []byte
+	seq         uint64
+	initialized bool
+	node        *node
+}
+
+func (it *memtableIterator) next() bool {
+	m := it.memtable
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	var n *node
+	if !it.initialized {
+		it.initialized = true
+		n = m.seek(it.start, it.seq)
+	} else if it.node != nil {
+		// Skip any older versions of the current key.
+		n = it.node.next[0]
+		for n != nil && bytes.Equal(n.key, it.node.key) {
+			n = n.next[0]
+		}
+	}
+	// Skip any versions that were written after the snapshot was taken.
+	for n != nil && n.seq > it.seq {
+		n = m.seek(n.key, it.seq)
+	}
+	it.node = n
+	return n != nil
+}
+
+func (it *memtableIterator) key() []byte {
+	return it.node.key
+}
+
+func (it *memtableIterator) kind() kind {
+	return it.node.kind
+}
+
+func (it *memtableIterator) value() []byte {
+	return it.node.value
+}
+
+func (*memtableIterator) error() error {
+	return nil
+}
+
+func (it *memtableIterator) release() {
+	it.node = nil
+}
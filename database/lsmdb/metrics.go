@@ -0,0 +1,309 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package lsmdb
+
+import (
+	"strconv"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/coinflect/coinflectchain/utils/wrappers"
+)
+
+var levelLabels = []string{"level"}
+
+// stats are updated atomically as the database operates and are periodically
+// reported through [metrics].
+type stats struct {
+	writeDelayCount    uint64
+	writeDelayDuration int64
+	writePaused        int32
+
+	aliveIterators int64
+	openTables     int64
+
+	// tableWrite is the number of bytes written into tables.
+	tableWrite uint64
+	// tableRead is the number of bytes read from tables.
+	tableRead uint64
+
+	memCompactions       uint64
+	level0Compactions    uint64
+	nonLevel0Compactions uint64
+
+	levelDurations [numLevels]int64
+	levelReads     [numLevels]uint64
+	levelWrites    [numLevels]uint64
+}
+
+// dbStats is a point-in-time copy of the database's statistics.
+type dbStats struct {
+	writeDelayCount    uint64
+	writeDelayDuration int64
+	writePaused        bool
+
+	aliveIterators int64
+	openTables     int64
+
+	ioWrite uint64
+	ioRead  uint64
+
+	memCompactions       uint64
+	level0Compactions    uint64
+	nonLevel0Compactions uint64
+
+	levelTableCounts [numLevels]int
+	levelSizes       [numLevels]uint64
+	levelDurations   [numLevels]int64
+	levelReads       [numLevels]uint64
+	levelWrites      [numLevels]uint64
+}
+
+type metrics struct {
+	// total number of writes that have been delayed due to compaction
+	writesDelayedCount prometheus.Counter
+	// total amount of time (in ns) that writes that have been delayed due to
+	// compaction
+	writesDelayedDuration prometheus.Counter
+	// set to 1 if there is currently at least one write that is being delayed
+	// due to compaction
+	writeIsDelayed prometheus.Gauge
+
+	// number of currently alive iterators
+	aliveIterators prometheus.Gauge
+
+	// total amount of data written
+	ioWrite prometheus.Counter
+	// total amount of data read
+	ioRead prometheus.Counter
+
+	// current number of open tables
+	openTables prometheus.Gauge
+
+	// number of tables per level
+	levelTableCount *prometheus.GaugeVec
+	// size of each level
+	levelSize *prometheus.GaugeVec
+	// amount of time spent compacting each level
+	levelDuration *prometheus.CounterVec
+	// amount of bytes read while compacting each level
+	levelReads *prometheus.CounterVec
+	// amount of bytes written while compacting each level
+	levelWrites *prometheus.CounterVec
+
+	// total number memory compactions performed
+	memCompactions prometheus.Counter
+	// total number of level 0 compactions performed
+	level0Compactions prometheus.Counter
+	// total number of non-level 0 compactions performed
+	nonLevel0Compactions prometheus.Counter
+
+	priorStats, currentStats *dbStats
+}
+
+func newMetrics(namespace string, reg prometheus.Registerer) (metrics, error) {
+	m := metrics{
+		writesDelayedCount: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "writes_delayed",
+			Help:      "number of cumulative writes that have been delayed due to compaction",
+		}),
+		writesDelayedDuration: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "writes_delayed_duration",
+			Help:      "amount of time (in ns) that writes have been delayed due to compaction",
+		}),
+		writeIsDelayed: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "write_delayed",
+			Help:      "1 if there is currently a write that is being delayed due to compaction",
+		}),
+
+		aliveIterators: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "alive_iterators",
+			Help:      "number of currently alive iterators",
+		}),
+
+		ioWrite: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "io_write",
+			Help:      "cumulative amount of io write during flushes and compaction",
+		}),
+		ioRead: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "io_read",
+			Help:      "cumulative amount of io read from tables",
+		}),
+
+		openTables: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "open_tables",
+			Help:      "number of currently opened tables",
+		}),
+
+		levelTableCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "table_count",
+				Help:      "number of tables allocated by level",
+			},
+			levelLabels,
+		),
+		levelSize: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "size",
+				Help:      "amount of bytes allocated by level",
+			},
+			levelLabels,
+		),
+		levelDuration: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "duration",
+				Help:      "amount of time (in ns) spent in compaction by level",
+			},
+			levelLabels,
+		),
+		levelReads: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "reads",
+				Help:      "amount of bytes read during compaction by level",
+			},
+			levelLabels,
+		),
+		levelWrites: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "writes",
+				Help:      "amount of bytes written during compaction by level",
+			},
+			levelLabels,
+		),
+
+		memCompactions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "mem_comps",
+			Help:      "total number of memory compactions performed",
+		}),
+		level0Compactions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "level_0_comps",
+			Help:      "total number of level 0 compactions performed",
+		}),
+		nonLevel0Compactions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "non_level_0_comps",
+			Help:      "total number of non-level 0 compactions performed",
+		}),
+
+		priorStats:   &dbStats{},
+		currentStats: &dbStats{},
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		reg.Register(m.writesDelayedCount),
+		reg.Register(m.writesDelayedDuration),
+		reg.Register(m.writeIsDelayed),
+
+		reg.Register(m.aliveIterators),
+
+		reg.Register(m.ioWrite),
+		reg.Register(m.ioRead),
+
+		reg.Register(m.openTables),
+
+		reg.Register(m.levelTableCount),
+		reg.Register(m.levelSize),
+		reg.Register(m.levelDuration),
+		reg.Register(m.levelReads),
+		reg.Register(m.levelWrites),
+
+		reg.Register(m.memCompactions),
+		reg.Register(m.level0Compactions),
+		reg.Register(m.nonLevel0Compactions),
+	)
+	return m, errs.Err
+}
+
+// fillStats populates [s] with the current statistics of the database.
+func (db *Database) fillStats(s *dbStats) {
+	s.writeDelayCount = atomic.LoadUint64(&db.stats.writeDelayCount)
+	s.writeDelayDuration = atomic.LoadInt64(&db.stats.writeDelayDuration)
+	s.writePaused = atomic.LoadInt32(&db.stats.writePaused) == 1
+
+	s.aliveIterators = atomic.LoadInt64(&db.stats.aliveIterators)
+	s.openTables = atomic.LoadInt64(&db.stats.openTables)
+
+	s.ioWrite = atomic.LoadUint64(&db.stats.tableWrite)
+	s.ioRead = atomic.LoadUint64(&db.stats.tableRead)
+
+	s.memCompactions = atomic.LoadUint64(&db.stats.memCompactions)
+	s.level0Compactions = atomic.LoadUint64(&db.stats.level0Compactions)
+	s.nonLevel0Compactions = atomic.LoadUint64(&db.stats.nonLevel0Compactions)
+
+	db.lock.RLock()
+	for level, tables := range db.current.levels {
+		s.levelTableCounts[level] = len(tables)
+		s.levelSizes[level] = db.current.levelSize(level)
+	}
+	db.lock.RUnlock()
+
+	for level := 0; level < numLevels; level++ {
+		s.levelDurations[level] = atomic.LoadInt64(&db.stats.levelDurations[level])
+		s.levelReads[level] = atomic.LoadUint64(&db.stats.levelReads[level])
+		s.levelWrites[level] = atomic.LoadUint64(&db.stats.levelWrites[level])
+	}
+}
+
+func (db *Database) updateMetrics() {
+	metrics := &db.metrics
+
+	priorStats := metrics.priorStats
+	currentStats := metrics.currentStats
+
+	// Retrieve the database stats
+	db.fillStats(currentStats)
+
+	metrics.writesDelayedCount.Add(float64(currentStats.writeDelayCount - priorStats.writeDelayCount))
+	metrics.writesDelayedDuration.Add(float64(currentStats.writeDelayDuration - priorStats.writeDelayDuration))
+	if currentStats.writePaused {
+		metrics.writeIsDelayed.Set(1)
+	} else {
+		metrics.writeIsDelayed.Set(0)
+	}
+
+	metrics.aliveIterators.Set(float64(currentStats.aliveIterators))
+
+	metrics.ioWrite.Add(float64(currentStats.ioWrite - priorStats.ioWrite))
+	metrics.ioRead.Add(float64(currentStats.ioRead - priorStats.ioRead))
+
+	metrics.openTables.Set(float64(currentStats.openTables))
+
+	for level := 0; level < numLevels; level++ {
+		levelStr := strconv.Itoa(level)
+		metrics.levelTableCount.WithLabelValues(levelStr).Set(float64(currentStats.levelTableCounts[level]))
+		metrics.levelSize.WithLabelValues(levelStr).Set(float64(currentStats.levelSizes[level]))
+		metrics.levelDuration.WithLabelValues(levelStr).Add(float64(currentStats.levelDurations[level] - priorStats.levelDurations[level]))
+		metrics.levelReads.WithLabelValues(levelStr).Add(float64(currentStats.levelReads[level] - priorStats.levelReads[level]))
+		metrics.levelWrites.WithLabelValues(levelStr).Add(float64(currentStats.levelWrites[level] - priorStats.levelWrites[level]))
+	}
+
+	metrics.memCompactions.Add(float64(currentStats.memCompactions - priorStats.memCompactions))
+	metrics.level0Compactions.Add(float64(currentStats.level0Compactions - priorStats.level0Compactions))
+	metrics.nonLevel0Compactions.Add(float64(currentStats.nonLevel0Compactions - priorStats.nonLevel0Compactions))
+
+	// update the priorStats to update the counters correctly next time this
+	// method is called
+	metrics.priorStats = currentStats
+
+	// update currentStats to a pre-allocated stats struct. This avoids
+	// performing memory allocations for each update
+	metrics.currentStats = priorStats
+}
@@ -0,0 +1,194 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package lsmdb
+
+import (
+	"bytes"
+	"sync"
+	"sync/atomic"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/nodb"
+	"github.com/coinflect/coinflectchain/utils"
+)
+
+var _ database.Snapshot = (*snapshot)(nil)
+
+// readState is a consistent view of the database. Memtables are never
+// modified below [seq] and the tables of [version] are immutable, so the view
+// remains valid while the database continues to be written to.
+type readState struct {
+	mem     *memtable
+	imm     []*memtable
+	version *version
+	seq     uint64
+}
+
+// acquireReadState returns the current view of the database. The returned
+// state holds a reference to its version that must be released.
+func (db *Database) acquireReadState() (*readState, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed.GetValue() {
+		return nil, database.ErrClosed
+	}
+	db.current.ref()
+	return &readState{
+		mem:     db.mem,
+		imm:     db.imm,
+		version: db.current,
+		seq:     db.seq,
+	}, nil
+}
+
+func (s *readState) release() {
+	s.version.unref()
+}
+
+// get searches the view for [key], from the newest data to the oldest. The
+// returned value must not be modified.
+func (s *readState) get(key []byte) ([]byte, error) {
+	if value, k, ok := s.mem.get(key, s.seq); ok {
+		return foundValue(value, k)
+	}
+	for _, m := range s.imm {
+		if value, k, ok := m.get(key, s.seq); ok {
+			return foundValue(value, k)
+		}
+	}
+	value, k, ok, err := s.version.get(key)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return foundValue(value, k)
+	}
+	return nil, database.ErrNotFound
+}
+
+// newIterator returns an iterator over the view. The iterator holds its own
+// reference to the version, so the view may be released before the iterator.
+func (s *readState) newIterator(db *Database, start, prefix []byte) database.Iterator {
+	lowerBound := prefix
+	if bytes.Compare(start, prefix) == 1 {
+		lowerBound = start
+	}
+	lowerBound = utils.CopyBytes(lowerBound)
+
+	v := s.version
+	iterators := make([]internalIterator, 0, 1+len(s.imm)+len(v.levels[0])+numLevels-1)
+	iterators = append(iterators, s.mem.newIterator(lowerBound, s.seq))
+	for _, m := range s.imm {
+		iterators = append(iterators, m.newIterator(lowerBound, s.seq))
+	}
+	for _, t := range v.levels[0] {
+		if t.overlaps(lowerBound, nil) {
+			iterators = append(iterators, t.newIterator(lowerBound))
+		}
+	}
+	for _, tables := range v.levels[1:] {
+		if len(tables) > 0 {
+			iterators = append(iterators, newLevelIterator(tables, lowerBound))
+		}
+	}
+
+	v.ref()
+	atomic.AddInt64(&db.stats.aliveIterators, 1)
+	return &iter{
+		db:      db,
+		version: v,
+		merged:  newMergingIterator(iterators),
+		prefix:  utils.CopyBytes(prefix),
+	}
+}
+
+// NewSnapshot returns a read-only view of the current state of the database.
+// The snapshot keeps the memtables and tables it references alive until it is
+// released.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	state, err := db.acquireReadState()
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		db:    db,
+		state: state,
+	}, nil
+}
+
+type snapshot struct {
+	db *Database
+
+	// lock protects [state] from being released while it is being read.
+	lock  sync.RWMutex
+	state *readState
+}
+
+// Has returns if the key was set in the database when the snapshot was taken
+func (s *snapshot) Has(key []byte) (bool, error) {
+	_, err := s.get(key)
+	switch err {
+	case nil:
+		return true, nil
+	case database.ErrNotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// Get returns the value the key mapped to in the database when the snapshot
+// was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	value, err := s.get(key)
+	if err != nil {
+		return nil, err
+	}
+	return utils.CopyBytes(value), nil
+}
+
+func (s *snapshot) get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.state == nil || s.db.closed.GetValue() {
+		return nil, database.ErrClosed
+	}
+	return s.state.get(key)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.state == nil || s.db.closed.GetValue() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return s.state.newIterator(s.db, start, prefix)
+}
+
+func (s *snapshot) Release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.state == nil {
+		return
+	}
+	s.state.release()
+	s.state = nil
+}
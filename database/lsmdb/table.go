@@ -0,0 +1,670 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package lsmdb
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"hash/fnv"
+	"os"
+	"sort"
+	"sync/atomic"
+
+	"github.com/coinflect/coinflectchain/cache"
+	"github.com/coinflect/coinflectchain/utils/perms"
+)
+
+// Table layout:
+//
+//	[data block 0]...[data block n][filter][index][footer]
+//
+// Each data block is a sequence of entries followed by a crc32 checksum of the
+// entries. An entry is encoded as:
+//
+//	uvarint(len(key)) | key | kind | uvarint(len(value)) | value
+//
+// The index contains, for every data block, the last key stored in the block
+// along with the block's offset and length. The footer stores the offset and
+// length of the filter and the index followed by a magic number.
+const (
+	tableMagic      uint64 = 0x6c736d6462746162 // "lsmdbtab"
+	tableFooterSize        = 5 * 8
+	checksumSize           = 4
+
+	// numFilterHashes is the number of bits that are set in the bloom filter
+	// for every key.
+	numFilterHashes = 7
+)
+
+var (
+	errCorruptTable = errors.New("corrupt table")
+	errBadChecksum  = errors.New("checksum mismatch")
+
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+)
+
+type blockHandle struct {
+	lastKey []byte
+	offset  uint64
+	length  uint64
+}
+
+// tableWriter writes a new table file. Entries must be added in strictly
+// increasing key order.
+type tableWriter struct {
+	file   *os.File
+	writer *bufio.Writer
+
+	blockSize    int
+	bitsPerKey   int
+	offset       uint64
+	block        []byte
+	lastKey      []byte
+	numEntries   int
+	keyHashes    []uint64
+	index        []blockHandle
+	smallest     []byte
+	scratchBytes [binary.MaxVarintLen64]byte
+}
+
+func newTableWriter(path string, blockSize int, bitsPerKey int) (*tableWriter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perms.ReadWrite)
+	if err != nil {
+		return nil, err
+	}
+	return &tableWriter{
+		file:       file,
+		writer:     bufio.NewWriter(file),
+		blockSize:  blockSize,
+		bitsPerKey: bitsPerKey,
+	}, nil
+}
+
+func (w *tableWriter) add(key []byte, k kind, value []byte) error {
+	if w.numEntries == 0 {
+		w.smallest = append([]byte(nil), key...)
+	}
+	w.block = w.appendUvarint(w.block, uint64(len(key)))
+	w.block = append(w.block, key...)
+	w.block = append(w.block, byte(k))
+	w.block = w.appendUvarint(w.block, uint64(len(value)))
+	w.block = append(w.block, value...)
+	w.lastKey = append(w.lastKey[:0], key...)
+	w.keyHashes = append(w.keyHashes, hashKey(key))
+	w.numEntries++
+
+	if len(w.block) >= w.blockSize {
+		return w.flushBlock()
+	}
+	return nil
+}
+
+// estimatedSize returns the number of bytes that have been added to the table
+// so far.
+func (w *tableWriter) estimatedSize() uint64 {
+	return w.offset + uint64(len(w.block))
+}
+
+func (w *tableWriter) flushBlock() error {
+	if len(w.block) == 0 {
+		return nil
+	}
+	w.block = appendUint32(w.block, crc32.Checksum(w.block, crcTable))
+	if _, err := w.writer.Write(w.block); err != nil {
+		return err
+	}
+	w.index = append(w.index, blockHandle{
+		lastKey: append([]byte(nil), w.lastKey...),
+		offset:  w.offset,
+		length:  uint64(len(w.block)),
+	})
+	w.offset += uint64(len(w.block))
+	w.block = w.block[:0]
+	return nil
+}
+
+// finish writes the remaining table metadata, syncs the file and closes it.
+// The returned metadata describes the written table.
+func (w *tableWriter) finish(num uint64) (tableMeta, error) {
+	if err := w.flushBlock(); err != nil {
+		_ = w.file.Close()
+		return tableMeta{}, err
+	}
+
+	filter := buildFilter(w.keyHashes, w.bitsPerKey)
+	filterOffset := w.offset
+	if _, err := w.writer.Write(filter); err != nil {
+		_ = w.file.Close()
+		return tableMeta{}, err
+	}
+	w.offset += uint64(len(filter))
+
+	var index []byte
+	for _, handle := range w.index {
+		index = w.appendUvarint(index, uint64(len(handle.lastKey)))
+		index = append(index, handle.lastKey...)
+		index = w.appendUvarint(index, handle.offset)
+		index = w.appendUvarint(index, handle.length)
+	}
+	index = appendUint32(index, crc32.Checksum(index, crcTable))
+	indexOffset := w.offset
+	if _, err := w.writer.Write(index); err != nil {
+		_ = w.file.Close()
+		return tableMeta{}, err
+	}
+	w.offset += uint64(len(index))
+
+	footer := make([]byte, 0, tableFooterSize)
+	footer = appendUint64(footer, filterOffset)
+	footer = appendUint64(footer, uint64(len(filter)))
+	footer = appendUint64(footer, indexOffset)
+	footer = appendUint64(footer, uint64(len(index)))
+	footer = appendUint64(footer, tableMagic)
+	if _, err := w.writer.Write(footer); err != nil {
+		_ = w.file.Close()
+		return tableMeta{}, err
+	}
+	w.offset += uint64(len(footer))
+
+	if err := w.writer.Flush(); err != nil {
+		_ = w.file.Close()
+		return tableMeta{}, err
+	}
+	if err := w.file.Sync(); err != nil {
+		_ = w.file.Close()
+		return tableMeta{}, err
+	}
+	if err := w.file.Close(); err != nil {
+		return tableMeta{}, err
+	}
+	return tableMeta{
+		Num:      num,
+		Size:     w.offset,
+		Smallest: w.smallest,
+		Largest:  append([]byte(nil), w.lastKey...),
+	}, nil
+}
+
+// abandon closes the file without finishing the table. The caller is
+// responsible for removing the file.
+func (w *tableWriter) abandon() {
+	_ = w.file.Close()
+}
+
+func (w *tableWriter) appendUvarint(b []byte, v uint64) []byte {
+	n := binary.PutUvarint(w.scratchBytes[:], v)
+	return append(b, w.scratchBytes[:n]...)
+}
+
+// tableMeta is the persisted description of a table.
+type tableMeta struct {
+	Num      uint64 `json:"num"`
+	Size     uint64 `json:"size"`
+	Smallest []byte `json:"smallest"`
+	Largest  []byte `json:"largest"`
+}
+
+// overlaps returns true if the table may contain keys in [start, limit]. A nil
+// [start] or [limit] is treated as unbounded.
+func (m *tableMeta) overlaps(start, limit []byte) bool {
+	if start != nil && bytes.Compare(m.Largest, start) < 0 {
+		return false
+	}
+	if limit != nil && bytes.Compare(m.Smallest, limit) > 0 {
+		return false
+	}
+	return true
+}
+
+// table is an open, immutable, sorted table file.
+type table struct {
+	tableMeta
+
+	path       string
+	file       *os.File
+	index      []blockHandle
+	filter     []byte
+	blockCache *cache.LRU
+
+	// refs is the number of versions that currently reference this table.
+	refs int32
+	// obsolete is set once the table is no longer part of the current version.
+	// Obsolete tables are removed from disk once they are no longer
+	// referenced.
+	obsolete int32
+	// stats is updated with the number of bytes read from the table file.
+	stats *stats
+}
+
+type blockCacheKey struct {
+	num    uint64
+	offset uint64
+}
+
+func openTable(path string, meta tableMeta, blockCache *cache.LRU, s *stats) (*table, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	t := &table{
+		tableMeta:  meta,
+		path:       path,
+		file:       file,
+		blockCache: blockCache,
+		stats:      s,
+	}
+	if err := t.readMetadata(); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to open table %s: %w", path, err)
+	}
+	atomic.AddInt64(&s.openTables, 1)
+	return t, nil
+}
+
+func (t *table) readMetadata() error {
+	if t.Size < tableFooterSize {
+		return errCorruptTable
+	}
+	footer := make([]byte, tableFooterSize)
+	if _, err := t.file.ReadAt(footer, int64(t.Size-tableFooterSize)); err != nil {
+		return err
+	}
+	filterOffset := binary.LittleEndian.Uint64(footer[0:])
+	filterLen := binary.LittleEndian.Uint64(footer[8:])
+	indexOffset := binary.LittleEndian.Uint64(footer[16:])
+	indexLen := binary.LittleEndian.Uint64(footer[24:])
+	if binary.LittleEndian.Uint64(footer[32:]) != tableMagic {
+		return errCorruptTable
+	}
+	if filterOffset+filterLen > t.Size || indexOffset+indexLen > t.Size || indexLen < checksumSize {
+		return errCorruptTable
+	}
+
+	t.filter = make([]byte, filterLen)
+	if _, err := t.file.ReadAt(t.filter, int64(filterOffset)); err != nil {
+		return err
+	}
+
+	index := make([]byte, indexLen)
+	if _, err := t.file.ReadAt(index, int64(indexOffset)); err != nil {
+		return err
+	}
+	index, err := verifyChecksum(index)
+	if err != nil {
+		return err
+	}
+	for len(index) > 0 {
+		keyLen, n := binary.Uvarint(index)
+		if n <= 0 || uint64(len(index)-n) < keyLen {
+			return errCorruptTable
+		}
+		index = index[n:]
+		lastKey := index[:keyLen]
+		index = index[keyLen:]
+
+		offset, n := binary.Uvarint(index)
+		if n <= 0 {
+			return errCorruptTable
+		}
+		index = index[n:]
+
+		length, n := binary.Uvarint(index)
+		if n <= 0 {
+			return errCorruptTable
+		}
+		index = index[n:]
+
+		t.index = append(t.index, blockHandle{
+			lastKey: lastKey,
+			offset:  offset,
+			length:  length,
+		})
+	}
+	return nil
+}
+
+func (t *table) ref() {
+	atomic.AddInt32(&t.refs, 1)
+}
+
+func (t *table) unref() {
+	if atomic.AddInt32(&t.refs, -1) != 0 {
+		return
+	}
+	_ = t.file.Close()
+	atomic.AddInt64(&t.stats.openTables, -1)
+	if atomic.LoadInt32(&t.obsolete) == 1 {
+		_ = os.Remove(t.path)
+	}
+}
+
+func (t *table) markObsolete() {
+	atomic.StoreInt32(&t.obsolete, 1)
+}
+
+// get returns the entry for [key] if it is contained in this table.
+func (t *table) get(key []byte) ([]byte, kind, bool, error) {
+	if !t.overlaps(key, key) || !filterMayContain(t.filter, hashKey(key)) {
+		return nil, kindDelete, false, nil
+	}
+
+	blockIndex := t.findBlock(key)
+	if blockIndex >= len(t.index) {
+		return nil, kindDelete, false, nil
+	}
+	block, err := t.readBlock(blockIndex)
+	if err != nil {
+		return nil, kindDelete, false, err
+	}
+
+	it := blockIterator{block: block}
+	for it.next() {
+		switch bytes.Compare(it.key, key) {
+		case 0:
+			return it.value, it.kind, true, nil
+		case 1:
+			return nil, kindDelete, false, nil
+		}
+	}
+	return nil, kindDelete, false, it.err
+}
+
+// findBlock returns the index of the first block that may contain keys >=
+// [key].
+func (t *table) findBlock(key []byte) int {
+	return sort.Search(len(t.index), func(i int) bool {
+		return bytes.Compare(t.index[i].lastKey, key) >= 0
+	})
+}
+
+func (t *table) readBlock(i int) ([]byte, error) {
+	handle := t.index[i]
+	cacheKey := blockCacheKey{
+		num:    t.Num,
+		offset: handle.offset,
+	}
+	if t.blockCache != nil {
+		if block, ok := t.blockCache.Get(cacheKey); ok {
+			return block.([]byte), nil
+		}
+	}
+
+	block := make([]byte, handle.length)
+	if _, err := t.file.ReadAt(block, int64(handle.offset)); err != nil {
+		return nil, err
+	}
+	atomic.AddUint64(&t.stats.tableRead, handle.length)
+	block, err := verifyChecksum(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read block at offset %d of table %s: %w", handle.offset, t.path, err)
+	}
+	if t.blockCache != nil {
+		t.blockCache.Put(cacheKey, block)
+	}
+	return block, nil
+}
+
+// newIterator returns an iterator over the entries of the table that are >=
+// [start].
+func (t *table) newIterator(start []byte) *tableIterator {
+	return &tableIterator{
+		table: t,
+		start: start,
+	}
+}
+
+var _ internalIterator = (*tableIterator)(nil)
+
+type tableIterator struct {
+	table       *table
+	start       []byte
+	initialized bool
+	blockIndex  int
+	block       blockIterator
+	err         error
+}
+
+func (it *tableIterator) next() bool {
+	if it.err != nil {
+		return false
+	}
+	if !it.initialized {
+		it.initialized = true
+		it.blockIndex = it.table.findBlock(it.start)
+		if !it.loadBlock() {
+			return false
+		}
+		for it.block.next() {
+			if bytes.Compare(it.block.key, it.start) >= 0 {
+				return true
+			}
+		}
+		if it.block.err != nil {
+			it.err = it.block.err
+			return false
+		}
+	} else if it.block.next() {
+		return true
+	} else if it.block.err != nil {
+		it.err = it.block.err
+		return false
+	}
+
+	for {
+		it.blockIndex++
+		if !it.loadBlock() {
+			return false
+		}
+		if it.block.next() {
+			return true
+		}
+		if it.block.err != nil {
+			it.err = it.block.err
+			return false
+		}
+	}
+}
+
+func (it *tableIterator) loadBlock() bool {
+	if it.blockIndex >= len(it.table.index) {
+		it.block = blockIterator{}
+		return false
+	}
+	block, err := it.table.readBlock(it.blockIndex)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.block = blockIterator{block: block}
+	return true
+}
+
+func (it *tableIterator) key() []byte {
+	return it.block.key
+}
+
+func (it *tableIterator) kind() kind {
+	return it.block.kind
+}
+
+func (it *tableIterator) value() []byte {
+	return it.block.value
+}
+
+func (it *tableIterator) error() error {
+	return it.err
+}
+
+func (it *tableIterator) release() {
+	it.block = blockIterator{}
+	it.blockIndex = len(it.table.index)
+}
+
+// blockIterator decodes the entries of a single data block.
+type blockIterator struct {
+	block []byte
+	key   []byte
+	kind  kind
+	value []byte
+	err   error
+}
+
+func (it *blockIterator) next() bool {
+	if len(it.block) == 0 || it.err != nil {
+		return false
+	}
+
+	keyLen, n := binary.Uvarint(it.block)
+	if n <= 0 || uint64(len(it.block)-n) <= keyLen {
+		it.err = errCorruptTable
+		return false
+	}
+	it.block = it.block[n:]
+	it.key = it.block[:keyLen:keyLen]
+	it.kind = kind(it.block[keyLen])
+	it.block = it.block[keyLen+1:]
+
+	valueLen, n := binary.Uvarint(it.block)
+	if n <= 0 || uint64(len(it.block)-n) < valueLen {
+		it.err = errCorruptTable
+		return false
+	}
+	it.block = it.block[n:]
+	it.value = it.block[:valueLen:valueLen]
+	it.block = it.block[valueLen:]
+	return true
+}
+
+// levelIterator iterates over the non-overlapping, sorted tables of a level
+// other than level 0. Tables are only opened for iteration once they are
+// reached.
+type levelIterator struct {
+	tables  []*table
+	start   []byte
+	current *tableIterator
+	err     error
+}
+
+var _ internalIterator = (*levelIterator)(nil)
+
+func newLevelIterator(tables []*table, start []byte) *levelIterator {
+	i := sort.Search(len(tables), func(i int) bool {
+		return bytes.Compare(tables[i].Largest, start) >= 0
+	})
+	return &levelIterator{
+		tables: tables[i:],
+		start:  start,
+	}
+}
+
+func (it *levelIterator) next() bool {
+	for {
+		if it.current != nil {
+			if it.current.next() {
+				return true
+			}
+			if err := it.current.error(); err != nil {
+				it.err = err
+				return false
+			}
+		}
+		if len(it.tables) == 0 {
+			it.current = nil
+			return false
+		}
+		it.current = it.tables[0].newIterator(it.start)
+		it.tables = it.tables[1:]
+	}
+}
+
+func (it *levelIterator) key() []byte {
+	return it.current.key()
+}
+
+func (it *levelIterator) kind() kind {
+	return it.current.kind()
+}
+
+func (it *levelIterator) value() []byte {
+	return it.current.value()
+}
+
+func (it *levelIterator) error() error {
+	return it.err
+}
+
+func (it *levelIterator) release() {
+	it.tables = nil
+	it.current = nil
+}
+
+func verifyChecksum(b []byte) ([]byte, error) {
+	if len(b) < checksumSize {
+		return nil, errCorruptTable
+	}
+	data := b[:len(b)-checksumSize]
+	expected := binary.LittleEndian.Uint32(b[len(b)-checksumSize:])
+	if crc32.Checksum(data, crcTable) != expected {
+		return nil, errBadChecksum
+	}
+	return data, nil
+}
+
+func hashKey(key []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(key)
+	return h.Sum64()
+}
+
+// buildFilter returns a bloom filter containing the provided key hashes.
+func buildFilter(keyHashes []uint64, bitsPerKey int) []byte {
+	numBits := len(keyHashes) * bitsPerKey
+	if numBits < 64 {
+		numBits = 64
+	}
+	numBytes := (numBits + 7) / 8
+	numBits = numBytes * 8
+
+	filter := make([]byte, numBytes)
+	for _, h := range keyHashes {
+		h1, h2 := uint32(h), uint32(h>>32)
+		for i := uint32(0); i < numFilterHashes; i++ {
+			bit := (h1 + i*h2) % uint32(numBits)
+			filter[bit/8] |= 1 << (bit % 8)
+		}
+	}
+	return filter
+}
+
+func filterMayContain(filter []byte, h uint64) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	numBits := uint32(len(filter) * 8)
+	h1, h2 := uint32(h), uint32(h>>32)
+	for i := uint32(0); i < numFilterHashes; i++ {
+		bit := (h1 + i*h2) % numBits
+		if filter[bit/8]&(1<<(bit%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
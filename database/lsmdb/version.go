@@ -0,0 +1,296 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package lsmdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/coinflect/coinflectchain/utils/perms"
+)
+
+const (
+	// numLevels is the number of levels in the LSM tree. Level 0 contains
+	// tables that were flushed directly from memtables and may overlap. Every
+	// other level contains non-overlapping tables sorted by key.
+	numLevels = 7
+
+	manifestFileName    = "MANIFEST"
+	manifestTmpFileName = "MANIFEST.tmp"
+	logFileExtension    = ".log"
+	tableFileExtension  = ".sst"
+)
+
+var (
+	errUnexpectedLevels = errors.New("unexpected number of levels in manifest")
+	errForeignFiles     = errors.New("directory contains files that weren't written by lsmdb")
+	errNoManifest       = errors.New("directory isn't empty but has no lsmdb manifest")
+)
+
+// manifest is the persisted description of the database's current state.
+type manifest struct {
+	// NextFileNum is the next number to assign to a log or table file.
+	NextFileNum uint64 `json:"nextFileNum"`
+	// LogNum is the number of the oldest log that contains writes that have
+	// not yet been flushed into a table. Logs with smaller numbers can be
+	// removed.
+	LogNum uint64 `json:"logNum"`
+	// Levels contains the tables of every level. Level 0 is sorted from newest
+	// to oldest; every other level is sorted by key.
+	Levels [][]tableMeta `json:"levels"`
+}
+
+func readManifest(dir string) (*manifest, error) {
+	b, err := os.ReadFile(filepath.Join(dir, manifestFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return &manifest{
+			NextFileNum: 1,
+			Levels:      make([][]tableMeta, numLevels),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	m := &manifest{}
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if len(m.Levels) != numLevels {
+		return nil, fmt.Errorf("%w: %d", errUnexpectedLevels, len(m.Levels))
+	}
+	return m, nil
+}
+
+// checkDir verifies that [dir] belongs to lsmdb before any of its files are
+// replayed or removed. A directory written by another database, such as a
+// leveldb directory that uses the same log file names, is refused. If [dir] is
+// empty, an initial manifest is written so that the files created next are
+// recognized as lsmdb's, unless [readOnly] is true.
+func checkDir(dir string, readOnly bool) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var (
+		hasManifest  bool
+		hasDataFiles bool
+		foreign      []string
+	)
+	for _, entry := range entries {
+		name := entry.Name()
+		switch {
+		case name == manifestFileName:
+			hasManifest = true
+		case name == manifestTmpFileName, name == lockFileName:
+		case !entry.IsDir() && isNumberedFile(name):
+			hasDataFiles = true
+		default:
+			foreign = append(foreign, name)
+		}
+	}
+	switch {
+	case len(foreign) > 0:
+		return fmt.Errorf("%w: %s", errForeignFiles, strings.Join(foreign, ", "))
+	case hasManifest:
+		return nil
+	case hasDataFiles:
+		return errNoManifest
+	case readOnly:
+		return nil
+	default:
+		return writeManifest(dir, &manifest{
+			NextFileNum: 1,
+			Levels:      make([][]tableMeta, numLevels),
+		})
+	}
+}
+
+// isNumberedFile returns true if [name] is the name of a log or a table.
+func isNumberedFile(name string) bool {
+	ext := filepath.Ext(name)
+	if ext != logFileExtension && ext != tableFileExtension {
+		return false
+	}
+	_, err := strconv.ParseUint(strings.TrimSuffix(name, ext), 10, 64)
+	return err == nil
+}
+
+// writeManifest atomically replaces the manifest in [dir].
+func writeManifest(dir string, m *manifest) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := filepath.Join(dir, manifestTmpFileName)
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perms.ReadWrite)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, filepath.Join(dir, manifestFileName)); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	// Syncing a directory isn't supported on all platforms, so the error is
+	// intentionally dropped.
+	_ = d.Sync()
+	return d.Close()
+}
+
+func logFileName(dir string, num uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("%06d%s", num, logFileExtension))
+}
+
+func tableFileName(dir string, num uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("%06d%s", num, tableFileExtension))
+}
+
+// version is an immutable snapshot of the tables that make up the database.
+// Readers hold a reference to a version for the duration of their read so that
+// the tables they are using are not removed by a concurrent compaction.
+type version struct {
+	levels [numLevels][]*table
+	refs   int32
+}
+
+func (v *version) ref() {
+	atomic.AddInt32(&v.refs, 1)
+}
+
+func (v *version) unref() {
+	if atomic.AddInt32(&v.refs, -1) != 0 {
+		return
+	}
+	for _, tables := range v.levels {
+		for _, t := range tables {
+			t.unref()
+		}
+	}
+}
+
+// edit returns a new version created by removing [deleted] tables and adding
+// [added] tables to the levels of this version. The returned version has a
+// single reference.
+func (v *version) edit(deleted map[uint64]struct{}, added [numLevels][]*table) *version {
+	newVersion := &version{refs: 1}
+	for level, tables := range v.levels {
+		newTables := make([]*table, 0, len(tables)+len(added[level]))
+		if level == 0 {
+			// Newly added level 0 tables are always newer than the existing
+			// tables.
+			newTables = append(newTables, added[level]...)
+		}
+		for _, t := range tables {
+			if _, ok := deleted[t.Num]; !ok {
+				newTables = append(newTables, t)
+			}
+		}
+		if level != 0 {
+			newTables = append(newTables, added[level]...)
+			sort.Slice(newTables, func(i, j int) bool {
+				return bytes.Compare(newTables[i].Smallest, newTables[j].Smallest) < 0
+			})
+		}
+		for _, t := range newTables {
+			t.ref()
+		}
+		newVersion.levels[level] = newTables
+	}
+	return newVersion
+}
+
+// get searches the tables of this version for [key], from the newest data to
+// the oldest.
+func (v *version) get(key []byte) ([]byte, kind, bool, error) {
+	for _, t := range v.levels[0] {
+		value, k, ok, err := t.get(key)
+		if err != nil || ok {
+			return value, k, ok, err
+		}
+	}
+	for _, tables := range v.levels[1:] {
+		i := sort.Search(len(tables), func(i int) bool {
+			return bytes.Compare(tables[i].Largest, key) >= 0
+		})
+		if i == len(tables) {
+			continue
+		}
+		value, k, ok, err := tables[i].get(key)
+		if err != nil || ok {
+			return value, k, ok, err
+		}
+	}
+	return nil, kindDelete, false, nil
+}
+
+// overlapping returns the tables of [level] that may contain keys in [start,
+// limit].
+func (v *version) overlapping(level int, start, limit []byte) []*table {
+	var tables []*table
+	for _, t := range v.levels[level] {
+		if t.overlaps(start, limit) {
+			tables = append(tables, t)
+		}
+	}
+	return tables
+}
+
+func (v *version) levelSize(level int) uint64 {
+	var size uint64
+	for _, t := range v.levels[level] {
+		size += t.Size
+	}
+	return size
+}
+
+// deepestLevel returns the deepest level that contains at least one table, or
+// 0 if the version is empty.
+func (v *version) deepestLevel() int {
+	for level := numLevels - 1; level > 0; level-- {
+		if len(v.levels[level]) > 0 {
+			return level
+		}
+	}
+	return 0
+}
+
+func (v *version) tableMetas() [][]tableMeta {
+	levels := make([][]tableMeta, numLevels)
+	for level, tables := range v.levels {
+		metas := make([]tableMeta, len(tables))
+		for i, t := range tables {
+			metas[i] = t.tableMeta
+		}
+		levels[level] = metas
+	}
+	return levels
+}
@@ -0,0 +1,185 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package lsmdb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+
+	"github.com/coinflect/coinflectchain/utils/perms"
+)
+
+// Write-ahead log layout:
+//
+// The log is a sequence of records. Each record contains exactly one batch:
+//
+//	crc32(payload) | uint32(len(payload)) | payload
+//
+// The payload is encoded as:
+//
+//	uint64(seq) | uvarint(numOps) | op...
+//
+// where every op is encoded as:
+//
+//	kind | uvarint(len(key)) | key | [uvarint(len(value)) | value]
+//
+// The value is only included for puts.
+const logRecordHeaderSize = 8
+
+var (
+	errCorruptBatch = errors.New("corrupt batch")
+	errCorruptLog   = errors.New("corrupt log record")
+)
+
+type logWriter struct {
+	file *os.File
+	sync bool
+	buf  []byte
+}
+
+func newLogWriter(path string, sync bool) (*logWriter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perms.ReadWrite)
+	if err != nil {
+		return nil, err
+	}
+	return &logWriter{
+		file: file,
+		sync: sync,
+	}, nil
+}
+
+// write appends [payload] to the log as a single record and returns the number
+// of bytes written.
+func (w *logWriter) write(payload []byte) (int, error) {
+	w.buf = appendUint32(w.buf[:0], crc32.Checksum(payload, crcTable))
+	w.buf = appendUint32(w.buf, uint32(len(payload)))
+	w.buf = append(w.buf, payload...)
+	if _, err := w.file.Write(w.buf); err != nil {
+		return 0, err
+	}
+	if w.sync {
+		if err := w.file.Sync(); err != nil {
+			return 0, err
+		}
+	}
+	return len(w.buf), nil
+}
+
+func (w *logWriter) close() error {
+	if err := w.file.Sync(); err != nil {
+		_ = w.file.Close()
+		return err
+	}
+	return w.file.Close()
+}
+
+// readLog calls [f] with the payload of every record in the log at [path]. A
+// torn final record is assumed to be the result of a crash during the write
+// and terminates the replay. Any other corrupted record returns an error, as
+// the records after it would otherwise be silently dropped.
+func readLog(path string, f func(payload []byte) error) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	offset := 0
+	for len(b) >= logRecordHeaderSize {
+		checksum := binary.LittleEndian.Uint32(b)
+		length := binary.LittleEndian.Uint32(b[4:])
+		b = b[logRecordHeaderSize:]
+		if uint64(length) > uint64(len(b)) {
+			return nil
+		}
+		payload := b[:length]
+		b = b[length:]
+		if crc32.Checksum(payload, crcTable) != checksum {
+			if len(b) == 0 {
+				return nil
+			}
+			return fmt.Errorf("%w at offset %d", errCorruptLog, offset)
+		}
+		if err := f(payload); err != nil {
+			return err
+		}
+		offset += logRecordHeaderSize + int(length)
+	}
+	return nil
+}
+
+// encodeBatch appends the log encoding of [ops] to [b].
+func encodeBatch(b []byte, seq uint64, ops []op) []byte {
+	var scratch [binary.MaxVarintLen64]byte
+	appendUvarint := func(b []byte, v uint64) []byte {
+		n := binary.PutUvarint(scratch[:], v)
+		return append(b, scratch[:n]...)
+	}
+
+	b = appendUint64(b, seq)
+	b = appendUvarint(b, uint64(len(ops)))
+	for _, o := range ops {
+		b = append(b, byte(o.kind))
+		b = appendUvarint(b, uint64(len(o.key)))
+		b = append(b, o.key...)
+		if o.kind == kindPut {
+			b = appendUvarint(b, uint64(len(o.value)))
+			b = append(b, o.value...)
+		}
+	}
+	return b
+}
+
+// decodeBatch parses a payload that was encoded with encodeBatch. The returned
+// ops reference [b].
+func decodeBatch(b []byte) (uint64, []op, error) {
+	if len(b) < 8 {
+		return 0, nil, errCorruptBatch
+	}
+	seq := binary.LittleEndian.Uint64(b)
+	b = b[8:]
+
+	numOps, n := binary.Uvarint(b)
+	if n <= 0 {
+		return 0, nil, errCorruptBatch
+	}
+	b = b[n:]
+
+	readBytes := func() ([]byte, bool) {
+		length, n := binary.Uvarint(b)
+		if n <= 0 || uint64(len(b)-n) < length {
+			return nil, false
+		}
+		v := b[n : uint64(n)+length]
+		b = b[uint64(n)+length:]
+		return v, true
+	}
+
+	ops := make([]op, 0, numOps)
+	for i := uint64(0); i < numOps; i++ {
+		if len(b) == 0 {
+			return 0, nil, errCorruptBatch
+		}
+		o := op{kind: kind(b[0])}
+		b = b[1:]
+
+		var ok bool
+		if o.key, ok = readBytes(); !ok {
+			return 0, nil, errCorruptBatch
+		}
+		switch o.kind {
+		case kindPut:
+			if o.value, ok = readBytes(); !ok {
+				return 0, nil, errCorruptBatch
+			}
+		case kindDelete:
+		default:
+			return 0, nil, errCorruptBatch
+		}
+		ops = append(ops, o)
+	}
+	return seq, ops, nil
+}
@@ -14,11 +14,15 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/cachedb"
 	"github.com/coinflect/coinflectchain/database/corruptabledb"
 	"github.com/coinflect/coinflectchain/database/leveldb"
+	"github.com/coinflect/coinflectchain/database/lsmdb"
 	"github.com/coinflect/coinflectchain/database/memdb"
 	"github.com/coinflect/coinflectchain/database/meterdb"
 	"github.com/coinflect/coinflectchain/database/prefixdb"
+	"github.com/coinflect/coinflectchain/database/quotadb"
+	"github.com/coinflect/coinflectchain/database/sealdb"
 	"github.com/coinflect/coinflectchain/utils"
 	"github.com/coinflect/coinflectchain/utils/logging"
 	"github.com/coinflect/coinflectchain/utils/wrappers"
@@ -65,6 +69,21 @@ type Manager interface {
 	// Note: calling this more than once with the same [namespace] will cause a
 	// conflict error for the [registerer].
 	NewCompleteMeterDBManager(namespace string, registerer prometheus.Registerer) (Manager, error)
+
+	// NewCacheDBManager returns a new database manager with the current
+	// database wrapped with a cachedb instance that caches its values in
+	// [cache].
+	NewCacheDBManager(namespace string, registerer prometheus.Registerer, cache *cachedb.Cache) (Manager, error)
+
+	// NewQuotaDBManager returns a new database manager with the current
+	// database wrapped with a quotadb instance that refuses writes once more
+	// than [quota] bytes are stored. If [quota] is 0, writes are never refused.
+	NewQuotaDBManager(namespace string, registerer prometheus.Registerer, quota uint64) (Manager, error)
+
+	// NewSealDBManager returns a new database manager with the current
+	// database wrapped with a sealdb instance whose data encryption keys are
+	// wrapped by [masterKey].
+	NewSealDBManager(masterKey []byte, log logging.Logger) (Manager, error)
 }
 
 type manager struct {
@@ -97,6 +116,27 @@ func NewLevelDB(
 	)
 }
 
+// NewLSMDB creates a database manager of lsmdbs at [filePath] by creating a
+// database instance from each directory with a version <= [currentVersion].
+func NewLSMDB(
+	dbDirPath string,
+	dbConfig []byte,
+	log logging.Logger,
+	currentVersion *version.Semantic,
+	namespace string,
+	reg prometheus.Registerer,
+) (Manager, error) {
+	return new(
+		lsmdb.New,
+		dbDirPath,
+		dbConfig,
+		log,
+		currentVersion,
+		namespace,
+		reg,
+	)
+}
+
 // new creates a database manager at [filePath] by creating a database instance
 // from each directory with a version <= [currentVersion]. If
 // [includePreviousVersions], opens previous database versions and includes them
@@ -304,6 +344,87 @@ func (m *manager) NewCompleteMeterDBManager(namespace string, registerer prometh
 	})
 }
 
+// NewCacheDBManager wraps the current database instance with a cachedb
+// instance. Note: calling this more than once with the same [namespace] will
+// cause a conflict error for the [registerer]
+func (m *manager) NewCacheDBManager(namespace string, registerer prometheus.Registerer, cache *cachedb.Cache) (Manager, error) {
+	currentDB := m.Current()
+	currentCacheDB, err := cachedb.New(namespace, registerer, cache, currentDB.Database)
+	if err != nil {
+		return nil, err
+	}
+	newManager := &manager{
+		databases: make([]*VersionedDatabase, len(m.databases)),
+	}
+	copy(newManager.databases[1:], m.databases[1:])
+	// Overwrite the current database with the cache DB
+	newManager.databases[0] = &VersionedDatabase{
+		Database: currentCacheDB,
+		Version:  currentDB.Version,
+	}
+	return newManager, nil
+}
+
+// NewQuotaDBManager wraps the current database instance with a quotadb
+// instance. Note: calling this more than once with the same [namespace] will
+// cause a conflict error for the [registerer]
+func (m *manager) NewQuotaDBManager(namespace string, registerer prometheus.Registerer, quota uint64) (Manager, error) {
+	currentDB := m.Current()
+	currentQuotaDB, err := quotadb.New(namespace, registerer, quota, currentDB.Database)
+	if err != nil {
+		return nil, err
+	}
+	newManager := &manager{
+		databases: make([]*VersionedDatabase, len(m.databases)),
+	}
+	copy(newManager.databases[1:], m.databases[1:])
+	// Overwrite the current database with the quota DB
+	newManager.databases[0] = &VersionedDatabase{
+		Database: currentQuotaDB,
+		Version:  currentDB.Version,
+	}
+	return newManager, nil
+}
+
+// NewSealDBManager wraps the current database instance with a sealdb
+// instance. Closing the sealdb instance closes the current database too.
+func (m *manager) NewSealDBManager(masterKey []byte, log logging.Logger) (Manager, error) {
+	currentDB := m.Current()
+	currentSealDB, err := sealdb.New(masterKey, currentDB.Database, log)
+	if err != nil {
+		return nil, err
+	}
+	newManager := &manager{
+		databases: make([]*VersionedDatabase, len(m.databases)),
+	}
+	copy(newManager.databases[1:], m.databases[1:])
+	// Overwrite the current database with the seal DB
+	newManager.databases[0] = &VersionedDatabase{
+		Database: &sealedDatabase{
+			Database: currentSealDB,
+			db:       currentDB.Database,
+		},
+		Version: currentDB.Version,
+	}
+	return newManager, nil
+}
+
+// sealedDatabase closes the database it encrypts when it's closed, like the
+// other wrappers of the current database do.
+type sealedDatabase struct {
+	*sealdb.Database
+	db database.Database
+}
+
+func (db *sealedDatabase) Close() error {
+	errs := wrappers.Errs{}
+	errs.Add(
+		db.Database.Close(),
+		db.db.Close(),
+	)
+	return errs.Err
+}
+
 // wrapManager returns a new database manager with each managed database wrapped
 // by the [wrap] function. If an error is returned by wrap, the error is
 // returned immediately. If [wrap] never returns an error, then wrapManager is
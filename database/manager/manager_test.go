@@ -13,11 +13,16 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/cachedb"
 	"github.com/coinflect/coinflectchain/database/leveldb"
 	"github.com/coinflect/coinflectchain/database/memdb"
 	"github.com/coinflect/coinflectchain/database/meterdb"
 	"github.com/coinflect/coinflectchain/database/prefixdb"
+	"github.com/coinflect/coinflectchain/database/quotadb"
+	"github.com/coinflect/coinflectchain/database/sealdb"
 	"github.com/coinflect/coinflectchain/utils/logging"
+	"github.com/coinflect/coinflectchain/utils/units"
 	"github.com/coinflect/coinflectchain/version"
 )
 
@@ -318,6 +323,122 @@ func TestMeterDBManager(t *testing.T) {
 	require.Error(err)
 }
 
+func TestCacheDBManager(t *testing.T) {
+	require := require.New(t)
+
+	registry := prometheus.NewRegistry()
+	cache, err := cachedb.NewCache(units.MiB, "cache", registry)
+	require.NoError(err)
+
+	m := &manager{databases: []*VersionedDatabase{
+		{
+			Database: memdb.New(),
+			Version: &version.Semantic{
+				Major: 2,
+				Minor: 0,
+				Patch: 0,
+			},
+		},
+		{
+			Database: memdb.New(),
+			Version:  version.Semantic1_0_0,
+		},
+	}}
+
+	manager, err := m.NewCacheDBManager("", registry, cache)
+	require.NoError(err)
+
+	dbs := manager.GetDatabases()
+	require.Len(dbs, 2)
+
+	_, ok := dbs[0].Database.(*cachedb.Database)
+	require.True(ok)
+	_, ok = dbs[1].Database.(*cachedb.Database)
+	require.False(ok)
+
+	// Confirm that the error from a name conflict is handled correctly
+	_, err = m.NewCacheDBManager("", registry, cache)
+	require.Error(err)
+}
+
+func TestQuotaDBManager(t *testing.T) {
+	require := require.New(t)
+
+	registry := prometheus.NewRegistry()
+
+	m := &manager{databases: []*VersionedDatabase{
+		{
+			Database: memdb.New(),
+			Version: &version.Semantic{
+				Major: 2,
+				Minor: 0,
+				Patch: 0,
+			},
+		},
+		{
+			Database: memdb.New(),
+			Version:  version.Semantic1_0_0,
+		},
+	}}
+
+	manager, err := m.NewQuotaDBManager("", registry, units.MiB)
+	require.NoError(err)
+
+	dbs := manager.GetDatabases()
+	require.Len(dbs, 2)
+
+	_, ok := dbs[0].Database.(*quotadb.Database)
+	require.True(ok)
+	_, ok = dbs[1].Database.(*quotadb.Database)
+	require.False(ok)
+
+	// Confirm that the error from a name conflict is handled correctly
+	_, err = m.NewQuotaDBManager("", registry, units.MiB)
+	require.Error(err)
+}
+
+func TestSealDBManager(t *testing.T) {
+	require := require.New(t)
+
+	current := memdb.New()
+	m := &manager{databases: []*VersionedDatabase{
+		{
+			Database: current,
+			Version: &version.Semantic{
+				Major: 2,
+				Minor: 0,
+				Patch: 0,
+			},
+		},
+		{
+			Database: memdb.New(),
+			Version:  version.Semantic1_0_0,
+		},
+	}}
+
+	masterKey := make([]byte, sealdb.MasterKeyLen)
+	manager, err := m.NewSealDBManager(masterKey, logging.NoLog{})
+	require.NoError(err)
+
+	dbs := manager.GetDatabases()
+	require.Len(dbs, 2)
+
+	_, ok := dbs[0].Database.(*sealedDatabase)
+	require.True(ok)
+	_, ok = dbs[1].Database.(*sealedDatabase)
+	require.False(ok)
+
+	// Values are encrypted before they're written to the current database
+	require.NoError(dbs[0].Database.Put([]byte("key"), []byte("value")))
+	_, err = current.Get([]byte("key"))
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Closing the manager closes the encrypted database
+	require.NoError(manager.Close())
+	_, err = current.Get([]byte("key"))
+	require.ErrorIs(err, database.ErrClosed)
+}
+
 func TestCompleteMeterDBManager(t *testing.T) {
 	require := require.New(t)
 
@@ -24,9 +24,13 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database     = (*Database)(nil)
+	_ database.Snapshotter  = (*Database)(nil)
+	_ database.RangeDeleter = (*Database)(nil)
+	_ database.Batch        = (*batch)(nil)
+	_ database.RangeDeleter = (*batch)(nil)
+	_ database.Iterator     = (*iterator)(nil)
+	_ database.Snapshot     = (*snapshot)(nil)
 )
 
 // Database is an ephemeral key-value store that implements the Database
@@ -111,6 +115,27 @@ func (db *Database) Delete(key []byte) error {
 	return nil
 }
 
+// DeleteRange removes every key in [start, limit)
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	db.deleteRange(string(start), string(limit))
+	return nil
+}
+
+// Assumes [db.lock] is held
+func (db *Database) deleteRange(start, limit string) {
+	for key := range db.db {
+		if key >= start && (len(limit) == 0 || key < limit) {
+			delete(db.db, key)
+		}
+	}
+}
+
 func (db *Database) NewBatch() database.Batch {
 	return &batch{db: db}
 }
@@ -155,6 +180,27 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	}
 }
 
+// NewSnapshot returns a snapshot of the database. The contents of the database
+// are copied when the snapshot is created.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+
+	// Values are never modified in place, so they don't need to be copied.
+	contents := NewWithSize(len(db.db))
+	for key, value := range db.db {
+		contents.db[key] = value
+	}
+	return &snapshot{
+		db:       db,
+		contents: contents,
+	}, nil
+}
+
 func (db *Database) Compact(_, _ []byte) error {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
@@ -176,6 +222,10 @@ type keyValue struct {
 	key    []byte
 	value  []byte
 	delete bool
+
+	// If deleteRange is true, every key in [key, limit) is deleted.
+	deleteRange bool
+	limit       []byte
 }
 
 type batch struct {
@@ -185,17 +235,27 @@ type batch struct {
 }
 
 func (b *batch) Put(key, value []byte) error {
-	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), utils.CopyBytes(value), false})
+	b.writes = append(b.writes, keyValue{key: utils.CopyBytes(key), value: utils.CopyBytes(value)})
 	b.size += len(key) + len(value)
 	return nil
 }
 
 func (b *batch) Delete(key []byte) error {
-	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), nil, true})
+	b.writes = append(b.writes, keyValue{key: utils.CopyBytes(key), delete: true})
 	b.size += len(key)
 	return nil
 }
 
+func (b *batch) DeleteRange(start, limit []byte) error {
+	b.writes = append(b.writes, keyValue{
+		key:         utils.CopyBytes(start),
+		deleteRange: true,
+		limit:       utils.CopyBytes(limit),
+	})
+	b.size += len(start) + len(limit)
+	return nil
+}
+
 func (b *batch) Size() int {
 	return b.size
 }
@@ -210,9 +270,12 @@ func (b *batch) Write() error {
 
 	for _, kv := range b.writes {
 		key := string(kv.key)
-		if kv.delete {
+		switch {
+		case kv.deleteRange:
+			b.db.deleteRange(key, string(kv.limit))
+		case kv.delete:
 			delete(b.db.db, key)
-		} else {
+		default:
 			b.db.db[key] = kv.value
 		}
 	}
@@ -230,12 +293,19 @@ func (b *batch) Reset() {
 
 func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 	for _, keyvalue := range b.writes {
-		if keyvalue.delete {
+		switch {
+		case keyvalue.deleteRange:
+			if err := database.DeleteRange(w, keyvalue.key, keyvalue.limit); err != nil {
+				return err
+			}
+		case keyvalue.delete:
 			if err := w.Delete(keyvalue.key); err != nil {
 				return err
 			}
-		} else if err := w.Put(keyvalue.key, keyvalue.value); err != nil {
-			return err
+		default:
+			if err := w.Put(keyvalue.key, keyvalue.value); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -300,3 +370,47 @@ func (it *iterator) Release() {
 	it.keys = nil
 	it.values = nil
 }
+
+// snapshot is a read-only copy of the contents of a database.
+type snapshot struct {
+	db *Database
+	// contents is closed when the snapshot is released.
+	contents *Database
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if s.db.isClosed() {
+		return false, database.ErrClosed
+	}
+	return s.contents.Has(key)
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if s.db.isClosed() {
+		return nil, database.ErrClosed
+	}
+	return s.contents.Get(key)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	if s.db.isClosed() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return s.contents.NewIteratorWithStartAndPrefix(start, prefix)
+}
+
+func (s *snapshot) Release() {
+	_ = s.contents.Close()
+}
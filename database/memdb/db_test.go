@@ -16,6 +16,18 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		test(t, New())
+	}
+}
+
+func TestDeleteRangeInterface(t *testing.T) {
+	for _, test := range database.DeleteRangeTests {
+		test(t, New())
+	}
+}
+
 func FuzzInterface(f *testing.F) {
 	for _, test := range database.FuzzTests {
 		test(f, New())
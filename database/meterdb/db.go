@@ -14,9 +14,13 @@ import (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database     = (*Database)(nil)
+	_ database.Snapshotter  = (*Database)(nil)
+	_ database.RangeDeleter = (*Database)(nil)
+	_ database.Batch        = (*batch)(nil)
+	_ database.RangeDeleter = (*batch)(nil)
+	_ database.Iterator     = (*iterator)(nil)
+	_ database.Snapshot     = (*snapshot)(nil)
 )
 
 // Database tracks the amount of time each operation takes and how many bytes
@@ -80,6 +84,14 @@ func (db *Database) Delete(key []byte) error {
 	return err
 }
 
+func (db *Database) DeleteRange(start, limit []byte) error {
+	startTime := db.clock.Time()
+	err := database.DeleteRange(db.db, start, limit)
+	end := db.clock.Time()
+	db.deleteRange.Observe(float64(end.Sub(startTime)))
+	return err
+}
+
 func (db *Database) NewBatch() database.Batch {
 	start := db.clock.Time()
 	b := &batch{
@@ -141,6 +153,76 @@ func (db *Database) HealthCheck(ctx context.Context) (interface{}, error) {
 	return result, err
 }
 
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	start := db.clock.Time()
+	s, err := database.NewSnapshot(db.db)
+	end := db.clock.Time()
+	db.newSnapshot.Observe(float64(end.Sub(start)))
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		snapshot: s,
+		db:       db,
+	}, nil
+}
+
+type snapshot struct {
+	snapshot database.Snapshot
+	db       *Database
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	start := s.db.clock.Time()
+	has, err := s.snapshot.Has(key)
+	end := s.db.clock.Time()
+	s.db.readSize.Observe(float64(len(key)))
+	s.db.sHas.Observe(float64(end.Sub(start)))
+	return has, err
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	start := s.db.clock.Time()
+	value, err := s.snapshot.Get(key)
+	end := s.db.clock.Time()
+	s.db.readSize.Observe(float64(len(key) + len(value)))
+	s.db.sGet.Observe(float64(end.Sub(start)))
+	return value, err
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(
+	start,
+	prefix []byte,
+) database.Iterator {
+	startTime := s.db.clock.Time()
+	it := &iterator{
+		iterator: s.snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       s.db,
+	}
+	end := s.db.clock.Time()
+	s.db.sNewIterator.Observe(float64(end.Sub(startTime)))
+	return it
+}
+
+func (s *snapshot) Release() {
+	start := s.db.clock.Time()
+	s.snapshot.Release()
+	end := s.db.clock.Time()
+	s.db.sRelease.Observe(float64(end.Sub(start)))
+}
+
 type batch struct {
 	batch database.Batch
 	db    *Database
@@ -164,6 +246,14 @@ func (b *batch) Delete(key []byte) error {
 	return err
 }
 
+func (b *batch) DeleteRange(start, limit []byte) error {
+	startTime := b.db.clock.Time()
+	err := database.DeleteRange(b.batch, start, limit)
+	end := b.db.clock.Time()
+	b.db.bDeleteRange.Observe(float64(end.Sub(startTime)))
+	return err
+}
+
 func (b *batch) Size() int {
 	start := b.db.clock.Time()
 	size := b.batch.Size()
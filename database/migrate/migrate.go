@@ -0,0 +1,264 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/manager"
+	"github.com/coinflect/coinflectchain/utils/logging"
+	"github.com/coinflect/coinflectchain/utils/perms"
+	"github.com/coinflect/coinflectchain/utils/units"
+	"github.com/coinflect/coinflectchain/version"
+)
+
+const (
+	// DefaultBatchSize is the number of bytes written to the destination
+	// database in each batch.
+	DefaultBatchSize = 4 * units.MiB
+
+	progressFilePrefix = "migration_"
+	progressFileSuffix = ".json"
+	progressLogPeriod  = 10 * time.Second
+)
+
+var (
+	errDestinationNotEmpty = errors.New("destination database is not empty")
+	errVerificationFailed  = errors.New("verification failed")
+)
+
+// Config describes how a migration is performed.
+type Config struct {
+	// BatchSize is the number of bytes to buffer before writing a batch to
+	// the destination database. Progress is persisted after every batch.
+	BatchSize int
+	// PrefixLen is the number of leading key bytes used to group keys when
+	// verifying the migration.
+	PrefixLen int
+	// ProgressPath is the file used to persist the progress of the migration
+	// so that it can be resumed after an interruption.
+	ProgressPath string
+}
+
+// Progress is the persisted state of a migration.
+type Progress struct {
+	// LastKey is the last key that is known to have been written to the
+	// destination database.
+	LastKey []byte `json:"lastKey"`
+	// Source summarizes every key that was read from the source database up
+	// to and including [LastKey].
+	Source Summaries `json:"source"`
+	// Done is true once the migration has been completed and verified.
+	Done bool `json:"done"`
+}
+
+func readProgress(path string) (*Progress, bool, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Progress{Source: make(Summaries)}, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	p := &Progress{}
+	if err := json.Unmarshal(b, p); err != nil {
+		return nil, false, fmt.Errorf("failed to parse progress file %s: %w", path, err)
+	}
+	if p.Source == nil {
+		p.Source = make(Summaries)
+	}
+	return p, true, nil
+}
+
+func writeProgress(path string, p *Progress) error {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err := perms.WriteFile(tmpPath, b, perms.ReadWrite); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// Database copies every key of [src] into [dst] and verifies that the
+// contents of [dst] match what was read from [src].
+//
+// If the migration is interrupted, calling Database again with the same
+// [config] resumes the migration after the last persisted batch. A migration
+// that isn't being resumed requires [dst] to be empty. Its progress is
+// persisted before anything is written to [dst], so that it can be resumed
+// even if it is interrupted before the first batch is persisted.
+func Database(log logging.Logger, src database.Iteratee, dst database.Database, config Config) (*Progress, error) {
+	progress, resuming, err := readProgress(config.ProgressPath)
+	if err != nil {
+		return nil, err
+	}
+	if progress.Done {
+		log.Info("migration was already completed",
+			zap.String("progressPath", config.ProgressPath),
+		)
+		return progress, nil
+	}
+
+	// Keys written to [dst] after the last persisted batch are written again,
+	// with the same values, when the migration is resumed.
+	var it database.Iterator
+	switch {
+	case resuming && progress.LastKey != nil:
+		log.Info("resuming migration",
+			zap.Binary("lastKey", progress.LastKey),
+			zap.Uint64("numMigrated", progress.Source.Total().Count),
+		)
+		// Start iterating at the first key after [LastKey].
+		start := make([]byte, len(progress.LastKey)+1)
+		copy(start, progress.LastKey)
+		it = src.NewIteratorWithStart(start)
+	case resuming:
+		log.Info("restarting migration that was interrupted before its first batch was persisted")
+		it = src.NewIterator()
+	default:
+		isEmpty, err := database.IsEmpty(dst)
+		if err != nil {
+			return nil, err
+		}
+		if !isEmpty {
+			return nil, errDestinationNotEmpty
+		}
+		if err := writeProgress(config.ProgressPath, progress); err != nil {
+			return nil, err
+		}
+		it = src.NewIterator()
+	}
+	defer it.Release()
+
+	var (
+		batch      = dst.NewBatch()
+		pending    = make(Summaries)
+		lastKey    []byte
+		nextLogMsg = time.Now().Add(progressLogPeriod)
+	)
+	commit := func() error {
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+
+		progress.Source.Merge(pending)
+		pending = make(Summaries)
+		progress.LastKey = lastKey
+		return writeProgress(config.ProgressPath, progress)
+	}
+
+	for it.Next() {
+		key := it.Key()
+		value := it.Value()
+		if err := batch.Put(key, value); err != nil {
+			return nil, err
+		}
+		pending.Add(key, value, config.PrefixLen)
+		lastKey = key
+
+		if batch.Size() < config.BatchSize {
+			continue
+		}
+		if err := commit(); err != nil {
+			return nil, err
+		}
+		if now := time.Now(); now.After(nextLogMsg) {
+			nextLogMsg = now.Add(progressLogPeriod)
+			log.Info("migrating database",
+				zap.Uint64("numMigrated", progress.Source.Total().Count),
+			)
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	if len(pending) > 0 {
+		if err := commit(); err != nil {
+			return nil, err
+		}
+	}
+
+	log.Info("verifying migration",
+		zap.Uint64("numMigrated", progress.Source.Total().Count),
+	)
+	if err := Verify(progress.Source, dst, config.PrefixLen); err != nil {
+		return nil, err
+	}
+
+	progress.Done = true
+	return progress, writeProgress(config.ProgressPath, progress)
+}
+
+// Verify returns an error if the contents of [db] do not match [expected].
+func Verify(expected Summaries, db database.Iteratee, prefixLen int) error {
+	actual, err := Summarize(db, prefixLen)
+	if err != nil {
+		return err
+	}
+	if diffs := expected.Diff(actual); len(diffs) > 0 {
+		return fmt.Errorf("%w: %s", errVerificationFailed, formatDiffs(diffs))
+	}
+	return nil
+}
+
+// Manager migrates every versioned database managed by [src]. The destination
+// database of each version is opened with [openDst]. The progress of each
+// version is persisted in [progressDir].
+func Manager(
+	log logging.Logger,
+	src manager.Manager,
+	openDst func(*version.Semantic) (database.Database, error),
+	progressDir string,
+	config Config,
+) error {
+	for _, srcDB := range src.GetDatabases() {
+		log.Info("migrating database version",
+			zap.Stringer("version", srcDB.Version),
+		)
+
+		dstDB, err := openDst(srcDB.Version)
+		if err != nil {
+			return fmt.Errorf("couldn't open destination database for version %s: %w", srcDB.Version, err)
+		}
+
+		versionConfig := config
+		versionConfig.ProgressPath = ProgressPath(progressDir, srcDB.Version)
+		progress, err := Database(log, srcDB.Database, dstDB, versionConfig)
+		if closeErr := dstDB.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't migrate database version %s: %w", srcDB.Version, err)
+		}
+
+		total := progress.Source.Total()
+		log.Info("migrated database version",
+			zap.Stringer("version", srcDB.Version),
+			zap.Uint64("numKeys", total.Count),
+			zap.Uint64("numBytes", total.Size),
+			zap.Int("numPrefixes", len(progress.Source)),
+			zap.Stringer("checksum", total.Checksum),
+		)
+	}
+	return nil
+}
+
+// ProgressPath returns the path of the file that records the progress of
+// migrating the database with version [v].
+func ProgressPath(dir string, v *version.Semantic) string {
+	return filepath.Join(dir, progressFilePrefix+v.String()+progressFileSuffix)
+}
@@ -0,0 +1,237 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/manager"
+	"github.com/coinflect/coinflectchain/database/memdb"
+	"github.com/coinflect/coinflectchain/database/prefixdb"
+	"github.com/coinflect/coinflectchain/utils/logging"
+	"github.com/coinflect/coinflectchain/version"
+)
+
+var errTest = errors.New("non-nil error")
+
+// failingDB fails to write batches once [remainingWrites] batches have been
+// written.
+type failingDB struct {
+	database.Database
+	remainingWrites int
+}
+
+func (db *failingDB) NewBatch() database.Batch {
+	return &failingBatch{
+		Batch: db.Database.NewBatch(),
+		db:    db,
+	}
+}
+
+type failingBatch struct {
+	database.Batch
+	db *failingDB
+}
+
+func (b *failingBatch) Write() error {
+	if b.db.remainingWrites == 0 {
+		return errTest
+	}
+	b.db.remainingWrites--
+	return b.Batch.Write()
+}
+
+func populate(t *testing.T, db database.Database, numKeys int) {
+	for _, prefix := range []string{"chain1", "chain2", "chain3"} {
+		prefixDB := prefixdb.New([]byte(prefix), db)
+		for i := 0; i < numKeys; i++ {
+			require.NoError(t, prefixDB.Put([]byte(fmt.Sprintf("key-%d", i)), []byte(fmt.Sprintf("value-%d", i))))
+		}
+	}
+	require.NoError(t, db.Put([]byte("root"), []byte("value")))
+}
+
+func TestDatabase(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	populate(t, src, 100)
+	dst := memdb.New()
+
+	config := Config{
+		BatchSize:    256,
+		PrefixLen:    DefaultPrefixLen,
+		ProgressPath: filepath.Join(t.TempDir(), "progress.json"),
+	}
+	progress, err := Database(logging.NoLog{}, src, dst, config)
+	require.NoError(err)
+	require.True(progress.Done)
+	require.Len(progress.Source, 4)
+	require.Equal(uint64(301), progress.Source.Total().Count)
+
+	expected, err := Summarize(src, DefaultPrefixLen)
+	require.NoError(err)
+	require.Empty(expected.Diff(progress.Source))
+
+	// Running the migration again should be a no-op.
+	progress, err = Database(logging.NoLog{}, src, dst, config)
+	require.NoError(err)
+	require.True(progress.Done)
+}
+
+func TestDatabaseResume(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	populate(t, src, 100)
+	dst := memdb.New()
+
+	config := Config{
+		BatchSize:    256,
+		PrefixLen:    DefaultPrefixLen,
+		ProgressPath: filepath.Join(t.TempDir(), "progress.json"),
+	}
+	_, err := Database(logging.NoLog{}, src, &failingDB{Database: dst, remainingWrites: 3}, config)
+	require.ErrorIs(err, errTest)
+
+	progress, _, err := readProgress(config.ProgressPath)
+	require.NoError(err)
+	require.False(progress.Done)
+	require.NotNil(progress.LastKey)
+
+	progress, err = Database(logging.NoLog{}, src, dst, config)
+	require.NoError(err)
+	require.True(progress.Done)
+	require.Equal(uint64(301), progress.Source.Total().Count)
+
+	require.NoError(Verify(progress.Source, src, DefaultPrefixLen))
+}
+
+func TestDatabaseResumeBeforeFirstProgress(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	populate(t, src, 100)
+	dst := memdb.New()
+
+	config := Config{
+		BatchSize:    256,
+		PrefixLen:    DefaultPrefixLen,
+		ProgressPath: filepath.Join(t.TempDir(), "progress.json"),
+	}
+	_, err := Database(logging.NoLog{}, src, &failingDB{Database: dst}, config)
+	require.ErrorIs(err, errTest)
+
+	// The progress is persisted before the first batch
+	progress, resuming, err := readProgress(config.ProgressPath)
+	require.NoError(err)
+	require.True(resuming)
+	require.Nil(progress.LastKey)
+
+	// The first batch was written, but its progress wasn't persisted
+	it := src.NewIterator()
+	require.True(it.Next())
+	require.NoError(dst.Put(it.Key(), it.Value()))
+	it.Release()
+
+	progress, err = Database(logging.NoLog{}, src, dst, config)
+	require.NoError(err)
+	require.True(progress.Done)
+	require.Equal(uint64(301), progress.Source.Total().Count)
+}
+
+func TestDatabaseNonEmptyDestination(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	populate(t, src, 10)
+	dst := memdb.New()
+	require.NoError(dst.Put([]byte("unexpected"), nil))
+
+	_, err := Database(logging.NoLog{}, src, dst, Config{
+		BatchSize:    256,
+		PrefixLen:    DefaultPrefixLen,
+		ProgressPath: filepath.Join(t.TempDir(), "progress.json"),
+	})
+	require.ErrorIs(err, errDestinationNotEmpty)
+}
+
+func TestVerifyDetectsCorruption(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	populate(t, db, 10)
+	expected, err := Summarize(db, DefaultPrefixLen)
+	require.NoError(err)
+
+	require.NoError(prefixdb.New([]byte("chain2"), db).Put([]byte("key-3"), []byte("tampered")))
+	err = Verify(expected, db, DefaultPrefixLen)
+	require.ErrorIs(err, errVerificationFailed)
+}
+
+func TestManager(t *testing.T) {
+	require := require.New(t)
+
+	v1 := &version.Semantic{
+		Major: 1,
+		Minor: 0,
+		Patch: 0,
+	}
+	v2 := &version.Semantic{
+		Major: 1,
+		Minor: 1,
+		Patch: 0,
+	}
+	srcDB1 := memdb.New()
+	populate(t, srcDB1, 10)
+	srcDB2 := memdb.New()
+	populate(t, srcDB2, 20)
+	src, err := manager.NewManagerFromDBs([]*manager.VersionedDatabase{
+		{Database: srcDB1, Version: v1},
+		{Database: srcDB2, Version: v2},
+	})
+	require.NoError(err)
+
+	dsts := make(map[string]database.Database)
+	openDst := func(v *version.Semantic) (database.Database, error) {
+		db, ok := dsts[v.String()]
+		if !ok {
+			db = memdb.New()
+			dsts[v.String()] = db
+		}
+		return &nopCloser{Database: db}, nil
+	}
+
+	progressDir := t.TempDir()
+	require.NoError(Manager(logging.NoLog{}, src, openDst, progressDir, Config{
+		BatchSize: DefaultBatchSize,
+		PrefixLen: DefaultPrefixLen,
+	}))
+	require.Len(dsts, 2)
+
+	for _, srcDB := range src.GetDatabases() {
+		expected, err := Summarize(srcDB.Database, DefaultPrefixLen)
+		require.NoError(err)
+		require.NoError(Verify(expected, dsts[srcDB.Version.String()], DefaultPrefixLen))
+
+		progress, _, err := readProgress(ProgressPath(progressDir, srcDB.Version))
+		require.NoError(err)
+		require.True(progress.Done)
+	}
+}
+
+type nopCloser struct {
+	database.Database
+}
+
+func (*nopCloser) Close() error {
+	return nil
+}
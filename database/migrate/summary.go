@@ -0,0 +1,152 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migrate
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/utils/hashing"
+)
+
+// DefaultPrefixLen is the number of leading key bytes used to group keys into
+// summaries. It matches the length of the prefixes applied by prefixdb, so
+// every chain and every VM sub-database is summarized separately.
+const DefaultPrefixLen = hashing.HashLen
+
+// Summary describes the contents of a group of keys.
+type Summary struct {
+	// Count is the number of keys in the group.
+	Count uint64 `json:"count"`
+	// Size is the total number of key and value bytes in the group.
+	Size uint64 `json:"size"`
+	// Checksum is the XOR of the hash of every key/value pair in the group.
+	// It is independent of the order the pairs were added in.
+	Checksum ids.ID `json:"checksum"`
+}
+
+func (s *Summary) add(key, value []byte) {
+	s.Count++
+	s.Size += uint64(len(key) + len(value))
+
+	var lenBytes [2 * binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lenBytes[:], uint64(len(key)))
+	n += binary.PutUvarint(lenBytes[n:], uint64(len(value)))
+
+	entry := make([]byte, 0, n+len(key)+len(value))
+	entry = append(entry, lenBytes[:n]...)
+	entry = append(entry, key...)
+	entry = append(entry, value...)
+	h := hashing.ComputeHash256Array(entry)
+	for i := range s.Checksum {
+		s.Checksum[i] ^= h[i]
+	}
+}
+
+func (s *Summary) merge(other *Summary) {
+	s.Count += other.Count
+	s.Size += other.Size
+	for i := range s.Checksum {
+		s.Checksum[i] ^= other.Checksum[i]
+	}
+}
+
+// Summaries maps the hex encoded prefix of a group of keys to the summary of
+// that group.
+type Summaries map[string]*Summary
+
+// Add records the key/value pair in the summary of the group it belongs to.
+func (s Summaries) Add(key, value []byte, prefixLen int) {
+	if len(key) < prefixLen {
+		prefixLen = len(key)
+	}
+	prefix := hex.EncodeToString(key[:prefixLen])
+	summary, ok := s[prefix]
+	if !ok {
+		summary = &Summary{}
+		s[prefix] = summary
+	}
+	summary.add(key, value)
+}
+
+// Merge adds the groups of [other] into [s].
+func (s Summaries) Merge(other Summaries) {
+	for prefix, summary := range other {
+		existing, ok := s[prefix]
+		if !ok {
+			existing = &Summary{}
+			s[prefix] = existing
+		}
+		existing.merge(summary)
+	}
+}
+
+// Total returns the summary of all the groups combined.
+func (s Summaries) Total() Summary {
+	total := Summary{}
+	for _, summary := range s {
+		total.merge(summary)
+	}
+	return total
+}
+
+// Diff returns a description of every group that differs between [s] and
+// [other]. If the summaries are equal, nil is returned.
+func (s Summaries) Diff(other Summaries) []string {
+	prefixes := make(map[string]struct{}, len(s)+len(other))
+	for prefix := range s {
+		prefixes[prefix] = struct{}{}
+	}
+	for prefix := range other {
+		prefixes[prefix] = struct{}{}
+	}
+
+	var diffs []string
+	for prefix := range prefixes {
+		expected, ok := s[prefix]
+		if !ok {
+			expected = &Summary{}
+		}
+		actual, ok := other[prefix]
+		if !ok {
+			actual = &Summary{}
+		}
+		if *expected != *actual {
+			diffs = append(diffs, fmt.Sprintf(
+				"prefix %s: expected %d keys (%d bytes, checksum %s) but found %d keys (%d bytes, checksum %s)",
+				prefix,
+				expected.Count,
+				expected.Size,
+				expected.Checksum,
+				actual.Count,
+				actual.Size,
+				actual.Checksum,
+			))
+		}
+	}
+	sort.Strings(diffs)
+	return diffs
+}
+
+// Summarize returns the summaries of every key in [db].
+func Summarize(db database.Iteratee, prefixLen int) (Summaries, error) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	summaries := make(Summaries)
+	for it.Next() {
+		summaries.Add(it.Key(), it.Value(), prefixLen)
+	}
+	return summaries, it.Error()
+}
+
+func formatDiffs(diffs []string) string {
+	return strings.Join(diffs, "; ")
+}
@@ -19,9 +19,13 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database     = (*Database)(nil)
+	_ database.Snapshotter  = (*Database)(nil)
+	_ database.RangeDeleter = (*Database)(nil)
+	_ database.Batch        = (*batch)(nil)
+	_ database.RangeDeleter = (*batch)(nil)
+	_ database.Iterator     = (*iterator)(nil)
+	_ database.Snapshot     = (*snapshot)(nil)
 )
 
 // Database partitions a database into a sub-database by prefixing all keys with
@@ -130,6 +134,20 @@ func (db *Database) Delete(key []byte) error {
 	return err
 }
 
+// DeleteRange removes every key in [start, limit). If the underlying database
+// doesn't support range deletion, [database.ErrRangeDeletionNotSupported] is
+// returned.
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	prefixedStart, prefixedLimit := db.prefixRange(start, limit)
+	return database.DeleteRange(db.db, prefixedStart, prefixedLimit)
+}
+
 func (db *Database) NewBatch() database.Batch {
 	return &batch{
 		Batch: db.db.NewBatch(),
@@ -169,6 +187,26 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	return it
 }
 
+// NewSnapshot returns a snapshot of the keys in this database. Returns
+// [database.ErrSnapshotsNotSupported] if the underlying database doesn't
+// support snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	s, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		Snapshot: s,
+		db:       db,
+	}, nil
+}
+
 func (db *Database) Compact(start, limit []byte) error {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
@@ -210,6 +248,37 @@ func (db *Database) HealthCheck(ctx context.Context) (interface{}, error) {
 // Return a copy of [key], prepended with this db's prefix.
 // The returned slice should be put back in the pool
 // when it's done being used.
+// prefixRange returns the range of the underlying database that contains the
+// keys in [start, limit). The returned slices aren't taken from the buffer
+// pool.
+func (db *Database) prefixRange(start, limit []byte) ([]byte, []byte) {
+	prefixedStart := make([]byte, len(db.dbPrefix)+len(start))
+	copy(prefixedStart, db.dbPrefix)
+	copy(prefixedStart[len(db.dbPrefix):], start)
+
+	if len(limit) == 0 {
+		return prefixedStart, prefixEnd(db.dbPrefix)
+	}
+	prefixedLimit := make([]byte, len(db.dbPrefix)+len(limit))
+	copy(prefixedLimit, db.dbPrefix)
+	copy(prefixedLimit[len(db.dbPrefix):], limit)
+	return prefixedStart, prefixedLimit
+}
+
+// prefixEnd returns the smallest key that is larger than every key that starts
+// with [prefix]. If there is no such key, nil is returned.
+func prefixEnd(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xff {
+			end := make([]byte, i+1)
+			copy(end, prefix)
+			end[i]++
+			return end
+		}
+	}
+	return nil
+}
+
 func (db *Database) prefix(key []byte) []byte {
 	// Get a []byte from the pool
 	prefixedKey := db.bufferPool.Get().([]byte)
@@ -232,6 +301,11 @@ type keyValue struct {
 	key    []byte
 	value  []byte
 	delete bool
+
+	// If deleteRange is true, every key in [start, limit) was deleted. Unlike
+	// [key], [start] and [limit] aren't prefixed.
+	deleteRange  bool
+	start, limit []byte
 }
 
 // Batch of database operations
@@ -252,7 +326,7 @@ type batch struct {
 func (b *batch) Put(key, value []byte) error {
 	prefixedKey := b.db.prefix(key)
 	copiedValue := utils.CopyBytes(value)
-	b.writes = append(b.writes, keyValue{prefixedKey, copiedValue, false})
+	b.writes = append(b.writes, keyValue{key: prefixedKey, value: copiedValue})
 	return b.Batch.Put(prefixedKey, copiedValue)
 }
 
@@ -261,10 +335,26 @@ func (b *batch) Put(key, value []byte) error {
 // [key] may be modified after this method returns.
 func (b *batch) Delete(key []byte) error {
 	prefixedKey := b.db.prefix(key)
-	b.writes = append(b.writes, keyValue{prefixedKey, nil, true})
+	b.writes = append(b.writes, keyValue{key: prefixedKey, delete: true})
 	return b.Batch.Delete(prefixedKey)
 }
 
+// DeleteRange deletes every key in [start, limit) during writing. If the
+// underlying batch doesn't support range deletion,
+// [database.ErrRangeDeletionNotSupported] is returned.
+func (b *batch) DeleteRange(start, limit []byte) error {
+	prefixedStart, prefixedLimit := b.db.prefixRange(start, limit)
+	if err := database.DeleteRange(b.Batch, prefixedStart, prefixedLimit); err != nil {
+		return err
+	}
+	b.writes = append(b.writes, keyValue{
+		deleteRange: true,
+		start:       utils.CopyBytes(start),
+		limit:       utils.CopyBytes(limit),
+	})
+	return nil
+}
+
 // Write flushes any accumulated data to the memory database.
 func (b *batch) Write() error {
 	b.db.lock.RLock()
@@ -283,7 +373,9 @@ func (b *batch) Reset() {
 	// because we assume in batch.Replay that it's not safe to modify the
 	// value argument to w.Put.
 	for _, kv := range b.writes {
-		b.db.bufferPool.Put(kv.key)
+		if !kv.deleteRange {
+			b.db.bufferPool.Put(kv.key)
+		}
 	}
 
 	// Clear b.writes
@@ -300,6 +392,13 @@ func (b *batch) Reset() {
 // after those methods return.
 func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 	for _, keyvalue := range b.writes {
+		if keyvalue.deleteRange {
+			if err := database.DeleteRange(w, keyvalue.start, keyvalue.limit); err != nil {
+				return err
+			}
+			continue
+		}
+
 		keyWithoutPrefix := keyvalue.key[len(b.db.dbPrefix):]
 		if keyvalue.delete {
 			if err := w.Delete(keyWithoutPrefix); err != nil {
@@ -314,6 +413,69 @@ func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 	return nil
 }
 
+// snapshot of the underlying database that only exposes the keys of this
+// database
+type snapshot struct {
+	database.Snapshot
+	db *Database
+}
+
+// Assumes that it is OK for the argument to s.Snapshot.Has
+// to be modified after s.Snapshot.Has returns
+// [key] may be modified after this method returns.
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if s.db.isClosed() {
+		return false, database.ErrClosed
+	}
+	prefixedKey := s.db.prefix(key)
+	has, err := s.Snapshot.Has(prefixedKey)
+	s.db.bufferPool.Put(prefixedKey)
+	return has, err
+}
+
+// Assumes that it is OK for the argument to s.Snapshot.Get
+// to be modified after s.Snapshot.Get returns.
+// [key] may be modified after this method returns.
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if s.db.isClosed() {
+		return nil, database.ErrClosed
+	}
+	prefixedKey := s.db.prefix(key)
+	val, err := s.Snapshot.Get(prefixedKey)
+	s.db.bufferPool.Put(prefixedKey)
+	return val, err
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// Assumes it is safe to modify the arguments to
+// s.Snapshot.NewIteratorWithStartAndPrefix after it returns.
+// It is safe to modify [start] and [prefix] after this method returns.
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	if s.db.isClosed() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	prefixedStart := s.db.prefix(start)
+	prefixedPrefix := s.db.prefix(prefix)
+	it := &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(prefixedStart, prefixedPrefix),
+		db:       s.db,
+	}
+	s.db.bufferPool.Put(prefixedStart)
+	s.db.bufferPool.Put(prefixedPrefix)
+	return it
+}
+
 type iterator struct {
 	database.Iterator
 	db *Database
@@ -7,6 +7,8 @@ package prefixdb
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/coinflect/coinflectchain/database"
 	"github.com/coinflect/coinflectchain/database/memdb"
 )
@@ -23,6 +25,71 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		test(t, New([]byte("hello"), memdb.New()))
+		test(t, New([]byte("wor"), New([]byte("ld"), memdb.New())))
+		test(t, NewNested([]byte("wor"), New([]byte("ld"), memdb.New())))
+	}
+}
+
+func TestSnapshotNotSupported(t *testing.T) {
+	require := require.New(t)
+
+	db := New([]byte("hello"), &plainDB{Database: memdb.New()})
+	_, err := db.NewSnapshot()
+	require.Equal(database.ErrSnapshotsNotSupported, err)
+}
+
+// plainDB hides the Snapshotter and RangeDeleter implementations of the
+// wrapped database.
+type plainDB struct {
+	database.Database
+}
+
+func TestDeleteRangeInterface(t *testing.T) {
+	for _, test := range database.DeleteRangeTests {
+		test(t, New([]byte("hello"), memdb.New()))
+		test(t, New([]byte("wor"), New([]byte("ld"), memdb.New())))
+		test(t, NewNested([]byte("wor"), New([]byte("ld"), memdb.New())))
+	}
+}
+
+func TestDeleteRangeIsolation(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	hello := New([]byte("hello"), db)
+	world := New([]byte("world"), db)
+	require.NoError(hello.Put([]byte("key"), nil))
+	require.NoError(world.Put([]byte("key"), nil))
+
+	require.NoError(hello.DeleteRange(nil, nil))
+
+	has, err := hello.Has([]byte("key"))
+	require.NoError(err)
+	require.False(has)
+
+	has, err = world.Has([]byte("key"))
+	require.NoError(err)
+	require.True(has)
+}
+
+func TestDeleteRangeNotSupported(t *testing.T) {
+	require := require.New(t)
+
+	db := New([]byte("hello"), &plainDB{Database: memdb.New()})
+	require.Equal(database.ErrRangeDeletionNotSupported, db.DeleteRange(nil, nil))
+}
+
+func TestPrefixEnd(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal([]byte{0x01, 0x03}, prefixEnd([]byte{0x01, 0x02}))
+	require.Equal([]byte{0x02}, prefixEnd([]byte{0x01, 0xff}))
+	require.Nil(prefixEnd([]byte{0xff, 0xff}))
+}
+
 func FuzzInterface(f *testing.F) {
 	for _, test := range database.FuzzTests {
 		test(f, New([]byte(""), memdb.New()))
@@ -0,0 +1,539 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package quotadb implements a database that tracks the approximate number of
+// bytes it stores and refuses writes once a quota is exceeded.
+package quotadb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/utils"
+	"github.com/coinflect/coinflectchain/utils/wrappers"
+)
+
+var (
+	_ database.Database     = (*Database)(nil)
+	_ database.RangeDeleter = (*Database)(nil)
+	_ database.Snapshotter  = (*Database)(nil)
+	_ database.Batch        = (*batch)(nil)
+	_ database.RangeDeleter = (*batch)(nil)
+	_ database.Snapshot     = (*snapshot)(nil)
+	_ database.Iterator     = (*iterator)(nil)
+
+	// usageKey holds the persisted usage. It is stored next to the data so
+	// that it is updated atomically with the writes it accounts for, and is
+	// hidden from the users of the database.
+	usageKey = []byte("quotadb_usage")
+
+	errReservedKey = errors.New("key is reserved by quotadb")
+)
+
+// Database tracks the approximate number of bytes stored in the underlying
+// database. The usage is persisted in the underlying database, so it is only
+// measured by iterating over the database the first time the database is
+// wrapped.
+//
+// Writes aren't preceded by reads: every put is assumed to add a new key and
+// deletions aren't subtracted, so the tracked usage is an upper bound. Once it
+// would exceed the quota, the usage is measured again and the write is
+// accounted for exactly, by reading the previous values of the written keys.
+//
+// Writes that would increase the usage past the quota are refused with
+// [database.ErrQuotaExceeded]. Writes that don't increase the usage, such as
+// deletions, are always allowed so that space can be freed.
+//
+// The underlying database must only be written to through this Database.
+type Database struct {
+	// lock serializes writes so that the usage is updated consistently
+	lock sync.Mutex
+	db   database.Database
+
+	// quota is the maximum number of bytes that may be stored. If 0, writes
+	// are never refused.
+	quota uint64
+	usage uint64
+	// exact is true if [usage] was measured or accounted for exactly since
+	// the last write that was only estimated
+	exact bool
+
+	usageMetric  prometheus.Gauge
+	quotaMetric  prometheus.Gauge
+	refusedCount prometheus.Counter
+}
+
+// New returns a new database that allows at most [quota] bytes to be stored in
+// [db]. If [quota] is 0, the usage is tracked but writes are never refused.
+// If [db] wasn't wrapped before, creating the database iterates over all of
+// [db] to measure its usage.
+func New(
+	namespace string,
+	registerer prometheus.Registerer,
+	quota uint64,
+	db database.Database,
+) (*Database, error) {
+	quotaDB := &Database{
+		db:    db,
+		quota: quota,
+		usageMetric: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "usage",
+			Help:      "approximate number of bytes stored in the database",
+		}),
+		quotaMetric: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "quota",
+			Help:      "maximum number of bytes that may be stored in the database, 0 if unlimited",
+		}),
+		refusedCount: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "refused",
+			Help:      "# of writes that were refused because the quota was exceeded",
+		}),
+	}
+
+	usage, err := database.GetUInt64(db, usageKey)
+	switch err {
+	case nil:
+		quotaDB.usage = usage
+	case database.ErrNotFound:
+		if err := quotaDB.measure(); err != nil {
+			return nil, err
+		}
+		if quotaDB.usage > 0 {
+			if err := database.PutUInt64(db, usageKey, quotaDB.usage); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, err
+	}
+	quotaDB.usageMetric.Set(float64(quotaDB.usage))
+	quotaDB.quotaMetric.Set(float64(quota))
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(quotaDB.usageMetric),
+		registerer.Register(quotaDB.quotaMetric),
+		registerer.Register(quotaDB.refusedCount),
+	)
+	return quotaDB, errs.Err
+}
+
+// Usage returns the approximate number of bytes stored in the database.
+func (db *Database) Usage() uint64 {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	return db.usage
+}
+
+func (db *Database) Has(key []byte) (bool, error) {
+	if isUsageKey(key) {
+		return false, nil
+	}
+	return db.db.Has(key)
+}
+
+func (db *Database) Get(key []byte) ([]byte, error) {
+	if isUsageKey(key) {
+		return nil, database.ErrNotFound
+	}
+	return db.db.Get(key)
+}
+
+func (db *Database) Put(key, value []byte) error {
+	return db.write([]keyValue{{key: key, value: value}})
+}
+
+func (db *Database) Delete(key []byte) error {
+	return db.write([]keyValue{{key: key, delete: true}})
+}
+
+// DeleteRange removes every key in [start, limit) from the underlying database
+// with the underlying database's range deletion.
+func (db *Database) DeleteRange(start, limit []byte) error {
+	return db.write([]keyValue{{key: start, deleteRange: true, limit: limit}})
+}
+
+// NewSnapshot returns a snapshot of the underlying database
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	s, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{Snapshot: s}, nil
+}
+
+// write applies [writes] to the underlying database if doing so doesn't
+// exceed the quota.
+func (db *Database) write(writes []keyValue) error {
+	added := uint64(0)
+	for _, kv := range writes {
+		if isUsageKey(kv.key) && !kv.deleteRange {
+			return errReservedKey
+		}
+		if !kv.delete && !kv.deleteRange {
+			added += uint64(database.EntrySize(kv.key, kv.value))
+		}
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	newUsage := db.usage + added
+	exact := false
+	if db.quota != 0 && added > 0 && newUsage > db.quota {
+		// The estimate exceeds the quota, so the write is accounted for
+		// exactly before it is refused.
+		if !db.exact {
+			if err := db.measure(); err != nil {
+				return err
+			}
+		}
+		var err error
+		newUsage, err = db.usageAfter(writes)
+		if err != nil {
+			return err
+		}
+		if newUsage > db.usage && newUsage > db.quota {
+			db.refusedCount.Inc()
+			return database.ErrQuotaExceeded
+		}
+		exact = true
+	}
+
+	batch := db.db.NewBatch()
+	for _, kv := range writes {
+		if err := kv.apply(batch); err != nil {
+			return err
+		}
+	}
+	// The usage is written last, so that it isn't removed by a range
+	// deletion in [writes].
+	if err := database.PutUInt64(batch, usageKey, newUsage); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	db.usage = newUsage
+	db.exact = exact
+	db.usageMetric.Set(float64(newUsage))
+	return nil
+}
+
+// measure sets the usage to the number of bytes stored in the underlying
+// database.
+// Assumes [db.lock] is held, or that [db] isn't used concurrently.
+func (db *Database) measure() error {
+	it := db.db.NewIterator()
+	defer it.Release()
+
+	usage := uint64(0)
+	for it.Next() {
+		key := it.Key()
+		if !isUsageKey(key) {
+			usage += uint64(database.EntrySize(key, it.Value()))
+		}
+	}
+	if err := it.Error(); err != nil {
+		return fmt.Errorf("failed to measure database usage: %w", err)
+	}
+	db.usage = usage
+	db.exact = true
+	db.usageMetric.Set(float64(usage))
+	return nil
+}
+
+// usageAfter returns the exact usage of the database after [writes] are
+// applied, given that [db.usage] is exact.
+// Assumes [db.lock] is held.
+func (db *Database) usageAfter(writes []keyValue) (uint64, error) {
+	usage := int64(db.usage)
+
+	// sizes holds the size of the keys that were already written by [writes]
+	sizes := make(map[string]int)
+	for _, kv := range writes {
+		if kv.deleteRange {
+			removed, err := db.rangeSize(sizes, kv.key, kv.limit)
+			if err != nil {
+				return 0, err
+			}
+			usage -= int64(removed)
+			continue
+		}
+
+		prevSize, ok := sizes[string(kv.key)]
+		if !ok {
+			value, err := db.db.Get(kv.key)
+			switch err {
+			case nil:
+				prevSize = database.EntrySize(kv.key, value)
+			case database.ErrNotFound:
+			default:
+				return 0, err
+			}
+		}
+		newSize := 0
+		if !kv.delete {
+			newSize = database.EntrySize(kv.key, kv.value)
+		}
+		sizes[string(kv.key)] = newSize
+		usage += int64(newSize - prevSize)
+	}
+
+	if usage < 0 {
+		return 0, nil
+	}
+	return uint64(usage), nil
+}
+
+// rangeSize returns the size of the keys in [start, limit), taking into
+// account the sizes of the keys in [sizes]. The keys in the range are marked as
+// deleted in [sizes].
+// Assumes [db.lock] is held.
+func (db *Database) rangeSize(sizes map[string]int, start, limit []byte) (int, error) {
+	size := 0
+	it := db.db.NewIteratorWithStart(start)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if !database.InRange(key, start, limit) {
+			break
+		}
+		if isUsageKey(key) {
+			continue
+		}
+		if _, ok := sizes[string(key)]; !ok {
+			size += database.EntrySize(key, it.Value())
+		}
+	}
+	if err := it.Error(); err != nil {
+		return 0, err
+	}
+
+	for key, keySize := range sizes {
+		if database.InRange([]byte(key), start, limit) {
+			size += keySize
+			sizes[key] = 0
+		}
+	}
+	return size, nil
+}
+
+func (db *Database) NewBatch() database.Batch {
+	return &batch{db: db}
+}
+
+func (db *Database) NewIterator() database.Iterator {
+	return &iterator{Iterator: db.db.NewIterator()}
+}
+
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return &iterator{Iterator: db.db.NewIteratorWithStart(start)}
+}
+
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &iterator{Iterator: db.db.NewIteratorWithPrefix(prefix)}
+}
+
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix)}
+}
+
+func (db *Database) Compact(start, limit []byte) error {
+	return db.db.Compact(start, limit)
+}
+
+func (db *Database) Close() error {
+	return db.db.Close()
+}
+
+// HealthCheck reports the usage of the database and returns an error if the
+// quota was exceeded.
+func (db *Database) HealthCheck(ctx context.Context) (interface{}, error) {
+	db.lock.Lock()
+	if db.quota != 0 && db.usage >= db.quota && !db.exact {
+		// The estimate may be too high, so the usage is measured before the
+		// database is reported as unhealthy.
+		if err := db.measure(); err != nil {
+			db.lock.Unlock()
+			return nil, err
+		}
+	}
+	usage := db.usage
+	db.lock.Unlock()
+
+	details := map[string]interface{}{
+		"usage": usage,
+		"quota": db.quota,
+	}
+	if db.quota != 0 && usage >= db.quota {
+		return details, fmt.Errorf("%w: %d bytes stored with a quota of %d bytes",
+			database.ErrQuotaExceeded,
+			usage,
+			db.quota,
+		)
+	}
+	if _, err := db.db.HealthCheck(ctx); err != nil {
+		return details, err
+	}
+	return details, nil
+}
+
+type keyValue struct {
+	key    []byte
+	value  []byte
+	delete bool
+
+	// If deleteRange is true, every key in [key, limit) is deleted.
+	deleteRange bool
+	limit       []byte
+}
+
+func (kv *keyValue) apply(w database.KeyValueWriterDeleter) error {
+	switch {
+	case kv.deleteRange:
+		return database.DeleteRange(w, kv.key, kv.limit)
+	case kv.delete:
+		return w.Delete(kv.key)
+	default:
+		return w.Put(kv.key, kv.value)
+	}
+}
+
+// batch records its writes so that the usage of the database can be updated
+// when the batch is written. If the batch would exceed the quota, none of its
+// writes are applied.
+type batch struct {
+	db     *Database
+	writes []keyValue
+	size   int
+}
+
+func (b *batch) Put(key, value []byte) error {
+	b.writes = append(b.writes, keyValue{
+		key:   utils.CopyBytes(key),
+		value: utils.CopyBytes(value),
+	})
+	b.size += len(key) + len(value)
+	return nil
+}
+
+func (b *batch) Delete(key []byte) error {
+	b.writes = append(b.writes, keyValue{
+		key:    utils.CopyBytes(key),
+		delete: true,
+	})
+	b.size += len(key)
+	return nil
+}
+
+func (b *batch) DeleteRange(start, limit []byte) error {
+	b.writes = append(b.writes, keyValue{
+		key:         utils.CopyBytes(start),
+		deleteRange: true,
+		limit:       utils.CopyBytes(limit),
+	})
+	b.size += len(start) + len(limit)
+	return nil
+}
+
+func (b *batch) Size() int {
+	return b.size
+}
+
+func (b *batch) Write() error {
+	if len(b.writes) == 0 {
+		return nil
+	}
+	return b.db.write(b.writes)
+}
+
+func (b *batch) Reset() {
+	if cap(b.writes) > len(b.writes)*database.MaxExcessCapacityFactor {
+		b.writes = make([]keyValue, 0, cap(b.writes)/database.CapacityReductionFactor)
+	} else {
+		b.writes = b.writes[:0]
+	}
+	b.size = 0
+}
+
+func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
+	for i := range b.writes {
+		if err := b.writes[i].apply(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Inner returns itself, so that writes made through the inner batch are still
+// accounted for.
+func (b *batch) Inner() database.Batch {
+	return b
+}
+
+// snapshot hides the persisted usage from the snapshot of the underlying
+// database
+type snapshot struct {
+	database.Snapshot
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if isUsageKey(key) {
+		return false, nil
+	}
+	return s.Snapshot.Has(key)
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if isUsageKey(key) {
+		return nil, database.ErrNotFound
+	}
+	return s.Snapshot.Get(key)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return &iterator{Iterator: s.Snapshot.NewIterator()}
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return &iterator{Iterator: s.Snapshot.NewIteratorWithStart(start)}
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &iterator{Iterator: s.Snapshot.NewIteratorWithPrefix(prefix)}
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(start, prefix)}
+}
+
+// iterator skips the persisted usage
+type iterator struct {
+	database.Iterator
+}
+
+func (it *iterator) Next() bool {
+	for it.Iterator.Next() {
+		if !isUsageKey(it.Iterator.Key()) {
+			return true
+		}
+	}
+	return false
+}
+
+func isUsageKey(key []byte) bool {
+	return bytes.Equal(key, usageKey)
+}
@@ -0,0 +1,158 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quotadb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/memdb"
+)
+
+func newDB(t testing.TB, quota uint64, db database.Database) *Database {
+	quotaDB, err := New("", prometheus.NewRegistry(), quota, db)
+	require.NoError(t, err)
+	return quotaDB
+}
+
+func TestInterface(t *testing.T) {
+	for _, test := range database.Tests {
+		test(t, newDB(t, 0, memdb.New()))
+	}
+}
+
+func TestDeleteRangeInterface(t *testing.T) {
+	for _, test := range database.DeleteRangeTests {
+		test(t, newDB(t, 0, memdb.New()))
+	}
+}
+
+func FuzzInterface(f *testing.F) {
+	for _, test := range database.FuzzTests {
+		test(f, newDB(f, 0, memdb.New()))
+	}
+}
+
+func BenchmarkInterface(b *testing.B) {
+	for _, size := range database.BenchmarkSizes {
+		keys, values := database.SetupBenchmark(b, size[0], size[1], size[2])
+		for _, bench := range database.Benchmarks {
+			bench(b, newDB(b, 0, memdb.New()), "quotadb", keys, values)
+		}
+	}
+}
+
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		test(t, newDB(t, 0, memdb.New()))
+	}
+}
+
+func TestUsage(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	require.NoError(baseDB.Put([]byte("a"), []byte("value")))
+
+	// The usage of the existing values is measured on creation.
+	db := newDB(t, 0, baseDB)
+	entrySize := uint64(database.EntrySize([]byte("a"), []byte("value")))
+	require.Equal(entrySize, db.Usage())
+	require.Equal(float64(entrySize), testutil.ToFloat64(db.usageMetric))
+
+	// Writes aren't preceded by reads, so overwrites are estimated as new
+	// keys and deletions aren't subtracted.
+	require.NoError(db.Put([]byte("a"), []byte("v")))
+	require.Equal(2*entrySize-4, db.Usage())
+	require.NoError(db.Put([]byte("b"), []byte("value")))
+	require.NoError(db.Delete([]byte("a")))
+	require.Equal(3*entrySize-4, db.Usage())
+
+	// The usage is persisted, so it isn't measured again.
+	db = newDB(t, 0, baseDB)
+	require.Equal(3*entrySize-4, db.Usage())
+
+	// The persisted usage is hidden from the users of the database.
+	count, err := database.Count(db)
+	require.NoError(err)
+	require.Equal(1, count)
+	has, err := db.Has(usageKey)
+	require.NoError(err)
+	require.False(has)
+	require.ErrorIs(db.Put(usageKey, nil), errReservedKey)
+
+	snapshot, err := db.NewSnapshot()
+	require.NoError(err)
+	defer snapshot.Release()
+	count, err = database.Count(snapshot)
+	require.NoError(err)
+	require.Equal(1, count)
+
+	// A range deletion doesn't remove the persisted usage.
+	require.NoError(db.DeleteRange(nil, nil))
+	has, err = baseDB.Has(usageKey)
+	require.NoError(err)
+	require.True(has)
+}
+
+func TestQuota(t *testing.T) {
+	require := require.New(t)
+
+	entrySize := uint64(database.EntrySize([]byte("a"), []byte("value")))
+	db := newDB(t, 2*entrySize, memdb.New())
+	require.NoError(db.Put([]byte("a"), []byte("value")))
+	require.NoError(db.Put([]byte("b"), []byte("value")))
+
+	// Writes that increase the usage past the quota are refused.
+	require.ErrorIs(db.Put([]byte("c"), []byte("value")), database.ErrQuotaExceeded)
+	require.ErrorIs(db.Put([]byte("a"), []byte("value2")), database.ErrQuotaExceeded)
+	require.Equal(float64(2), testutil.ToFloat64(db.refusedCount))
+
+	// Writes that don't increase the usage are allowed.
+	require.NoError(db.Put([]byte("a"), []byte("v")))
+
+	// Batches are refused as a whole.
+	batch := db.NewBatch()
+	require.NoError(batch.Delete([]byte("a")))
+	require.NoError(batch.Put([]byte("c"), []byte("value")))
+	require.NoError(batch.Put([]byte("d"), []byte("value")))
+	require.ErrorIs(batch.Write(), database.ErrQuotaExceeded)
+	has, err := db.Has([]byte("a"))
+	require.NoError(err)
+	require.True(has)
+
+	// Deleting values frees space.
+	require.NoError(db.Delete([]byte("a")))
+	require.NoError(db.Put([]byte("c"), []byte("value")))
+}
+
+func TestHealthCheck(t *testing.T) {
+	require := require.New(t)
+
+	entrySize := uint64(database.EntrySize([]byte("a"), []byte("value")))
+	baseDB := memdb.New()
+	require.NoError(baseDB.Put([]byte("a"), []byte("value")))
+	require.NoError(baseDB.Put([]byte("b"), []byte("value")))
+
+	db := newDB(t, 3*entrySize, baseDB)
+	_, err := db.HealthCheck(context.Background())
+	require.NoError(err)
+
+	// A database that holds more than its quota, for example because the
+	// quota was lowered, is reported as unhealthy.
+	db = newDB(t, entrySize, baseDB)
+	details, err := db.HealthCheck(context.Background())
+	require.ErrorIs(err, database.ErrQuotaExceeded)
+	require.Equal(map[string]interface{}{
+		"usage": 2 * entrySize,
+		"quota": entrySize,
+	}, details)
+}
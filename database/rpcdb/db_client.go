@@ -29,9 +29,13 @@ const (
 )
 
 var (
-	_ database.Database = (*DatabaseClient)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database     = (*DatabaseClient)(nil)
+	_ database.Snapshotter  = (*DatabaseClient)(nil)
+	_ database.RangeDeleter = (*DatabaseClient)(nil)
+	_ database.Batch        = (*batch)(nil)
+	_ database.RangeDeleter = (*batch)(nil)
+	_ database.Iterator     = (*iterator)(nil)
+	_ database.Snapshot     = (*snapshot)(nil)
 )
 
 // DatabaseClient is an implementation of database that talks over RPC.
@@ -92,6 +96,18 @@ func (db *DatabaseClient) Delete(key []byte) error {
 	return errCodeToError[resp.Err]
 }
 
+// DeleteRange attempts to remove every key in [start, limit)
+func (db *DatabaseClient) DeleteRange(start, limit []byte) error {
+	resp, err := db.client.DeleteRange(context.Background(), &rpcdbpb.DeleteRangeRequest{
+		Start: start,
+		Limit: limit,
+	})
+	if err != nil {
+		return err
+	}
+	return errCodeToError[resp.Err]
+}
+
 // NewBatch returns a new batch
 func (db *DatabaseClient) NewBatch() database.Batch {
 	return &batch{db: db}
@@ -124,6 +140,21 @@ func (db *DatabaseClient) NewIteratorWithStartAndPrefix(start, prefix []byte) da
 	}
 }
 
+// NewSnapshot returns a snapshot of the remote database
+func (db *DatabaseClient) NewSnapshot() (database.Snapshot, error) {
+	resp, err := db.client.NewSnapshot(context.Background(), &rpcdbpb.NewSnapshotRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if err := errCodeToError[resp.Err]; err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		db: db,
+		id: resp.Id,
+	}, nil
+}
+
 // Compact attempts to optimize the space utilization in the provided range
 func (db *DatabaseClient) Compact(start, limit []byte) error {
 	resp, err := db.client.Compact(context.Background(), &rpcdbpb.CompactRequest{
@@ -159,6 +190,10 @@ type keyValue struct {
 	key    []byte
 	value  []byte
 	delete bool
+
+	// If deleteRange is true, every key in [key, limit) is deleted.
+	deleteRange bool
+	limit       []byte
 }
 
 type batch struct {
@@ -168,17 +203,33 @@ type batch struct {
 }
 
 func (b *batch) Put(key, value []byte) error {
-	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), utils.CopyBytes(value), false})
+	b.writes = append(b.writes, keyValue{
+		key:   utils.CopyBytes(key),
+		value: utils.CopyBytes(value),
+	})
 	b.size += len(key) + len(value)
 	return nil
 }
 
 func (b *batch) Delete(key []byte) error {
-	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), nil, true})
+	b.writes = append(b.writes, keyValue{
+		key:    utils.CopyBytes(key),
+		delete: true,
+	})
 	b.size += len(key)
 	return nil
 }
 
+func (b *batch) DeleteRange(start, limit []byte) error {
+	b.writes = append(b.writes, keyValue{
+		key:         utils.CopyBytes(start),
+		deleteRange: true,
+		limit:       utils.CopyBytes(limit),
+	})
+	b.size += len(start) + len(limit)
+	return nil
+}
+
 func (b *batch) Size() int {
 	return b.size
 }
@@ -189,14 +240,37 @@ func (b *batch) Write() error {
 		Continues: true,
 	}
 	currentSize := 0
+
+	// The server deletes the ranges before applying the puts and deletes, so
+	// every range is sent with the first request and the puts and deletes
+	// that are overwritten by a later range are dropped.
+	for _, kv := range b.writes {
+		if !kv.deleteRange {
+			continue
+		}
+		request.DeleteRanges = append(request.DeleteRanges, &rpcdbpb.DeleteRangeRequest{
+			Start: kv.key,
+			Limit: kv.limit,
+		})
+		currentSize += baseElementSize + len(kv.key) + len(kv.limit)
+	}
+
+	var laterRanges []keyValue
 	keySet := make(map[string]struct{}, len(b.writes))
 	for i := len(b.writes) - 1; i >= 0; i-- {
 		kv := b.writes[i]
+		if kv.deleteRange {
+			laterRanges = append(laterRanges, kv)
+			continue
+		}
 		key := string(kv.key)
 		if _, overwritten := keySet[key]; overwritten {
 			continue
 		}
 		keySet[key] = struct{}{}
+		if coveredByRange(kv.key, laterRanges) {
+			continue
+		}
 
 		sizeChange := baseElementSize + len(kv.key) + len(kv.value)
 		if newSize := currentSize + sizeChange; newSize > maxBatchSize {
@@ -208,6 +282,7 @@ func (b *batch) Write() error {
 				return err
 			}
 			currentSize = 0
+			request.DeleteRanges = nil
 			request.Deletes = request.Deletes[:0]
 			request.Puts = request.Puts[:0]
 		}
@@ -244,7 +319,11 @@ func (b *batch) Reset() {
 
 func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 	for _, keyvalue := range b.writes {
-		if keyvalue.delete {
+		if keyvalue.deleteRange {
+			if err := database.DeleteRange(w, keyvalue.key, keyvalue.limit); err != nil {
+				return err
+			}
+		} else if keyvalue.delete {
 			if err := w.Delete(keyvalue.key); err != nil {
 				return err
 			}
@@ -259,6 +338,98 @@ func (b *batch) Inner() database.Batch {
 	return b
 }
 
+// coveredByRange returns true if [key] is in any of [ranges].
+func coveredByRange(key []byte, ranges []keyValue) bool {
+	for _, r := range ranges {
+		if database.InRange(key, r.key, r.limit) {
+			return true
+		}
+	}
+	return false
+}
+
+type snapshot struct {
+	db       *DatabaseClient
+	id       uint64
+	released utils.AtomicBool
+}
+
+// Has attempts to return if the snapshot has a key with the provided value.
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if s.released.GetValue() {
+		return false, database.ErrClosed
+	}
+	resp, err := s.db.client.SnapshotHas(context.Background(), &rpcdbpb.SnapshotHasRequest{
+		Id:  s.id,
+		Key: key,
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Has, errCodeToError[resp.Err]
+}
+
+// Get attempts to return the value that was mapped to the key that was
+// provided when the snapshot was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if s.released.GetValue() {
+		return nil, database.ErrClosed
+	}
+	resp, err := s.db.client.SnapshotGet(context.Background(), &rpcdbpb.SnapshotGetRequest{
+		Id:  s.id,
+		Key: key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Value, errCodeToError[resp.Err]
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix returns a new iterator over the snapshot
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	if s.released.GetValue() {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	resp, err := s.db.client.SnapshotNewIteratorWithStartAndPrefix(context.Background(), &rpcdbpb.SnapshotNewIteratorWithStartAndPrefixRequest{
+		Id:     s.id,
+		Start:  start,
+		Prefix: prefix,
+	})
+	if err != nil {
+		return &nodb.Iterator{Err: err}
+	}
+	return &iterator{
+		db: s.db,
+		id: resp.Id,
+	}
+}
+
+// Release frees the resources held by the snapshot
+func (s *snapshot) Release() {
+	if s.released.GetValue() {
+		return
+	}
+	s.released.SetValue(true)
+
+	// The snapshot is released on a best-effort basis. If the remote database
+	// is unreachable, its resources are freed when the server shuts down.
+	_, _ = s.db.client.SnapshotRelease(context.Background(), &rpcdbpb.SnapshotReleaseRequest{
+		Id: s.id,
+	})
+}
+
 type iterator struct {
 	db *DatabaseClient
 	id uint64
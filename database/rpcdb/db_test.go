@@ -80,6 +80,24 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		db := setupDB(t)
+		test(t, db.client)
+
+		db.closeFn()
+	}
+}
+
+func TestDeleteRangeInterface(t *testing.T) {
+	for _, test := range database.DeleteRangeTests {
+		db := setupDB(t)
+		test(t, db.client)
+
+		db.closeFn()
+	}
+}
+
 func FuzzInterface(f *testing.F) {
 	for _, test := range database.FuzzTests {
 		db := setupDB(f)
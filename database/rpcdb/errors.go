@@ -12,10 +12,17 @@ var (
 	errCodeToError = map[uint32]error{
 		1: database.ErrClosed,
 		2: database.ErrNotFound,
+		3: database.ErrSnapshotsNotSupported,
+		4: database.ErrRangeDeletionNotSupported,
+		5: database.ErrQuotaExceeded,
 	}
 	errorToErrCode = map[error]uint32{
 		database.ErrClosed:   1,
 		database.ErrNotFound: 2,
+
+		database.ErrSnapshotsNotSupported:     3,
+		database.ErrRangeDeletionNotSupported: 4,
+		database.ErrQuotaExceeded:             5,
 	}
 )
 
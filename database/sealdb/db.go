@@ -0,0 +1,490 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package sealdb implements a database that encrypts and authenticates every
+// value with versioned data encryption keys. The data encryption keys are
+// stored in the database, wrapped by a master key that is kept outside of the
+// database.
+package sealdb
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/nodb"
+	"github.com/coinflect/coinflectchain/database/prefixdb"
+	"github.com/coinflect/coinflectchain/utils"
+	"github.com/coinflect/coinflectchain/utils/logging"
+)
+
+// reencryptBatchSize is the number of keys that are re-encrypted at a time
+// while the database is being re-encrypted.
+const reencryptBatchSize = 1024
+
+var (
+	_ database.Database     = (*Database)(nil)
+	_ database.RangeDeleter = (*Database)(nil)
+	_ database.Batch        = (*batch)(nil)
+	_ database.RangeDeleter = (*batch)(nil)
+	_ database.Iterator     = (*iterator)(nil)
+
+	dataPrefix     = []byte("data")
+	metadataPrefix = []byte("metadata")
+
+	activeVersionKey = []byte("activeVersion")
+	dekPrefix        = []byte("dek")
+	// cursorKey is only present while the database is being re-encrypted. It
+	// maps to the last key that was re-encrypted.
+	cursorKey = []byte("cursor")
+)
+
+// Database encrypts all values that are provided. The keys are stored in
+// plaintext, so that the iteration order is preserved, but are authenticated
+// together with the values.
+type Database struct {
+	log logging.Logger
+
+	// lock protects all of the fields below, except for [wg]. Re-encrypted
+	// values are written while holding [lock], so that they can't overwrite
+	// concurrent writes.
+	lock     sync.RWMutex
+	keys     *keyring
+	data     database.Database
+	metadata database.Database
+	closed   bool
+
+	// cursor is the last key that was re-encrypted. It is nil if
+	// re-encryption (re)started from the first key.
+	cursor         []byte
+	reencrypting   bool
+	reencryptErr   error
+	reencryptBatch int
+
+	// wg is used to wait for re-encryption to stop when the database is
+	// closed.
+	wg sync.WaitGroup
+}
+
+// New returns a new encrypted database. If [db] doesn't have a data
+// encryption key yet, one is generated. If [db] was being re-encrypted when it
+// was closed, re-encryption is resumed in the background.
+func New(masterKey []byte, db database.Database, log logging.Logger) (*Database, error) {
+	keys, err := newKeyring(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	encDB := &Database{
+		log:            log,
+		keys:           keys,
+		data:           prefixdb.New(dataPrefix, db),
+		metadata:       prefixdb.New(metadataPrefix, db),
+		reencryptBatch: reencryptBatchSize,
+	}
+
+	activeVersion, err := database.GetUInt32(encDB.metadata, activeVersionKey)
+	if err == database.ErrNotFound {
+		return encDB, encDB.initialize()
+	}
+	if err != nil {
+		return nil, err
+	}
+	keys.active = activeVersion
+
+	if err := encDB.loadKeys(); err != nil {
+		return nil, err
+	}
+	if _, ok := keys.keys[activeVersion]; !ok {
+		return nil, fmt.Errorf("missing data encryption key with version %d", activeVersion)
+	}
+
+	cursor, err := encDB.metadata.Get(cursorKey)
+	switch err {
+	case nil:
+		if len(cursor) > 0 {
+			encDB.cursor = cursor
+		}
+		encDB.startReencryption()
+	case database.ErrNotFound:
+	default:
+		return nil, err
+	}
+	return encDB, nil
+}
+
+// initialize generates the first data encryption key.
+func (db *Database) initialize() error {
+	wrapped, err := db.keys.generate(db.keys.active)
+	if err != nil {
+		return err
+	}
+	batch := db.metadata.NewBatch()
+	if err := batch.Put(dekKey(db.keys.active), wrapped); err != nil {
+		return err
+	}
+	if err := database.PutUInt32(batch, activeVersionKey, db.keys.active); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// loadKeys unwraps every data encryption key in the database.
+func (db *Database) loadKeys() error {
+	it := db.metadata.NewIteratorWithPrefix(dekPrefix)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(dekPrefix)+versionLen {
+			return fmt.Errorf("unexpected data encryption key 0x%x", key)
+		}
+		keyVersion := binary.BigEndian.Uint32(key[len(dekPrefix):])
+		if err := db.keys.unwrap(keyVersion, it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// Has authenticates the value of [key], so that a key whose value was
+// tampered with isn't reported as present.
+func (db *Database) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return false, database.ErrClosed
+	}
+	sealed, err := db.data.Get(key)
+	if err == database.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	_, err = db.keys.open(key, sealed)
+	return err == nil, err
+}
+
+func (db *Database) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	sealed, err := db.data.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return db.keys.open(key, sealed)
+}
+
+func (db *Database) Put(key, value []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	sealed, err := db.keys.seal(key, value)
+	if err != nil {
+		return err
+	}
+	return db.data.Put(key, sealed)
+}
+
+func (db *Database) Delete(key []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return db.data.Delete(key)
+}
+
+// DeleteRange removes every key in [start, limit) from the underlying
+// database
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return database.DeleteRange(db.data, start, limit)
+}
+
+func (db *Database) NewBatch() database.Batch {
+	return &batch{db: db}
+}
+
+func (db *Database) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &iterator{
+		Iterator: db.data.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       db,
+	}
+}
+
+func (db *Database) Compact(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return db.data.Compact(start, limit)
+}
+
+// Close stops any re-encryption that is in progress. Re-encryption resumes
+// from where it stopped the next time the database is opened.
+func (db *Database) Close() error {
+	db.lock.Lock()
+	if db.closed {
+		db.lock.Unlock()
+		return database.ErrClosed
+	}
+	db.closed = true
+	db.lock.Unlock()
+
+	db.wg.Wait()
+	return nil
+}
+
+func (db *Database) isClosed() bool {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	return db.closed
+}
+
+// Status describes the data encryption keys of the database.
+type Status struct {
+	// ActiveVersion is the version of the data encryption key that new
+	// values are encrypted with.
+	ActiveVersion uint32 `json:"activeVersion"`
+	// Reencrypting is true while values encrypted with older data encryption
+	// keys are being re-encrypted with the active key.
+	Reencrypting bool `json:"reencrypting"`
+}
+
+// Status returns the current encryption status of the database.
+func (db *Database) Status() Status {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	return db.status()
+}
+
+func (db *Database) status() Status {
+	return Status{
+		ActiveVersion: db.keys.active,
+		Reencrypting:  db.reencrypting,
+	}
+}
+
+// HealthCheck reports the encryption status of the database. The database is
+// unhealthy if re-encryption failed.
+func (db *Database) HealthCheck(ctx context.Context) (interface{}, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	status := db.status()
+	if db.reencryptErr != nil {
+		return status, fmt.Errorf("re-encryption failed: %w", db.reencryptErr)
+	}
+	if _, err := db.data.HealthCheck(ctx); err != nil {
+		return status, err
+	}
+	return status, nil
+}
+
+type keyValue struct {
+	key    []byte
+	value  []byte
+	delete bool
+
+	// If deleteRange is true, every key in [key, limit) is deleted.
+	deleteRange bool
+	limit       []byte
+}
+
+// batch buffers plaintext writes. Values are encrypted when the batch is
+// written, so that they are always encrypted with the active key.
+type batch struct {
+	db     *Database
+	writes []keyValue
+	size   int
+}
+
+func (b *batch) Put(key, value []byte) error {
+	b.writes = append(b.writes, keyValue{
+		key:   utils.CopyBytes(key),
+		value: utils.CopyBytes(value),
+	})
+	b.size += len(key) + len(value)
+	return nil
+}
+
+func (b *batch) Delete(key []byte) error {
+	b.writes = append(b.writes, keyValue{
+		key:    utils.CopyBytes(key),
+		delete: true,
+	})
+	b.size += len(key)
+	return nil
+}
+
+func (b *batch) DeleteRange(start, limit []byte) error {
+	b.writes = append(b.writes, keyValue{
+		key:         utils.CopyBytes(start),
+		deleteRange: true,
+		limit:       utils.CopyBytes(limit),
+	})
+	b.size += len(start) + len(limit)
+	return nil
+}
+
+func (b *batch) Size() int {
+	return b.size
+}
+
+func (b *batch) Write() error {
+	b.db.lock.Lock()
+	defer b.db.lock.Unlock()
+
+	if b.db.closed {
+		return database.ErrClosed
+	}
+
+	batch := b.db.data.NewBatch()
+	for _, kv := range b.writes {
+		var err error
+		switch {
+		case kv.deleteRange:
+			err = database.DeleteRange(batch, kv.key, kv.limit)
+		case kv.delete:
+			err = batch.Delete(kv.key)
+		default:
+			var sealed []byte
+			sealed, err = b.db.keys.seal(kv.key, kv.value)
+			if err == nil {
+				err = batch.Put(kv.key, sealed)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+// Reset resets the batch for reuse.
+func (b *batch) Reset() {
+	if cap(b.writes) > len(b.writes)*database.MaxExcessCapacityFactor {
+		b.writes = make([]keyValue, 0, cap(b.writes)/database.CapacityReductionFactor)
+	} else {
+		b.writes = b.writes[:0]
+	}
+	b.size = 0
+}
+
+// Replay replays the batch contents.
+func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
+	for _, kv := range b.writes {
+		var err error
+		switch {
+		case kv.deleteRange:
+			err = database.DeleteRange(w, kv.key, kv.limit)
+		case kv.delete:
+			err = w.Delete(kv.key)
+		default:
+			err = w.Put(kv.key, kv.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *batch) Inner() database.Batch {
+	return b
+}
+
+type iterator struct {
+	database.Iterator
+	db *Database
+
+	key, val []byte
+	err      error
+}
+
+func (it *iterator) Next() bool {
+	// Short-circuit and set an error if the underlying database has been closed.
+	if it.db.isClosed() {
+		it.key = nil
+		it.val = nil
+		it.err = database.ErrClosed
+		return false
+	}
+	if it.err != nil || !it.Iterator.Next() {
+		it.key = nil
+		it.val = nil
+		return false
+	}
+
+	key := it.Iterator.Key()
+	it.db.lock.RLock()
+	val, err := it.db.keys.open(key, it.Iterator.Value())
+	it.db.lock.RUnlock()
+	if err != nil {
+		it.key = nil
+		it.val = nil
+		it.err = err
+		return false
+	}
+	it.key = key
+	it.val = val
+	return true
+}
+
+func (it *iterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.Iterator.Error()
+}
+
+func (it *iterator) Key() []byte {
+	return it.key
+}
+
+func (it *iterator) Value() []byte {
+	return it.val
+}
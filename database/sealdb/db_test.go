@@ -0,0 +1,274 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sealdb
+
+import (
+	"context"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/corruptabledb"
+	"github.com/coinflect/coinflectchain/database/memdb"
+	"github.com/coinflect/coinflectchain/database/prefixdb"
+	"github.com/coinflect/coinflectchain/utils/logging"
+)
+
+func newMasterKey(t testing.TB) []byte {
+	key := make([]byte, MasterKeyLen)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return key
+}
+
+func newDB(t testing.TB) *Database {
+	db, err := New(newMasterKey(t), memdb.New(), logging.NoLog{})
+	require.NoError(t, err)
+	return db
+}
+
+func waitForReencryption(t *testing.T, db *Database) {
+	require.Eventually(t, func() bool {
+		return !db.Status().Reencrypting
+	}, 5*time.Second, time.Millisecond)
+}
+
+func TestInterface(t *testing.T) {
+	for _, test := range database.Tests {
+		test(t, newDB(t))
+	}
+}
+
+func TestDeleteRangeInterface(t *testing.T) {
+	for _, test := range database.DeleteRangeTests {
+		test(t, newDB(t))
+	}
+}
+
+func FuzzInterface(f *testing.F) {
+	for _, test := range database.FuzzTests {
+		test(f, newDB(f))
+	}
+}
+
+func BenchmarkInterface(b *testing.B) {
+	for _, size := range database.BenchmarkSizes {
+		keys, values := database.SetupBenchmark(b, size[0], size[1], size[2])
+		for _, bench := range database.Benchmarks {
+			bench(b, newDB(b), "sealdb", keys, values)
+		}
+	}
+}
+
+func TestMasterKeyFile(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "keys", "master.key")
+	key, err := GenerateMasterKey(path)
+	require.NoError(err)
+
+	loadedKey, err := LoadMasterKey(path)
+	require.NoError(err)
+	require.Equal(key, loadedKey)
+
+	_, err = GenerateMasterKey(path)
+	require.ErrorIs(err, os.ErrExist)
+
+	invalidPath := filepath.Join(t.TempDir(), "invalid.key")
+	require.NoError(os.WriteFile(invalidPath, []byte("abcd\n"), 0o600))
+	_, err = LoadMasterKey(invalidPath)
+	require.ErrorIs(err, errInvalidMasterKey)
+}
+
+func TestReopen(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	masterKey := newMasterKey(t)
+	db, err := New(masterKey, baseDB, logging.NoLog{})
+	require.NoError(err)
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+	require.NoError(db.Close())
+
+	db, err = New(masterKey, baseDB, logging.NoLog{})
+	require.NoError(err)
+	value, err := db.Get([]byte("key"))
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+	require.NoError(db.Close())
+
+	_, err = New(newMasterKey(t), baseDB, logging.NoLog{})
+	require.ErrorIs(err, errWrongMasterKey)
+}
+
+func TestValuesAreEncrypted(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := New(newMasterKey(t), baseDB, logging.NoLog{})
+	require.NoError(err)
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+
+	sealed, err := prefixdb.New(dataPrefix, baseDB).Get([]byte("key"))
+	require.NoError(err)
+	require.NotContains(string(sealed), "value")
+}
+
+func TestTampering(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := New(newMasterKey(t), baseDB, logging.NoLog{})
+	require.NoError(err)
+	require.NoError(db.Put([]byte("key1"), []byte("value1")))
+	require.NoError(db.Put([]byte("key2"), []byte("value2")))
+
+	dataDB := prefixdb.New(dataPrefix, baseDB)
+	sealed, err := dataDB.Get([]byte("key1"))
+	require.NoError(err)
+
+	// Moving a value to a different key is detected.
+	require.NoError(dataDB.Put([]byte("key2"), sealed))
+	_, err = db.Get([]byte("key2"))
+	require.ErrorIs(err, ErrAuthentication)
+
+	// Modifying a value is detected.
+	sealed[len(sealed)-1] ^= 1
+	require.NoError(dataDB.Put([]byte("key1"), sealed))
+	_, err = db.Get([]byte("key1"))
+	require.ErrorIs(err, ErrAuthentication)
+
+	// Truncating a value is detected.
+	require.NoError(dataDB.Put([]byte("key1"), sealed[:headerLen-1]))
+	_, err = db.Get([]byte("key1"))
+	require.ErrorIs(err, ErrAuthentication)
+	_, err = db.Has([]byte("key1"))
+	require.ErrorIs(err, ErrAuthentication)
+
+	it := db.NewIterator()
+	require.False(it.Next())
+	require.ErrorIs(it.Error(), ErrAuthentication)
+	it.Release()
+
+	// Tampering is treated as corruption.
+	corruptableDB := corruptabledb.New(db)
+	_, err = corruptableDB.Get([]byte("key1"))
+	require.ErrorIs(err, ErrAuthentication)
+	require.ErrorIs(corruptableDB.Put([]byte("key3"), nil), ErrAuthentication)
+}
+
+func TestRotate(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	masterKey := newMasterKey(t)
+	db, err := New(masterKey, baseDB, logging.NoLog{})
+	require.NoError(err)
+	db.reencryptBatch = 3
+
+	for i := byte(0); i < 10; i++ {
+		require.NoError(db.Put([]byte{i}, []byte{i}))
+	}
+
+	newVersion, err := db.Rotate()
+	require.NoError(err)
+	require.Equal(uint32(1), newVersion)
+	waitForReencryption(t, db)
+
+	_, err = db.HealthCheck(context.Background())
+	require.NoError(err)
+	require.Equal(Status{ActiveVersion: 1}, db.Status())
+
+	// Every value is encrypted with the new key.
+	it := prefixdb.New(dataPrefix, baseDB).NewIterator()
+	for it.Next() {
+		keyVersion, ok := version(it.Value())
+		require.True(ok)
+		require.Equal(uint32(1), keyVersion)
+	}
+	require.NoError(it.Error())
+	it.Release()
+
+	// Only the new key is kept in the database.
+	metadataDB := prefixdb.New(metadataPrefix, baseDB)
+	has, err := metadataDB.Has(dekKey(0))
+	require.NoError(err)
+	require.False(has)
+	has, err = metadataDB.Has(cursorKey)
+	require.NoError(err)
+	require.False(has)
+	require.NoError(db.Close())
+
+	db, err = New(masterKey, baseDB, logging.NoLog{})
+	require.NoError(err)
+	for i := byte(0); i < 10; i++ {
+		value, err := db.Get([]byte{i})
+		require.NoError(err)
+		require.Equal([]byte{i}, value)
+	}
+	require.NoError(db.Close())
+}
+
+func TestResumeReencryption(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	masterKey := newMasterKey(t)
+	db, err := New(masterKey, baseDB, logging.NoLog{})
+	require.NoError(err)
+	for i := byte(0); i < 10; i++ {
+		require.NoError(db.Put([]byte{i}, []byte{i}))
+	}
+
+	// Rotate the key without starting re-encryption, as if the node had
+	// stopped before any value was re-encrypted.
+	db.lock.Lock()
+	db.reencrypting = true
+	db.lock.Unlock()
+	_, err = db.Rotate()
+	require.NoError(err)
+	require.NoError(db.Close())
+
+	cursor, err := prefixdb.New(metadataPrefix, baseDB).Get(cursorKey)
+	require.NoError(err)
+	require.Empty(cursor)
+
+	db, err = New(masterKey, baseDB, logging.NoLog{})
+	require.NoError(err)
+	require.Equal(uint32(1), db.Status().ActiveVersion)
+	waitForReencryption(t, db)
+
+	it := prefixdb.New(dataPrefix, baseDB).NewIterator()
+	for it.Next() {
+		keyVersion, ok := version(it.Value())
+		require.True(ok)
+		require.Equal(uint32(1), keyVersion)
+	}
+	require.NoError(it.Error())
+	it.Release()
+	require.NoError(db.Close())
+}
+
+func TestReencryptionFailure(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := New(newMasterKey(t), baseDB, logging.NoLog{})
+	require.NoError(err)
+	require.NoError(prefixdb.New(dataPrefix, baseDB).Put([]byte("key"), []byte("tampered value that is long enough")))
+
+	_, err = db.Rotate()
+	require.NoError(err)
+	waitForReencryption(t, db)
+
+	_, err = db.HealthCheck(context.Background())
+	require.ErrorIs(err, ErrAuthentication)
+	require.NoError(db.Close())
+}
@@ -0,0 +1,199 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sealdb
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/coinflect/coinflectchain/utils/perms"
+	"github.com/coinflect/coinflectchain/utils/wrappers"
+)
+
+const (
+	// MasterKeyLen is the number of bytes in a master key.
+	MasterKeyLen = chacha20poly1305.KeySize
+
+	versionLen = wrappers.IntLen
+	nonceLen   = chacha20poly1305.NonceSizeX
+	headerLen  = versionLen + nonceLen
+)
+
+var (
+	// ErrAuthentication is returned when a value stored in the underlying
+	// database wasn't written by a Database with access to the same keys. It
+	// indicates that the underlying database was tampered with or corrupted.
+	ErrAuthentication = errors.New("authentication failed")
+
+	errInvalidMasterKey = errors.New("invalid master key")
+	errWrongMasterKey   = errors.New("master key can't unwrap the data encryption keys")
+)
+
+// LoadMasterKey reads a hex encoded master key from [path].
+func LoadMasterKey(path string) ([]byte, error) {
+	keyHex, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := hex.DecodeString(string(bytes.TrimSpace(keyHex)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidMasterKey, err)
+	}
+	if len(key) != MasterKeyLen {
+		return nil, fmt.Errorf("%w: expected %d bytes but got %d", errInvalidMasterKey, MasterKeyLen, len(key))
+	}
+	return key, nil
+}
+
+// GenerateMasterKey writes a new random master key to [path]. The file is
+// only readable by its owner.
+func GenerateMasterKey(path string) ([]byte, error) {
+	key := make([]byte, MasterKeyLen)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), perms.ReadWriteExecute); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perms.ReadOnly)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.WriteString(hex.EncodeToString(key)); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return key, f.Close()
+}
+
+// keyring holds every data encryption key that values in the database may be
+// encrypted with.
+type keyring struct {
+	master cipher.AEAD
+	active uint32
+	keys   map[uint32]cipher.AEAD
+}
+
+func newKeyring(masterKey []byte) (*keyring, error) {
+	if len(masterKey) != MasterKeyLen {
+		return nil, fmt.Errorf("%w: expected %d bytes but got %d", errInvalidMasterKey, MasterKeyLen, len(masterKey))
+	}
+	master, err := chacha20poly1305.NewX(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	return &keyring{
+		master: master,
+		keys:   make(map[uint32]cipher.AEAD),
+	}, nil
+}
+
+// generate creates a new data encryption key with [version] and returns the
+// key wrapped by the master key.
+func (k *keyring) generate(version uint32) ([]byte, error) {
+	dek := make([]byte, chacha20poly1305.KeySize)
+	if _, err := rand.Read(dek); err != nil {
+		return nil, err
+	}
+	wrapped, err := seal(k.master, dekKey(version), dek)
+	if err != nil {
+		return nil, err
+	}
+	return wrapped, k.add(version, dek)
+}
+
+// unwrap adds the data encryption key with [version] that was wrapped by the
+// master key.
+func (k *keyring) unwrap(version uint32, wrapped []byte) error {
+	if len(wrapped) < nonceLen {
+		return errWrongMasterKey
+	}
+	dek, err := k.master.Open(nil, wrapped[:nonceLen], wrapped[nonceLen:], dekKey(version))
+	if err != nil {
+		return errWrongMasterKey
+	}
+	return k.add(version, dek)
+}
+
+func (k *keyring) add(version uint32, dek []byte) error {
+	aead, err := chacha20poly1305.NewX(dek)
+	if err != nil {
+		return err
+	}
+	k.keys[version] = aead
+	return nil
+}
+
+// seal encrypts [value] with the active data encryption key. Both [key] and
+// the version of the data encryption key are authenticated.
+//
+// The result is formatted as:
+// [version (4 bytes)] [nonce (24 bytes)] [ciphertext and tag]
+func (k *keyring) seal(key, value []byte) ([]byte, error) {
+	nonce := make([]byte, nonceLen, headerLen+len(value)+chacha20poly1305.Overhead)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	header := make([]byte, versionLen, cap(nonce))
+	binary.BigEndian.PutUint32(header, k.active)
+	header = append(header, nonce...)
+	return k.keys[k.active].Seal(header, nonce, value, additionalData(header[:versionLen], key)), nil
+}
+
+// open authenticates and decrypts [sealed], which was stored under [key].
+func (k *keyring) open(key, sealed []byte) ([]byte, error) {
+	if len(sealed) < headerLen {
+		return nil, fmt.Errorf("%w: value of key 0x%x is too short", ErrAuthentication, key)
+	}
+	version := binary.BigEndian.Uint32(sealed)
+	aead, ok := k.keys[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: value of key 0x%x uses unknown key version %d", ErrAuthentication, key, version)
+	}
+	value, err := aead.Open(nil, sealed[versionLen:headerLen], sealed[headerLen:], additionalData(sealed[:versionLen], key))
+	if err != nil {
+		return nil, fmt.Errorf("%w: value of key 0x%x", ErrAuthentication, key)
+	}
+	return value, nil
+}
+
+// version returns the version of the data encryption key that [sealed] was
+// encrypted with.
+func version(sealed []byte) (uint32, bool) {
+	if len(sealed) < versionLen {
+		return 0, false
+	}
+	return binary.BigEndian.Uint32(sealed), true
+}
+
+func additionalData(version, key []byte) []byte {
+	ad := make([]byte, 0, len(version)+len(key))
+	ad = append(ad, version...)
+	return append(ad, key...)
+}
+
+func seal(aead cipher.AEAD, additionalData, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, nonceLen, nonceLen+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func dekKey(version uint32) []byte {
+	key := make([]byte, len(dekPrefix)+versionLen)
+	copy(key, dekPrefix)
+	binary.BigEndian.PutUint32(key[len(dekPrefix):], version)
+	return key
+}
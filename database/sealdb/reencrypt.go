@@ -0,0 +1,188 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sealdb
+
+import (
+	"bytes"
+
+	"go.uber.org/zap"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/utils"
+)
+
+// Rotate generates a new data encryption key and returns its version. New
+// values are encrypted with the new key immediately. Values that were
+// encrypted with older keys are re-encrypted in the background, after which
+// the older keys are removed from the database.
+//
+// If the database is closed before re-encryption finishes, re-encryption is
+// resumed when the database is reopened.
+func (db *Database) Rotate() (uint32, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return 0, database.ErrClosed
+	}
+
+	newVersion := db.keys.active + 1
+	wrapped, err := db.keys.generate(newVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	batch := db.metadata.NewBatch()
+	if err := batch.Put(dekKey(newVersion), wrapped); err != nil {
+		return 0, err
+	}
+	if err := database.PutUInt32(batch, activeVersionKey, newVersion); err != nil {
+		return 0, err
+	}
+	// Values that were re-encrypted with the previous key need to be
+	// re-encrypted again, so re-encryption restarts from the first key.
+	if err := batch.Put(cursorKey, nil); err != nil {
+		return 0, err
+	}
+	if err := batch.Write(); err != nil {
+		delete(db.keys.keys, newVersion)
+		return 0, err
+	}
+
+	db.keys.active = newVersion
+	db.cursor = nil
+	db.reencryptErr = nil
+	if !db.reencrypting {
+		db.startReencryption()
+	}
+
+	db.log.Info("rotated data encryption key",
+		zap.Uint32("version", newVersion),
+	)
+	return newVersion, nil
+}
+
+// startReencryption assumes that [db.lock] is held or that [db] isn't shared
+// yet.
+func (db *Database) startReencryption() {
+	db.reencrypting = true
+	db.wg.Add(1)
+	go db.reencrypt()
+}
+
+func (db *Database) reencrypt() {
+	defer db.wg.Done()
+
+	for {
+		if db.reencryptNext() {
+			return
+		}
+	}
+}
+
+// reencryptNext re-encrypts the next batch of keys. Returns true once
+// re-encryption stopped.
+func (db *Database) reencryptNext() bool {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return true
+	}
+
+	done, err := db.reencryptBatchOfKeys()
+	if err != nil {
+		db.reencrypting = false
+		db.reencryptErr = err
+		db.log.Error("failed to re-encrypt database",
+			zap.Uint32("version", db.keys.active),
+			zap.Error(err),
+		)
+		return true
+	}
+	if done {
+		db.reencrypting = false
+		db.log.Info("finished re-encrypting database",
+			zap.Uint32("version", db.keys.active),
+		)
+	}
+	return done
+}
+
+// reencryptBatchOfKeys re-encrypts up to [db.reencryptBatch] keys after
+// [db.cursor] with the active key and persists the progress. Returns true if
+// there are no keys left to re-encrypt.
+//
+// Assumes [db.lock] is held.
+func (db *Database) reencryptBatchOfKeys() (bool, error) {
+	it := db.data.NewIteratorWithStart(db.cursor)
+	defer it.Release()
+
+	var (
+		batch   = db.data.NewBatch()
+		numKeys int
+		lastKey []byte
+	)
+	for numKeys < db.reencryptBatch && it.Next() {
+		key := it.Key()
+		if db.cursor != nil && bytes.Equal(key, db.cursor) {
+			continue
+		}
+		numKeys++
+		lastKey = utils.CopyBytes(key)
+
+		sealed := it.Value()
+		if keyVersion, ok := version(sealed); ok && keyVersion == db.keys.active {
+			continue
+		}
+		value, err := db.keys.open(key, sealed)
+		if err != nil {
+			return false, err
+		}
+		resealed, err := db.keys.seal(key, value)
+		if err != nil {
+			return false, err
+		}
+		if err := batch.Put(key, resealed); err != nil {
+			return false, err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return false, err
+	}
+
+	// The cursor is persisted after the re-encrypted values. If the node
+	// stops in between, the values are skipped when re-encryption resumes
+	// because they are already encrypted with the active key.
+	if err := batch.Write(); err != nil {
+		return false, err
+	}
+	if numKeys == db.reencryptBatch {
+		db.cursor = lastKey
+		return false, db.metadata.Put(cursorKey, lastKey)
+	}
+	return true, db.removeInactiveKeys()
+}
+
+// removeInactiveKeys removes every data encryption key other than the active
+// key from the database. The keys are kept in memory so that iterators that
+// were created before re-encryption finished can still decrypt their values.
+//
+// Assumes [db.lock] is held.
+func (db *Database) removeInactiveKeys() error {
+	batch := db.metadata.NewBatch()
+	for keyVersion := range db.keys.keys {
+		if keyVersion == db.keys.active {
+			continue
+		}
+		if err := batch.Delete(dekKey(keyVersion)); err != nil {
+			return err
+		}
+	}
+	if err := batch.Delete(cursorKey); err != nil {
+		return err
+	}
+	return batch.Write()
+}
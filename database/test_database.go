@@ -53,6 +53,26 @@ var Tests = []func(t *testing.T, db Database){
 	TestPutGetEmpty,
 }
 
+// SnapshotTests is a list of all tests for databases that implement
+// Snapshotter
+var SnapshotTests = []func(t *testing.T, db Database){
+	TestSnapshotIsolation,
+	TestSnapshotIteratorStartPrefix,
+	TestSnapshotBatchIsolation,
+	TestSnapshotRelease,
+	TestSnapshotClosed,
+}
+
+// DeleteRangeTests is a list of all tests for databases whose databases and
+// batches implement RangeDeleter
+var DeleteRangeTests = []func(t *testing.T, db Database){
+	TestDeleteRange,
+	TestDeleteRangeUnbounded,
+	TestBatchDeleteRange,
+	TestBatchDeleteRangeReplay,
+	TestDeleteRangeClosed,
+}
+
 var FuzzTests = []func(*testing.F, Database){
 	FuzzKeyValue,
 }
@@ -1356,6 +1376,327 @@ func TestPutGetEmpty(t *testing.T, db Database) {
 	require.Empty(value) // May be nil or empty byte slice.
 }
 
+// TestSnapshotIsolation tests to make sure that writes made after a snapshot
+// was created are not visible through the snapshot.
+func TestSnapshotIsolation(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	require.NoError(db.Put(key1, value1))
+	require.NoError(db.Put(key2, value2))
+
+	snapshot, err := NewSnapshot(db)
+	require.NoError(err)
+	defer snapshot.Release()
+
+	require.NoError(db.Put(key1, value3))
+	require.NoError(db.Delete(key2))
+	require.NoError(db.Put(key3, value3))
+
+	value, err := snapshot.Get(key1)
+	require.NoError(err)
+	require.Equal(value1, value)
+
+	has, err := snapshot.Has(key2)
+	require.NoError(err)
+	require.True(has)
+
+	has, err = snapshot.Has(key3)
+	require.NoError(err)
+	require.False(has)
+
+	_, err = snapshot.Get(key3)
+	require.Equal(ErrNotFound, err)
+
+	iterator := snapshot.NewIterator()
+	defer iterator.Release()
+
+	require.True(iterator.Next())
+	require.Equal(key1, iterator.Key())
+	require.Equal(value1, iterator.Value())
+	require.True(iterator.Next())
+	require.Equal(key2, iterator.Key())
+	require.Equal(value2, iterator.Value())
+	require.False(iterator.Next())
+	require.NoError(iterator.Error())
+
+	// The database itself must reflect the writes.
+	value, err = db.Get(key1)
+	require.NoError(err)
+	require.Equal(value3, value)
+
+	has, err = db.Has(key2)
+	require.NoError(err)
+	require.False(has)
+}
+
+// TestSnapshotIteratorStartPrefix tests to make sure that the iterators of a
+// snapshot respect the requested start and prefix.
+func TestSnapshotIteratorStartPrefix(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+	key2 := []byte("z")
+	value2 := []byte("world2")
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+	key4 := []byte("hello4")
+	value4 := []byte("world4")
+
+	require.NoError(db.Put(key1, value1))
+	require.NoError(db.Put(key2, value2))
+	require.NoError(db.Put(key3, value3))
+
+	snapshot, err := NewSnapshot(db)
+	require.NoError(err)
+	defer snapshot.Release()
+
+	require.NoError(db.Put(key4, value4))
+
+	iterator := snapshot.NewIteratorWithStartAndPrefix(key1, []byte("h"))
+	defer iterator.Release()
+
+	require.True(iterator.Next())
+	require.Equal(key1, iterator.Key())
+	require.Equal(value1, iterator.Value())
+	require.True(iterator.Next())
+	require.Equal(key3, iterator.Key())
+	require.Equal(value3, iterator.Value())
+	require.False(iterator.Next())
+	require.NoError(iterator.Error())
+
+	startIterator := snapshot.NewIteratorWithStart(key3)
+	defer startIterator.Release()
+
+	require.True(startIterator.Next())
+	require.Equal(key3, startIterator.Key())
+	require.True(startIterator.Next())
+	require.Equal(key2, startIterator.Key())
+	require.False(startIterator.Next())
+	require.NoError(startIterator.Error())
+}
+
+// TestSnapshotBatchIsolation tests to make sure that batches written after a
+// snapshot was created are not visible through the snapshot.
+func TestSnapshotBatchIsolation(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	require.NoError(db.Put(key1, value1))
+
+	snapshot, err := NewSnapshot(db)
+	require.NoError(err)
+	defer snapshot.Release()
+
+	batch := db.NewBatch()
+	require.NoError(batch.Delete(key1))
+	require.NoError(batch.Put(key2, value2))
+	require.NoError(batch.Write())
+
+	value, err := snapshot.Get(key1)
+	require.NoError(err)
+	require.Equal(value1, value)
+
+	has, err := snapshot.Has(key2)
+	require.NoError(err)
+	require.False(has)
+
+	count, err := Count(snapshot)
+	require.NoError(err)
+	require.Equal(1, count)
+}
+
+// TestSnapshotRelease tests to make sure that a snapshot can't be used after
+// it has been released.
+func TestSnapshotRelease(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key := []byte("hello")
+	value := []byte("world")
+
+	require.NoError(db.Put(key, value))
+
+	snapshot, err := NewSnapshot(db)
+	require.NoError(err)
+
+	snapshot.Release()
+	snapshot.Release()
+
+	_, err = snapshot.Get(key)
+	require.Equal(ErrClosed, err)
+
+	_, err = snapshot.Has(key)
+	require.Equal(ErrClosed, err)
+
+	iterator := snapshot.NewIterator()
+	defer iterator.Release()
+
+	require.False(iterator.Next())
+	require.Equal(ErrClosed, iterator.Error())
+
+	// Releasing a snapshot must not affect the database.
+	got, err := db.Get(key)
+	require.NoError(err)
+	require.Equal(value, got)
+}
+
+// TestSnapshotClosed tests to make sure that a snapshot can't be used after
+// its database has been closed.
+func TestSnapshotClosed(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key := []byte("hello")
+	value := []byte("world")
+
+	require.NoError(db.Put(key, value))
+
+	snapshot, err := NewSnapshot(db)
+	require.NoError(err)
+	defer snapshot.Release()
+
+	require.NoError(db.Close())
+
+	_, err = snapshot.Get(key)
+	require.Equal(ErrClosed, err)
+
+	_, err = snapshot.Has(key)
+	require.Equal(ErrClosed, err)
+
+	iterator := snapshot.NewIterator()
+	defer iterator.Release()
+
+	require.False(iterator.Next())
+	require.Equal(ErrClosed, iterator.Error())
+
+	_, err = NewSnapshot(db)
+	require.Equal(ErrClosed, err)
+}
+
+func putKeys(t *testing.T, db KeyValueWriter, keys ...string) {
+	for _, key := range keys {
+		require.NoError(t, db.Put([]byte(key), []byte(key)))
+	}
+}
+
+func requireKeys(t *testing.T, db Iteratee, keys ...string) {
+	require := require.New(t)
+
+	iterator := db.NewIterator()
+	defer iterator.Release()
+
+	var found []string
+	for iterator.Next() {
+		found = append(found, string(iterator.Key()))
+		require.Equal(iterator.Key(), iterator.Value())
+	}
+	require.NoError(iterator.Error())
+	require.Equal(keys, found)
+}
+
+// TestDeleteRange tests to make sure that DeleteRange only removes the keys in
+// the range
+func TestDeleteRange(t *testing.T, db Database) {
+	require := require.New(t)
+
+	putKeys(t, db, "a", "b", "bb", "c", "d")
+
+	require.NoError(DeleteRange(db, []byte("b"), []byte("c")))
+	requireKeys(t, db, "a", "c", "d")
+
+	has, err := db.Has([]byte("bb"))
+	require.NoError(err)
+	require.False(has)
+
+	_, err = db.Get([]byte("b"))
+	require.Equal(ErrNotFound, err)
+
+	// Deleting an empty range is a no-op.
+	require.NoError(DeleteRange(db, []byte("c"), []byte("c")))
+	requireKeys(t, db, "a", "c", "d")
+
+	// Keys can be written into a deleted range.
+	putKeys(t, db, "b")
+	requireKeys(t, db, "a", "b", "c", "d")
+}
+
+// TestDeleteRangeUnbounded tests to make sure that empty bounds are treated as
+// the start and the end of the key space
+func TestDeleteRangeUnbounded(t *testing.T, db Database) {
+	require := require.New(t)
+
+	putKeys(t, db, "a", "b", "c", "d")
+
+	require.NoError(DeleteRange(db, []byte("c"), nil))
+	requireKeys(t, db, "a", "b")
+
+	require.NoError(DeleteRange(db, nil, []byte("b")))
+	requireKeys(t, db, "b")
+
+	require.NoError(DeleteRange(db, nil, nil))
+	requireKeys(t, db)
+}
+
+// TestBatchDeleteRange tests to make sure that a range deleted in a batch is
+// only deleted when the batch is written and only affects the operations that
+// were added to the batch before it
+func TestBatchDeleteRange(t *testing.T, db Database) {
+	require := require.New(t)
+
+	putKeys(t, db, "a", "b", "c", "d")
+
+	batch := db.NewBatch()
+	putKeys(t, batch, "bb", "e")
+	require.NoError(DeleteRange(batch, []byte("b"), []byte("d")))
+	putKeys(t, batch, "c")
+	require.NoError(batch.Delete([]byte("a")))
+
+	// Nothing is deleted until the batch is written.
+	requireKeys(t, db, "a", "b", "c", "d")
+
+	require.NoError(batch.Write())
+	requireKeys(t, db, "c", "d", "e")
+}
+
+// TestBatchDeleteRangeReplay tests to make sure that range deletions are
+// replayed in order
+func TestBatchDeleteRangeReplay(t *testing.T, db Database) {
+	require := require.New(t)
+
+	putKeys(t, db, "a", "b", "c")
+
+	batch := db.NewBatch()
+	putKeys(t, batch, "bb")
+	require.NoError(DeleteRange(batch, []byte("b"), nil))
+	putKeys(t, batch, "c")
+
+	// Replaying the batch into the database itself must have the same effect
+	// as writing it.
+	require.NoError(batch.Replay(db))
+	requireKeys(t, db, "a", "c")
+}
+
+// TestDeleteRangeClosed tests to make sure that DeleteRange returns an error
+// once the database is closed
+func TestDeleteRangeClosed(t *testing.T, db Database) {
+	require := require.New(t)
+
+	putKeys(t, db, "a")
+
+	require.NoError(db.Close())
+	require.Equal(ErrClosed, DeleteRange(db, nil, nil))
+}
+
 func FuzzKeyValue(f *testing.F, db Database) {
 	f.Fuzz(func(t *testing.T, key []byte, value []byte) {
 		require := require.New(t)
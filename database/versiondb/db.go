@@ -21,10 +21,13 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ Commitable        = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database     = (*Database)(nil)
+	_ database.RangeDeleter = (*Database)(nil)
+	_ Commitable            = (*Database)(nil)
+	_ database.Batch        = (*batch)(nil)
+	_ database.RangeDeleter = (*batch)(nil)
+	_ database.Iterator     = (*iterator)(nil)
+	_ database.Iterator     = (*rangeIterator)(nil)
 )
 
 // Commitable defines the interface that specifies that something may be
@@ -42,6 +45,28 @@ type Database struct {
 	mem   map[string]valueDelete
 	db    database.Database
 	batch database.Batch
+
+	// ranges that were deleted from the underlying database. Keys in [mem]
+	// were written after the ranges were deleted.
+	ranges keyRanges
+
+	// If non-nil, commits are written through the journal
+	journal *Journal
+}
+
+type keyRange struct {
+	start, limit []byte
+}
+
+type keyRanges []keyRange
+
+func (rs keyRanges) contains(key []byte) bool {
+	for _, r := range rs {
+		if database.InRange(key, r.start, r.limit) {
+			return true
+		}
+	}
+	return false
 }
 
 type valueDelete struct {
@@ -68,6 +93,9 @@ func (db *Database) Has(key []byte) (bool, error) {
 	if val, has := db.mem[string(key)]; has {
 		return !val.delete, nil
 	}
+	if db.ranges.contains(key) {
+		return false, nil
+	}
 	return db.db.Has(key)
 }
 
@@ -84,6 +112,9 @@ func (db *Database) Get(key []byte) ([]byte, error) {
 		}
 		return utils.CopyBytes(val.value), nil
 	}
+	if db.ranges.contains(key) {
+		return nil, database.ErrNotFound
+	}
 	return db.db.Get(key)
 }
 
@@ -109,6 +140,32 @@ func (db *Database) Delete(key []byte) error {
 	return nil
 }
 
+// DeleteRange removes every key in [start, limit). The range is deleted from
+// the underlying database when the database is committed.
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.mem == nil {
+		return database.ErrClosed
+	}
+	db.deleteRange(start, limit)
+	return nil
+}
+
+// Assumes [db.lock] is held
+func (db *Database) deleteRange(start, limit []byte) {
+	for key := range db.mem {
+		if database.InRange([]byte(key), start, limit) {
+			delete(db.mem, key)
+		}
+	}
+	db.ranges = append(db.ranges, keyRange{
+		start: utils.CopyBytes(start),
+		limit: utils.CopyBytes(limit),
+	})
+}
+
 func (db *Database) NewBatch() database.Batch {
 	return &batch{db: db}
 }
@@ -147,9 +204,16 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 		values[i] = db.mem[key]
 	}
 
+	var it database.Iterator = db.db.NewIteratorWithStartAndPrefix(start, prefix)
+	if len(db.ranges) > 0 {
+		it = &rangeIterator{
+			Iterator: it,
+			ranges:   db.ranges,
+		}
+	}
 	return &iterator{
 		db:       db,
-		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		Iterator: it,
 		keys:     keys,
 		values:   values,
 	}
@@ -187,8 +251,20 @@ func (db *Database) GetDatabase() database.Database {
 	return db.db
 }
 
+// NewWithJournal returns a new versioned database whose commits are written
+// atomically through [journal]. [db] must be backed by the journal's database.
+func NewWithJournal(db database.Database, journal *Journal) *Database {
+	vdb := New(db)
+	vdb.journal = journal
+	return vdb
+}
+
 // Commit writes all the operations of this database to the underlying database
 func (db *Database) Commit() error {
+	if db.journal != nil {
+		return db.journal.Commit(db)
+	}
+
 	db.lock.Lock()
 	defer db.lock.Unlock()
 
@@ -213,6 +289,8 @@ func (db *Database) Abort() {
 }
 
 func (db *Database) abort() {
+	db.ranges = nil
+
 	// If there are a lot of keys, clear the map by just allocating a new one
 	if len(db.mem) > iterativeDeleteThreshold {
 		db.mem = make(map[string]valueDelete, memdb.DefaultSize)
@@ -243,6 +321,11 @@ func (db *Database) commitBatch() (database.Batch, error) {
 	}
 
 	db.batch.Reset()
+	for _, r := range db.ranges {
+		if err := deleteRange(db.db, db.batch, r); err != nil {
+			return nil, err
+		}
+	}
 	for key, value := range db.mem {
 		if value.delete {
 			if err := db.batch.Delete([]byte(key)); err != nil {
@@ -266,9 +349,33 @@ func (db *Database) Close() error {
 	db.batch = nil
 	db.mem = nil
 	db.db = nil
+	db.ranges = nil
 	return nil
 }
 
+// deleteRange adds the deletion of [r] from [db] to [batch]. If [batch] doesn't
+// support range deletion, the keys in [r] are deleted individually.
+func deleteRange(db database.Iteratee, batch database.Batch, r keyRange) error {
+	err := database.DeleteRange(batch, r.start, r.limit)
+	if err != database.ErrRangeDeletionNotSupported {
+		return err
+	}
+
+	it := db.NewIteratorWithStart(r.start)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if !database.InRange(key, r.start, r.limit) {
+			break
+		}
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
 func (db *Database) isClosed() bool {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
@@ -290,6 +397,10 @@ type keyValue struct {
 	key    []byte
 	value  []byte
 	delete bool
+
+	// If deleteRange is true, every key in [key, limit) is deleted.
+	deleteRange bool
+	limit       []byte
 }
 
 type batch struct {
@@ -299,17 +410,27 @@ type batch struct {
 }
 
 func (b *batch) Put(key, value []byte) error {
-	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), utils.CopyBytes(value), false})
+	b.writes = append(b.writes, keyValue{key: utils.CopyBytes(key), value: utils.CopyBytes(value)})
 	b.size += len(key) + len(value)
 	return nil
 }
 
 func (b *batch) Delete(key []byte) error {
-	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), nil, true})
+	b.writes = append(b.writes, keyValue{key: utils.CopyBytes(key), delete: true})
 	b.size += len(key)
 	return nil
 }
 
+func (b *batch) DeleteRange(start, limit []byte) error {
+	b.writes = append(b.writes, keyValue{
+		key:         utils.CopyBytes(start),
+		deleteRange: true,
+		limit:       utils.CopyBytes(limit),
+	})
+	b.size += len(start) + len(limit)
+	return nil
+}
+
 func (b *batch) Size() int {
 	return b.size
 }
@@ -323,6 +444,10 @@ func (b *batch) Write() error {
 	}
 
 	for _, kv := range b.writes {
+		if kv.deleteRange {
+			b.db.deleteRange(kv.key, kv.limit)
+			continue
+		}
 		b.db.mem[string(kv.key)] = valueDelete{
 			value:  kv.value,
 			delete: kv.delete,
@@ -342,12 +467,19 @@ func (b *batch) Reset() {
 
 func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 	for _, kv := range b.writes {
-		if kv.delete {
+		switch {
+		case kv.deleteRange:
+			if err := database.DeleteRange(w, kv.key, kv.limit); err != nil {
+				return err
+			}
+		case kv.delete:
 			if err := w.Delete(kv.key); err != nil {
 				return err
 			}
-		} else if err := w.Put(kv.key, kv.value); err != nil {
-			return err
+		default:
+			if err := w.Put(kv.key, kv.value); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -478,3 +610,19 @@ func (it *iterator) Release() {
 	it.values = nil
 	it.Iterator.Release()
 }
+
+// rangeIterator skips the keys of the underlying database that are in a
+// deleted range.
+type rangeIterator struct {
+	database.Iterator
+	ranges keyRanges
+}
+
+func (it *rangeIterator) Next() bool {
+	for it.Iterator.Next() {
+		if !it.ranges.contains(it.Iterator.Key()) {
+			return true
+		}
+	}
+	return false
+}
@@ -8,6 +8,8 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/coinflect/coinflectchain/database"
 	"github.com/coinflect/coinflectchain/database/memdb"
 )
@@ -19,6 +21,13 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestDeleteRangeInterface(t *testing.T) {
+	for _, test := range database.DeleteRangeTests {
+		baseDB := memdb.New()
+		test(t, New(baseDB))
+	}
+}
+
 func FuzzInterface(f *testing.F) {
 	for _, test := range database.FuzzTests {
 		baseDB := memdb.New()
@@ -391,3 +400,77 @@ func BenchmarkInterface(b *testing.B) {
 		}
 	}
 }
+
+// noRangeDeleterDB hides the RangeDeleter implementation of the wrapped
+// database.
+type noRangeDeleterDB struct {
+	database.Database
+}
+
+func (db *noRangeDeleterDB) NewBatch() database.Batch {
+	return &noRangeDeleterBatch{Batch: db.Database.NewBatch()}
+}
+
+type noRangeDeleterBatch struct {
+	database.Batch
+}
+
+func TestCommitDeleteRange(t *testing.T) {
+	for _, baseDB := range []database.Database{
+		memdb.New(),
+		&noRangeDeleterDB{Database: memdb.New()},
+	} {
+		require := require.New(t)
+
+		for _, key := range []string{"a", "b", "c", "d"} {
+			require.NoError(baseDB.Put([]byte(key), nil))
+		}
+
+		db := New(baseDB)
+		require.NoError(db.Put([]byte("bb"), nil))
+		require.NoError(db.DeleteRange([]byte("b"), []byte("d")))
+		require.NoError(db.Put([]byte("c"), nil))
+
+		// The range isn't deleted from the underlying database until commit.
+		has, err := baseDB.Has([]byte("b"))
+		require.NoError(err)
+		require.True(has)
+
+		require.NoError(db.Commit())
+
+		count, err := database.Count(baseDB)
+		require.NoError(err)
+		require.Equal(3, count)
+		for key, expected := range map[string]bool{
+			"a":  true,
+			"b":  false,
+			"bb": false,
+			"c":  true,
+			"d":  true,
+		} {
+			has, err := baseDB.Has([]byte(key))
+			require.NoError(err)
+			require.Equal(expected, has, key)
+		}
+	}
+}
+
+func TestAbortDeleteRange(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	require.NoError(baseDB.Put([]byte("a"), nil))
+
+	db := New(baseDB)
+	require.NoError(db.DeleteRange(nil, nil))
+
+	has, err := db.Has([]byte("a"))
+	require.NoError(err)
+	require.False(has)
+
+	db.Abort()
+
+	has, err = db.Has([]byte("a"))
+	require.NoError(err)
+	require.True(has)
+}
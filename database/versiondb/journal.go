@@ -0,0 +1,230 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package versiondb
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/prefixdb"
+	"github.com/coinflect/coinflectchain/utils/wrappers"
+)
+
+const (
+	putOp byte = iota
+	deleteOp
+	deleteRangeOp
+)
+
+var (
+	_ database.KeyValueWriterDeleter = (*record)(nil)
+	_ database.RangeDeleter          = (*record)(nil)
+
+	journalPrefix = []byte("journal")
+
+	errUnknownOp = errors.New("unknown journal operation")
+)
+
+// Journal makes writes to a database atomic, even if the writes are spread
+// over multiple batches or multiple versioned databases.
+//
+// Before anything is written, every operation is written to the database as a
+// single record. The record is deleted once all of its operations were
+// written. If the process stops while the operations are being written, the
+// record is found by NewJournal, which writes the operations again.
+//
+// The operations are written to the database the journal was created with, so
+// every database committed through a journal must be backed by it, such as
+// through prefixdb.
+type Journal struct {
+	lock    sync.Mutex
+	db      database.Database
+	records database.Database
+	nextID  uint64
+}
+
+// NewJournal returns a journal that writes to [db]. The records that were left
+// in [db] by an interrupted write are written before this function returns.
+func NewJournal(db database.Database) (*Journal, error) {
+	j := &Journal{
+		db:      db,
+		records: prefixdb.New(journalPrefix, db),
+	}
+	return j, j.recover()
+}
+
+// recover writes the operations of every record in [j.records].
+func (j *Journal) recover() error {
+	it := j.records.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		id, err := database.ParseUInt64(it.Key())
+		if err != nil {
+			return err
+		}
+		r, err := parseRecord(it.Value())
+		if err != nil {
+			return fmt.Errorf("failed to parse journal record %d: %w", id, err)
+		}
+		if err := j.apply(id, r); err != nil {
+			return fmt.Errorf("failed to apply journal record %d: %w", id, err)
+		}
+		j.nextID = id + 1
+	}
+	return it.Error()
+}
+
+// Commit atomically writes the operations of all of [dbs] to their underlying
+// databases.
+func (j *Journal) Commit(dbs ...*Database) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	for _, db := range dbs {
+		db.lock.Lock()
+		defer db.lock.Unlock()
+	}
+
+	batches := make([]database.Batch, len(dbs))
+	for i, db := range dbs {
+		batch, err := db.commitBatch()
+		if err != nil {
+			return err
+		}
+		batches[i] = batch
+	}
+	if err := j.writeAll(batches); err != nil {
+		return err
+	}
+	for i, db := range dbs {
+		batches[i].Reset()
+		db.abort()
+	}
+	return nil
+}
+
+// WriteAll atomically writes [batches]. The inner batch of every batch must
+// write to the journal's database.
+func (j *Journal) WriteAll(batches ...database.Batch) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	return j.writeAll(batches)
+}
+
+// Assumes [j.lock] is held
+func (j *Journal) writeAll(batches []database.Batch) error {
+	var r record
+	for _, batch := range batches {
+		if err := batch.Inner().Replay(&r); err != nil {
+			return err
+		}
+	}
+	if len(r) == 0 {
+		return nil
+	}
+
+	id := j.nextID
+	bytes, err := r.bytes()
+	if err != nil {
+		return err
+	}
+	if err := j.records.Put(database.PackUInt64(id), bytes); err != nil {
+		return err
+	}
+	j.nextID++
+	return j.apply(id, r)
+}
+
+// apply writes the operations of [r] to [j.db] and then deletes the record.
+func (j *Journal) apply(id uint64, r record) error {
+	batch := j.db.NewBatch()
+	for _, kv := range r {
+		var err error
+		switch {
+		case kv.deleteRange:
+			err = deleteRange(j.db, batch, keyRange{start: kv.key, limit: kv.limit})
+		case kv.delete:
+			err = batch.Delete(kv.key)
+		default:
+			err = batch.Put(kv.key, kv.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	return j.records.Delete(database.PackUInt64(id))
+}
+
+// record is the list of operations that are written atomically.
+type record []keyValue
+
+func (r *record) Put(key, value []byte) error {
+	*r = append(*r, keyValue{key: key, value: value})
+	return nil
+}
+
+func (r *record) Delete(key []byte) error {
+	*r = append(*r, keyValue{key: key, delete: true})
+	return nil
+}
+
+func (r *record) DeleteRange(start, limit []byte) error {
+	*r = append(*r, keyValue{key: start, deleteRange: true, limit: limit})
+	return nil
+}
+
+func (r record) bytes() ([]byte, error) {
+	p := wrappers.Packer{MaxSize: math.MaxInt32}
+	p.PackInt(uint32(len(r)))
+	for _, kv := range r {
+		switch {
+		case kv.deleteRange:
+			p.PackByte(deleteRangeOp)
+			p.PackBytes(kv.key)
+			p.PackBytes(kv.limit)
+		case kv.delete:
+			p.PackByte(deleteOp)
+			p.PackBytes(kv.key)
+		default:
+			p.PackByte(putOp)
+			p.PackBytes(kv.key)
+			p.PackBytes(kv.value)
+		}
+	}
+	return p.Bytes, p.Err
+}
+
+func parseRecord(bytes []byte) (record, error) {
+	p := wrappers.Packer{Bytes: bytes}
+	numOps := p.UnpackInt()
+	if p.Errored() {
+		return nil, p.Err
+	}
+
+	r := make(record, 0, numOps)
+	for i := uint32(0); i < numOps && !p.Errored(); i++ {
+		op := p.UnpackByte()
+		key := p.UnpackBytes()
+		switch op {
+		case putOp:
+			r = append(r, keyValue{key: key, value: p.UnpackBytes()})
+		case deleteOp:
+			r = append(r, keyValue{key: key, delete: true})
+		case deleteRangeOp:
+			r = append(r, keyValue{key: key, deleteRange: true, limit: p.UnpackBytes()})
+		default:
+			return nil, fmt.Errorf("%w: %d", errUnknownOp, op)
+		}
+	}
+	return r, p.Err
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package versiondb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/crashdb"
+	"github.com/coinflect/coinflectchain/database/memdb"
+	"github.com/coinflect/coinflectchain/database/prefixdb"
+)
+
+var (
+	namespaceA = []byte("a")
+	namespaceB = []byte("b")
+)
+
+// newJournalTestDB returns a database that holds the same keys in two
+// namespaces.
+func newJournalTestDB(t *testing.T) database.Database {
+	baseDB := memdb.New()
+	for _, ns := range [][]byte{namespaceA, namespaceB} {
+		db := prefixdb.New(ns, baseDB)
+		for _, key := range []string{"k1", "k2", "k3"} {
+			require.NoError(t, db.Put([]byte(key), []byte("old")))
+		}
+	}
+	return baseDB
+}
+
+// writeJournalTestOps writes the operations that are committed by the tests
+// to [a] and [b].
+func writeJournalTestOps(t *testing.T, a, b *Database) {
+	require := require.New(t)
+
+	require.NoError(a.Put([]byte("k1"), []byte("new")))
+	require.NoError(a.Delete([]byte("k2")))
+	require.NoError(a.Put([]byte("k4"), []byte("new")))
+	require.NoError(b.DeleteRange([]byte("k1"), []byte("k3")))
+	require.NoError(b.Put([]byte("k2"), []byte("new")))
+}
+
+// readNamespaces returns the contents of both namespaces of [db].
+func readNamespaces(t *testing.T, db database.Database) map[string]string {
+	contents := make(map[string]string)
+	for _, ns := range [][]byte{namespaceA, namespaceB} {
+		it := prefixdb.New(ns, db).NewIterator()
+		for it.Next() {
+			contents[string(ns)+"/"+string(it.Key())] = string(it.Value())
+		}
+		require.NoError(t, it.Error())
+		it.Release()
+	}
+	return contents
+}
+
+func TestJournalCommitIsAtomic(t *testing.T) {
+	oldState := map[string]string{
+		"a/k1": "old",
+		"a/k2": "old",
+		"a/k3": "old",
+		"b/k1": "old",
+		"b/k2": "old",
+		"b/k3": "old",
+	}
+	newState := map[string]string{
+		"a/k1": "new",
+		"a/k3": "old",
+		"a/k4": "new",
+		"b/k2": "new",
+		"b/k3": "old",
+	}
+
+	for unsynced := 0; unsynced < 3; unsynced++ {
+		for crashAfter := 1; ; crashAfter++ {
+			require := require.New(t)
+
+			baseDB := newJournalTestDB(t)
+			crashDB := crashdb.New(baseDB, crashAfter, unsynced)
+
+			journal, err := NewJournal(crashDB)
+			require.NoError(err)
+			a := NewWithJournal(prefixdb.New(namespaceA, crashDB), journal)
+			b := NewWithJournal(prefixdb.New(namespaceB, crashDB), journal)
+			writeJournalTestOps(t, a, b)
+
+			err = journal.Commit(a, b)
+			if !crashDB.Crashed() {
+				require.NoError(err)
+				require.Equal(newState, readNamespaces(t, baseDB))
+				break
+			}
+			require.ErrorIs(err, crashdb.ErrCrashed)
+
+			// Recovering from the journal leaves either none or all of the
+			// operations applied.
+			_, err = NewJournal(baseDB)
+			require.NoError(err)
+			state := readNamespaces(t, baseDB)
+			if state["a/k1"] == "old" {
+				require.Equal(oldState, state, "unsynced=%d crashAfter=%d", unsynced, crashAfter)
+			} else {
+				require.Equal(newState, state, "unsynced=%d crashAfter=%d", unsynced, crashAfter)
+			}
+
+			isEmpty, err := database.IsEmpty(prefixdb.New(journalPrefix, baseDB))
+			require.NoError(err)
+			require.True(isEmpty)
+		}
+	}
+}
+
+func TestCommitWithoutJournalIsNotAtomic(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := newJournalTestDB(t)
+	crashDB := crashdb.New(baseDB, 1, 0)
+	a := New(prefixdb.New(namespaceA, crashDB))
+	b := New(prefixdb.New(namespaceB, crashDB))
+	writeJournalTestOps(t, a, b)
+
+	require.ErrorIs(a.Commit(), crashdb.ErrCrashed)
+	require.ErrorIs(b.Commit(), crashdb.ErrCrashed)
+
+	// The first namespace was committed while the second one wasn't.
+	state := readNamespaces(t, baseDB)
+	require.Equal("new", state["a/k1"])
+	require.Equal("old", state["b/k1"])
+	require.Equal("old", state["b/k2"])
+}
+
+func TestJournalWriteAll(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	journal, err := NewJournal(baseDB)
+	require.NoError(err)
+
+	db := New(prefixdb.New(namespaceA, baseDB))
+	require.NoError(db.Put([]byte("k1"), []byte("v1")))
+	commitBatch, err := db.CommitBatch()
+	require.NoError(err)
+
+	// Writes that aren't made through a versioned database are written in the
+	// same record.
+	batch := baseDB.NewBatch()
+	require.NoError(batch.Put([]byte("other"), []byte("v2")))
+
+	require.NoError(journal.WriteAll(commitBatch, batch))
+
+	value, err := prefixdb.New(namespaceA, baseDB).Get([]byte("k1"))
+	require.NoError(err)
+	require.Equal([]byte("v1"), value)
+	value, err = baseDB.Get([]byte("other"))
+	require.NoError(err)
+	require.Equal([]byte("v2"), value)
+
+	// Records are numbered after the records that were already written.
+	require.NoError(journal.records.Put(database.PackUInt64(5), []byte{0, 0, 0, 0}))
+	journal, err = NewJournal(baseDB)
+	require.NoError(err)
+	require.Equal(uint64(6), journal.nextID)
+}
@@ -0,0 +1,491 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/snow"
+	"github.com/coinflect/coinflectchain/snow/choices"
+	"github.com/coinflect/coinflectchain/snow/engine/coinflect"
+	"github.com/coinflect/coinflectchain/snow/engine/snowman"
+	"github.com/coinflect/coinflectchain/snow/engine/snowman/block"
+	"github.com/coinflect/coinflectchain/utils/logging"
+)
+
+const (
+	// Number of containers added to a backfilled index each time the chain's
+	// context lock is grabbed
+	backfillBatchSize = 256
+	// How long to wait before checking again whether a VM's height index is
+	// ready
+	backfillRetryFrequency = 5 * time.Second
+)
+
+var (
+	errNotHeightIndexed = errors.New("chain doesn't index its blocks by height")
+	errUnknownHeight    = errors.New("chain doesn't know the block at height")
+)
+
+// history of the containers accepted by a chain
+type history interface {
+	// init captures the containers accepted so far.
+	// Assumes the chain's context lock is held.
+	init(ctx context.Context) error
+	// prepare lists the next captured containers. Returns true, along with
+	// the number of captured containers, once all of them are listed.
+	// Assumes the chain's context lock is held.
+	prepare(ctx context.Context) (bool, uint64, error)
+	// next returns the following containers of the history, or nothing once
+	// all of them have been returned. Every container is returned after the
+	// containers it depends on, e.g. a block or vertex after its parents. For
+	// DAG chains, this isn't necessarily the order in which they were
+	// accepted. The timestamps of the containers may be unset.
+	// Assumes the chain's context lock is held.
+	next(ctx context.Context) ([]Container, error)
+}
+
+// blockHistory walks the blocks of a snowman chain by height
+type blockHistory struct {
+	engine snowman.Engine
+
+	vm         block.HeightIndexedChainVM
+	height     uint64
+	lastHeight uint64
+}
+
+func (h *blockHistory) init(ctx context.Context) error {
+	vm, ok := h.engine.GetVM().(block.ChainVM)
+	if !ok {
+		return errNotHeightIndexed
+	}
+	h.vm, ok = vm.(block.HeightIndexedChainVM)
+	if !ok {
+		return errNotHeightIndexed
+	}
+	if err := h.vm.VerifyHeightIndex(ctx); err != nil {
+		return err
+	}
+
+	lastAcceptedID, err := vm.LastAccepted(ctx)
+	if err != nil {
+		return fmt.Errorf("couldn't get last accepted block: %w", err)
+	}
+	lastAccepted, err := h.engine.GetBlock(ctx, lastAcceptedID)
+	if err != nil {
+		return fmt.Errorf("couldn't get block %s: %w", lastAcceptedID, err)
+	}
+	// The genesis block is never indexed
+	h.height = 1
+	h.lastHeight = lastAccepted.Height()
+	return nil
+}
+
+// prepare is a no-op, since the blocks are listed by the VM's height index
+func (h *blockHistory) prepare(context.Context) (bool, uint64, error) {
+	return true, h.lastHeight, nil
+}
+
+func (h *blockHistory) next(ctx context.Context) ([]Container, error) {
+	containers := make([]Container, 0, backfillBatchSize)
+	for ; h.height <= h.lastHeight && len(containers) < backfillBatchSize; h.height++ {
+		blkID, err := h.vm.GetBlockIDAtHeight(ctx, h.height)
+		if err == database.ErrNotFound {
+			return nil, fmt.Errorf("%w %d", errUnknownHeight, h.height)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get block at height %d: %w", h.height, err)
+		}
+		blk, err := h.engine.GetBlock(ctx, blkID)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get block %s: %w", blkID, err)
+		}
+		containers = append(containers, Container{
+			ID:        blkID,
+			Bytes:     blk.Bytes(),
+			Timestamp: blk.Timestamp().UnixNano(),
+		})
+	}
+	return containers, nil
+}
+
+// vertexHistory walks the accepted vertices of a DAG chain by height. If [txs]
+// is true, the transactions of the vertices are returned rather than the
+// vertices.
+type vertexHistory struct {
+	engine coinflect.Engine
+	txs    bool
+
+	// Vertices whose ancestors are yet to be listed
+	toProcess []ids.ID
+	visited   ids.Set
+	// Accepted vertices listed so far
+	vertices []vertexHeight
+	numTxs   uint64
+
+	// Accepted vertices, sorted by height
+	vtxIDs []ids.ID
+	// Transactions that have already been returned
+	seenTxs ids.Set
+}
+
+type vertexHeight struct {
+	vtxID  ids.ID
+	height uint64
+}
+
+func (h *vertexHistory) init(ctx context.Context) error {
+	edge := h.engine.Edge(ctx)
+	// [edge] may be the engine's own frontier, so it must not be modified.
+	h.toProcess = make([]ids.ID, len(edge))
+	copy(h.toProcess, edge)
+	h.visited = ids.Set{}
+	h.visited.Add(h.toProcess...)
+	h.vertices = nil
+	h.numTxs = 0
+	h.vtxIDs = nil
+	h.seenTxs = ids.Set{}
+	return nil
+}
+
+// prepare walks the ancestors of the captured edge, [backfillBatchSize]
+// vertices at a time. The ancestors of accepted vertices are accepted, so
+// they don't change while the chain's context lock isn't held.
+func (h *vertexHistory) prepare(ctx context.Context) (bool, uint64, error) {
+	for i := 0; i < backfillBatchSize && len(h.toProcess) > 0; i++ {
+		vtxID := h.toProcess[len(h.toProcess)-1]
+		h.toProcess = h.toProcess[:len(h.toProcess)-1]
+
+		vtx, err := h.engine.GetVtx(ctx, vtxID)
+		if err != nil {
+			return false, 0, fmt.Errorf("couldn't get vertex %s: %w", vtxID, err)
+		}
+		if vtx.Status() != choices.Accepted {
+			continue
+		}
+		height, err := vtx.Height()
+		if err != nil {
+			return false, 0, fmt.Errorf("couldn't get height of vertex %s: %w", vtxID, err)
+		}
+		h.vertices = append(h.vertices, vertexHeight{
+			vtxID:  vtxID,
+			height: height,
+		})
+
+		if h.txs {
+			txs, err := vtx.Txs(ctx)
+			if err != nil {
+				return false, 0, fmt.Errorf("couldn't get transactions of vertex %s: %w", vtxID, err)
+			}
+			for _, tx := range txs {
+				if tx.Status() == choices.Accepted {
+					h.numTxs++
+				}
+			}
+		}
+
+		parents, err := vtx.Parents()
+		if err != nil {
+			return false, 0, fmt.Errorf("couldn't get parents of vertex %s: %w", vtxID, err)
+		}
+		for _, parent := range parents {
+			parentID := parent.ID()
+			if h.visited.Contains(parentID) {
+				continue
+			}
+			h.visited.Add(parentID)
+			h.toProcess = append(h.toProcess, parentID)
+		}
+	}
+	if len(h.toProcess) > 0 {
+		return false, 0, nil
+	}
+
+	// Parents are lower than their children, so sorting by height lists every
+	// vertex after its parents. Vertices with the same height are sorted by ID,
+	// which isn't necessarily the order in which they were accepted.
+	sort.Slice(h.vertices, func(i, j int) bool {
+		if h.vertices[i].height != h.vertices[j].height {
+			return h.vertices[i].height < h.vertices[j].height
+		}
+		return bytes.Compare(h.vertices[i].vtxID[:], h.vertices[j].vtxID[:]) < 0
+	})
+	h.vtxIDs = make([]ids.ID, len(h.vertices))
+	for i, vtx := range h.vertices {
+		h.vtxIDs[i] = vtx.vtxID
+	}
+	h.vertices = nil
+	h.visited = nil
+
+	if h.txs {
+		return true, h.numTxs, nil
+	}
+	return true, uint64(len(h.vtxIDs)), nil
+}
+
+func (h *vertexHistory) next(ctx context.Context) ([]Container, error) {
+	containers := make([]Container, 0, backfillBatchSize)
+	for len(h.vtxIDs) > 0 && len(containers) < backfillBatchSize {
+		vtxID := h.vtxIDs[0]
+		h.vtxIDs = h.vtxIDs[1:]
+
+		vtx, err := h.engine.GetVtx(ctx, vtxID)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get vertex %s: %w", vtxID, err)
+		}
+		if !h.txs {
+			containers = append(containers, Container{
+				ID:    vtxID,
+				Bytes: vtx.Bytes(),
+			})
+			continue
+		}
+
+		txs, err := vtx.Txs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get transactions of vertex %s: %w", vtxID, err)
+		}
+		for _, tx := range txs {
+			txID := tx.ID()
+			if tx.Status() != choices.Accepted || h.seenTxs.Contains(txID) {
+				continue
+			}
+			h.seenTxs.Add(txID)
+			containers = append(containers, Container{
+				ID:    txID,
+				Bytes: tx.Bytes(),
+			})
+		}
+	}
+	return containers, nil
+}
+
+// backfillStatus reports the progress of a backfill.
+// backfillStatus is thread-safe.
+type backfillStatus struct {
+	lock sync.RWMutex
+	// True if the index may be missing accepted containers
+	incomplete bool
+	// True while the missing containers are being added to the index
+	backfilling bool
+	// Number of containers of the chain's history that have been processed
+	processed uint64
+	// Number of containers in the chain's history
+	total uint64
+	// The reason the last backfill failed, if it did
+	err error
+}
+
+func (s *backfillStatus) get() (incomplete, backfilling bool, processed, total uint64, err error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.incomplete, s.backfilling, s.processed, s.total, s.err
+}
+
+// start a backfill whose number of containers isn't known yet
+func (s *backfillStatus) start() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.backfilling = true
+	s.processed = 0
+	s.total = 0
+	s.err = nil
+}
+
+func (s *backfillStatus) setTotal(total uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.total = total
+}
+
+func (s *backfillStatus) progress(processed uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.processed += processed
+}
+
+func (s *backfillStatus) stop(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.backfilling = false
+	s.err = err
+}
+
+func (s *backfillStatus) complete() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.incomplete = false
+}
+
+// backfiller rebuilds an index from the history of its chain.
+// The rebuilt index is written to [staging] and, once it includes every
+// accepted container, replaces the containers of [active].
+type backfiller struct {
+	name     string
+	log      logging.Logger
+	chainCtx *snow.ConsensusContext
+	history  history
+	status   *backfillStatus
+
+	active  *index
+	staging *index
+	// Called, while no containers can be accepted, to persist that [staging]
+	// replaces [active]
+	commit func() error
+}
+
+// run the backfill until it completes, fails or [ctx] is cancelled.
+// Returns true if the backfill completed.
+func (b *backfiller) run(ctx context.Context) bool {
+	err := b.backfill(ctx)
+	if err == nil {
+		b.status.stop(nil)
+		b.log.Info("finished backfilling index",
+			zap.String("index", b.name),
+		)
+		return true
+	}
+
+	b.active.stopBackfill()
+	_ = b.staging.Close()
+	if ctx.Err() != nil {
+		b.status.stop(nil)
+		return false
+	}
+	b.status.stop(err)
+	b.log.Error("failed to backfill index",
+		zap.String("index", b.name),
+		zap.Error(err),
+	)
+	return false
+}
+
+func (b *backfiller) backfill(ctx context.Context) error {
+	err := b.init(ctx)
+	for errors.Is(err, block.ErrIndexIncomplete) {
+		b.log.Info("waiting for the chain's height index to backfill index",
+			zap.String("index", b.name),
+		)
+		select {
+		case <-time.After(backfillRetryFrequency):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		err = b.init(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	var (
+		prepared bool
+		total    uint64
+	)
+	for !prepared {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		prepared, total, err = b.prepare(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	b.status.setTotal(total)
+
+	b.log.Info("backfilling index",
+		zap.String("index", b.name),
+		zap.Uint64("numToProcess", total),
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		done, err := b.step(ctx)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+	}
+}
+
+// init the history and start recording the containers accepted after it.
+func (b *backfiller) init(ctx context.Context) error {
+	b.chainCtx.Lock.Lock()
+	defer b.chainCtx.Lock.Unlock()
+
+	if err := b.history.init(ctx); err != nil {
+		return err
+	}
+	b.active.startBackfill()
+	b.status.start()
+	return nil
+}
+
+// prepare the next part of the history. Returns true, along with the number
+// of containers in the history, once all of it is prepared.
+func (b *backfiller) prepare(ctx context.Context) (bool, uint64, error) {
+	b.chainCtx.Lock.Lock()
+	defer b.chainCtx.Lock.Unlock()
+
+	return b.history.prepare(ctx)
+}
+
+// step adds the next containers of the history to [b.staging]. Returns true
+// once [b.staging] replaced the containers of [b.active].
+func (b *backfiller) step(ctx context.Context) (bool, error) {
+	b.chainCtx.Lock.Lock()
+	defer b.chainCtx.Lock.Unlock()
+
+	containers, err := b.history.next(ctx)
+	if err != nil {
+		return false, err
+	}
+	if len(containers) > 0 {
+		for _, container := range containers {
+			// Keep the time at which this node accepted the container, if it
+			// was already indexed. Otherwise, the time may be unknown.
+			if indexed, err := b.active.GetContainerByID(container.ID); err == nil {
+				container.Timestamp = indexed.Timestamp
+			} else if err != database.ErrNotFound {
+				return false, err
+			}
+			if err := b.staging.add(container); err != nil {
+				return false, err
+			}
+		}
+		b.status.progress(uint64(len(containers)))
+		return false, nil
+	}
+
+	replaced, err := b.active.replace(b.staging, b.commit)
+	if err != nil {
+		return false, err
+	}
+	if err := database.Clear(replaced.baseDB, replaced.baseDB); err != nil {
+		b.log.Warn("failed to clear replaced index",
+			zap.String("index", b.name),
+			zap.Error(err),
+		)
+	}
+	return true, replaced.Close()
+}
@@ -7,6 +7,7 @@ package indexer
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/coinflect/coinflectchain/ids"
 	"github.com/coinflect/coinflectchain/utils/formatting"
@@ -25,6 +26,18 @@ type Client interface {
 	GetContainerRange(ctx context.Context, startIndex uint64, numToFetch int, options ...rpc.Option) ([]Container, error)
 	// Get a container by its index
 	GetContainerByIndex(ctx context.Context, index uint64, options ...rpc.Option) (Container, error)
+	// GetContainerRangeReverse returns the transactions at index [startIndex], [startIndex-1], ... , [startIndex-n+1]
+	// If [startIndex] > the last accepted index, starts from the last accepted index.
+	// If we run out of transactions, returns the ones fetched before running out.
+	GetContainerRangeReverse(ctx context.Context, startIndex uint64, numToFetch int, options ...rpc.Option) ([]Container, error)
+	// GetContainersByTime returns up to [numToFetch] containers accepted in [startTime, endTime),
+	// starting from [cursor] if it isn't empty. A zero [startTime] or [endTime] leaves that end
+	// of the range open.
+	// Returns the cursor to get the next containers of the range with, or an empty string if
+	// there are none.
+	GetContainersByTime(ctx context.Context, startTime, endTime time.Time, cursor string, numToFetch int, options ...rpc.Option) ([]Container, string, error)
+	// Get the first container accepted at or after [timestamp]
+	GetContainerByTime(ctx context.Context, timestamp time.Time, options ...rpc.Option) (Container, error)
 	// Get the most recently accepted container
 	GetLastAccepted(context.Context, ...rpc.Option) (Container, error)
 	// Returns 1 less than the number of containers accepted on this chain
@@ -33,6 +46,9 @@ type Client interface {
 	IsAccepted(ctx context.Context, containerID ids.ID, options ...rpc.Option) (bool, error)
 	// Get a container by its index
 	GetContainerByID(ctx context.Context, containerID ids.ID, options ...rpc.Option) (Container, error)
+	// Returns whether the index is missing accepted containers and the
+	// progress of adding them to the index
+	GetBackfillStatus(context.Context, ...rpc.Option) (*GetBackfillStatusResponse, error)
 }
 
 // Client implementation for Coinflect Indexer API Endpoint
@@ -78,6 +94,51 @@ func (c *client) GetContainerRange(ctx context.Context, startIndex uint64, numTo
 	return response, nil
 }
 
+func (c *client) GetContainerRangeReverse(ctx context.Context, startIndex uint64, numToFetch int, options ...rpc.Option) ([]Container, error) {
+	var fcs GetContainerRangeResponse
+	err := c.requester.SendRequest(ctx, "index.getContainerRangeReverse", &GetContainerRangeArgs{
+		StartIndex: json.Uint64(startIndex),
+		NumToFetch: json.Uint64(numToFetch),
+		Encoding:   formatting.Hex,
+	}, &fcs, options...)
+	if err != nil {
+		return nil, err
+	}
+	return newContainers(fcs.Containers)
+}
+
+func (c *client) GetContainersByTime(ctx context.Context, startTime, endTime time.Time, cursor string, numToFetch int, options ...rpc.Option) ([]Container, string, error) {
+	var fcs GetContainersByTimeResponse
+	err := c.requester.SendRequest(ctx, "index.getContainersByTime", &GetContainersByTimeArgs{
+		StartTime:  startTime,
+		EndTime:    endTime,
+		Cursor:     cursor,
+		NumToFetch: json.Uint64(numToFetch),
+		Encoding:   formatting.Hex,
+	}, &fcs, options...)
+	if err != nil {
+		return nil, "", err
+	}
+	containers, err := newContainers(fcs.Containers)
+	return containers, fcs.NextCursor, err
+}
+
+func (c *client) GetContainerByTime(ctx context.Context, timestamp time.Time, options ...rpc.Option) (Container, error) {
+	var fc FormattedContainer
+	err := c.requester.SendRequest(ctx, "index.getContainerByTime", &GetContainerByTimeArgs{
+		Time:     timestamp,
+		Encoding: formatting.Hex,
+	}, &fc, options...)
+	if err != nil {
+		return Container{}, err
+	}
+	containers, err := newContainers([]FormattedContainer{fc})
+	if err != nil {
+		return Container{}, err
+	}
+	return containers[0], nil
+}
+
 func (c *client) GetContainerByIndex(ctx context.Context, index uint64, options ...rpc.Option) (Container, error) {
 	var fc FormattedContainer
 	err := c.requester.SendRequest(ctx, "index.getContainerByIndex", &GetContainerByIndexArgs{
@@ -155,3 +216,25 @@ func (c *client) GetContainerByID(ctx context.Context, id ids.ID, options ...rpc
 		Bytes:     containerBytes,
 	}, nil
 }
+
+func (c *client) GetBackfillStatus(ctx context.Context, options ...rpc.Option) (*GetBackfillStatusResponse, error) {
+	res := &GetBackfillStatusResponse{}
+	err := c.requester.SendRequest(ctx, "index.getBackfillStatus", struct{}{}, res, options...)
+	return res, err
+}
+
+func newContainers(fcs []FormattedContainer) ([]Container, error) {
+	containers := make([]Container, len(fcs))
+	for i, fc := range fcs {
+		containerBytes, err := formatting.Decode(fc.Encoding, fc.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't decode container %s: %w", fc.ID, err)
+		}
+		containers[i] = Container{
+			ID:        fc.ID,
+			Timestamp: fc.Timestamp.Unix(),
+			Bytes:     containerBytes,
+		}
+	}
+	return containers, nil
+}
@@ -7,6 +7,7 @@ package indexer
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -125,4 +126,98 @@ func TestIndexClient(t *testing.T) {
 		require.EqualValues(id, container.ID)
 		require.EqualValues(bytes, container.Bytes)
 	}
+	{
+		// Test GetContainerRangeReverse
+		id := ids.GenerateTestID()
+		bytes := utils.RandomBytes(10)
+		bytesStr, err := formatting.Encode(formatting.Hex, bytes)
+		require.NoError(err)
+		client.requester = &mockClient{
+			require:        require,
+			expectedMethod: "index.getContainerRangeReverse",
+			onSendRequestF: func(reply interface{}) error {
+				*(reply.(*GetContainerRangeResponse)) = GetContainerRangeResponse{Containers: []FormattedContainer{{
+					ID:    id,
+					Bytes: bytesStr,
+				}}}
+				return nil
+			},
+		}
+		containers, err := client.GetContainerRangeReverse(context.Background(), 10, 1)
+		require.NoError(err)
+		require.Len(containers, 1)
+		require.EqualValues(id, containers[0].ID)
+		require.EqualValues(bytes, containers[0].Bytes)
+	}
+	{
+		// Test GetContainersByTime
+		id := ids.GenerateTestID()
+		bytes := utils.RandomBytes(10)
+		bytesStr, err := formatting.Encode(formatting.Hex, bytes)
+		require.NoError(err)
+		client.requester = &mockClient{
+			require:        require,
+			expectedMethod: "index.getContainersByTime",
+			onSendRequestF: func(reply interface{}) error {
+				*(reply.(*GetContainersByTimeResponse)) = GetContainersByTimeResponse{
+					Containers: []FormattedContainer{{
+						ID:    id,
+						Bytes: bytesStr,
+					}},
+					NextCursor: "cursor",
+				}
+				return nil
+			},
+		}
+		containers, cursor, err := client.GetContainersByTime(context.Background(), time.Time{}, time.Now(), "", 1)
+		require.NoError(err)
+		require.Len(containers, 1)
+		require.EqualValues(id, containers[0].ID)
+		require.EqualValues(bytes, containers[0].Bytes)
+		require.Equal("cursor", cursor)
+	}
+	{
+		// Test GetContainerByTime
+		id := ids.GenerateTestID()
+		bytes := utils.RandomBytes(10)
+		bytesStr, err := formatting.Encode(formatting.Hex, bytes)
+		require.NoError(err)
+		client.requester = &mockClient{
+			require:        require,
+			expectedMethod: "index.getContainerByTime",
+			onSendRequestF: func(reply interface{}) error {
+				*(reply.(*FormattedContainer)) = FormattedContainer{
+					ID:    id,
+					Bytes: bytesStr,
+				}
+				return nil
+			},
+		}
+		container, err := client.GetContainerByTime(context.Background(), time.Now())
+		require.NoError(err)
+		require.EqualValues(id, container.ID)
+		require.EqualValues(bytes, container.Bytes)
+	}
+	{
+		// Test GetBackfillStatus
+		client.requester = &mockClient{
+			require:        require,
+			expectedMethod: "index.getBackfillStatus",
+			onSendRequestF: func(reply interface{}) error {
+				*(reply.(*GetBackfillStatusResponse)) = GetBackfillStatusResponse{
+					IsIncomplete:  true,
+					IsBackfilling: true,
+					NumProcessed:  5,
+					NumToProcess:  10,
+				}
+				return nil
+			},
+		}
+		status, err := client.GetBackfillStatus(context.Background())
+		require.NoError(err)
+		require.True(status.IsIncomplete)
+		require.True(status.IsBackfilling)
+		require.EqualValues(5, status.NumProcessed)
+		require.EqualValues(10, status.NumToProcess)
+	}
 }
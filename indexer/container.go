@@ -13,6 +13,9 @@ type Container struct {
 	ID ids.ID `serialize:"true"`
 	// Byte representation of this container
 	Bytes []byte `serialize:"true"`
-	// Unix time, in nanoseconds, at which this container was accepted by this node
+	// Unix time, in nanoseconds, at which this container was accepted by this
+	// node. 0 if the time is unknown, e.g. for vertices and transactions that
+	// were accepted before they were indexed. Such containers aren't indexed
+	// by time.
 	Timestamp int64 `serialize:"true"`
 }
@@ -5,6 +5,7 @@
 package indexer
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
@@ -18,6 +19,7 @@ import (
 	"github.com/coinflect/coinflectchain/database/versiondb"
 	"github.com/coinflect/coinflectchain/ids"
 	"github.com/coinflect/coinflectchain/snow"
+	"github.com/coinflect/coinflectchain/utils"
 	"github.com/coinflect/coinflectchain/utils/logging"
 	"github.com/coinflect/coinflectchain/utils/math"
 	"github.com/coinflect/coinflectchain/utils/timer/mockable"
@@ -28,6 +30,12 @@ const (
 	// Maximum number of containers IDs that can be fetched at a time
 	// in a call to GetContainerRange
 	MaxFetchedByRange = 1024
+
+	// Number of containers whose timestamps are indexed per commit when
+	// indexing the timestamps of previously accepted containers
+	timestampBatchSize = 1024
+
+	signBit = uint64(1) << 63
 )
 
 var (
@@ -35,8 +43,14 @@ var (
 	nextAcceptedIndexKey   = []byte{0x00}
 	indexToContainerPrefix = []byte{0x01}
 	containerToIDPrefix    = []byte{0x02}
-	errNoneAccepted        = errors.New("no containers have been accepted")
-	errNumToFetchZero      = fmt.Errorf("numToFetch must be in [1,%d]", MaxFetchedByRange)
+	timestampToIndexPrefix = []byte{0x03}
+	// Present once the timestamps of all accepted containers are indexed
+	timestampsIndexedKey = []byte{0x04}
+
+	errNoneAccepted     = errors.New("no containers have been accepted")
+	errNumToFetchZero   = fmt.Errorf("numToFetch must be in [1,%d]", MaxFetchedByRange)
+	errInvalidTimeRange = errors.New("end time must be after start time")
+	errInvalidCursor    = errors.New("invalid cursor")
 
 	_ Index = (*index)(nil)
 )
@@ -49,6 +63,9 @@ type Index interface {
 	snow.Acceptor
 	GetContainerByIndex(index uint64) (Container, error)
 	GetContainerRange(startIndex uint64, numToFetch uint64) ([]Container, error)
+	GetContainerRangeReverse(startIndex uint64, numToFetch uint64) ([]Container, error)
+	GetContainersByTime(startTime, endTime int64, cursor []byte, numToFetch uint64) ([]Container, []byte, error)
+	GetContainerByTime(timestamp int64) (Container, error)
 	GetLastAccepted() (Container, error)
 	GetIndex(id ids.ID) (uint64, error)
 	GetContainerByID(id ids.ID) (Container, error)
@@ -70,7 +87,15 @@ type index struct {
 	indexToContainer database.Database
 	// Container ID --> Index
 	containerToIndex database.Database
+	// Timestamp + Index --> nil
+	timestampToIndex database.Database
 	log              logging.Logger
+
+	// True while a backfilled copy of this index is being built
+	backfilling bool
+	// Containers accepted while [backfilling]. They are added to the
+	// backfilled index before it replaces this one.
+	pending []Container
 }
 
 // Returns a new, thread-safe Index.
@@ -84,6 +109,7 @@ func newIndex(
 	vDB := versiondb.New(baseDB)
 	indexToContainer := prefixdb.New(indexToContainerPrefix, vDB)
 	containerToIndex := prefixdb.New(containerToIDPrefix, vDB)
+	timestampToIndex := prefixdb.New(timestampToIndexPrefix, vDB)
 
 	i := &index{
 		clock:            clock,
@@ -92,34 +118,71 @@ func newIndex(
 		vDB:              vDB,
 		indexToContainer: indexToContainer,
 		containerToIndex: containerToIndex,
+		timestampToIndex: timestampToIndex,
 		log:              log,
 	}
 
 	// Get next accepted index from db
 	nextAcceptedIndex, err := database.GetUInt64(i.vDB, nextAcceptedIndexKey)
-	if err == database.ErrNotFound {
+	switch err {
+	case nil:
+		i.nextAcceptedIndex = nextAcceptedIndex
+	case database.ErrNotFound:
 		// Couldn't find it in the database. Must not have accepted any containers in previous runs.
-		i.log.Info("created new index",
-			zap.Uint64("nextAcceptedIndex", i.nextAcceptedIndex),
-		)
-		return i, nil
-	}
-	if err != nil {
+	default:
 		return nil, fmt.Errorf("couldn't get next accepted index from database: %w", err)
 	}
-	i.nextAcceptedIndex = nextAcceptedIndex
+	if err := i.indexTimestamps(); err != nil {
+		return nil, fmt.Errorf("couldn't index timestamps: %w", err)
+	}
 	i.log.Info("created new index",
 		zap.Uint64("nextAcceptedIndex", i.nextAcceptedIndex),
 	)
 	return i, nil
 }
 
+// indexTimestamps indexes the timestamps of the containers that were accepted
+// before timestamps were indexed.
+func (i *index) indexTimestamps() error {
+	indexed, err := i.vDB.Has(timestampsIndexedKey)
+	if err != nil || indexed {
+		return err
+	}
+
+	if i.nextAcceptedIndex > 0 {
+		i.log.Info("indexing timestamps of accepted containers",
+			zap.Uint64("numContainers", i.nextAcceptedIndex),
+		)
+	}
+	for index := uint64(0); index < i.nextAcceptedIndex; index++ {
+		container, err := i.getContainerByIndex(index)
+		if err != nil {
+			return err
+		}
+		if container.Timestamp != 0 {
+			if err := i.timestampToIndex.Put(timestampKey(container.Timestamp, index), nil); err != nil {
+				return err
+			}
+		}
+		if (index+1)%timestampBatchSize == 0 {
+			if err := i.vDB.Commit(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := i.vDB.Put(timestampsIndexedKey, nil); err != nil {
+		return err
+	}
+	return i.vDB.Commit()
+}
+
 // Close this index
 func (i *index) Close() error {
 	errs := wrappers.Errs{}
 	errs.Add(
 		i.indexToContainer.Close(),
 		i.containerToIndex.Close(),
+		i.timestampToIndex.Close(),
 		i.vDB.Close(),
 		i.baseDB.Close(),
 	)
@@ -137,28 +200,66 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 	// as accepted and then the node shut down before the VM committed [containerID] as accepted.
 	// In that case, when the node restarts Accept will be called with the same container.
 	// Make sure we don't index the same container twice in that event.
-	_, err := i.containerToIndex.Get(containerID[:])
-	if err == nil {
+	isAccepted, err := i.has(containerID)
+	if err != nil {
+		return err
+	}
+	if isAccepted {
 		ctx.Log.Debug("not indexing already accepted container",
 			zap.Stringer("containerID", containerID),
 		)
 		return nil
 	}
-	if err != database.ErrNotFound {
-		return fmt.Errorf("couldn't get whether %s is accepted: %w", containerID, err)
-	}
 
 	ctx.Log.Debug("indexing container",
 		zap.Uint64("nextAcceptedIndex", i.nextAcceptedIndex),
 		zap.Stringer("containerID", containerID),
 	)
-	// Persist index --> Container
-	nextAcceptedIndexBytes := database.PackUInt64(i.nextAcceptedIndex)
-	bytes, err := i.codec.Marshal(codecVersion, Container{
+	container := Container{
 		ID:        containerID,
 		Bytes:     containerBytes,
 		Timestamp: i.clock.Time().UnixNano(),
-	})
+	}
+	if err := i.put(container); err != nil {
+		return err
+	}
+	if i.backfilling {
+		i.pending = append(i.pending, container)
+	}
+	return nil
+}
+
+// add indexes [container] as the next accepted container, unless it's already
+// indexed.
+func (i *index) add(container Container) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	isAccepted, err := i.has(container.ID)
+	if err != nil || isAccepted {
+		return err
+	}
+	return i.put(container)
+}
+
+// has returns true if [containerID] is indexed.
+// Assumes [i.lock] is held
+func (i *index) has(containerID ids.ID) (bool, error) {
+	isAccepted, err := i.containerToIndex.Has(containerID[:])
+	if err != nil {
+		return false, fmt.Errorf("couldn't get whether %s is accepted: %w", containerID, err)
+	}
+	return isAccepted, nil
+}
+
+// put indexes [container] as the next accepted container.
+// Assumes [i.lock] is held and that [container] isn't indexed.
+func (i *index) put(container Container) error {
+	containerID := container.ID
+
+	// Persist index --> Container
+	nextAcceptedIndexBytes := database.PackUInt64(i.nextAcceptedIndex)
+	bytes, err := i.codec.Marshal(codecVersion, container)
 	if err != nil {
 		return fmt.Errorf("couldn't serialize container %s: %w", containerID, err)
 	}
@@ -171,16 +272,87 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 		return fmt.Errorf("couldn't map container %s to index: %w", containerID, err)
 	}
 
+	// Persist timestamp + index, unless the timestamp is unknown
+	if container.Timestamp != 0 {
+		if err := i.timestampToIndex.Put(timestampKey(container.Timestamp, i.nextAcceptedIndex), nil); err != nil {
+			return fmt.Errorf("couldn't map timestamp of container %s to index: %w", containerID, err)
+		}
+	}
+
 	// Persist next accepted index
 	i.nextAcceptedIndex++
 	if err := database.PutUInt64(i.vDB, nextAcceptedIndexKey, i.nextAcceptedIndex); err != nil {
 		return fmt.Errorf("couldn't put accepted container %s into index: %w", containerID, err)
 	}
 
-	// Atomically commit [i.vDB], [i.indexToContainer], [i.containerToIndex] to [i.baseDB]
+	// Atomically commit [i.vDB], [i.indexToContainer], [i.containerToIndex],
+	// [i.timestampToIndex] to [i.baseDB]
 	return i.vDB.Commit()
 }
 
+// startBackfill records the containers accepted from now on, so that they can
+// be added to a backfilled copy of this index.
+func (i *index) startBackfill() {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.backfilling = true
+	i.pending = nil
+}
+
+// stopBackfill stops recording the accepted containers.
+func (i *index) stopBackfill() {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.backfilling = false
+	i.pending = nil
+}
+
+// replace the containers of this index with the containers of [backfilled],
+// to which the containers accepted since the backfill started are added.
+// [commit] is called before the containers are replaced; if it fails, this
+// index is unchanged. Returns an index over the replaced containers, which is
+// no longer used by this index. [backfilled] must not be used afterwards.
+func (i *index) replace(backfilled *index, commit func() error) (*index, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	for _, container := range i.pending {
+		if err := backfilled.add(container); err != nil {
+			return nil, err
+		}
+	}
+	if err := commit(); err != nil {
+		return nil, err
+	}
+
+	replaced := &index{
+		codec:             i.codec,
+		clock:             i.clock,
+		nextAcceptedIndex: i.nextAcceptedIndex,
+		vDB:               i.vDB,
+		baseDB:            i.baseDB,
+		indexToContainer:  i.indexToContainer,
+		containerToIndex:  i.containerToIndex,
+		timestampToIndex:  i.timestampToIndex,
+		log:               i.log,
+	}
+
+	backfilled.lock.Lock()
+	defer backfilled.lock.Unlock()
+
+	i.nextAcceptedIndex = backfilled.nextAcceptedIndex
+	i.vDB = backfilled.vDB
+	i.baseDB = backfilled.baseDB
+	i.indexToContainer = backfilled.indexToContainer
+	i.containerToIndex = backfilled.containerToIndex
+	i.timestampToIndex = backfilled.timestampToIndex
+	i.backfilling = false
+	i.pending = nil
+	return replaced, nil
+}
+
 // Returns the ID of the [index]th accepted container and the container itself.
 // For example, if [index] == 0, returns the first accepted container.
 // If [index] == 1, returns the second accepted container, etc.
@@ -259,6 +431,117 @@ func (i *index) GetContainerRange(startIndex, numToFetch uint64) ([]Container, e
 	return containers, nil
 }
 
+// GetContainerRangeReverse returns the containers at indices
+// [startIndex], [startIndex-1], ..., [startIndex-numToFetch+1].
+// If [startIndex] > i.lastAcceptedIndex(), starts from the last accepted
+// container.
+// [numToFetch] should be in [0, MaxFetchedByRange]
+func (i *index) GetContainerRangeReverse(startIndex, numToFetch uint64) ([]Container, error) {
+	// Check arguments for validity
+	if numToFetch == 0 {
+		return nil, errNumToFetchZero
+	} else if numToFetch > MaxFetchedByRange {
+		return nil, fmt.Errorf("requested %d but maximum page size is %d", numToFetch, MaxFetchedByRange)
+	}
+
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	lastAcceptedIndex, ok := i.lastAcceptedIndex()
+	if !ok {
+		return nil, errNoneAccepted
+	}
+	startIndex = math.Min(startIndex, lastAcceptedIndex)
+
+	// [numToFetch] is limited to [MaxFetchedByRange] so [containers] is bounded in size.
+	containers := make([]Container, 0, math.Min(numToFetch, startIndex+1))
+	for j := startIndex; uint64(len(containers)) < numToFetch; j-- {
+		container, err := i.getContainerByIndex(j)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get container at index %d: %w", j, err)
+		}
+		containers = append(containers, container)
+		if j == 0 {
+			break
+		}
+	}
+	return containers, nil
+}
+
+// GetContainersByTime returns up to [numToFetch] containers accepted in
+// [startTime, endTime), ordered by their timestamp. Containers whose timestamp
+// is unknown are never returned. If [cursor] is non-nil,
+// starts from the container it refers to.
+// Returns the cursor of the next container in the range, or nil if there is
+// none.
+// [numToFetch] should be in [0, MaxFetchedByRange]
+func (i *index) GetContainersByTime(startTime, endTime int64, cursor []byte, numToFetch uint64) ([]Container, []byte, error) {
+	// Check arguments for validity
+	if numToFetch == 0 {
+		return nil, nil, errNumToFetchZero
+	} else if numToFetch > MaxFetchedByRange {
+		return nil, nil, fmt.Errorf("requested %d but maximum page size is %d", numToFetch, MaxFetchedByRange)
+	} else if endTime <= startTime {
+		return nil, nil, errInvalidTimeRange
+	}
+
+	start := timestampKey(startTime, 0)
+	if cursor != nil {
+		if len(cursor) != len(start) {
+			return nil, nil, errInvalidCursor
+		}
+		timestamp, _ := parseTimestampKey(cursor)
+		if timestamp < startTime || timestamp >= endTime {
+			return nil, nil, errInvalidCursor
+		}
+		start = cursor
+	}
+
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	it := i.timestampToIndex.NewIteratorWithStart(start)
+	defer it.Release()
+
+	var containers []Container
+	for it.Next() {
+		key := it.Key()
+		timestamp, index := parseTimestampKey(key)
+		if timestamp >= endTime {
+			break
+		}
+		if uint64(len(containers)) == numToFetch {
+			return containers, utils.CopyBytes(key), nil
+		}
+		container, err := i.getContainerByIndex(index)
+		if err != nil {
+			return nil, nil, fmt.Errorf("couldn't get container at index %d: %w", index, err)
+		}
+		containers = append(containers, container)
+	}
+	return containers, nil, it.Error()
+}
+
+// GetContainerByTime returns the first container accepted at or after
+// [timestamp]. Containers whose timestamp is unknown are never returned.
+// Returns database.ErrNotFound if no container was accepted since.
+func (i *index) GetContainerByTime(timestamp int64) (Container, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	it := i.timestampToIndex.NewIteratorWithStart(timestampKey(timestamp, 0))
+	defer it.Release()
+
+	if !it.Next() {
+		if err := it.Error(); err != nil {
+			return Container{}, err
+		}
+		return Container{}, database.ErrNotFound
+	}
+	_, index := parseTimestampKey(it.Key())
+	return i.getContainerByIndex(index)
+}
+
 // Returns database.ErrNotFound if the container is not indexed as accepted
 func (i *index) GetIndex(id ids.ID) (uint64, error) {
 	i.lock.RLock()
@@ -300,3 +583,20 @@ func (i *index) GetLastAccepted() (Container, error) {
 func (i *index) lastAcceptedIndex() (uint64, bool) {
 	return i.nextAcceptedIndex - 1, i.nextAcceptedIndex != 0
 }
+
+// timestampKey returns the key of the container accepted at [timestamp] with
+// [index]. Keys are ordered by timestamp, then by index.
+func timestampKey(timestamp int64, index uint64) []byte {
+	key := make([]byte, 2*wrappers.LongLen)
+	// Flipping the sign bit orders negative timestamps before positive ones
+	binary.BigEndian.PutUint64(key, uint64(timestamp)^signBit)
+	binary.BigEndian.PutUint64(key[wrappers.LongLen:], index)
+	return key
+}
+
+// parseTimestampKey returns the timestamp and index [key] refers to.
+// Assumes [key] was returned by timestampKey.
+func parseTimestampKey(key []byte) (int64, uint64) {
+	timestamp := int64(binary.BigEndian.Uint64(key) ^ signBit)
+	return timestamp, binary.BigEndian.Uint64(key[wrappers.LongLen:])
+}
@@ -5,13 +5,18 @@
 package indexer
 
 import (
+	"errors"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/coinflect/coinflectchain/codec"
 	"github.com/coinflect/coinflectchain/codec/linearcodec"
+	"github.com/coinflect/coinflectchain/database"
 	"github.com/coinflect/coinflectchain/database/memdb"
+	"github.com/coinflect/coinflectchain/database/prefixdb"
 	"github.com/coinflect/coinflectchain/database/versiondb"
 	"github.com/coinflect/coinflectchain/ids"
 	"github.com/coinflect/coinflectchain/snow"
@@ -175,3 +180,168 @@ func TestDontIndexSameContainerTwice(t *testing.T) {
 	require.NoError(err)
 	require.EqualValues(gotContainer.Bytes, []byte{1, 2, 3}, "should not have accepted same container twice")
 }
+
+func TestIndexReplace(t *testing.T) {
+	// Setup
+	require := require.New(t)
+	codec := codec.NewDefaultManager()
+	err := codec.RegisterCodec(codecVersion, linearcodec.NewDefault())
+	require.NoError(err)
+	db := memdb.New()
+	ctx := snow.DefaultConsensusContextTest()
+	indexIntf, err := newIndex(prefixdb.New([]byte{0}, db), logging.NoLog{}, codec, mockable.Clock{})
+	require.NoError(err)
+	idx := indexIntf.(*index)
+	backfilledIntf, err := newIndex(prefixdb.New([]byte{1}, db), logging.NoLog{}, codec, mockable.Clock{})
+	require.NoError(err)
+	backfilled := backfilledIntf.(*index)
+
+	// [container1] was accepted before the index was created
+	container0 := Container{ID: ids.GenerateTestID(), Bytes: utils.RandomBytes(32), Timestamp: 1}
+	container1 := Container{ID: ids.GenerateTestID(), Bytes: utils.RandomBytes(32), Timestamp: 2}
+	require.NoError(idx.Accept(ctx, container1.ID, container1.Bytes))
+
+	// [container2] is accepted while backfilling
+	idx.startBackfill()
+	require.NoError(backfilled.add(container0))
+	require.NoError(backfilled.add(container1))
+	require.NoError(backfilled.add(container1))
+	container2ID := ids.GenerateTestID()
+	require.NoError(idx.Accept(ctx, container2ID, []byte{2}))
+
+	// A failed commit leaves the index unchanged
+	errCommit := errors.New("commit failed")
+	_, err = idx.replace(backfilled, func() error {
+		return errCommit
+	})
+	require.ErrorIs(err, errCommit)
+	gotIndex, err := idx.GetIndex(container2ID)
+	require.NoError(err)
+	require.EqualValues(1, gotIndex)
+
+	replaced, err := idx.replace(backfilled, func() error {
+		return nil
+	})
+	require.NoError(err)
+	require.False(idx.backfilling)
+	require.Empty(idx.pending)
+
+	// The index has the backfilled containers, followed by the ones accepted
+	// while backfilling
+	containers, err := idx.GetContainerRange(0, MaxFetchedByRange)
+	require.NoError(err)
+	require.Len(containers, 3)
+	require.Equal(container0, containers[0])
+	require.Equal(container1, containers[1])
+	require.Equal(container2ID, containers[2].ID)
+
+	// The replaced containers are still in the replaced index
+	containers, err = replaced.GetContainerRange(0, MaxFetchedByRange)
+	require.NoError(err)
+	require.Len(containers, 2)
+	require.NoError(replaced.Close())
+
+	// Containers accepted after the replacement are added to the index
+	container3ID := ids.GenerateTestID()
+	require.NoError(idx.Accept(ctx, container3ID, []byte{3}))
+	gotIndex, err = idx.GetIndex(container3ID)
+	require.NoError(err)
+	require.EqualValues(3, gotIndex)
+}
+
+func TestIndexTimeRanges(t *testing.T) {
+	// Setup
+	require := require.New(t)
+	codec := codec.NewDefaultManager()
+	err := codec.RegisterCodec(codecVersion, linearcodec.NewDefault())
+	require.NoError(err)
+	db := memdb.New()
+	ctx := snow.DefaultConsensusContextTest()
+	indexIntf, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{})
+	require.NoError(err)
+	idx := indexIntf.(*index)
+
+	_, err = idx.GetContainerRangeReverse(0, 1)
+	require.ErrorIs(err, errNoneAccepted)
+
+	// Accept 10 containers, 1 second apart. The clock goes back for the last
+	// one.
+	start := time.Unix(1000, 0)
+	containerIDs := make([]ids.ID, 10)
+	for i := range containerIDs {
+		containerIDs[i] = ids.GenerateTestID()
+		idx.clock.Set(start.Add(time.Duration(i) * time.Second))
+		if i == len(containerIDs)-1 {
+			idx.clock.Set(start.Add(5 * time.Second))
+		}
+		require.NoError(idx.Accept(ctx, containerIDs[i], utils.RandomBytes(32)))
+	}
+
+	// Reverse ranges start from the last accepted container at most
+	containers, err := idx.GetContainerRangeReverse(math.MaxUint64, 3)
+	require.NoError(err)
+	require.Len(containers, 3)
+	require.Equal(containerIDs[9], containers[0].ID)
+	require.Equal(containerIDs[8], containers[1].ID)
+	require.Equal(containerIDs[7], containers[2].ID)
+	containers, err = idx.GetContainerRangeReverse(1, 3)
+	require.NoError(err)
+	require.Len(containers, 2)
+	require.Equal(containerIDs[1], containers[0].ID)
+	require.Equal(containerIDs[0], containers[1].ID)
+	_, err = idx.GetContainerRangeReverse(1, MaxFetchedByRange+1)
+	require.Error(err)
+
+	// Page through the containers accepted in [3s, 7s)
+	startTime := start.Add(3 * time.Second).UnixNano()
+	endTime := start.Add(7 * time.Second).UnixNano()
+	containers, cursor, err := idx.GetContainersByTime(startTime, endTime, nil, 3)
+	require.NoError(err)
+	require.NotNil(cursor)
+	require.Len(containers, 3)
+	require.Equal(containerIDs[3], containers[0].ID)
+	require.Equal(containerIDs[4], containers[1].ID)
+	require.Equal(containerIDs[5], containers[2].ID)
+	containers, cursor, err = idx.GetContainersByTime(startTime, endTime, cursor, 3)
+	require.NoError(err)
+	require.Nil(cursor)
+	require.Len(containers, 2)
+	require.Equal(containerIDs[9], containers[0].ID)
+	require.Equal(containerIDs[6], containers[1].ID)
+
+	_, _, err = idx.GetContainersByTime(endTime, startTime, nil, 3)
+	require.ErrorIs(err, errInvalidTimeRange)
+	_, _, err = idx.GetContainersByTime(startTime, endTime, []byte{1}, 3)
+	require.ErrorIs(err, errInvalidCursor)
+	_, _, err = idx.GetContainersByTime(startTime, endTime, timestampKey(endTime, 0), 3)
+	require.ErrorIs(err, errInvalidCursor)
+
+	// Find the first containers accepted at or after a time
+	container, err := idx.GetContainerByTime(start.Add(2500 * time.Millisecond).UnixNano())
+	require.NoError(err)
+	require.Equal(containerIDs[3], container.ID)
+	container, err = idx.GetContainerByTime(0)
+	require.NoError(err)
+	require.Equal(containerIDs[0], container.ID)
+	_, err = idx.GetContainerByTime(start.Add(time.Minute).UnixNano())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Remove the timestamps, as if the containers were accepted before
+	// timestamps were indexed. They are indexed again when the index is
+	// re-opened.
+	require.NoError(db.Delete(timestampsIndexedKey))
+	timestampDB := prefixdb.New(timestampToIndexPrefix, db)
+	require.NoError(database.Clear(timestampDB, timestampDB))
+	_, err = idx.GetContainerByTime(0)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	indexIntf, err = newIndex(db, logging.NoLog{}, codec, mockable.Clock{})
+	require.NoError(err)
+	idx = indexIntf.(*index)
+	containers, cursor, err = idx.GetContainersByTime(math.MinInt64, math.MaxInt64, nil, MaxFetchedByRange)
+	require.NoError(err)
+	require.Nil(cursor)
+	require.Len(containers, len(containerIDs))
+	require.Equal(containerIDs[5], containers[5].ID)
+	require.Equal(containerIDs[9], containers[6].ID)
+}
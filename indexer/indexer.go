@@ -5,15 +5,15 @@
 package indexer
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"math"
 	"sync"
 
-	"github.com/gorilla/rpc/v2"
-
 	"go.uber.org/zap"
 
+	"github.com/coinflect/coinflectchain/api/openrpc"
 	"github.com/coinflect/coinflectchain/api/server"
 	"github.com/coinflect/coinflectchain/chains"
 	"github.com/coinflect/coinflectchain/codec"
@@ -51,6 +51,7 @@ var (
 	blockPrefix             = byte(0x03)
 	isIncompletePrefix      = byte(0x04)
 	previouslyIndexedPrefix = byte(0x05)
+	generationPrefix        = byte(0x06)
 	hasRunKey               = []byte{0x07}
 
 	_ Indexer = (*indexer)(nil)
@@ -62,6 +63,7 @@ type Config struct {
 	Log                    logging.Logger
 	IndexingEnabled        bool
 	AllowIncompleteIndex   bool
+	BackfillEnabled        bool
 	DecisionAcceptorGroup  snow.AcceptorGroup
 	ConsensusAcceptorGroup snow.AcceptorGroup
 	APIServer              server.PathAdder
@@ -80,11 +82,15 @@ type Indexer interface {
 
 // NewIndexer returns a new Indexer and registers a new endpoint on the given API server.
 func NewIndexer(config Config) (Indexer, error) {
+	backfillCtx, backfillCancel := context.WithCancel(context.Background())
 	indexer := &indexer{
 		codec:                  codec.NewManager(codecMaxSize),
 		log:                    config.Log,
 		db:                     config.DB,
 		allowIncompleteIndex:   config.AllowIncompleteIndex,
+		backfillEnabled:        config.BackfillEnabled,
+		backfillCtx:            backfillCtx,
+		backfillCancel:         backfillCancel,
 		indexingEnabled:        config.IndexingEnabled,
 		decisionAcceptorGroup:  config.DecisionAcceptorGroup,
 		consensusAcceptorGroup: config.ConsensusAcceptorGroup,
@@ -99,10 +105,12 @@ func NewIndexer(config Config) (Indexer, error) {
 		codecVersion,
 		linearcodec.NewCustomMaxLength(math.MaxUint32),
 	); err != nil {
+		backfillCancel()
 		return nil, fmt.Errorf("couldn't register codec: %w", err)
 	}
 	hasRun, err := indexer.hasRun()
 	if err != nil {
+		backfillCancel()
 		return nil, err
 	}
 	indexer.hasRunBefore = hasRun
@@ -130,6 +138,15 @@ type indexer struct {
 	// of an index which could be missing accepted containers.
 	allowIncompleteIndex bool
 
+	// If true, the containers missing from an incomplete index are added to it
+	// from the history of its chain
+	backfillEnabled bool
+	// Cancelled when the indexer closes to stop the running backfills
+	backfillCtx    context.Context
+	backfillCancel context.CancelFunc
+	// Running backfills
+	backfills sync.WaitGroup
+
 	// If false, don't create index for a chain when RegisterChain is called
 	indexingEnabled bool
 
@@ -236,7 +253,12 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 		return
 	}
 
-	if !i.allowIncompleteIndex && isIncomplete && (previouslyIndexed || i.hasRunBefore) {
+	// If the index is incomplete but can be backfilled, it will be complete
+	// once the backfill finishes. If the backfill can't start or fails, the
+	// indexer shuts down as it would if backfills weren't enabled.
+	backfill := isIncomplete && i.backfillEnabled
+	requireComplete := !i.allowIncompleteIndex && isIncomplete && (previouslyIndexed || i.hasRunBefore)
+	if requireComplete && !backfill {
 		i.log.Fatal("index is incomplete but incomplete indices are disabled. Shutting down",
 			zap.String("chainName", name),
 		)
@@ -262,9 +284,9 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 		return
 	}
 
-	switch engine.(type) {
+	switch engine := engine.(type) {
 	case snowman.Engine:
-		index, err := i.registerChainHelper(chainID, blockPrefix, name, "block", i.consensusAcceptorGroup)
+		index, status, err := i.registerChainHelper(chainID, blockPrefix, name, "block", i.consensusAcceptorGroup, isIncomplete)
 		if err != nil {
 			i.log.Fatal("failed to create block index",
 				zap.String("chainName", name),
@@ -278,8 +300,26 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 			return
 		}
 		i.blockIndices[chainID] = index
+
+		if !backfill {
+			return
+		}
+		blockBackfiller, err := i.newBackfiller(
+			chainID,
+			blockPrefix,
+			name+"/block",
+			ctx,
+			&blockHistory{engine: engine},
+			index,
+			status,
+		)
+		if err != nil {
+			i.failBackfill(name, "block", requireComplete, err)
+			return
+		}
+		i.backfill(chainID, name, requireComplete, blockBackfiller)
 	case coinflect.Engine:
-		vtxIndex, err := i.registerChainHelper(chainID, vtxPrefix, name, "vtx", i.consensusAcceptorGroup)
+		vtxIndex, vtxStatus, err := i.registerChainHelper(chainID, vtxPrefix, name, "vtx", i.consensusAcceptorGroup, isIncomplete)
 		if err != nil {
 			i.log.Fatal("couldn't create vertex index",
 				zap.String("chainName", name),
@@ -294,7 +334,7 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 		}
 		i.vtxIndices[chainID] = vtxIndex
 
-		txIndex, err := i.registerChainHelper(chainID, txPrefix, name, "tx", i.decisionAcceptorGroup)
+		txIndex, txStatus, err := i.registerChainHelper(chainID, txPrefix, name, "tx", i.decisionAcceptorGroup, isIncomplete)
 		if err != nil {
 			i.log.Fatal("couldn't create tx index for",
 				zap.String("chainName", name),
@@ -308,6 +348,38 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 			return
 		}
 		i.txIndices[chainID] = txIndex
+
+		if !backfill {
+			return
+		}
+		vtxBackfiller, err := i.newBackfiller(
+			chainID,
+			vtxPrefix,
+			name+"/vtx",
+			ctx,
+			&vertexHistory{engine: engine},
+			vtxIndex,
+			vtxStatus,
+		)
+		if err != nil {
+			i.failBackfill(name, "vertex", requireComplete, err)
+			return
+		}
+		txBackfiller, err := i.newBackfiller(
+			chainID,
+			txPrefix,
+			name+"/tx",
+			ctx,
+			&vertexHistory{engine: engine, txs: true},
+			txIndex,
+			txStatus,
+		)
+		if err != nil {
+			_ = vtxBackfiller.staging.Close()
+			i.failBackfill(name, "tx", requireComplete, err)
+			return
+		}
+		i.backfill(chainID, name, requireComplete, vtxBackfiller, txBackfiller)
 	default:
 		engineType := fmt.Sprintf("%T", engine)
 		i.log.Error("got unexpected engine type",
@@ -327,38 +399,162 @@ func (i *indexer) registerChainHelper(
 	prefixEnd byte,
 	name, endpoint string,
 	acceptorGroup snow.AcceptorGroup,
-) (Index, error) {
-	prefix := make([]byte, hashing.HashLen+wrappers.ByteLen)
-	copy(prefix, chainID[:])
-	prefix[hashing.HashLen] = prefixEnd
-	indexDB := prefixdb.New(prefix, i.db)
-	index, err := newIndex(indexDB, i.log, i.codec, i.clock)
+	isIncomplete bool,
+) (*index, *backfillStatus, error) {
+	generation, err := i.generation(chainID, prefixEnd)
+	if err != nil {
+		return nil, nil, err
+	}
+	if generation > 0 {
+		// The index may have been replaced by a backfilled index before the
+		// replaced index was cleared.
+		replacedDB := i.indexDB(chainID, prefixEnd, generation-1)
+		err := database.Clear(replacedDB, replacedDB)
+		_ = replacedDB.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("couldn't clear replaced index: %w", err)
+		}
+	}
+
+	indexDB := i.indexDB(chainID, prefixEnd, generation)
+	indexIntf, err := newIndex(indexDB, i.log, i.codec, i.clock)
 	if err != nil {
 		_ = indexDB.Close()
-		return nil, err
+		return nil, nil, err
 	}
+	index := indexIntf.(*index)
 
 	// Register index to learn about new accepted vertices
 	if err := acceptorGroup.RegisterAcceptor(chainID, fmt.Sprintf("%s%s", indexNamePrefix, chainID), index, true); err != nil {
 		_ = index.Close()
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Create an API endpoint for this index
-	apiServer := rpc.NewServer()
+	apiServer := openrpc.NewServer()
 	codec := json.NewCodec()
 	apiServer.RegisterCodec(codec, "application/json")
 	apiServer.RegisterCodec(codec, "application/json;charset=UTF-8")
-	if err := apiServer.RegisterService(&service{Index: index}, "index"); err != nil {
+	status := &backfillStatus{incomplete: isIncomplete}
+	if err := apiServer.RegisterService(&service{Index: index, status: status}, "index"); err != nil {
 		_ = index.Close()
-		return nil, err
+		return nil, nil, err
 	}
 	handler := &common.HTTPHandler{LockOptions: common.NoLock, Handler: apiServer}
 	if err := i.pathAdder.AddRoute(handler, &sync.RWMutex{}, "index/"+name, "/"+endpoint); err != nil {
 		_ = index.Close()
+		return nil, nil, err
+	}
+	return index, status, nil
+}
+
+// newBackfiller returns a backfiller that rebuilds [active] in the generation
+// of the index after the current one.
+func (i *indexer) newBackfiller(
+	chainID ids.ID,
+	prefixEnd byte,
+	name string,
+	chainCtx *snow.ConsensusContext,
+	history history,
+	active *index,
+	status *backfillStatus,
+) (*backfiller, error) {
+	generation, err := i.generation(chainID, prefixEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	// Discard what a previous, interrupted, backfill wrote
+	stagingDB := i.indexDB(chainID, prefixEnd, generation+1)
+	if err := database.Clear(stagingDB, stagingDB); err != nil {
+		_ = stagingDB.Close()
+		return nil, fmt.Errorf("couldn't clear backfilled index: %w", err)
+	}
+	staging, err := newIndex(stagingDB, i.log, i.codec, i.clock)
+	if err != nil {
+		_ = stagingDB.Close()
 		return nil, err
 	}
-	return index, nil
+	return &backfiller{
+		name:     name,
+		log:      i.log,
+		chainCtx: chainCtx,
+		history:  history,
+		status:   status,
+		active:   active,
+		staging:  staging.(*index),
+		commit: func() error {
+			return database.PutUInt64(i.db, generationKey(chainID, prefixEnd), generation+1)
+		},
+	}, nil
+}
+
+// failBackfill handles a backfill of [name]'s [indexType] index that couldn't
+// start. If the index must be complete, the indexer is closed.
+// Assumes [i.lock] is held.
+func (i *indexer) failBackfill(name, indexType string, requireComplete bool, err error) {
+	if !requireComplete {
+		i.log.Error("couldn't backfill index",
+			zap.String("chainName", name),
+			zap.String("indexType", indexType),
+			zap.Error(err),
+		)
+		return
+	}
+	i.log.Fatal("couldn't backfill index but incomplete indices are disabled. Shutting down",
+		zap.String("chainName", name),
+		zap.String("indexType", indexType),
+		zap.Error(err),
+	)
+	if err := i.close(); err != nil {
+		i.log.Error("failed to close indexer",
+			zap.Error(err),
+		)
+	}
+}
+
+// backfill runs [backfillers] one after the other and marks [chainID] as
+// complete once they have all completed. If one of them fails and
+// [requireComplete] is true, the indexer is closed.
+func (i *indexer) backfill(chainID ids.ID, name string, requireComplete bool, backfillers ...*backfiller) {
+	i.backfills.Add(1)
+	go func() {
+		defer i.backfills.Done()
+
+		for j, b := range backfillers {
+			if !b.run(i.backfillCtx) {
+				for _, b := range backfillers[j+1:] {
+					_ = b.staging.Close()
+				}
+				// The backfill was stopped because the indexer is closing
+				if i.backfillCtx.Err() != nil || !requireComplete {
+					return
+				}
+				i.log.Fatal("backfill failed but incomplete indices are disabled. Shutting down",
+					zap.String("chainName", name),
+				)
+				// Close waits for this goroutine to return
+				go func() {
+					if err := i.Close(); err != nil {
+						i.log.Error("failed to close indexer",
+							zap.Error(err),
+						)
+					}
+				}()
+				return
+			}
+		}
+		if err := i.markComplete(chainID); err != nil {
+			i.log.Error("couldn't mark chain as complete",
+				zap.String("chainName", name),
+				zap.Error(err),
+			)
+			return
+		}
+		for _, b := range backfillers {
+			b.status.complete()
+		}
+	}()
 }
 
 // Close this indexer. Stops indexing all chains.
@@ -378,6 +574,10 @@ func (i *indexer) close() error {
 	}
 	i.closed = true
 
+	// Backfills must stop before the indices they write to are closed
+	i.backfillCancel()
+	i.backfills.Wait()
+
 	errs := &wrappers.Errs{}
 	for chainID, txIndex := range i.txIndices {
 		errs.Add(
@@ -410,6 +610,13 @@ func (i *indexer) markIncomplete(chainID ids.ID) error {
 	return i.db.Put(key, nil)
 }
 
+func (i *indexer) markComplete(chainID ids.ID) error {
+	key := make([]byte, hashing.HashLen+wrappers.ByteLen)
+	copy(key, chainID[:])
+	key[hashing.HashLen] = isIncompletePrefix
+	return i.db.Delete(key)
+}
+
 // Returns true if this chain is incomplete
 func (i *indexer) isIncomplete(chainID ids.ID) (bool, error) {
 	key := make([]byte, hashing.HashLen+wrappers.ByteLen)
@@ -433,6 +640,38 @@ func (i *indexer) previouslyIndexed(chainID ids.ID) (bool, error) {
 	return i.db.Has(key)
 }
 
+// Returns the generation of the index of [chainID] with [prefixEnd].
+// Each time an index is backfilled, the backfilled index is written to the
+// next generation.
+func (i *indexer) generation(chainID ids.ID, prefixEnd byte) (uint64, error) {
+	generation, err := database.GetUInt64(i.db, generationKey(chainID, prefixEnd))
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return generation, err
+}
+
+// Returns the database of the [generation] of the index of [chainID] with
+// [prefixEnd]
+func (i *indexer) indexDB(chainID ids.ID, prefixEnd byte, generation uint64) database.Database {
+	prefix := make([]byte, hashing.HashLen+wrappers.ByteLen, hashing.HashLen+wrappers.ByteLen+wrappers.LongLen)
+	copy(prefix, chainID[:])
+	prefix[hashing.HashLen] = prefixEnd
+	// The first generation keeps the prefix indices were created with
+	if generation > 0 {
+		prefix = append(prefix, database.PackUInt64(generation)...)
+	}
+	return prefixdb.New(prefix, i.db)
+}
+
+func generationKey(chainID ids.ID, prefixEnd byte) []byte {
+	key := make([]byte, hashing.HashLen+2*wrappers.ByteLen)
+	copy(key, chainID[:])
+	key[hashing.HashLen] = generationPrefix
+	key[hashing.HashLen+wrappers.ByteLen] = prefixEnd
+	return key
+}
+
 // Mark that the node has run at least once
 func (i *indexer) markHasRun() error {
 	return i.db.Put(hasRunKey, nil)
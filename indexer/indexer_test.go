@@ -5,6 +5,8 @@
 package indexer
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"sync"
 	"testing"
@@ -15,6 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/coinflect/coinflectchain/api/server"
+	"github.com/coinflect/coinflectchain/database"
 	"github.com/coinflect/coinflectchain/database/memdb"
 	"github.com/coinflect/coinflectchain/database/versiondb"
 	"github.com/coinflect/coinflectchain/ids"
@@ -25,9 +28,11 @@ import (
 	"github.com/coinflect/coinflectchain/snow/engine/coinflect/vertex"
 	"github.com/coinflect/coinflectchain/snow/engine/common"
 	"github.com/coinflect/coinflectchain/snow/engine/snowman"
+	"github.com/coinflect/coinflectchain/snow/engine/snowman/block"
 	"github.com/coinflect/coinflectchain/utils"
 	"github.com/coinflect/coinflectchain/utils/logging"
 
+	smcon "github.com/coinflect/coinflectchain/snow/consensus/snowman"
 	aveng "github.com/coinflect/coinflectchain/snow/engine/coinflect"
 	smblockmocks "github.com/coinflect/coinflectchain/snow/engine/snowman/block/mocks"
 )
@@ -540,3 +545,331 @@ func TestIgnoreNonDefaultChains(t *testing.T) {
 	idxr.RegisterChain("chain1", chainEngine)
 	require.Len(idxr.blockIndices, 0)
 }
+
+type heightIndexedVM struct {
+	block.TestVM
+	block.TestHeightIndexedVM
+}
+
+// Make sure an incomplete block index is backfilled from the chain's history
+func TestBackfillBlockIndex(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Accepted blocks by height
+	now := time.Now()
+	blks := make([]*smcon.TestBlock, 5)
+	blkIDs := map[ids.ID]*smcon.TestBlock{}
+	for height := range blks {
+		blk := &smcon.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Accepted,
+			},
+			HeightV:    uint64(height),
+			TimestampV: now.Add(time.Duration(height) * time.Second),
+			BytesV:     utils.RandomBytes(32),
+		}
+		blks[height] = blk
+		blkIDs[blk.ID()] = blk
+	}
+	lastAccepted := blks[3]
+
+	chainVM := &heightIndexedVM{}
+	chainVM.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return lastAccepted.ID(), nil
+	}
+	chainVM.VerifyHeightIndexF = func(context.Context) error {
+		return nil
+	}
+	chainVM.GetBlockIDAtHeightF = func(_ context.Context, height uint64) (ids.ID, error) {
+		return blks[height].ID(), nil
+	}
+
+	chainCtx := snow.DefaultConsensusContextTest()
+	chainCtx.ChainID = ids.GenerateTestID()
+	chainEngine := snowman.NewMockEngine(ctrl)
+	chainEngine.EXPECT().Context().AnyTimes().Return(chainCtx)
+	chainEngine.EXPECT().GetVM().AnyTimes().Return(chainVM)
+	chainEngine.EXPECT().GetBlock(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ context.Context, blkID ids.ID) (smcon.Block, error) {
+			return blkIDs[blkID], nil
+		},
+	)
+
+	// Index the chain from the third block only
+	baseDB := memdb.New()
+	config := Config{
+		IndexingEnabled:        false,
+		AllowIncompleteIndex:   true,
+		Log:                    logging.NoLog{},
+		DB:                     versiondb.New(baseDB),
+		DecisionAcceptorGroup:  snow.NewAcceptorGroup(logging.NoLog{}),
+		ConsensusAcceptorGroup: snow.NewAcceptorGroup(logging.NoLog{}),
+		APIServer:              &apiServerMock{},
+		ShutdownF:              func() {},
+	}
+	idxrIntf, err := NewIndexer(config)
+	require.NoError(err)
+	idxr := idxrIntf.(*indexer)
+	idxr.RegisterChain("chain1", chainEngine)
+	require.NoError(config.DB.(*versiondb.Database).Commit())
+	require.NoError(idxr.Close())
+
+	config.IndexingEnabled = true
+	config.DB = versiondb.New(baseDB)
+	idxrIntf, err = NewIndexer(config)
+	require.NoError(err)
+	idxr = idxrIntf.(*indexer)
+	acceptedAt := now.Add(time.Minute)
+	idxr.clock.Set(acceptedAt)
+	idxr.RegisterChain("chain1", chainEngine)
+	require.NoError(config.ConsensusAcceptorGroup.Accept(chainCtx, blks[3].ID(), blks[3].Bytes()))
+	require.NoError(config.DB.(*versiondb.Database).Commit())
+	require.NoError(idxr.Close())
+
+	// Backfill the index
+	config.AllowIncompleteIndex = false
+	config.BackfillEnabled = true
+	config.DB = versiondb.New(baseDB)
+	idxrIntf, err = NewIndexer(config)
+	require.NoError(err)
+	idxr = idxrIntf.(*indexer)
+	idxr.RegisterChain("chain1", chainEngine)
+	require.False(idxr.closed)
+	idxr.backfills.Wait()
+
+	isIncomplete, err := idxr.isIncomplete(chainCtx.ChainID)
+	require.NoError(err)
+	require.False(isIncomplete)
+
+	// The genesis block isn't indexed. The block that was already indexed
+	// keeps the time it was accepted at.
+	blkIdx := idxr.blockIndices[chainCtx.ChainID]
+	containers, err := blkIdx.GetContainerRange(0, MaxFetchedByRange)
+	require.NoError(err)
+	require.Equal([]Container{
+		{
+			ID:        blks[1].ID(),
+			Bytes:     blks[1].Bytes(),
+			Timestamp: blks[1].Timestamp().UnixNano(),
+		},
+		{
+			ID:        blks[2].ID(),
+			Bytes:     blks[2].Bytes(),
+			Timestamp: blks[2].Timestamp().UnixNano(),
+		},
+		{
+			ID:        blks[3].ID(),
+			Bytes:     blks[3].Bytes(),
+			Timestamp: acceptedAt.UnixNano(),
+		},
+	}, containers)
+
+	// Newly accepted blocks are added to the backfilled index
+	require.NoError(config.ConsensusAcceptorGroup.Accept(chainCtx, blks[4].ID(), blks[4].Bytes()))
+	index, err := blkIdx.GetIndex(blks[4].ID())
+	require.NoError(err)
+	require.EqualValues(3, index)
+	require.NoError(config.DB.(*versiondb.Database).Commit())
+	require.NoError(idxr.Close())
+
+	// The backfilled index is used after a restart
+	config.DB = versiondb.New(baseDB)
+	idxrIntf, err = NewIndexer(config)
+	require.NoError(err)
+	idxr = idxrIntf.(*indexer)
+	idxr.RegisterChain("chain1", chainEngine)
+	require.False(idxr.closed)
+	idxr.backfills.Wait()
+	lastAcceptedContainer, err := idxr.blockIndices[chainCtx.ChainID].GetLastAccepted()
+	require.NoError(err)
+	require.Equal(blks[4].ID(), lastAcceptedContainer.ID)
+	index, err = idxr.blockIndices[chainCtx.ChainID].GetIndex(blks[1].ID())
+	require.NoError(err)
+	require.Zero(index)
+	require.NoError(idxr.Close())
+}
+
+// Make sure the indexer shuts down if an incomplete index can't be backfilled
+// and incomplete indices are disabled
+func TestBackfillFailureClosesIndexer(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The VM doesn't index its blocks by height, so they can't be backfilled
+	chainVM := smblockmocks.NewMockChainVM(ctrl)
+	chainCtx := snow.DefaultConsensusContextTest()
+	chainCtx.ChainID = ids.GenerateTestID()
+	chainEngine := snowman.NewMockEngine(ctrl)
+	chainEngine.EXPECT().Context().AnyTimes().Return(chainCtx)
+	chainEngine.EXPECT().GetVM().AnyTimes().Return(chainVM)
+
+	// Don't index the chain
+	baseDB := memdb.New()
+	config := Config{
+		IndexingEnabled:        false,
+		AllowIncompleteIndex:   true,
+		Log:                    logging.NoLog{},
+		DB:                     versiondb.New(baseDB),
+		DecisionAcceptorGroup:  snow.NewAcceptorGroup(logging.NoLog{}),
+		ConsensusAcceptorGroup: snow.NewAcceptorGroup(logging.NoLog{}),
+		APIServer:              &apiServerMock{},
+		ShutdownF:              func() {},
+	}
+	idxrIntf, err := NewIndexer(config)
+	require.NoError(err)
+	idxr := idxrIntf.(*indexer)
+	idxr.RegisterChain("chain1", chainEngine)
+	require.NoError(config.DB.(*versiondb.Database).Commit())
+	require.NoError(idxr.Close())
+
+	// The failed backfill is allowed if incomplete indices are
+	config.IndexingEnabled = true
+	config.BackfillEnabled = true
+	config.DB = versiondb.New(baseDB)
+	idxrIntf, err = NewIndexer(config)
+	require.NoError(err)
+	idxr = idxrIntf.(*indexer)
+	idxr.RegisterChain("chain1", chainEngine)
+	idxr.backfills.Wait()
+	require.False(idxr.closed)
+	require.NoError(config.DB.(*versiondb.Database).Commit())
+	require.NoError(idxr.Close())
+
+	// Otherwise the indexer shuts down
+	shutdown := make(chan struct{})
+	config.AllowIncompleteIndex = false
+	config.DB = versiondb.New(baseDB)
+	config.ShutdownF = func() {
+		close(shutdown)
+	}
+	idxrIntf, err = NewIndexer(config)
+	require.NoError(err)
+	idxr = idxrIntf.(*indexer)
+	idxr.RegisterChain("chain1", chainEngine)
+	<-shutdown
+	idxr.lock.RLock()
+	require.True(idxr.closed)
+	idxr.lock.RUnlock()
+}
+
+// Make sure incomplete vertex and tx indices are backfilled from the vertices
+// accepted by the chain
+func TestBackfillDAGIndices(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// [vtx0] is the parent of [vtx1] and [vtx2], which are the parents of
+	// [vtx3]
+	txs := make([]*snowstorm.TestTx, 4)
+	for i := range txs {
+		txs[i] = &snowstorm.TestTx{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Accepted,
+			},
+			BytesV: utils.RandomBytes(32),
+		}
+	}
+	newVtx := func(height uint64, parents []coinflect.Vertex, txs ...snowstorm.Tx) *coinflect.TestVertex {
+		return &coinflect.TestVertex{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Accepted,
+			},
+			ParentsV: parents,
+			HeightV:  height,
+			TxsV:     txs,
+			BytesV:   utils.RandomBytes(32),
+		}
+	}
+	vtx0 := newVtx(0, nil, txs[0])
+	vtx1 := newVtx(1, []coinflect.Vertex{vtx0}, txs[1])
+	vtx2 := newVtx(1, []coinflect.Vertex{vtx0}, txs[2])
+	vtx3 := newVtx(2, []coinflect.Vertex{vtx1, vtx2}, txs[3])
+	vtxs := map[ids.ID]*coinflect.TestVertex{}
+	for _, vtx := range []*coinflect.TestVertex{vtx0, vtx1, vtx2, vtx3} {
+		vtxs[vtx.ID()] = vtx
+	}
+	// Vertices with the same height are backfilled in the order of their IDs
+	if bytes.Compare(vtx2.IDV[:], vtx1.IDV[:]) < 0 {
+		vtx1, vtx2 = vtx2, vtx1
+	}
+
+	chainCtx := snow.DefaultConsensusContextTest()
+	chainCtx.ChainID = ids.GenerateTestID()
+	dagEngine := aveng.NewMockEngine(ctrl)
+	dagEngine.EXPECT().Context().AnyTimes().Return(chainCtx)
+	dagEngine.EXPECT().Edge(gomock.Any()).AnyTimes().Return([]ids.ID{vtx3.ID()})
+	dagEngine.EXPECT().GetVtx(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ context.Context, vtxID ids.ID) (coinflect.Vertex, error) {
+			return vtxs[vtxID], nil
+		},
+	)
+
+	// Don't index the chain
+	baseDB := memdb.New()
+	config := Config{
+		IndexingEnabled:        false,
+		AllowIncompleteIndex:   true,
+		Log:                    logging.NoLog{},
+		DB:                     versiondb.New(baseDB),
+		DecisionAcceptorGroup:  snow.NewAcceptorGroup(logging.NoLog{}),
+		ConsensusAcceptorGroup: snow.NewAcceptorGroup(logging.NoLog{}),
+		APIServer:              &apiServerMock{},
+		ShutdownF:              func() {},
+	}
+	idxrIntf, err := NewIndexer(config)
+	require.NoError(err)
+	idxr := idxrIntf.(*indexer)
+	idxr.RegisterChain("chain1", dagEngine)
+	require.NoError(config.DB.(*versiondb.Database).Commit())
+	require.NoError(idxr.Close())
+
+	// Backfill the indices
+	config.IndexingEnabled = true
+	config.AllowIncompleteIndex = false
+	config.BackfillEnabled = true
+	config.DB = versiondb.New(baseDB)
+	idxrIntf, err = NewIndexer(config)
+	require.NoError(err)
+	idxr = idxrIntf.(*indexer)
+	idxr.RegisterChain("chain1", dagEngine)
+	require.False(idxr.closed)
+	idxr.backfills.Wait()
+
+	isIncomplete, err := idxr.isIncomplete(chainCtx.ChainID)
+	require.NoError(err)
+	require.False(isIncomplete)
+
+	vtxContainers, err := idxr.vtxIndices[chainCtx.ChainID].GetContainerRange(0, MaxFetchedByRange)
+	require.NoError(err)
+	require.Len(vtxContainers, 4)
+	for i, vtx := range []*coinflect.TestVertex{vtx0, vtx1, vtx2, vtx3} {
+		// The times at which the vertices were accepted are unknown
+		require.Equal(Container{
+			ID:    vtx.ID(),
+			Bytes: vtx.Bytes(),
+		}, vtxContainers[i])
+	}
+	_, err = idxr.vtxIndices[chainCtx.ChainID].GetContainerByTime(0)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	txContainers, err := idxr.txIndices[chainCtx.ChainID].GetContainerRange(0, MaxFetchedByRange)
+	require.NoError(err)
+	require.Len(txContainers, 4)
+	for i, vtx := range []*coinflect.TestVertex{vtx0, vtx1, vtx2, vtx3} {
+		tx := vtx.TxsV[0]
+		require.Equal(Container{
+			ID:    tx.ID(),
+			Bytes: tx.Bytes(),
+		}, txContainers[i])
+	}
+	_, err = idxr.txIndices[chainCtx.ChainID].GetContainerByTime(0)
+	require.ErrorIs(err, database.ErrNotFound)
+	require.NoError(idxr.Close())
+}
@@ -6,6 +6,7 @@ package indexer
 
 import (
 	"fmt"
+	"math"
 	"net/http"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 
 type service struct {
 	Index
+	status *backfillStatus
 }
 
 type FormattedContainer struct {
@@ -112,6 +114,108 @@ func (s *service) GetContainerRange(_ *http.Request, args *GetContainerRangeArgs
 	return nil
 }
 
+// GetContainerRangeReverse returns the transactions at index [startIndex], [startIndex-1], ... , [startIndex-n+1]
+// If [startIndex] > the last accepted index, starts from the last accepted index.
+// If [n] > [MaxFetchedByRange], returns an error.
+// If we run out of transactions, returns the ones fetched before running out.
+func (s *service) GetContainerRangeReverse(_ *http.Request, args *GetContainerRangeArgs, reply *GetContainerRangeResponse) error {
+	containers, err := s.Index.GetContainerRangeReverse(uint64(args.StartIndex), uint64(args.NumToFetch))
+	if err != nil {
+		return err
+	}
+	reply.Containers, err = s.format(containers, args.Encoding)
+	return err
+}
+
+type GetContainersByTimeArgs struct {
+	// If zero, the range starts from the first accepted container
+	StartTime time.Time `json:"startTime"`
+	// If zero, the range ends at the last accepted container
+	EndTime    time.Time           `json:"endTime"`
+	Cursor     string              `json:"cursor"`
+	NumToFetch json.Uint64         `json:"numToFetch"`
+	Encoding   formatting.Encoding `json:"encoding"`
+}
+
+type GetContainersByTimeResponse struct {
+	Containers []FormattedContainer `json:"containers"`
+	// Passed as the cursor of the next request to get the next containers in
+	// the range. Empty if there are none.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// GetContainersByTime returns up to [numToFetch] containers accepted in
+// [startTime, endTime), ordered by the time they were accepted.
+func (s *service) GetContainersByTime(_ *http.Request, args *GetContainersByTimeArgs, reply *GetContainersByTimeResponse) error {
+	var cursor []byte
+	if args.Cursor != "" {
+		var err error
+		cursor, err = formatting.Decode(formatting.Hex, args.Cursor)
+		if err != nil {
+			return fmt.Errorf("couldn't decode cursor: %w", err)
+		}
+	}
+
+	startTime, endTime := int64(math.MinInt64), int64(math.MaxInt64)
+	if !args.StartTime.IsZero() {
+		startTime = args.StartTime.UnixNano()
+	}
+	if !args.EndTime.IsZero() {
+		endTime = args.EndTime.UnixNano()
+	}
+	containers, nextCursor, err := s.Index.GetContainersByTime(startTime, endTime, cursor, uint64(args.NumToFetch))
+	if err != nil {
+		return err
+	}
+	reply.Containers, err = s.format(containers, args.Encoding)
+	if err != nil {
+		return err
+	}
+	if nextCursor != nil {
+		reply.NextCursor, err = formatting.Encode(formatting.Hex, nextCursor)
+	}
+	return err
+}
+
+type GetContainerByTimeArgs struct {
+	Time     time.Time           `json:"time"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetContainerByTime returns the first container accepted at or after [time].
+func (s *service) GetContainerByTime(_ *http.Request, args *GetContainerByTimeArgs, reply *FormattedContainer) error {
+	timestamp := int64(math.MinInt64)
+	if !args.Time.IsZero() {
+		timestamp = args.Time.UnixNano()
+	}
+	container, err := s.Index.GetContainerByTime(timestamp)
+	if err != nil {
+		return err
+	}
+	index, err := s.Index.GetIndex(container.ID)
+	if err != nil {
+		return fmt.Errorf("couldn't get index: %w", err)
+	}
+	*reply, err = newFormattedContainer(container, index, args.Encoding)
+	return err
+}
+
+// format [containers] with their index
+func (s *service) format(containers []Container, enc formatting.Encoding) ([]FormattedContainer, error) {
+	formatted := make([]FormattedContainer, len(containers))
+	for i, container := range containers {
+		index, err := s.Index.GetIndex(container.ID)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get index: %w", err)
+		}
+		formatted[i], err = newFormattedContainer(container, index, enc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return formatted, nil
+}
+
 type GetIndexArgs struct {
 	ID ids.ID `json:"id"`
 }
@@ -164,3 +268,25 @@ func (s *service) GetContainerByID(_ *http.Request, args *GetContainerByIDArgs,
 	*reply, err = newFormattedContainer(container, index, args.Encoding)
 	return err
 }
+
+type GetBackfillStatusResponse struct {
+	IsIncomplete  bool        `json:"isIncomplete"`
+	IsBackfilling bool        `json:"isBackfilling"`
+	NumProcessed  json.Uint64 `json:"numProcessed"`
+	NumToProcess  json.Uint64 `json:"numToProcess"`
+	Error         string      `json:"error,omitempty"`
+}
+
+// GetBackfillStatus returns whether the index is missing accepted containers
+// and the progress of adding them to the index.
+func (s *service) GetBackfillStatus(_ *http.Request, _ *struct{}, reply *GetBackfillStatusResponse) error {
+	isIncomplete, isBackfilling, numProcessed, numToProcess, err := s.status.get()
+	reply.IsIncomplete = isIncomplete
+	reply.IsBackfilling = isBackfilling
+	reply.NumProcessed = json.Uint64(numProcessed)
+	reply.NumToProcess = json.Uint64(numToProcess)
+	if err != nil {
+		reply.Error = err.Error()
+	}
+	return nil
+}
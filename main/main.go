@@ -11,12 +11,24 @@ import (
 
 	"github.com/spf13/pflag"
 
+	"github.com/coinflect/coinflectchain/app/dbcmd"
 	"github.com/coinflect/coinflectchain/app/runner"
 	"github.com/coinflect/coinflectchain/config"
 	"github.com/coinflect/coinflectchain/version"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == dbcmd.Name {
+		if err := dbcmd.Run(os.Args[2:]); err != nil {
+			if errors.Is(err, pflag.ErrHelp) {
+				os.Exit(0)
+			}
+			fmt.Printf("couldn't run %s command: %s\n", dbcmd.Name, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	fs := config.BuildFlagSet()
 	v, err := config.BuildViper(fs, os.Args[1:])
 
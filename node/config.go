@@ -8,11 +8,15 @@ import (
 	"crypto/tls"
 	"time"
 
+	"github.com/coinflect/coinflectchain/api/auth"
+	"github.com/coinflect/coinflectchain/api/ratelimit"
+	"github.com/coinflect/coinflectchain/api/server"
 	"github.com/coinflect/coinflectchain/chains"
 	"github.com/coinflect/coinflectchain/genesis"
 	"github.com/coinflect/coinflectchain/ids"
 	"github.com/coinflect/coinflectchain/nat"
 	"github.com/coinflect/coinflectchain/network"
+	"github.com/coinflect/coinflectchain/sinks"
 	"github.com/coinflect/coinflectchain/snow/consensus/coinflect"
 	"github.com/coinflect/coinflectchain/snow/networking/benchlist"
 	"github.com/coinflect/coinflectchain/snow/networking/router"
@@ -32,28 +36,44 @@ type IPCConfig struct {
 	IPCAPIEnabled      bool     `json:"ipcAPIEnabled"`
 	IPCPath            string   `json:"ipcPath"`
 	IPCDefaultChainIDs []string `json:"ipcDefaultChainIDs"`
+	// AcceptorSinksConfig specifies the sinks that accepted containers are
+	// delivered to
+	AcceptorSinksConfig sinks.Config `json:"acceptorSinksConfig"`
 }
 
 type APIAuthConfig struct {
 	APIRequireAuthToken bool   `json:"apiRequireAuthToken"`
 	APIAuthPassword     string `json:"-"`
+	// Roles of the clients that authenticate with a certificate, by subject
+	APICertRoles auth.CertificateRoles `json:"apiCertRoles"`
 }
 
 type APIIndexerConfig struct {
 	IndexAPIEnabled      bool `json:"indexAPIEnabled"`
 	IndexAllowIncomplete bool `json:"indexAllowIncomplete"`
+	IndexBackfillEnabled bool `json:"indexBackfillEnabled"`
 }
 
 type HTTPConfig struct {
 	APIConfig `json:"apiConfig"`
 	HTTPHost  string `json:"httpHost"`
 	HTTPPort  uint16 `json:"httpPort"`
+	// Port of the gRPC gateway to the APIs. If 0, the gateway isn't started.
+	HTTPGRPCPort uint16 `json:"httpGRPCPort"`
 
 	HTTPSEnabled bool   `json:"httpsEnabled"`
 	HTTPSKey     []byte `json:"-"`
 	HTTPSCert    []byte `json:"-"`
+	// PEM encoded CAs of the client certificates accepted by the HTTPs server.
+	// If empty, clients can't authenticate with a certificate.
+	HTTPSClientCA []byte `json:"-"`
+
+	// Listeners that the API server accepts requests on, in addition to
+	// [HTTPHost]:[HTTPPort]
+	HTTPListeners []server.Listener `json:"httpListeners"`
 
 	APIAllowedOrigins []string `json:"apiAllowedOrigins"`
+	APIMaxBatchSize   int      `json:"apiMaxBatchSize"`
 
 	ShutdownTimeout time.Duration `json:"shutdownTimeout"`
 	ShutdownWait    time.Duration `json:"shutdownWait"`
@@ -64,12 +84,15 @@ type APIConfig struct {
 	APIIndexerConfig `json:"indexerConfig"`
 	IPCConfig        `json:"ipcConfig"`
 
+	APIRateLimitConfig ratelimit.Config `json:"rateLimitConfig"`
+
 	// Enable/Disable APIs
 	AdminAPIEnabled    bool `json:"adminAPIEnabled"`
 	InfoAPIEnabled     bool `json:"infoAPIEnabled"`
 	KeystoreAPIEnabled bool `json:"keystoreAPIEnabled"`
 	MetricsAPIEnabled  bool `json:"metricsAPIEnabled"`
 	HealthAPIEnabled   bool `json:"healthAPIEnabled"`
+	EventsAPIEnabled   bool `json:"eventsAPIEnabled"`
 }
 
 type IPConfig struct {
@@ -132,6 +155,17 @@ type DatabaseConfig struct {
 
 	// Path to config file
 	Config []byte `json:"-"`
+
+	// Maximum number of bytes of values read by chains to cache in memory.
+	// If 0, reads aren't cached.
+	CacheSize uint64 `json:"cacheSize"`
+
+	// Directory that database backups are written to
+	BackupDir string `json:"backupDir"`
+
+	// Path to the master key that the database is encrypted with. If empty,
+	// the database isn't encrypted.
+	MasterKeyPath string `json:"masterKeyPath"`
 }
 
 // Config contains all of the configurations of an Coinflect node.
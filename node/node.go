@@ -28,18 +28,24 @@ import (
 
 	"github.com/coinflect/coinflectchain/api/admin"
 	"github.com/coinflect/coinflectchain/api/auth"
+	"github.com/coinflect/coinflectchain/api/events"
+	"github.com/coinflect/coinflectchain/api/gateway"
 	"github.com/coinflect/coinflectchain/api/health"
 	"github.com/coinflect/coinflectchain/api/info"
 	"github.com/coinflect/coinflectchain/api/keystore"
 	"github.com/coinflect/coinflectchain/api/metrics"
+	"github.com/coinflect/coinflectchain/api/ratelimit"
 	"github.com/coinflect/coinflectchain/api/server"
 	"github.com/coinflect/coinflectchain/chains"
 	"github.com/coinflect/coinflectchain/chains/atomic"
 	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/cachedb"
 	"github.com/coinflect/coinflectchain/database/leveldb"
+	"github.com/coinflect/coinflectchain/database/lsmdb"
 	"github.com/coinflect/coinflectchain/database/manager"
 	"github.com/coinflect/coinflectchain/database/memdb"
 	"github.com/coinflect/coinflectchain/database/prefixdb"
+	"github.com/coinflect/coinflectchain/database/sealdb"
 	"github.com/coinflect/coinflectchain/genesis"
 	"github.com/coinflect/coinflectchain/ids"
 	"github.com/coinflect/coinflectchain/indexer"
@@ -49,6 +55,7 @@ import (
 	"github.com/coinflect/coinflectchain/network/dialer"
 	"github.com/coinflect/coinflectchain/network/peer"
 	"github.com/coinflect/coinflectchain/network/throttling"
+	"github.com/coinflect/coinflectchain/sinks"
 	"github.com/coinflect/coinflectchain/snow"
 	"github.com/coinflect/coinflectchain/snow/engine/common"
 	"github.com/coinflect/coinflectchain/snow/networking/benchlist"
@@ -87,6 +94,8 @@ import (
 var (
 	genesisHashKey  = []byte("genesisID")
 	indexerDBPrefix = []byte{0x00}
+	authDBPrefix    = []byte("auth")
+	sinksDBPrefix   = []byte("sinks")
 
 	errInvalidTLSKey = errors.New("invalid TLS key")
 	errShuttingDown  = errors.New("server shutting down")
@@ -104,6 +113,8 @@ type Node struct {
 	// Storage for this node
 	DBManager manager.Manager
 	DB        database.Database
+	// Caches the values read by chains. Nil if caching is disabled.
+	DBCache *cachedb.Cache
 
 	// Profiles the process. Nil if continuous profiling is disabled.
 	profiler profiler.ContinuousProfiler
@@ -137,6 +148,12 @@ type Node struct {
 
 	IPCs *ipcs.ChainIPCs
 
+	// delivers accepted containers to the configured acceptor sinks
+	sinks *sinks.Manager
+
+	// publishes chain events to WebSocket subscribers, nil if disabled
+	eventsPublisher events.Publisher
+
 	// Net runs the networking stack
 	networkNamespace string
 	Net              network.Network
@@ -154,6 +171,9 @@ type Node struct {
 	// Handles HTTP API calls
 	APIServer server.Server
 
+	// Serves the APIs over gRPC. Nil if the gRPC gateway is disabled.
+	grpcGateway gateway.Server
+
 	// This node's configuration
 	Config *Config
 
@@ -391,7 +411,7 @@ func (n *Node) Dispatch() error {
 		var err error
 		if n.Config.HTTPSEnabled {
 			n.Log.Debug("initializing API server with TLS")
-			err = n.APIServer.DispatchTLS(n.Config.HTTPSCert, n.Config.HTTPSKey)
+			err = n.APIServer.DispatchTLS(n.Config.HTTPSCert, n.Config.HTTPSKey, n.Config.HTTPSClientCA)
 		} else {
 			n.Log.Debug("initializing API server without TLS")
 			err = n.APIServer.Dispatch()
@@ -409,6 +429,45 @@ func (n *Node) Dispatch() error {
 		n.Shutdown(1)
 	})
 
+	// Start the API server's additional listeners
+	for _, listener := range n.Config.HTTPListeners {
+		listener := listener
+		go n.Log.RecoverAndPanic(func() {
+			var err error
+			if n.Config.HTTPSEnabled {
+				err = n.APIServer.DispatchListenerTLS(listener, n.Config.HTTPSCert, n.Config.HTTPSKey, n.Config.HTTPSClientCA)
+			} else {
+				err = n.APIServer.DispatchListener(listener)
+			}
+			if !n.shuttingDown.GetValue() {
+				n.Log.Fatal("API server listener dispatch failed",
+					zap.String("network", listener.Network),
+					zap.String("address", listener.Address),
+					zap.Error(err),
+				)
+			}
+			n.Shutdown(1)
+		})
+	}
+
+	// Start the gRPC API gateway
+	if n.grpcGateway != nil {
+		go n.Log.RecoverAndPanic(func() {
+			var err error
+			if n.Config.HTTPSEnabled {
+				err = n.grpcGateway.DispatchTLS(n.Config.HTTPSCert, n.Config.HTTPSKey, n.Config.HTTPSClientCA)
+			} else {
+				err = n.grpcGateway.Dispatch()
+			}
+			if !n.shuttingDown.GetValue() {
+				n.Log.Fatal("gRPC API gateway dispatch failed",
+					zap.Error(err),
+				)
+			}
+			n.Shutdown(1)
+		})
+	}
+
 	// Add state sync nodes to the peer network
 	for i, peerIP := range n.Config.StateSyncIPs {
 		n.Net.ManuallyTrack(n.Config.StateSyncIDs[i], peerIP)
@@ -456,13 +515,16 @@ func (n *Node) initDatabase() error {
 	switch n.Config.DatabaseConfig.Name {
 	case leveldb.Name:
 		dbManager, err = manager.NewLevelDB(n.Config.DatabaseConfig.Path, n.Config.DatabaseConfig.Config, n.Log, version.CurrentDatabase, "db_internal", n.MetricsRegisterer)
+	case lsmdb.Name:
+		dbManager, err = manager.NewLSMDB(n.Config.DatabaseConfig.Path, n.Config.DatabaseConfig.Config, n.Log, version.CurrentDatabase, "db_internal", n.MetricsRegisterer)
 	case memdb.Name:
 		dbManager = manager.NewMemDB(version.CurrentDatabase)
 	default:
 		err = fmt.Errorf(
-			"db-type was %q but should have been one of {%s, %s}",
+			"db-type was %q but should have been one of {%s, %s, %s}",
 			n.Config.DatabaseConfig.Name,
 			leveldb.Name,
+			lsmdb.Name,
 			memdb.Name,
 		)
 	}
@@ -470,6 +532,23 @@ func (n *Node) initDatabase() error {
 		return err
 	}
 
+	if path := n.Config.DatabaseConfig.MasterKeyPath; path != "" {
+		masterKey, err := sealdb.LoadMasterKey(path)
+		if errors.Is(err, os.ErrNotExist) {
+			n.Log.Info("generating database master key",
+				zap.String("path", path),
+			)
+			masterKey, err = sealdb.GenerateMasterKey(path)
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't load database master key: %w", err)
+		}
+		dbManager, err = dbManager.NewSealDBManager(masterKey, n.Log)
+		if err != nil {
+			return err
+		}
+	}
+
 	meterDBManager, err := dbManager.NewMeterDBManager("db", n.MetricsRegisterer)
 	if err != nil {
 		return err
@@ -477,6 +556,13 @@ func (n *Node) initDatabase() error {
 
 	n.DBManager = meterDBManager
 
+	if cacheSize := n.Config.DatabaseConfig.CacheSize; cacheSize > 0 {
+		n.DBCache, err = cachedb.NewCache(int(cacheSize), "db_cache", n.MetricsRegisterer)
+		if err != nil {
+			return err
+		}
+	}
+
 	currentDB := dbManager.Current()
 	n.Log.Info("initializing database",
 		zap.Stringer("dbVersion", currentDB.Version),
@@ -542,6 +628,27 @@ func (n *Node) initIPCs() error {
 	return err
 }
 
+// Initialize [n.sinks].
+// Should only be called after [n.DB], [n.DecisionAcceptorGroup],
+// [n.ConsensusAcceptorGroup], [n.Log], [n.MetricsRegisterer] and [n.health]
+// are initialized, and before the chains are created.
+func (n *Node) initAcceptorSinks() error {
+	var err error
+	n.sinks, err = sinks.New(
+		n.Log,
+		prefixdb.New(sinksDBPrefix, n.DB),
+		"acceptor_sinks",
+		n.MetricsRegisterer,
+		n.Config.AcceptorSinksConfig,
+		n.ConsensusAcceptorGroup,
+		n.DecisionAcceptorGroup,
+	)
+	if err != nil {
+		return err
+	}
+	return n.health.RegisterHealthCheck("acceptorSinks", n.sinks)
+}
+
 // Initialize [n.indexer].
 // Should only be called after [n.DB], [n.DecisionAcceptorGroup],
 // [n.ConsensusAcceptorGroup], [n.Log], [n.APIServer], [n.chainManager] are
@@ -552,6 +659,7 @@ func (n *Node) initIndexer() error {
 	n.indexer, err = indexer.NewIndexer(indexer.Config{
 		IndexingEnabled:        n.Config.IndexAPIEnabled,
 		AllowIncompleteIndex:   n.Config.IndexAllowIncomplete,
+		BackfillEnabled:        n.Config.IndexBackfillEnabled,
 		DB:                     txIndexerDB,
 		Log:                    n.Log,
 		DecisionAcceptorGroup:  n.DecisionAcceptorGroup,
@@ -593,6 +701,22 @@ func (n *Node) initAPIServer() error {
 	n.Log.Info("initializing API server")
 	n.APIServer = server.New()
 
+	var wrappers []server.Wrapper
+	if n.Config.APIRateLimitConfig.Enabled() {
+		// If auth tokens are required, they are verified before the rate
+		// limiter is called, so clients can be identified by their tokens.
+		limiter, err := ratelimit.New(
+			n.Config.APIRateLimitConfig,
+			"api",
+			n.MetricsRegisterer,
+		)
+		if err != nil {
+			return err
+		}
+		n.Log.Info("API rate limiting is enabled")
+		wrappers = append(wrappers, limiter)
+	}
+
 	if !n.Config.APIRequireAuthToken {
 		n.APIServer.Initialize(
 			n.Log,
@@ -601,14 +725,28 @@ func (n *Node) initAPIServer() error {
 			n.Config.HTTPPort,
 			n.Config.APIAllowedOrigins,
 			n.Config.ShutdownTimeout,
+			n.Config.APIMaxBatchSize,
 			n.ID,
 			n.Config.TraceConfig.Enabled,
 			n.tracer,
+			wrappers...,
 		)
 		return nil
 	}
 
-	a, err := auth.New(n.Log, "auth", n.Config.APIAuthPassword)
+	auditLog, err := n.LogFactory.Make("audit")
+	if err != nil {
+		return fmt.Errorf("problem initializing the audit log: %w", err)
+	}
+	authDB := prefixdb.New(authDBPrefix, n.DB)
+	a, err := auth.New(
+		n.Log,
+		auditLog,
+		"auth",
+		n.Config.APIAuthPassword,
+		authDB,
+		n.Config.APICertRoles,
+	)
 	if err != nil {
 		return err
 	}
@@ -620,10 +758,11 @@ func (n *Node) initAPIServer() error {
 		n.Config.HTTPPort,
 		n.Config.APIAllowedOrigins,
 		n.Config.ShutdownTimeout,
+		n.Config.APIMaxBatchSize,
 		n.ID,
 		n.Config.TraceConfig.Enabled,
 		n.tracer,
-		a,
+		append(wrappers, server.AuthWrapper{Wrapper: a})...,
 	)
 
 	// only create auth service if token authorization is required
@@ -639,6 +778,23 @@ func (n *Node) initAPIServer() error {
 	return n.APIServer.AddRoute(handler, &sync.RWMutex{}, "auth", "")
 }
 
+// initGRPCGateway initializes the gRPC gateway to the APIs, which forwards its
+// calls to the API server's handler.
+// Assumes n.APIServer is initialized
+func (n *Node) initGRPCGateway() {
+	if n.Config.HTTPGRPCPort == 0 {
+		n.Log.Info("skipping gRPC API gateway initialization because it has been disabled")
+		return
+	}
+	n.Log.Info("initializing gRPC API gateway")
+	n.grpcGateway = gateway.New(
+		n.Log,
+		n.Config.HTTPHost,
+		n.Config.HTTPGRPCPort,
+		n.APIServer.Handler(),
+	)
+}
+
 // Add the default VM aliases
 func (n *Node) addDefaultVMAliases() error {
 	n.Log.Info("adding the default VM aliases")
@@ -717,6 +873,7 @@ func (n *Node) initChainManager(cfltAssetID ids.ID) error {
 		DecisionAcceptorGroup:                   n.DecisionAcceptorGroup,
 		ConsensusAcceptorGroup:                  n.ConsensusAcceptorGroup,
 		DBManager:                               n.DBManager,
+		DBCache:                                 n.DBCache,
 		MsgCreator:                              n.msgCreator,
 		Router:                                  n.Config.ConsensusRouter,
 		Net:                                     n.Net,
@@ -875,12 +1032,15 @@ func (n *Node) initKeystoreAPI() error {
 	return n.APIServer.AddRoute(handler, &sync.RWMutex{}, "keystore", "")
 }
 
-// initMetricsAPI initializes the Metrics API
-// Assumes n.APIServer is already set
-func (n *Node) initMetricsAPI() error {
+// initMetrics initializes the metrics registerer and gatherer
+func (n *Node) initMetrics() {
 	n.MetricsRegisterer = prometheus.NewRegistry()
 	n.MetricsGatherer = metrics.NewMultiGatherer()
+}
 
+// initMetricsAPI initializes the Metrics API
+// Assumes n.APIServer and the metrics are already set
+func (n *Node) initMetricsAPI() error {
 	if !n.Config.MetricsAPIEnabled {
 		n.Log.Info("skipping metrics API initialization because it has been disabled")
 		return nil
@@ -936,6 +1096,11 @@ func (n *Node) initAdminAPI() error {
 			NodeConfig:   n.Config,
 			VMManager:    n.Config.VMManager,
 			VMRegistry:   n.VMRegistry,
+			DBManager:    n.DBManager,
+			BackupDir:    n.Config.DatabaseConfig.BackupDir,
+			Health:       n.health,
+			NetworkID:    n.Config.NetworkID,
+			GenesisID:    hashing.ComputeHash256Array(n.Config.GenesisBytes),
 		},
 	)
 	if err != nil {
@@ -1084,6 +1249,21 @@ func (n *Node) initHealthAPI() error {
 		return err
 	}
 
+	// GET requests to the health API report the health of the node, so its
+	// OpenRPC document is served on a separate endpoint.
+	err = n.APIServer.AddRoute(
+		&common.HTTPHandler{
+			LockOptions: common.NoLock,
+			Handler:     handler.DocumentHandler(),
+		},
+		&sync.RWMutex{},
+		"health",
+		"/openrpc",
+	)
+	if err != nil {
+		return err
+	}
+
 	err = n.APIServer.AddRoute(
 		&common.HTTPHandler{
 			LockOptions: common.NoLock,
@@ -1136,6 +1316,25 @@ func (n *Node) initIPCAPI() error {
 	return n.APIServer.AddRoute(service, &sync.RWMutex{}, "ipcs", "")
 }
 
+// initEventsAPI initializes the events API, which publishes chain events to
+// WebSocket subscribers.
+// Assumes n.APIServer, n.chainManager and the acceptor groups are initialized
+func (n *Node) initEventsAPI(primaryNetVdrs validators.Set) error {
+	if !n.Config.EventsAPIEnabled {
+		n.Log.Info("skipping events API initialization because it has been disabled")
+		return nil
+	}
+	n.Log.Info("initializing events API")
+	n.eventsPublisher = events.NewPublisher(events.Config{
+		Log:                    n.Log,
+		DecisionAcceptorGroup:  n.DecisionAcceptorGroup,
+		ConsensusAcceptorGroup: n.ConsensusAcceptorGroup,
+	})
+	n.chainManager.AddRegistrant(n.eventsPublisher)
+	primaryNetVdrs.RegisterCallbackListener(n.eventsPublisher)
+	return n.APIServer.AddRoute(n.eventsPublisher.Handler(), &sync.RWMutex{}, "events", "")
+}
+
 // Give chains aliases as specified by the genesis information
 func (n *Node) initChainAliases(genesisBytes []byte) error {
 	n.Log.Info("initializing chain aliases")
@@ -1266,18 +1465,24 @@ func (n *Node) Initialize(
 		n.Config.ConsensusRouter = router.Trace(n.Config.ConsensusRouter, n.tracer)
 	}
 
+	n.initMetrics()
+
+	// The database must be initialized before the API server, which persists
+	// the API keys in it.
+	if err := n.initDatabase(); err != nil { // Set up the node's database
+		return fmt.Errorf("problem initializing database: %w", err)
+	}
+
 	if err := n.initAPIServer(); err != nil { // Start the API Server
 		return fmt.Errorf("couldn't initialize API server: %w", err)
 	}
 
+	n.initGRPCGateway()
+
 	if err := n.initMetricsAPI(); err != nil { // Start the Metrics API
 		return fmt.Errorf("couldn't initialize metrics API: %w", err)
 	}
 
-	if err := n.initDatabase(); err != nil { // Set up the node's database
-		return fmt.Errorf("problem initializing database: %w", err)
-	}
-
 	if err := n.initKeystoreAPI(); err != nil { // Start the Keystore API
 		return fmt.Errorf("couldn't initialize keystore API: %w", err)
 	}
@@ -1338,9 +1543,15 @@ func (n *Node) Initialize(
 	if err := n.initIPCs(); err != nil { // Start the IPCs
 		return fmt.Errorf("couldn't initialize IPCs: %w", err)
 	}
+	if err := n.initAcceptorSinks(); err != nil { // Start the acceptor sinks
+		return fmt.Errorf("couldn't initialize acceptor sinks: %w", err)
+	}
 	if err := n.initIPCAPI(); err != nil { // Start the IPC API
 		return fmt.Errorf("couldn't initialize the IPC API: %w", err)
 	}
+	if err := n.initEventsAPI(primaryNetVdrs); err != nil { // Start the Events API
+		return fmt.Errorf("couldn't initialize the events API: %w", err)
+	}
 	if err := n.initChainAliases(n.Config.GenesisBytes); err != nil {
 		return fmt.Errorf("couldn't initialize chain aliases: %w", err)
 	}
@@ -1405,6 +1616,15 @@ func (n *Node) shutdown() {
 	if n.chainManager != nil {
 		n.chainManager.Shutdown()
 	}
+	// The sinks are shut down after the chains so that every container the
+	// chains accepted was queued for the sinks.
+	if n.sinks != nil {
+		if err := n.sinks.Shutdown(); err != nil {
+			n.Log.Debug("error during acceptor sinks shutdown",
+				zap.Error(err),
+			)
+		}
+	}
 	if n.profiler != nil {
 		n.profiler.Shutdown()
 	}
@@ -1416,11 +1636,21 @@ func (n *Node) shutdown() {
 			zap.Error(err),
 		)
 	}
+	if n.grpcGateway != nil {
+		n.grpcGateway.Shutdown()
+	}
 	if err := n.indexer.Close(); err != nil {
 		n.Log.Debug("error closing tx indexer",
 			zap.Error(err),
 		)
 	}
+	if n.eventsPublisher != nil {
+		if err := n.eventsPublisher.Close(); err != nil {
+			n.Log.Debug("error closing events publisher",
+				zap.Error(err),
+			)
+		}
+	}
 
 	// Make sure all plugin subprocesses are killed
 	n.Log.Info("cleaning up plugin subprocesses")
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -23,6 +24,8 @@ var (
 	ErrAddressLimit                = errors.New("address limit exceeded")
 	ErrInvalidFilterParam          = errors.New("invalid bloom filter params")
 	ErrInvalidCommand              = errors.New("invalid command")
+	ErrInvalidTopic                = errors.New("invalid topic")
+	ErrTopicLimit                  = errors.New("topic limit exceeded")
 	_                       Filter = (*connection)(nil)
 )
 
@@ -40,8 +43,15 @@ type connection struct {
 	// Buffered channel of outbound messages.
 	send chan interface{}
 
+	// Closed when the connection is dropped for falling behind.
+	dropped  chan struct{}
+	dropOnce sync.Once
+
 	fp *FilterParam
 
+	// topics this connection is subscribed to. Guarded by the server's lock.
+	topics map[string]struct{}
+
 	active uint32
 }
 
@@ -58,6 +68,14 @@ func (c *connection) deactivate() {
 	atomic.StoreUint32(&c.active, 0)
 }
 
+// drop deactivates the connection and makes the writePump close it.
+func (c *connection) drop() {
+	c.dropOnce.Do(func() {
+		c.deactivate()
+		close(c.dropped)
+	})
+}
+
 func (c *connection) Send(msg interface{}) bool {
 	if !c.isActive() {
 		return false
@@ -143,6 +161,15 @@ func (c *connection) writePump() {
 			if err := c.conn.WriteJSON(message); err != nil {
 				return
 			}
+		case <-c.dropped:
+			// The pending messages are discarded so that the client learns
+			// that it fell behind as soon as possible.
+			_ = c.conn.WriteControl(
+				websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too many pending messages"),
+				time.Now().Add(writeWait),
+			)
+			return
 		case <-ticker.C:
 			if err := c.conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
 				c.s.log.Debug("closing the connection",
@@ -176,6 +203,10 @@ func (c *connection) readMessage() error {
 		c.handleNewSet(cmd.NewSet)
 	case cmd.AddAddresses != nil:
 		err = c.handleAddAddresses(cmd.AddAddresses)
+	case cmd.Subscribe != nil:
+		err = c.handleSubscribe(cmd.Subscribe)
+	case cmd.Unsubscribe != nil:
+		err = c.handleUnsubscribe(cmd.Unsubscribe)
 	default:
 		err = ErrInvalidCommand
 	}
@@ -214,3 +245,18 @@ func (c *connection) handleAddAddresses(cmd *AddAddresses) error {
 	c.s.subscribedConnections.Add(c)
 	return nil
 }
+
+func (c *connection) handleSubscribe(cmd *Subscribe) error {
+	if !cmd.IsValid() {
+		return ErrInvalidTopic
+	}
+	return c.s.subscribe(c, cmd.Topic)
+}
+
+func (c *connection) handleUnsubscribe(cmd *Unsubscribe) error {
+	if !cmd.IsValid() {
+		return ErrInvalidTopic
+	}
+	c.s.unsubscribe(c, cmd.Topic)
+	return nil
+}
@@ -26,17 +26,28 @@ func TestAddAddressesParseAddresses(t *testing.T) {
 	addrStr, err := address.Format(chainAlias, hrp, addrID[:])
 	require.NoError(err)
 
+	nodeID := ids.NodeID{2}
+
 	msg := &AddAddresses{JSONAddresses: api.JSONAddresses{
 		Addresses: []string{
 			addrStr,
+			nodeID.String(),
 		},
 	}}
 
 	err = msg.parseAddresses()
 	require.NoError(err)
 
-	require.Len(msg.addressIds, 1)
+	require.Len(msg.addressIds, 2)
 	require.Equal(addrID[:], msg.addressIds[0])
+	require.Equal(nodeID.Bytes(), msg.addressIds[1])
+
+	msg = &AddAddresses{JSONAddresses: api.JSONAddresses{
+		Addresses: []string{
+			"NodeID-invalid",
+		},
+	}}
+	require.Error(msg.parseAddresses())
 }
 
 func TestFilterParamUpdateMulti(t *testing.T) {
@@ -4,6 +4,9 @@
 
 package pubsub
 
+// Filterer returns the message to send to each of the [connections]. A nil
+// message means that the connection's filter didn't match, and that nothing is
+// sent to the connection.
 type Filterer interface {
-	Filter(connections []Filter) ([]bool, interface{})
+	Filter(connections []Filter) []interface{}
 }
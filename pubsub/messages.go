@@ -5,7 +5,10 @@
 package pubsub
 
 import (
+	"strings"
+
 	"github.com/coinflect/coinflectchain/api"
+	"github.com/coinflect/coinflectchain/ids"
 	"github.com/coinflect/coinflectchain/utils/formatting/address"
 	"github.com/coinflect/coinflectchain/utils/json"
 )
@@ -21,7 +24,8 @@ type NewBloom struct {
 // NewSet command for a new map set
 type NewSet struct{}
 
-// AddAddresses command to add addresses
+// AddAddresses command to add addresses. Node IDs, formatted as
+// "NodeID-...", may be added to match the transactions that reference them.
 type AddAddresses struct {
 	api.JSONAddresses
 
@@ -29,11 +33,29 @@ type AddAddresses struct {
 	addressIds [][]byte
 }
 
+// Subscribe command to receive the events published to a topic
+type Subscribe struct {
+	Topic string `json:"topic"`
+}
+
+// Unsubscribe command to stop receiving the events published to a topic
+type Unsubscribe struct {
+	Topic string `json:"topic"`
+}
+
 // Command execution command
 type Command struct {
 	NewBloom     *NewBloom     `json:"newBloom,omitempty"`
 	NewSet       *NewSet       `json:"newSet,omitempty"`
 	AddAddresses *AddAddresses `json:"addAddresses,omitempty"`
+	Subscribe    *Subscribe    `json:"subscribe,omitempty"`
+	Unsubscribe  *Unsubscribe  `json:"unsubscribe,omitempty"`
+}
+
+// TopicMessage is sent to the connections subscribed to [Topic]
+type TopicMessage struct {
+	Topic string      `json:"topic"`
+	Event interface{} `json:"event"`
 }
 
 func (c *Command) String() string {
@@ -44,22 +66,48 @@ func (c *Command) String() string {
 		return "newSet"
 	case c.AddAddresses != nil:
 		return "addAddresses"
+	case c.Subscribe != nil:
+		return "subscribe"
+	case c.Unsubscribe != nil:
+		return "unsubscribe"
 	default:
 		return "unknown"
 	}
 }
 
+func (c *Subscribe) IsValid() bool {
+	return isValidTopic(c.Topic)
+}
+
+func (c *Unsubscribe) IsValid() bool {
+	return isValidTopic(c.Topic)
+}
+
+func isValidTopic(topic string) bool {
+	return topic != "" && len(topic) <= MaxTopicLength
+}
+
 func (c *NewBloom) IsParamsValid() bool {
 	p := float64(c.CollisionProb)
 	return c.MaxElements > 0 && 0 < p && p <= 1
 }
 
-// parseAddresses converts the bech32 addresses to their byte format.
+// parseAddresses converts the bech32 addresses and the node IDs to their byte
+// format.
 func (c *AddAddresses) parseAddresses() error {
 	if c.addressIds == nil {
 		c.addressIds = make([][]byte, len(c.Addresses))
 	}
 	for i, addrStr := range c.Addresses {
+		if strings.HasPrefix(addrStr, ids.NodeIDPrefix) {
+			nodeID, err := ids.NodeIDFromString(addrStr)
+			if err != nil {
+				return err
+			}
+			c.addressIds[i] = nodeID.Bytes()
+			continue
+		}
+
 		_, _, addrBytes, err := address.Parse(addrStr)
 		if err != nil {
 			return err
@@ -6,6 +6,7 @@ package pubsub
 
 import (
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -44,6 +45,12 @@ const (
 
 	// MaxAddresses the max number of addresses allowed
 	MaxAddresses = 10000
+
+	// MaxTopicLength the max number of bytes in a topic
+	MaxTopicLength = 256
+
+	// MaxTopics the max number of topics a connection can subscribe to
+	MaxTopics = 64
 )
 
 type errorMsg struct {
@@ -66,6 +73,8 @@ type Server struct {
 	conns map[*connection]struct{}
 	// subscribedConnections the connections that have activated subscriptions
 	subscribedConnections *connections
+	// topics maps a topic to the connections subscribed to it
+	topics map[string]map[*connection]struct{}
 }
 
 func New(log logging.Logger) *Server {
@@ -73,6 +82,7 @@ func New(log logging.Logger) *Server {
 		log:                   log,
 		conns:                 make(map[*connection]struct{}),
 		subscribedConnections: newConnections(),
+		topics:                make(map[string]map[*connection]struct{}),
 	}
 }
 
@@ -85,20 +95,22 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	conn := &connection{
-		s:      s,
-		conn:   wsConn,
-		send:   make(chan interface{}, maxPendingMessages),
-		fp:     NewFilterParam(),
-		active: 1,
+		s:       s,
+		conn:    wsConn,
+		send:    make(chan interface{}, maxPendingMessages),
+		dropped: make(chan struct{}),
+		fp:      NewFilterParam(),
+		topics:  make(map[string]struct{}),
+		active:  1,
 	}
 	s.addConnection(conn)
 }
 
 func (s *Server) Publish(parser Filterer) {
 	conns := s.subscribedConnections.Conns()
-	toNotify, msg := parser.Filter(conns)
-	for i, shouldNotify := range toNotify {
-		if !shouldNotify {
+	msgs := parser.Filter(conns)
+	for i, msg := range msgs {
+		if msg == nil {
 			continue
 		}
 		conn := conns[i].(*connection)
@@ -108,6 +120,99 @@ func (s *Server) Publish(parser Filterer) {
 	}
 }
 
+// PublishTopic sends [event] to the connections subscribed to [topic].
+//
+// Subscribers that don't read their events fast enough are dropped, rather
+// than silently missing events. A dropped subscriber is disconnected and must
+// reconnect and resubscribe.
+func (s *Server) PublishTopic(topic string, event interface{}) {
+	s.lock.RLock()
+	subscribers := s.topics[topic]
+	conns := make([]*connection, 0, len(subscribers))
+	for conn := range subscribers {
+		conns = append(conns, conn)
+	}
+	s.lock.RUnlock()
+
+	if len(conns) == 0 {
+		return
+	}
+	msg := &TopicMessage{
+		Topic: topic,
+		Event: event,
+	}
+	for _, conn := range conns {
+		if !conn.Send(msg) {
+			s.log.Debug("dropping subscriber",
+				zap.String("reason", "too many pending messages"),
+				zap.String("topic", topic),
+			)
+			conn.drop()
+		}
+	}
+}
+
+// HasSubscribers returns true if any connection is subscribed to [topic].
+func (s *Server) HasSubscribers(topic string) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return len(s.topics[topic]) > 0
+}
+
+// HasSubscribersWithPrefix returns true if any connection is subscribed to a
+// topic that starts with [prefix].
+func (s *Server) HasSubscribersWithPrefix(prefix string) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for topic := range s.topics {
+		if strings.HasPrefix(topic, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) subscribe(conn *connection, topic string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := conn.topics[topic]; ok {
+		return nil
+	}
+	if len(conn.topics) >= MaxTopics {
+		return ErrTopicLimit
+	}
+	conn.topics[topic] = struct{}{}
+
+	subscribers, ok := s.topics[topic]
+	if !ok {
+		subscribers = make(map[*connection]struct{})
+		s.topics[topic] = subscribers
+	}
+	subscribers[conn] = struct{}{}
+	return nil
+}
+
+func (s *Server) unsubscribe(conn *connection, topic string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.unsubscribeLocked(conn, topic)
+}
+
+// Assumes [s.lock] is held
+func (s *Server) unsubscribeLocked(conn *connection, topic string) {
+	delete(conn.topics, topic)
+
+	subscribers := s.topics[topic]
+	delete(subscribers, conn)
+	if len(subscribers) == 0 {
+		delete(s.topics, topic)
+	}
+}
+
 func (s *Server) addConnection(conn *connection) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -125,4 +230,7 @@ func (s *Server) removeConnection(conn *connection) {
 	defer s.lock.Unlock()
 
 	delete(s.conns, conn)
+	for topic := range conn.topics {
+		s.unsubscribeLocked(conn, topic)
+	}
 }
@@ -0,0 +1,181 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/api"
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/utils/logging"
+)
+
+func newTestConnection(s *Server, pendingMessages int) *connection {
+	return &connection{
+		s:       s,
+		send:    make(chan interface{}, pendingMessages),
+		dropped: make(chan struct{}),
+		fp:      NewFilterParam(),
+		topics:  make(map[string]struct{}),
+		active:  1,
+	}
+}
+
+func TestPublishTopic(t *testing.T) {
+	require := require.New(t)
+
+	s := New(logging.NoLog{})
+	httpServer := httptest.NewServer(s)
+	defer httpServer.Close()
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(err)
+	defer conn.Close()
+
+	require.NoError(conn.WriteJSON(&Command{Subscribe: &Subscribe{Topic: "a"}}))
+	require.NoError(conn.WriteJSON(&Command{Subscribe: &Subscribe{Topic: "b"}}))
+
+	// Wait for the subscriptions to be registered.
+	require.Eventually(func() bool {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+		return len(s.topics) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.True(s.HasSubscribers("a"))
+	require.False(s.HasSubscribers("c"))
+	require.True(s.HasSubscribersWithPrefix(""))
+	require.False(s.HasSubscribersWithPrefix("c"))
+	s.PublishTopic("c", "not subscribed")
+	s.PublishTopic("a", "event a")
+	s.PublishTopic("b", "event b")
+
+	var msg TopicMessage
+	require.NoError(conn.ReadJSON(&msg))
+	require.Equal(TopicMessage{Topic: "a", Event: "event a"}, msg)
+	require.NoError(conn.ReadJSON(&msg))
+	require.Equal(TopicMessage{Topic: "b", Event: "event b"}, msg)
+
+	require.NoError(conn.WriteJSON(&Command{Unsubscribe: &Unsubscribe{Topic: "a"}}))
+	require.Eventually(func() bool {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+		return len(s.topics) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	s.PublishTopic("a", "event a")
+	s.PublishTopic("b", "event b")
+	require.NoError(conn.ReadJSON(&msg))
+	require.Equal(TopicMessage{Topic: "b", Event: "event b"}, msg)
+
+	// Closing the connection removes its subscriptions.
+	require.NoError(conn.Close())
+	require.Eventually(func() bool {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+		return len(s.topics) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestPublish(t *testing.T) {
+	require := require.New(t)
+
+	s := New(logging.NoLog{})
+	addr1 := ids.ShortID{1}
+	addr2 := ids.ShortID{2}
+	conn1 := newTestConnection(s, 1)
+	require.NoError(conn1.fp.Add(addr1[:]))
+	conn2 := newTestConnection(s, 1)
+	require.NoError(conn2.fp.Add(addr2[:]))
+	conn3 := newTestConnection(s, 1)
+	require.NoError(conn3.fp.Add(ids.GenerateTestShortID().Bytes()))
+	s.subscribedConnections.Add(conn1)
+	s.subscribedConnections.Add(conn2)
+	s.subscribedConnections.Add(conn3)
+
+	txID := ids.GenerateTestID()
+	s.Publish(&TxFilterer{
+		Formatter: testFormatter{
+			addr1: "X-addr1",
+			addr2: "X-addr2",
+		},
+		TxID: txID,
+		Outputs: []Output{{
+			Addresses: [][]byte{addr1[:], addr2[:]},
+		}},
+	})
+
+	// Each connection receives the addresses of its own filter
+	require.Len(conn1.send, 1)
+	require.Equal([]string{"X-addr1"}, (<-conn1.send).(*api.TxEvent).Addresses)
+	require.Len(conn2.send, 1)
+	require.Equal([]string{"X-addr2"}, (<-conn2.send).(*api.TxEvent).Addresses)
+	require.Empty(conn3.send)
+}
+
+func TestPublishTopicDropsSlowSubscriber(t *testing.T) {
+	require := require.New(t)
+
+	s := New(logging.NoLog{})
+	slow := newTestConnection(s, 1)
+	fast := newTestConnection(s, 2)
+	require.NoError(s.subscribe(slow, "topic"))
+	require.NoError(s.subscribe(fast, "topic"))
+
+	s.PublishTopic("topic", 1)
+	<-fast.send
+	s.PublishTopic("topic", 2)
+
+	// The slow connection couldn't receive the second event, so it was
+	// dropped.
+	require.False(slow.isActive())
+	select {
+	case <-slow.dropped:
+	default:
+		require.FailNow("slow subscriber wasn't dropped")
+	}
+	require.True(fast.isActive())
+	require.Len(fast.send, 1)
+
+	// Dropped connections don't receive any more events.
+	s.PublishTopic("topic", 3)
+	require.Len(slow.send, 1)
+	require.Len(fast.send, 2)
+}
+
+func TestSubscribeTopicLimit(t *testing.T) {
+	require := require.New(t)
+
+	s := New(logging.NoLog{})
+	conn := newTestConnection(s, 1)
+	for i := 0; i < MaxTopics; i++ {
+		require.NoError(s.subscribe(conn, fmt.Sprint(i)))
+	}
+
+	// Subscribing twice to a topic doesn't count against the limit.
+	require.NoError(s.subscribe(conn, "0"))
+	require.ErrorIs(s.subscribe(conn, "new"), ErrTopicLimit)
+
+	s.unsubscribe(conn, "0")
+	require.NoError(s.subscribe(conn, "new"))
+}
+
+func TestSubscribeIsValid(t *testing.T) {
+	require := require.New(t)
+
+	require.True((&Subscribe{Topic: "topic"}).IsValid())
+	require.False((&Subscribe{}).IsValid())
+	require.False((&Subscribe{Topic: strings.Repeat("a", MaxTopicLength+1)}).IsValid())
+	require.False((&Unsubscribe{}).IsValid())
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"encoding/hex"
+
+	"github.com/coinflect/coinflectchain/api"
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/utils/json"
+)
+
+var _ Filterer = (*TxFilterer)(nil)
+
+// AddressFormatter formats the addresses of the chain that publishes the
+// events
+type AddressFormatter interface {
+	FormatLocalAddress(addr ids.ShortID) (string, error)
+}
+
+// Output is an amount of an asset sent to [Addresses]. Outputs that don't hold
+// any funds, such as the owners of staking rewards, have a zero [Amount].
+type Output struct {
+	Addresses [][]byte
+	AssetID   ids.ID
+	Amount    uint64
+}
+
+// TxFilterer sends an accepted transaction to the connections whose filter
+// matches the addresses of its outputs or the node IDs it references. Each
+// connection receives an [api.TxEvent] with the addresses and node IDs of its
+// filter.
+type TxFilterer struct {
+	Formatter AddressFormatter
+	TxID      ids.ID
+	// Height of the block that accepted the transaction, if any
+	Height  *uint64
+	Outputs []Output
+	NodeIDs []ids.NodeID
+}
+
+func (f *TxFilterer) Filter(filters []Filter) []interface{} {
+	msgs := make([]interface{}, len(filters))
+	for i, filter := range filters {
+		if event, ok := f.event(filter); ok {
+			msgs[i] = event
+		}
+	}
+	return msgs
+}
+
+// event returns the event sent to the connection with [filter], or false if
+// the filter doesn't match the transaction.
+func (f *TxFilterer) event(filter Filter) (*api.TxEvent, bool) {
+	var (
+		addresses = []string{}
+		matched   = make(map[string]struct{})
+		assetIDs  []ids.ID
+		amounts   = make(map[ids.ID]uint64)
+		nodeIDs   []ids.NodeID
+	)
+	for _, out := range f.Outputs {
+		isMatch := false
+		for _, addr := range out.Addresses {
+			if !filter.Check(addr) {
+				continue
+			}
+			isMatch = true
+			if _, ok := matched[string(addr)]; ok {
+				continue
+			}
+			matched[string(addr)] = struct{}{}
+			addresses = append(addresses, f.formatAddress(addr))
+		}
+		if !isMatch || out.Amount == 0 {
+			continue
+		}
+		if _, ok := amounts[out.AssetID]; !ok {
+			assetIDs = append(assetIDs, out.AssetID)
+		}
+		// The outputs of an accepted transaction can't overflow
+		amounts[out.AssetID] += out.Amount
+	}
+	for _, nodeID := range f.NodeIDs {
+		if filter.Check(nodeID.Bytes()) {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+	}
+	if len(addresses) == 0 && len(nodeIDs) == 0 {
+		return nil, false
+	}
+
+	event := &api.TxEvent{
+		TxID:      f.TxID,
+		Addresses: addresses,
+		Assets:    make([]api.AssetAmount, len(assetIDs)),
+		NodeIDs:   nodeIDs,
+	}
+	if f.Height != nil {
+		height := json.Uint64(*f.Height)
+		event.Height = &height
+	}
+	for i, assetID := range assetIDs {
+		event.Assets[i] = api.AssetAmount{
+			AssetID: assetID,
+			Amount:  json.Uint64(amounts[assetID]),
+		}
+	}
+	return event, true
+}
+
+// formatAddress returns the address of the chain with the bytes [addr]. If the
+// address can't be formatted, its hex encoding is returned so that the match
+// is still reported.
+func (f *TxFilterer) formatAddress(addr []byte) string {
+	if shortID, err := ids.ToShortID(addr); err == nil {
+		if addrStr, err := f.Formatter.FormatLocalAddress(shortID); err == nil {
+			return addrStr
+		}
+	}
+	return "0x" + hex.EncodeToString(addr)
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/api"
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/utils/json"
+)
+
+var errUnknownAddress = errors.New("unknown address")
+
+type testFilter [][]byte
+
+func (f testFilter) Check(addr []byte) bool {
+	for _, a := range f {
+		if bytes.Equal(a, addr) {
+			return true
+		}
+	}
+	return false
+}
+
+type testFormatter map[ids.ShortID]string
+
+func (f testFormatter) FormatLocalAddress(addr ids.ShortID) (string, error) {
+	addrStr, ok := f[addr]
+	if !ok {
+		return "", errUnknownAddress
+	}
+	return addrStr, nil
+}
+
+func TestTxFilterer(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		txID     = ids.ID{1}
+		assetID1 = ids.ID{2}
+		assetID2 = ids.ID{3}
+		addr1    = ids.ShortID{4}
+		addr2    = ids.ShortID{5}
+		addr3    = ids.ShortID{6}
+		nodeID   = ids.NodeID{7}
+		height   = uint64(8)
+	)
+	f := &TxFilterer{
+		Formatter: testFormatter{
+			addr1: "P-addr1",
+			addr2: "P-addr2",
+		},
+		TxID:   txID,
+		Height: &height,
+		Outputs: []Output{
+			{
+				Addresses: [][]byte{addr1[:]},
+				AssetID:   assetID1,
+				Amount:    1,
+			},
+			{
+				Addresses: [][]byte{addr1[:], addr2[:]},
+				AssetID:   assetID2,
+				Amount:    2,
+			},
+			{
+				Addresses: [][]byte{addr1[:]},
+				AssetID:   assetID1,
+				Amount:    3,
+			},
+			{
+				// Reward owner
+				Addresses: [][]byte{addr3[:]},
+			},
+		},
+		NodeIDs: []ids.NodeID{nodeID},
+	}
+
+	msgs := f.Filter([]Filter{
+		testFilter{addr1[:]},
+		testFilter{addr2[:], nodeID.Bytes()},
+		testFilter{addr3[:]},
+		testFilter{nodeID.Bytes()},
+		testFilter{ids.GenerateTestShortID().Bytes()},
+	})
+	require.Len(msgs, 5)
+
+	jsonHeight := json.Uint64(height)
+	require.Equal(&api.TxEvent{
+		TxID:      txID,
+		Height:    &jsonHeight,
+		Addresses: []string{"P-addr1"},
+		Assets: []api.AssetAmount{
+			{AssetID: assetID1, Amount: 4},
+			{AssetID: assetID2, Amount: 2},
+		},
+	}, msgs[0])
+	require.Equal(&api.TxEvent{
+		TxID:      txID,
+		Height:    &jsonHeight,
+		Addresses: []string{"P-addr2"},
+		Assets: []api.AssetAmount{
+			{AssetID: assetID2, Amount: 2},
+		},
+		NodeIDs: []ids.NodeID{nodeID},
+	}, msgs[1])
+	// Addresses that can't be formatted are reported in hex
+	require.Equal(&api.TxEvent{
+		TxID:      txID,
+		Height:    &jsonHeight,
+		Addresses: []string{"0x0600000000000000000000000000000000000000"},
+		Assets:    []api.AssetAmount{},
+	}, msgs[2])
+	require.Equal(&api.TxEvent{
+		TxID:      txID,
+		Height:    &jsonHeight,
+		Addresses: []string{},
+		Assets:    []api.AssetAmount{},
+		NodeIDs:   []ids.NodeID{nodeID},
+	}, msgs[3])
+	require.Nil(msgs[4])
+}
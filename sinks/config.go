@@ -0,0 +1,214 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sinks
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/utils/units"
+)
+
+const (
+	// FileType is the type of the sinks that append the accepted containers
+	// to segmented files
+	FileType = "file"
+	// KafkaType is the type of the sinks that produce the accepted containers
+	// to a Kafka topic
+	KafkaType = "kafka"
+	// WebhookType is the type of the sinks that POST the accepted containers
+	// to a URL
+	WebhookType = "webhook"
+
+	// ConsensusEvents are the containers accepted by the consensus engine,
+	// e.g. the vertices of the X-chain
+	ConsensusEvents = "consensus"
+	// DecisionEvents are the decisions accepted by the consensus engine, e.g.
+	// the transactions of the X-chain
+	DecisionEvents = "decisions"
+
+	// DropPolicy drops the oldest queued containers once the queue of a sink
+	// is full, and the containers that have been queued for too long
+	DropPolicy = "drop"
+	// BlockPolicy makes the chains wait for the queue of a sink to have room
+	// before accepting more containers
+	BlockPolicy = "block"
+
+	DefaultTimeout          = 10 * time.Second
+	DefaultRetryInterval    = time.Second
+	DefaultMaxRetryInterval = time.Minute
+	DefaultMaxSegmentSize   = 64 * units.MiB
+)
+
+var (
+	errNoName           = errors.New("sink has no name")
+	errDuplicateName    = errors.New("duplicate sink name")
+	errNoChainIDs       = errors.New("sink has no chain IDs")
+	errUnknownType      = errors.New("unknown sink type")
+	errUnknownEvents    = errors.New("unknown events")
+	errUnknownPolicy    = errors.New("unknown overflow policy")
+	errMissingConfig    = errors.New("missing config of the sink type")
+	errNegativeDuration = errors.New("durations can't be negative")
+	errNoDirectory      = errors.New("file sink has no directory")
+	errNoAddress        = errors.New("kafka sink has no address")
+	errNoTopic          = errors.New("kafka sink has no topic")
+	errInvalidURL       = errors.New("webhook sink URL must be http or https")
+)
+
+type Config struct {
+	Sinks []SinkConfig `json:"sinks"`
+}
+
+// SinkConfig configures a sink that the containers accepted on [ChainIDs] are
+// delivered to.
+type SinkConfig struct {
+	// Name identifies the sink. The delivery cursor of the sink is persisted
+	// under its name, so renaming a sink restarts its delivery from the next
+	// accepted container.
+	Name string `json:"name"`
+	// Type is one of [FileType], [KafkaType] or [WebhookType]
+	Type string `json:"type"`
+	// ChainIDs are the chains whose accepted containers are delivered
+	ChainIDs []ids.ID `json:"chainIDs"`
+	// Events is either [ConsensusEvents] or [DecisionEvents]. Defaults to
+	// [DecisionEvents].
+	Events string `json:"events"`
+	// Timeout of a delivery attempt. Defaults to [DefaultTimeout].
+	Timeout time.Duration `json:"timeout"`
+	// RetryInterval is the time waited before retrying a failed delivery. It
+	// doubles after every consecutive failure, up to [MaxRetryInterval].
+	// Defaults to [DefaultRetryInterval].
+	RetryInterval time.Duration `json:"retryInterval"`
+	// MaxRetryInterval defaults to [DefaultMaxRetryInterval].
+	MaxRetryInterval time.Duration `json:"maxRetryInterval"`
+	// MaxPending is the number of containers that can be queued for delivery
+	// before [OverflowPolicy] applies. 0 means the queue is unbounded.
+	MaxPending uint64 `json:"maxPending"`
+	// MaxPendingAge is the time a container can be queued for before
+	// [OverflowPolicy] applies. With [BlockPolicy], the containers aren't
+	// dropped but the sink is reported as unhealthy. 0 means containers can be
+	// queued for any time.
+	MaxPendingAge time.Duration `json:"maxPendingAge"`
+	// OverflowPolicy is either [DropPolicy] or [BlockPolicy]. Defaults to
+	// [DropPolicy].
+	OverflowPolicy string `json:"overflowPolicy"`
+
+	File    *FileConfig    `json:"file,omitempty"`
+	Kafka   *KafkaConfig   `json:"kafka,omitempty"`
+	Webhook *WebhookConfig `json:"webhook,omitempty"`
+}
+
+type FileConfig struct {
+	// Directory that the segments are written to
+	Directory string `json:"directory"`
+	// MaxSegmentSize is the size, in bytes, after which a new segment is
+	// started. Defaults to [DefaultMaxSegmentSize].
+	MaxSegmentSize uint64 `json:"maxSegmentSize"`
+}
+
+type KafkaConfig struct {
+	// Address of the broker that leads [Partition] of [Topic], e.g.
+	// "127.0.0.1:9092"
+	Address   string `json:"address"`
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+	ClientID  string `json:"clientID"`
+}
+
+type WebhookConfig struct {
+	URL string `json:"url"`
+	// Headers are added to every request, e.g. to authenticate the node
+	Headers map[string]string `json:"headers"`
+}
+
+func (c *Config) Verify() error {
+	names := make(map[string]struct{}, len(c.Sinks))
+	for i := range c.Sinks {
+		sink := &c.Sinks[i]
+		if _, ok := names[sink.Name]; ok {
+			return fmt.Errorf("%w: %q", errDuplicateName, sink.Name)
+		}
+		names[sink.Name] = struct{}{}
+
+		if err := sink.Verify(); err != nil {
+			return fmt.Errorf("invalid sink %q: %w", sink.Name, err)
+		}
+	}
+	return nil
+}
+
+func (c *SinkConfig) Verify() error {
+	switch {
+	case c.Name == "":
+		return errNoName
+	case len(c.ChainIDs) == 0:
+		return errNoChainIDs
+	case c.Events != "" && c.Events != ConsensusEvents && c.Events != DecisionEvents:
+		return fmt.Errorf("%w: %q", errUnknownEvents, c.Events)
+	case c.OverflowPolicy != "" && c.OverflowPolicy != DropPolicy && c.OverflowPolicy != BlockPolicy:
+		return fmt.Errorf("%w: %q", errUnknownPolicy, c.OverflowPolicy)
+	case c.Timeout < 0 || c.RetryInterval < 0 || c.MaxRetryInterval < 0 || c.MaxPendingAge < 0:
+		return errNegativeDuration
+	}
+
+	switch c.Type {
+	case FileType:
+		if c.File == nil {
+			return errMissingConfig
+		}
+		if c.File.Directory == "" {
+			return errNoDirectory
+		}
+	case KafkaType:
+		if c.Kafka == nil {
+			return errMissingConfig
+		}
+		if c.Kafka.Address == "" {
+			return errNoAddress
+		}
+		if c.Kafka.Topic == "" {
+			return errNoTopic
+		}
+	case WebhookType:
+		if c.Webhook == nil {
+			return errMissingConfig
+		}
+		u, err := url.Parse(c.Webhook.URL)
+		if err != nil {
+			return err
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errInvalidURL
+		}
+	default:
+		return fmt.Errorf("%w: %q", errUnknownType, c.Type)
+	}
+	return nil
+}
+
+// setDefaults fills in the unset optional fields of [c].
+func (c *SinkConfig) setDefaults() {
+	if c.Events == "" {
+		c.Events = DecisionEvents
+	}
+	if c.Timeout == 0 {
+		c.Timeout = DefaultTimeout
+	}
+	if c.RetryInterval == 0 {
+		c.RetryInterval = DefaultRetryInterval
+	}
+	if c.MaxRetryInterval == 0 {
+		c.MaxRetryInterval = DefaultMaxRetryInterval
+	}
+	if c.OverflowPolicy == "" {
+		c.OverflowPolicy = DropPolicy
+	}
+	if c.File != nil && c.File.MaxSegmentSize == 0 {
+		c.File.MaxSegmentSize = DefaultMaxSegmentSize
+	}
+}
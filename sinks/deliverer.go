@@ -0,0 +1,394 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sinks
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/snow"
+	"github.com/coinflect/coinflectchain/utils/logging"
+	"github.com/coinflect/coinflectchain/utils/timer/mockable"
+	"github.com/coinflect/coinflectchain/utils/wrappers"
+)
+
+var (
+	cursorKey   = []byte("cursor")
+	nextKey     = []byte("next")
+	queuePrefix = []byte("queue")
+
+	errQueueFull  = errors.New("queue is full")
+	errQueueStale = errors.New("queued containers are stale")
+
+	_ snow.Acceptor = (*deliverer)(nil)
+)
+
+// deliverer queues the containers accepted on the chains it's registered on
+// and delivers them to its sink in the order they were accepted in.
+//
+// If the queue holds [maxPending] records, or its oldest record was queued
+// more than [maxPendingAge] ago, [overflowPolicy] applies: with [DropPolicy],
+// the oldest records are dropped; with [BlockPolicy], Accept waits for the
+// queue to have room.
+//
+// The database structure is:
+// "cursor" => 3	Index of the next record to deliver
+// "next"   => 5	Index of the next record to queue
+// "queue" + 3 => record3
+// "queue" + 4 => record4
+type deliverer struct {
+	name    string
+	log     logging.Logger
+	sink    Sink
+	metrics *sinkMetrics
+	clock   mockable.Clock
+
+	timeout          time.Duration
+	retryInterval    time.Duration
+	maxRetryInterval time.Duration
+	maxPending       uint64
+	maxPendingAge    time.Duration
+	overflowPolicy   string
+
+	// lock protects [next], [cursor], [unblocked] and the database
+	lock   sync.Mutex
+	db     database.Database
+	next   uint64
+	cursor uint64
+
+	// room is signalled when records are removed from the queue
+	room *sync.Cond
+	// unblocked is true once Accept must no longer wait for room
+	unblocked bool
+
+	// wake is signalled when a record is queued
+	wake chan struct{}
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	closeCh chan struct{}
+	doneCh  chan struct{}
+}
+
+func newDeliverer(
+	config *SinkConfig,
+	log logging.Logger,
+	sink Sink,
+	metrics *sinkMetrics,
+	db database.Database,
+) (*deliverer, error) {
+	d := &deliverer{
+		name:             config.Name,
+		log:              log,
+		sink:             sink,
+		metrics:          metrics,
+		timeout:          config.Timeout,
+		retryInterval:    config.RetryInterval,
+		maxRetryInterval: config.MaxRetryInterval,
+		maxPending:       config.MaxPending,
+		maxPendingAge:    config.MaxPendingAge,
+		overflowPolicy:   config.OverflowPolicy,
+		db:               db,
+		wake:             make(chan struct{}, 1),
+		closeCh:          make(chan struct{}),
+		doneCh:           make(chan struct{}),
+	}
+	d.room = sync.NewCond(&d.lock)
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+
+	var err error
+	d.cursor, err = database.GetUInt64(db, cursorKey)
+	if err != nil && err != database.ErrNotFound {
+		return nil, fmt.Errorf("couldn't get the delivery cursor: %w", err)
+	}
+	d.next, err = database.GetUInt64(db, nextKey)
+	if err != nil && err != database.ErrNotFound {
+		return nil, fmt.Errorf("couldn't get the queue's next index: %w", err)
+	}
+	d.metrics.pending.Set(float64(d.next - d.cursor))
+	return d, nil
+}
+
+// start delivers the queued records until the deliverer is closed.
+func (d *deliverer) start() {
+	if d.cursor < d.next {
+		d.log.Info("resuming delivery of queued containers",
+			zap.String("sink", d.name),
+			zap.Uint64("cursor", d.cursor),
+			zap.Uint64("pending", d.next-d.cursor),
+		)
+	}
+	go d.log.RecoverAndPanic(d.run)
+}
+
+// Accept queues [container] for delivery. It's persisted before returning, so
+// that it's delivered even if the node restarts before its delivery.
+func (d *deliverer) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container []byte) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for d.full() {
+		if d.overflowPolicy == BlockPolicy {
+			if d.unblocked {
+				break
+			}
+			d.room.Wait()
+			continue
+		}
+		if err := d.remove(d.cursor, false); err != nil {
+			return fmt.Errorf("couldn't drop the oldest container queued for sink %s: %w", d.name, err)
+		}
+	}
+
+	record := &Record{
+		Index:       d.next,
+		ChainID:     ctx.ChainID,
+		ContainerID: containerID,
+		Timestamp:   d.clock.Time(),
+		Container:   container,
+	}
+	batch := d.db.NewBatch()
+	if err := database.PutUInt64(batch, nextKey, d.next+1); err != nil {
+		return err
+	}
+	if err := batch.Put(queueKey(d.next), record.Bytes()); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return fmt.Errorf("couldn't queue container %s for sink %s: %w", containerID, d.name, err)
+	}
+	d.next++
+	d.metrics.queued.Inc()
+	d.metrics.pending.Inc()
+
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (d *deliverer) run() {
+	defer close(d.doneCh)
+
+	for {
+		record, err := d.peek()
+		if err != nil {
+			d.log.Error("couldn't read the next queued container",
+				zap.String("sink", d.name),
+				zap.Error(err),
+			)
+			return
+		}
+		if record == nil {
+			select {
+			case <-d.wake:
+				continue
+			case <-d.closeCh:
+				return
+			}
+		}
+
+		delivered, ok := d.deliver(record)
+		if !ok {
+			return
+		}
+		if err := d.ack(record.Index, delivered); err != nil {
+			d.log.Error("couldn't persist the delivery cursor",
+				zap.String("sink", d.name),
+				zap.Error(err),
+			)
+			return
+		}
+	}
+}
+
+// peek returns the next record to deliver, or nil if all the queued records
+// were delivered.
+func (d *deliverer) peek() (*Record, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.oldest()
+}
+
+// oldest returns the oldest queued record, or nil if the queue is empty.
+// Assumes [d.lock] is held.
+func (d *deliverer) oldest() (*Record, error) {
+	if d.cursor >= d.next {
+		return nil, nil
+	}
+	recordBytes, err := d.db.Get(queueKey(d.cursor))
+	if err != nil {
+		return nil, err
+	}
+	return ParseRecord(recordBytes)
+}
+
+// full returns true if the queue has no room for another record.
+// Assumes [d.lock] is held.
+func (d *deliverer) full() bool {
+	return d.maxPending > 0 && d.next-d.cursor >= d.maxPending
+}
+
+// stale returns true if [record] has been queued for too long to be delivered.
+func (d *deliverer) stale(record *Record) bool {
+	return d.overflowPolicy == DropPolicy &&
+		d.maxPendingAge > 0 &&
+		d.clock.Time().Sub(record.Timestamp) > d.maxPendingAge
+}
+
+// deliver attempts to deliver [record] until it succeeds or, if stale records
+// are dropped, [record] becomes stale. Returns whether [record] was delivered,
+// and false if the deliverer was closed first.
+func (d *deliverer) deliver(record *Record) (bool, bool) {
+	retryInterval := d.retryInterval
+	for {
+		if d.stale(record) {
+			return false, true
+		}
+
+		start := d.clock.Time()
+		ctx, cancel := context.WithTimeout(d.ctx, d.timeout)
+		err := d.sink.Deliver(ctx, record)
+		cancel()
+		d.metrics.deliveryNs.Add(float64(d.clock.Time().Sub(start)))
+		if err == nil {
+			d.metrics.delivered.Inc()
+			return true, true
+		}
+
+		d.metrics.failures.Inc()
+		d.log.Warn("failed to deliver container",
+			zap.String("sink", d.name),
+			zap.Stringer("chainID", record.ChainID),
+			zap.Stringer("containerID", record.ContainerID),
+			zap.Uint64("index", record.Index),
+			zap.Duration("retryIn", retryInterval),
+			zap.Error(err),
+		)
+
+		timer := time.NewTimer(retryInterval)
+		select {
+		case <-timer.C:
+		case <-d.closeCh:
+			timer.Stop()
+			return false, false
+		}
+
+		retryInterval *= 2
+		if retryInterval > d.maxRetryInterval {
+			retryInterval = d.maxRetryInterval
+		}
+	}
+}
+
+// ack removes the record at [index], that was either [delivered] or is
+// dropped, from the queue.
+func (d *deliverer) ack(index uint64, delivered bool) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.remove(index, delivered)
+}
+
+// remove removes the record at [index], that was either [delivered] or is
+// dropped, from the queue and advances the cursor past it. Does nothing if
+// the record was already dropped.
+// Assumes [d.lock] is held.
+func (d *deliverer) remove(index uint64, delivered bool) error {
+	if index < d.cursor {
+		return nil
+	}
+
+	batch := d.db.NewBatch()
+	if err := database.PutUInt64(batch, cursorKey, index+1); err != nil {
+		return err
+	}
+	if err := batch.Delete(queueKey(index)); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	d.cursor = index + 1
+	d.metrics.pending.Dec()
+	d.room.Broadcast()
+	if !delivered {
+		d.metrics.dropped.Inc()
+		d.log.Warn("dropped queued container",
+			zap.String("sink", d.name),
+			zap.Uint64("index", index),
+		)
+	}
+	return nil
+}
+
+// healthCheck returns the state of the queue, and an error if it's full or
+// its oldest record has been queued for too long.
+func (d *deliverer) healthCheck() (interface{}, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	pending := d.next - d.cursor
+	details := map[string]interface{}{
+		"pending": pending,
+	}
+	if d.full() {
+		return details, fmt.Errorf("%w: %d containers pending", errQueueFull, pending)
+	}
+	if d.maxPendingAge == 0 {
+		return details, nil
+	}
+
+	record, err := d.oldest()
+	if err != nil || record == nil {
+		return details, err
+	}
+	age := d.clock.Time().Sub(record.Timestamp)
+	details["oldestPendingAge"] = age.String()
+	if age > d.maxPendingAge {
+		return details, fmt.Errorf("%w: oldest container pending for %s", errQueueStale, age)
+	}
+	return details, nil
+}
+
+// unblock stops Accept from waiting for the queue to have room, so that the
+// chains can't be blocked on shutdown. The records accepted from now on are
+// queued even if the queue is full.
+func (d *deliverer) unblock() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.unblocked = true
+	d.room.Broadcast()
+}
+
+// close stops the delivery and closes the sink. The records that weren't
+// delivered yet remain queued.
+func (d *deliverer) close() error {
+	d.unblock()
+	close(d.closeCh)
+	d.cancel()
+	<-d.doneCh
+	return d.sink.Close()
+}
+
+// queueKey returns the key that the record at [index] is queued at. The
+// records are ordered by their index.
+func queueKey(index uint64) []byte {
+	key := make([]byte, len(queuePrefix)+wrappers.LongLen)
+	copy(key, queuePrefix)
+	binary.BigEndian.PutUint64(key[len(queuePrefix):], index)
+	return key
+}
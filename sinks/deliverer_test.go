@@ -0,0 +1,279 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sinks
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/memdb"
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/snow"
+	"github.com/coinflect/coinflectchain/utils/logging"
+)
+
+var errTest = errors.New("non-nil error")
+
+// testSink records the records delivered to it. The first [failures]
+// deliveries fail.
+type testSink struct {
+	lock      sync.Mutex
+	failures  int
+	delivered chan *Record
+	closed    bool
+}
+
+func newTestSink(failures int) *testSink {
+	return &testSink{
+		failures:  failures,
+		delivered: make(chan *Record, 16),
+	}
+}
+
+func (s *testSink) Deliver(_ context.Context, record *Record) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.failures > 0 {
+		s.failures--
+		return errTest
+	}
+	s.delivered <- record
+	return nil
+}
+
+func (s *testSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.closed = true
+	return nil
+}
+
+func newTestDeliverer(t *testing.T, sink Sink, db database.Database) (*deliverer, *sinkMetrics) {
+	return newTestDelivererWithConfig(t, &SinkConfig{}, sink, db)
+}
+
+func newTestDelivererWithConfig(t *testing.T, config *SinkConfig, sink Sink, db database.Database) (*deliverer, *sinkMetrics) {
+	m, err := newMetrics("", prometheus.NewRegistry())
+	require.NoError(t, err)
+	metrics := m.forSink("test")
+
+	config.Name = "test"
+	config.RetryInterval = time.Millisecond
+	config.setDefaults()
+	d, err := newDeliverer(config, logging.NoLog{}, sink, metrics, db)
+	require.NoError(t, err)
+	return d, metrics
+}
+
+func TestDelivererDeliversInOrder(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	sink := newTestSink(0)
+	d, metrics := newTestDeliverer(t, sink, db)
+	d.start()
+
+	ctx := snow.DefaultConsensusContextTest()
+	containerIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()}
+	for i, containerID := range containerIDs {
+		require.NoError(d.Accept(ctx, containerID, []byte{byte(i)}))
+	}
+
+	for i, containerID := range containerIDs {
+		record := <-sink.delivered
+		require.Equal(uint64(i), record.Index)
+		require.Equal(ctx.ChainID, record.ChainID)
+		require.Equal(containerID, record.ContainerID)
+		require.Equal([]byte{byte(i)}, record.Container)
+	}
+	require.NoError(d.close())
+	require.True(sink.closed)
+
+	// The delivered records are removed from the queue.
+	cursor, err := database.GetUInt64(db, cursorKey)
+	require.NoError(err)
+	require.Equal(uint64(len(containerIDs)), cursor)
+	for i := range containerIDs {
+		has, err := db.Has(queueKey(uint64(i)))
+		require.NoError(err)
+		require.False(has)
+	}
+
+	require.Equal(float64(len(containerIDs)), testutil.ToFloat64(metrics.queued))
+	require.Equal(float64(len(containerIDs)), testutil.ToFloat64(metrics.delivered))
+	require.Zero(testutil.ToFloat64(metrics.pending))
+}
+
+func TestDelivererRetries(t *testing.T) {
+	require := require.New(t)
+
+	sink := newTestSink(2)
+	d, metrics := newTestDeliverer(t, sink, memdb.New())
+	d.start()
+
+	containerID := ids.GenerateTestID()
+	require.NoError(d.Accept(snow.DefaultConsensusContextTest(), containerID, nil))
+
+	record := <-sink.delivered
+	require.Equal(containerID, record.ContainerID)
+	require.NoError(d.close())
+
+	require.Equal(float64(2), testutil.ToFloat64(metrics.failures))
+	require.Equal(float64(1), testutil.ToFloat64(metrics.delivered))
+}
+
+func TestDelivererResumesAfterRestart(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	ctx := snow.DefaultConsensusContextTest()
+
+	// The sink is down, so the containers stay queued.
+	d, _ := newTestDeliverer(t, newTestSink(1000), db)
+	d.start()
+	containerIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID()}
+	for _, containerID := range containerIDs {
+		require.NoError(d.Accept(ctx, containerID, nil))
+	}
+	require.NoError(d.close())
+
+	sink := newTestSink(0)
+	d, metrics := newTestDeliverer(t, sink, db)
+	require.Equal(float64(len(containerIDs)), testutil.ToFloat64(metrics.pending))
+	d.start()
+
+	newContainerID := ids.GenerateTestID()
+	require.NoError(d.Accept(ctx, newContainerID, nil))
+
+	for i, containerID := range append(containerIDs, newContainerID) {
+		record := <-sink.delivered
+		require.Equal(uint64(i), record.Index)
+		require.Equal(containerID, record.ContainerID)
+	}
+	require.NoError(d.close())
+}
+
+func TestDelivererDropsOldestWhenFull(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	sink := newTestSink(0)
+	d, metrics := newTestDelivererWithConfig(t, &SinkConfig{MaxPending: 2}, sink, db)
+
+	ctx := snow.DefaultConsensusContextTest()
+	containerIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()}
+	for _, containerID := range containerIDs {
+		require.NoError(d.Accept(ctx, containerID, nil))
+	}
+	_, err := d.healthCheck()
+	require.ErrorIs(err, errQueueFull)
+
+	// The first container was dropped to make room for the third one.
+	d.start()
+	for i, containerID := range containerIDs[1:] {
+		record := <-sink.delivered
+		require.Equal(uint64(i+1), record.Index)
+		require.Equal(containerID, record.ContainerID)
+	}
+	require.NoError(d.close())
+
+	has, err := db.Has(queueKey(0))
+	require.NoError(err)
+	require.False(has)
+	require.Equal(float64(1), testutil.ToFloat64(metrics.dropped))
+	require.Zero(testutil.ToFloat64(metrics.pending))
+}
+
+func TestDelivererDropsStale(t *testing.T) {
+	require := require.New(t)
+
+	sink := newTestSink(0)
+	d, metrics := newTestDelivererWithConfig(t, &SinkConfig{MaxPendingAge: time.Minute}, sink, memdb.New())
+
+	now := time.Now()
+	d.clock.Set(now)
+	ctx := snow.DefaultConsensusContextTest()
+	require.NoError(d.Accept(ctx, ids.GenerateTestID(), nil))
+
+	d.clock.Set(now.Add(2 * time.Minute))
+	_, err := d.healthCheck()
+	require.ErrorIs(err, errQueueStale)
+
+	containerID := ids.GenerateTestID()
+	require.NoError(d.Accept(ctx, containerID, nil))
+
+	// The stale container is dropped rather than delivered.
+	d.start()
+	record := <-sink.delivered
+	require.Equal(containerID, record.ContainerID)
+	require.NoError(d.close())
+
+	require.Equal(float64(1), testutil.ToFloat64(metrics.dropped))
+	_, err = d.healthCheck()
+	require.NoError(err)
+}
+
+func TestDelivererBlocksWhenFull(t *testing.T) {
+	require := require.New(t)
+
+	sink := newTestSink(0)
+	d, metrics := newTestDelivererWithConfig(t, &SinkConfig{
+		MaxPending:     1,
+		OverflowPolicy: BlockPolicy,
+	}, sink, memdb.New())
+
+	ctx := snow.DefaultConsensusContextTest()
+	require.NoError(d.Accept(ctx, ids.GenerateTestID(), nil))
+
+	// The second container waits for the first one to be delivered.
+	accepted := make(chan error, 1)
+	go func() {
+		accepted <- d.Accept(ctx, ids.GenerateTestID(), nil)
+	}()
+	select {
+	case <-accepted:
+		require.FailNow("accepted a container while the queue was full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	d.start()
+	require.NoError(<-accepted)
+	<-sink.delivered
+	<-sink.delivered
+	require.NoError(d.close())
+	require.Zero(testutil.ToFloat64(metrics.dropped))
+}
+
+func TestDelivererUnblocksOnClose(t *testing.T) {
+	require := require.New(t)
+
+	// The sink is down, so the queue stays full.
+	d, _ := newTestDelivererWithConfig(t, &SinkConfig{
+		MaxPending:     1,
+		OverflowPolicy: BlockPolicy,
+	}, newTestSink(1000), memdb.New())
+	d.start()
+
+	ctx := snow.DefaultConsensusContextTest()
+	require.NoError(d.Accept(ctx, ids.GenerateTestID(), nil))
+
+	accepted := make(chan error, 1)
+	go func() {
+		accepted <- d.Accept(ctx, ids.GenerateTestID(), nil)
+	}()
+	require.NoError(d.close())
+	require.NoError(<-accepted)
+}
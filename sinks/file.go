@@ -0,0 +1,223 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sinks
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/coinflect/coinflectchain/utils/perms"
+	"github.com/coinflect/coinflectchain/utils/wrappers"
+)
+
+const (
+	// SegmentExtension is the extension of the segment files
+	SegmentExtension = ".seg"
+
+	frameHeaderLen = 2 * wrappers.IntLen
+)
+
+var (
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+	errCorruptFrame = errors.New("corrupt frame")
+
+	_ Sink = (*fileSink)(nil)
+)
+
+// fileSink appends the records to segment files in a directory. A segment is
+// named after the index of its first record, zero-padded so that the segments
+// sort in the order they were written in. Once a segment exceeds the maximum
+// segment size, the next record starts a new segment.
+//
+// Every record is written in a frame:
+//
+//	length of the record (4 bytes) | CRC-32C of the record (4 bytes) | record
+//
+// See Record.Bytes for the format of the record. Frames are synced to disk
+// before they're acknowledged.
+type fileSink struct {
+	directory      string
+	maxSegmentSize uint64
+
+	segment     *os.File
+	segmentSize uint64
+}
+
+// newFileSink opens the last segment in the configured directory. If it ends
+// with a partially written or corrupt frame, the segment is truncated before
+// the frame.
+func newFileSink(config *FileConfig) (*fileSink, error) {
+	if err := os.MkdirAll(config.Directory, perms.ReadWriteExecute); err != nil {
+		return nil, fmt.Errorf("couldn't create directory %s: %w", config.Directory, err)
+	}
+	s := &fileSink{
+		directory:      config.Directory,
+		maxSegmentSize: config.MaxSegmentSize,
+	}
+
+	segments, err := Segments(config.Directory)
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) == 0 {
+		return s, nil
+	}
+
+	lastSegment := segments[len(segments)-1]
+	_, validSize, err := readSegment(lastSegment)
+	if err != nil && !errors.Is(err, errCorruptFrame) {
+		return nil, err
+	}
+	s.segment, err = os.OpenFile(lastSegment, os.O_WRONLY, perms.ReadWrite)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.segment.Truncate(int64(validSize)); err != nil {
+		_ = s.segment.Close()
+		return nil, err
+	}
+	if _, err := s.segment.Seek(int64(validSize), io.SeekStart); err != nil {
+		_ = s.segment.Close()
+		return nil, err
+	}
+	s.segmentSize = validSize
+	return s, nil
+}
+
+func (s *fileSink) Deliver(_ context.Context, record *Record) error {
+	if s.segment == nil || s.segmentSize >= s.maxSegmentSize {
+		if err := s.startSegment(record.Index); err != nil {
+			return err
+		}
+	}
+
+	recordBytes := record.Bytes()
+	frame := make([]byte, frameHeaderLen+len(recordBytes))
+	binary.BigEndian.PutUint32(frame, uint32(len(recordBytes)))
+	binary.BigEndian.PutUint32(frame[wrappers.IntLen:], crc32.Checksum(recordBytes, crcTable))
+	copy(frame[frameHeaderLen:], recordBytes)
+
+	if _, err := s.segment.Write(frame); err != nil {
+		return s.rollback(err)
+	}
+	if err := s.segment.Sync(); err != nil {
+		return s.rollback(err)
+	}
+	s.segmentSize += uint64(len(frame))
+	return nil
+}
+
+// rollback truncates a partially written frame, so that the retried delivery
+// is written in its place, and returns [err].
+func (s *fileSink) rollback(err error) error {
+	if truncateErr := s.segment.Truncate(int64(s.segmentSize)); truncateErr != nil {
+		return fmt.Errorf("%w and couldn't truncate the segment: %v", err, truncateErr)
+	}
+	if _, seekErr := s.segment.Seek(int64(s.segmentSize), io.SeekStart); seekErr != nil {
+		return fmt.Errorf("%w and couldn't seek the segment: %v", err, seekErr)
+	}
+	return err
+}
+
+func (s *fileSink) Close() error {
+	if s.segment == nil {
+		return nil
+	}
+	return s.segment.Close()
+}
+
+// startSegment closes the current segment and starts a new segment whose first
+// record is at [index].
+func (s *fileSink) startSegment(index uint64) error {
+	if s.segment != nil {
+		if err := s.segment.Close(); err != nil {
+			return err
+		}
+		s.segment = nil
+	}
+
+	// If the first record was redelivered, the segment it started is reused.
+	name := filepath.Join(s.directory, fmt.Sprintf("%020d%s", index, SegmentExtension))
+	segment, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perms.ReadWrite)
+	if err != nil {
+		return err
+	}
+	s.segment = segment
+	s.segmentSize = 0
+	return nil
+}
+
+// Segments returns the paths of the segments in [directory], in the order they
+// were written in.
+func Segments(directory string) ([]string, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+	var segments []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), SegmentExtension) {
+			continue
+		}
+		segments = append(segments, filepath.Join(directory, entry.Name()))
+	}
+	sort.Strings(segments)
+	return segments, nil
+}
+
+// ReadSegment returns the records in the segment at [path]. A partially written
+// frame at the end of the segment is ignored.
+func ReadSegment(path string) ([]*Record, error) {
+	records, _, err := readSegment(path)
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// readSegment returns the records in the segment at [path] and the size of the
+// frames they're in. If a frame is corrupt, the records before it are returned
+// with the error.
+func readSegment(path string) ([]*Record, uint64, error) {
+	segmentBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		records []*Record
+		offset  uint64
+	)
+	for uint64(len(segmentBytes))-offset >= frameHeaderLen {
+		frame := segmentBytes[offset:]
+		recordLen := uint64(binary.BigEndian.Uint32(frame))
+		checksum := binary.BigEndian.Uint32(frame[wrappers.IntLen:])
+		if uint64(len(frame))-frameHeaderLen < recordLen {
+			// The frame was partially written.
+			break
+		}
+		recordBytes := frame[frameHeaderLen : frameHeaderLen+recordLen]
+		if crc32.Checksum(recordBytes, crcTable) != checksum {
+			return records, offset, fmt.Errorf("%w at offset %d of %s", errCorruptFrame, offset, path)
+		}
+
+		record, err := ParseRecord(recordBytes)
+		if err != nil {
+			return records, offset, fmt.Errorf("%w at offset %d of %s: %v", errCorruptFrame, offset, path, err)
+		}
+		records = append(records, record)
+		offset += frameHeaderLen + recordLen
+	}
+	return records, offset, nil
+}
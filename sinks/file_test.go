@@ -0,0 +1,105 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sinks
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/utils/perms"
+)
+
+func newTestRecord(index uint64) *Record {
+	return &Record{
+		Index:       index,
+		ChainID:     ids.GenerateTestID(),
+		ContainerID: ids.GenerateTestID(),
+		Timestamp:   time.Unix(0, int64(index)),
+		Container:   []byte{byte(index), 1, 2, 3},
+	}
+}
+
+func TestRecordBytes(t *testing.T) {
+	require := require.New(t)
+
+	record := newTestRecord(5)
+	parsedRecord, err := ParseRecord(record.Bytes())
+	require.NoError(err)
+	require.Equal(record, parsedRecord)
+
+	_, err = ParseRecord(append(record.Bytes(), 0))
+	require.Error(err)
+}
+
+func TestFileSinkSegments(t *testing.T) {
+	require := require.New(t)
+
+	config := &FileConfig{
+		Directory: t.TempDir(),
+		// Every segment holds 2 records.
+		MaxSegmentSize: 2*(frameHeaderLen+recordHeaderLen+4) - 1,
+	}
+	sink, err := newFileSink(config)
+	require.NoError(err)
+
+	records := make([]*Record, 5)
+	for i := range records {
+		records[i] = newTestRecord(uint64(i))
+		require.NoError(sink.Deliver(context.Background(), records[i]))
+	}
+	require.NoError(sink.Close())
+
+	segments, err := Segments(config.Directory)
+	require.NoError(err)
+	require.Len(segments, 3)
+
+	var readRecords []*Record
+	for _, segment := range segments {
+		segmentRecords, err := ReadSegment(segment)
+		require.NoError(err)
+		readRecords = append(readRecords, segmentRecords...)
+	}
+	require.Equal(records, readRecords)
+}
+
+func TestFileSinkTruncatesPartialFrame(t *testing.T) {
+	require := require.New(t)
+
+	config := &FileConfig{
+		Directory:      t.TempDir(),
+		MaxSegmentSize: DefaultMaxSegmentSize,
+	}
+	sink, err := newFileSink(config)
+	require.NoError(err)
+	first := newTestRecord(0)
+	require.NoError(sink.Deliver(context.Background(), first))
+	require.NoError(sink.Close())
+
+	// Simulate a crash while writing the second record.
+	segments, err := Segments(config.Directory)
+	require.NoError(err)
+	require.Len(segments, 1)
+	f, err := os.OpenFile(segments[0], os.O_WRONLY|os.O_APPEND, perms.ReadWrite)
+	require.NoError(err)
+	_, err = f.Write([]byte{0, 0, 1, 0, 1, 2})
+	require.NoError(err)
+	require.NoError(f.Close())
+
+	// The reopened sink appends after the first record.
+	sink, err = newFileSink(config)
+	require.NoError(err)
+	second := newTestRecord(1)
+	require.NoError(sink.Deliver(context.Background(), second))
+	require.NoError(sink.Close())
+
+	records, err := ReadSegment(segments[0])
+	require.NoError(err)
+	require.Equal([]*Record{first, second}, records)
+}
@@ -0,0 +1,244 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sinks
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"net"
+	"time"
+
+	"github.com/coinflect/coinflectchain/utils/wrappers"
+)
+
+const (
+	kafkaProduceAPIKey     int16 = 0
+	kafkaProduceAPIVersion int16 = 3
+	// kafkaAcksAll, -1, waits for all the in-sync replicas to persist a
+	// record
+	kafkaAcksAll = ^uint16(0)
+	// kafkaNull is the length of a null string
+	kafkaNull = ^uint16(0)
+	// kafkaRecordBatchMagic is the version of the record batch format
+	kafkaRecordBatchMagic byte = 2
+
+	// kafkaMaxResponseSize bounds the size of the responses that are read
+	kafkaMaxResponseSize = 1024 * 1024
+
+	// kafkaChainIDHeader and kafkaIndexHeader are the headers of the produced
+	// records that hold the chain ID and the index of the record
+	kafkaChainIDHeader = "chainID"
+	kafkaIndexHeader   = "index"
+)
+
+var (
+	errUnexpectedResponse = errors.New("unexpected produce response")
+	errProduceFailed      = errors.New("produce failed")
+
+	_ Sink = (*kafkaSink)(nil)
+)
+
+// kafkaSink produces the records to a partition of a Kafka topic with version
+// 3 of the Kafka produce API, which is supported by Kafka 0.11 and later and by
+// Kafka compatible brokers.
+//
+// Every record is produced with:
+// - the container ID as its key
+// - the container as its value
+// - the time the container was accepted at as its timestamp
+// - the chain ID and the big endian index of the record as its headers
+//
+// Records are produced one at a time, and are acknowledged once all the
+// in-sync replicas of the partition have persisted them.
+type kafkaSink struct {
+	config        KafkaConfig
+	dialer        net.Dialer
+	conn          net.Conn
+	reader        *bufio.Reader
+	correlationID int32
+}
+
+func newKafkaSink(config *KafkaConfig) *kafkaSink {
+	return &kafkaSink{
+		config: *config,
+	}
+}
+
+func (s *kafkaSink) Deliver(ctx context.Context, record *Record) error {
+	if err := s.produce(ctx, record); err != nil {
+		// The connection may be in an unknown state, so it's re-established
+		// by the next delivery.
+		_ = s.Close()
+		return err
+	}
+	return nil
+}
+
+func (s *kafkaSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	s.reader = nil
+	return err
+}
+
+func (s *kafkaSink) produce(ctx context.Context, record *Record) error {
+	if s.conn == nil {
+		conn, err := s.dialer.DialContext(ctx, "tcp", s.config.Address)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+		s.reader = bufio.NewReader(conn)
+	}
+
+	timeout := DefaultTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := s.conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+		timeout = time.Until(deadline)
+	}
+
+	s.correlationID++
+	request := s.produceRequest(s.correlationID, timeout, record)
+	if _, err := s.conn.Write(request); err != nil {
+		return err
+	}
+	return s.readProduceResponse(s.correlationID)
+}
+
+// produceRequest returns a size delimited produce request of [record].
+func (s *kafkaSink) produceRequest(correlationID int32, timeout time.Duration, record *Record) []byte {
+	batch := recordBatch(record)
+
+	p := wrappers.Packer{MaxSize: math.MaxInt32}
+	p.PackInt(0) // size, set below
+	p.PackShort(uint16(kafkaProduceAPIKey))
+	p.PackShort(uint16(kafkaProduceAPIVersion))
+	p.PackInt(uint32(correlationID))
+	p.PackStr(s.config.ClientID)
+	p.PackShort(kafkaNull) // transactional ID
+	p.PackShort(kafkaAcksAll)
+	p.PackInt(uint32(timeout.Milliseconds()))
+	p.PackInt(1) // number of topics
+	p.PackStr(s.config.Topic)
+	p.PackInt(1) // number of partitions
+	p.PackInt(uint32(s.config.Partition))
+	p.PackBytes(batch)
+
+	binary.BigEndian.PutUint32(p.Bytes, uint32(len(p.Bytes)-wrappers.IntLen))
+	return p.Bytes
+}
+
+// readProduceResponse reads the response to the produce request with
+// [correlationID] and returns an error if the record wasn't produced.
+func (s *kafkaSink) readProduceResponse(correlationID int32) error {
+	sizeBytes := make([]byte, wrappers.IntLen)
+	if _, err := io.ReadFull(s.reader, sizeBytes); err != nil {
+		return err
+	}
+	size := binary.BigEndian.Uint32(sizeBytes)
+	if size > kafkaMaxResponseSize {
+		return fmt.Errorf("%w: response of %d bytes is too large", errUnexpectedResponse, size)
+	}
+	response := make([]byte, size)
+	if _, err := io.ReadFull(s.reader, response); err != nil {
+		return err
+	}
+
+	p := wrappers.Packer{Bytes: response}
+	responseCorrelationID := int32(p.UnpackInt())
+	numTopics := p.UnpackInt()
+	_ = p.UnpackStr() // topic
+	numPartitions := p.UnpackInt()
+	partition := int32(p.UnpackInt())
+	errorCode := int16(p.UnpackShort())
+	if p.Err != nil {
+		return fmt.Errorf("%w: %v", errUnexpectedResponse, p.Err)
+	}
+
+	switch {
+	case responseCorrelationID != correlationID:
+		return fmt.Errorf("%w: expected correlation ID %d but got %d", errUnexpectedResponse, correlationID, responseCorrelationID)
+	case numTopics != 1 || numPartitions != 1:
+		return fmt.Errorf("%w: expected 1 topic and partition but got %d and %d", errUnexpectedResponse, numTopics, numPartitions)
+	case partition != s.config.Partition:
+		return fmt.Errorf("%w: expected partition %d but got %d", errUnexpectedResponse, s.config.Partition, partition)
+	case errorCode != 0:
+		return fmt.Errorf("%w with error code %d", errProduceFailed, errorCode)
+	default:
+		return nil
+	}
+}
+
+// recordBatch returns a batch of version 2 of the Kafka record batch format
+// that holds [record].
+func recordBatch(record *Record) []byte {
+	timestamp := record.Timestamp.UnixMilli()
+
+	encodedRecord := []byte{0}                     // attributes
+	encodedRecord = appendVarint(encodedRecord, 0) // timestamp delta
+	encodedRecord = appendVarint(encodedRecord, 0) // offset delta
+	encodedRecord = appendVarintBytes(encodedRecord, record.ContainerID[:])
+	encodedRecord = appendVarintBytes(encodedRecord, record.Container)
+	encodedRecord = appendVarint(encodedRecord, 2) // number of headers
+	encodedRecord = appendVarintBytes(encodedRecord, []byte(kafkaChainIDHeader))
+	encodedRecord = appendVarintBytes(encodedRecord, record.ChainID[:])
+	encodedRecord = appendVarintBytes(encodedRecord, []byte(kafkaIndexHeader))
+	encodedRecord = appendVarintBytes(encodedRecord, indexBytes(record.Index))
+
+	records := appendVarint(nil, int64(len(encodedRecord)))
+	records = append(records, encodedRecord...)
+
+	// The part of the batch that is covered by the CRC
+	p := wrappers.Packer{MaxSize: math.MaxInt32}
+	p.PackShort(0)                // attributes
+	p.PackInt(0)                  // last offset delta
+	p.PackLong(uint64(timestamp)) // first timestamp
+	p.PackLong(uint64(timestamp)) // max timestamp
+	p.PackLong(^uint64(0))        // no producer ID
+	p.PackShort(^uint16(0))       // no producer epoch
+	p.PackInt(^uint32(0))         // no base sequence
+	p.PackInt(1)                  // number of records
+	p.PackFixedBytes(records)
+	crc := crc32.Checksum(p.Bytes, crcTable)
+
+	batch := wrappers.Packer{MaxSize: math.MaxInt32}
+	batch.PackLong(0) // base offset
+	batch.PackInt(uint32(wrappers.IntLen + wrappers.ByteLen + wrappers.IntLen + len(p.Bytes)))
+	batch.PackInt(^uint32(0)) // partition leader epoch
+	batch.PackByte(kafkaRecordBatchMagic)
+	batch.PackInt(crc)
+	batch.PackFixedBytes(p.Bytes)
+	return batch.Bytes
+}
+
+// appendVarint appends the zig-zag encoded varint [v] to [dst]
+func appendVarint(dst []byte, v int64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], v)
+	return append(dst, buf[:n]...)
+}
+
+// appendVarintBytes appends [b] to [dst], prefixed by its varint length
+func appendVarintBytes(dst []byte, b []byte) []byte {
+	dst = appendVarint(dst, int64(len(b)))
+	return append(dst, b...)
+}
+
+func indexBytes(index uint64) []byte {
+	b := make([]byte, wrappers.LongLen)
+	binary.BigEndian.PutUint64(b, index)
+	return b
+}
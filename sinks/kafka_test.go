@@ -0,0 +1,193 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sinks
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"math"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/utils/wrappers"
+)
+
+// kafkaRecord is a record that was produced to a fake broker
+type kafkaRecord struct {
+	topic     string
+	partition int32
+	key       []byte
+	value     []byte
+	headers   map[string][]byte
+}
+
+// serveKafka accepts a connection on [listener] and responds to its produce
+// requests with [errorCode]. The produced records are sent on [records].
+func serveKafka(t *testing.T, listener net.Listener, errorCode int16, records chan<- *kafkaRecord) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	for {
+		sizeBytes := make([]byte, wrappers.IntLen)
+		if _, err := io.ReadFull(conn, sizeBytes); err != nil {
+			return
+		}
+		request := make([]byte, binary.BigEndian.Uint32(sizeBytes))
+		if _, err := io.ReadFull(conn, request); err != nil {
+			return
+		}
+
+		p := wrappers.Packer{Bytes: request}
+		require.Equal(t, uint16(kafkaProduceAPIKey), p.UnpackShort())
+		require.Equal(t, uint16(kafkaProduceAPIVersion), p.UnpackShort())
+		correlationID := p.UnpackInt()
+		_ = p.UnpackStr() // client ID
+		require.Equal(t, kafkaNull, p.UnpackShort())
+		require.Equal(t, kafkaAcksAll, p.UnpackShort())
+		_ = p.UnpackInt() // timeout
+		require.Equal(t, uint32(1), p.UnpackInt())
+		topic := p.UnpackStr()
+		require.Equal(t, uint32(1), p.UnpackInt())
+		partition := int32(p.UnpackInt())
+		batch := p.UnpackBytes()
+		require.NoError(t, p.Err)
+		require.Len(t, p.Bytes[p.Offset:], 0)
+
+		record := parseRecordBatch(t, batch)
+		record.topic = topic
+		record.partition = partition
+		records <- record
+
+		response := wrappers.Packer{MaxSize: math.MaxInt32}
+		response.PackInt(0) // size, set below
+		response.PackInt(correlationID)
+		response.PackInt(1)
+		response.PackStr(topic)
+		response.PackInt(1)
+		response.PackInt(uint32(partition))
+		response.PackShort(uint16(errorCode))
+		response.PackLong(0)          // base offset
+		response.PackLong(^uint64(0)) // log append time
+		response.PackInt(0)           // throttle time
+		binary.BigEndian.PutUint32(response.Bytes, uint32(len(response.Bytes)-wrappers.IntLen))
+		if _, err := conn.Write(response.Bytes); err != nil {
+			return
+		}
+	}
+}
+
+// parseRecordBatch verifies [batch] and returns the single record it holds
+func parseRecordBatch(t *testing.T, batch []byte) *kafkaRecord {
+	require := require.New(t)
+
+	p := wrappers.Packer{Bytes: batch}
+	require.Zero(p.UnpackLong()) // base offset
+	require.Equal(len(batch)-wrappers.LongLen-wrappers.IntLen, int(p.UnpackInt()))
+	_ = p.UnpackInt() // partition leader epoch
+	require.Equal(kafkaRecordBatchMagic, p.UnpackByte())
+	crc := p.UnpackInt()
+	require.NoError(p.Err)
+	require.Equal(crc32.Checksum(batch[p.Offset:], crcTable), crc)
+
+	// Skip the attributes, last offset delta, timestamps, producer ID,
+	// producer epoch and base sequence.
+	p.Offset += 2*wrappers.ShortLen + 2*wrappers.IntLen + 3*wrappers.LongLen
+	require.Equal(uint32(1), p.UnpackInt()) // number of records
+	require.NoError(p.Err)
+
+	records := batch[p.Offset:]
+	length, n := binary.Varint(records)
+	require.Positive(n)
+	encodedRecord := records[n:]
+	require.Len(encodedRecord, int(length))
+
+	varint := func() int64 {
+		v, n := binary.Varint(encodedRecord)
+		require.Positive(n)
+		encodedRecord = encodedRecord[n:]
+		return v
+	}
+	varintBytes := func() []byte {
+		length := varint()
+		b := encodedRecord[:length]
+		encodedRecord = encodedRecord[length:]
+		return b
+	}
+
+	encodedRecord = encodedRecord[1:] // attributes
+	require.Zero(varint())            // timestamp delta
+	require.Zero(varint())            // offset delta
+	record := &kafkaRecord{
+		key:     varintBytes(),
+		value:   varintBytes(),
+		headers: make(map[string][]byte),
+	}
+	numHeaders := varint()
+	for i := int64(0); i < numHeaders; i++ {
+		key := varintBytes()
+		record.headers[string(key)] = varintBytes()
+	}
+	require.Empty(encodedRecord)
+	return record
+}
+
+func TestKafkaSinkDeliver(t *testing.T) {
+	require := require.New(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer listener.Close()
+
+	records := make(chan *kafkaRecord, 2)
+	go serveKafka(t, listener, 0, records)
+
+	sink := newKafkaSink(&KafkaConfig{
+		Address:   listener.Addr().String(),
+		Topic:     "containers",
+		Partition: 3,
+		ClientID:  "test",
+	})
+	for i := uint64(0); i < 2; i++ {
+		record := newTestRecord(i)
+		require.NoError(sink.Deliver(context.Background(), record))
+
+		produced := <-records
+		require.Equal("containers", produced.topic)
+		require.Equal(int32(3), produced.partition)
+		require.Equal(record.ContainerID[:], produced.key)
+		require.Equal(record.Container, produced.value)
+		require.Equal(record.ChainID[:], produced.headers[kafkaChainIDHeader])
+		require.Equal(indexBytes(i), produced.headers[kafkaIndexHeader])
+	}
+	require.NoError(sink.Close())
+}
+
+func TestKafkaSinkProduceFailed(t *testing.T) {
+	require := require.New(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer listener.Close()
+
+	records := make(chan *kafkaRecord, 1)
+	// NOT_LEADER_OR_FOLLOWER
+	go serveKafka(t, listener, 6, records)
+
+	sink := newKafkaSink(&KafkaConfig{
+		Address: listener.Addr().String(),
+		Topic:   "containers",
+	})
+	err = sink.Deliver(context.Background(), newTestRecord(0))
+	require.ErrorIs(err, errProduceFailed)
+	// The connection is closed after a failure.
+	require.Nil(sink.conn)
+}
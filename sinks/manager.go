@@ -0,0 +1,182 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sinks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"github.com/coinflect/coinflectchain/api/health"
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/prefixdb"
+	"github.com/coinflect/coinflectchain/snow"
+	"github.com/coinflect/coinflectchain/utils/logging"
+	"github.com/coinflect/coinflectchain/utils/wrappers"
+)
+
+const acceptorNamePrefix = "sink-"
+
+var _ health.Checker = (*Manager)(nil)
+
+// Manager delivers the containers accepted on chains to the configured sinks
+type Manager struct {
+	log        logging.Logger
+	deliverers []*deliverer
+	// unregisterFns deregister the deliverers from the acceptor groups
+	unregisterFns []func() error
+}
+
+// New registers a sink for every sink in [config] with the acceptor group of
+// its events, and starts delivering the containers that were queued for the
+// sinks before the node restarted. The sinks are reported under [namespace].
+func New(
+	log logging.Logger,
+	db database.Database,
+	namespace string,
+	registerer prometheus.Registerer,
+	config Config,
+	consensusAcceptorGroup snow.AcceptorGroup,
+	decisionAcceptorGroup snow.AcceptorGroup,
+) (*Manager, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+	m := &Manager{
+		log: log,
+	}
+	if len(config.Sinks) == 0 {
+		return m, nil
+	}
+
+	metrics, err := newMetrics(namespace, registerer)
+	if err != nil {
+		return nil, err
+	}
+	for i := range config.Sinks {
+		sinkConfig := config.Sinks[i]
+		sinkConfig.setDefaults()
+
+		acceptorGroup := decisionAcceptorGroup
+		if sinkConfig.Events == ConsensusEvents {
+			acceptorGroup = consensusAcceptorGroup
+		}
+		if err := m.addSink(&sinkConfig, db, metrics, acceptorGroup); err != nil {
+			return nil, fmt.Errorf("couldn't add sink %q: %w", sinkConfig.Name, m.shutdown(err))
+		}
+	}
+	return m, nil
+}
+
+func (m *Manager) addSink(
+	config *SinkConfig,
+	db database.Database,
+	metrics *metrics,
+	acceptorGroup snow.AcceptorGroup,
+) error {
+	sink, err := newSink(config)
+	if err != nil {
+		return err
+	}
+	d, err := newDeliverer(
+		config,
+		m.log,
+		sink,
+		metrics.forSink(config.Name),
+		prefixdb.New([]byte(config.Name), db),
+	)
+	if err != nil {
+		_ = sink.Close()
+		return err
+	}
+
+	acceptorName := acceptorNamePrefix + config.Name
+	for _, chainID := range config.ChainIDs {
+		// The chain must not commit containers that weren't queued, or they
+		// would never be delivered.
+		if err := acceptorGroup.RegisterAcceptor(chainID, acceptorName, d, true); err != nil {
+			_ = sink.Close()
+			return err
+		}
+
+		chainID := chainID
+		m.unregisterFns = append(m.unregisterFns, func() error {
+			return acceptorGroup.DeregisterAcceptor(chainID, acceptorName)
+		})
+	}
+
+	d.start()
+	m.deliverers = append(m.deliverers, d)
+	m.log.Info("delivering accepted containers to sink",
+		zap.String("sink", config.Name),
+		zap.String("type", config.Type),
+		zap.String("events", config.Events),
+		zap.Stringers("chainIDs", config.ChainIDs),
+	)
+	return nil
+}
+
+// Shutdown stops the delivery to the sinks. The containers accepted from now
+// on aren't queued for the sinks.
+func (m *Manager) Shutdown() error {
+	m.log.Info("shutting down acceptor sinks")
+	return m.shutdown(nil)
+}
+
+// HealthCheck reports the queue of every sink, and returns an error if the
+// queue of a sink is full or its oldest container has been queued for longer
+// than the sink's MaxPendingAge.
+func (m *Manager) HealthCheck(context.Context) (interface{}, error) {
+	details := make(map[string]interface{}, len(m.deliverers))
+	var unhealthy []string
+	for _, d := range m.deliverers {
+		sinkDetails, err := d.healthCheck()
+		if err != nil {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s: %s", d.name, err))
+		}
+		details[d.name] = sinkDetails
+	}
+	if len(unhealthy) > 0 {
+		return details, fmt.Errorf("unhealthy sinks: %v", unhealthy)
+	}
+	return details, nil
+}
+
+// shutdown stops the delivery to the sinks and returns [err] with the errors
+// that occurred.
+func (m *Manager) shutdown(err error) error {
+	errs := wrappers.Errs{}
+	errs.Add(err)
+	// The chains may be waiting for room in the queues, which would prevent
+	// the deliverers from being deregistered.
+	for _, d := range m.deliverers {
+		d.unblock()
+	}
+	for _, unregister := range m.unregisterFns {
+		errs.Add(unregister())
+	}
+	for _, d := range m.deliverers {
+		errs.Add(d.close())
+	}
+	m.unregisterFns = nil
+	m.deliverers = nil
+	return errs.Err
+}
+
+func newSink(config *SinkConfig) (Sink, error) {
+	switch config.Type {
+	case FileType:
+		return newFileSink(config.File)
+	case KafkaType:
+		return newKafkaSink(config.Kafka), nil
+	case WebhookType:
+		return newWebhookSink(config.Webhook), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownType, config.Type)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sinks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/database/memdb"
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/snow"
+	"github.com/coinflect/coinflectchain/utils/logging"
+)
+
+func TestConfigVerify(t *testing.T) {
+	chainIDs := []ids.ID{ids.GenerateTestID()}
+	tests := []struct {
+		name        string
+		config      Config
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			config: Config{Sinks: []SinkConfig{
+				{Name: "file", Type: FileType, ChainIDs: chainIDs, File: &FileConfig{Directory: "dir"}},
+				{Name: "kafka", Type: KafkaType, ChainIDs: chainIDs, Events: ConsensusEvents, Kafka: &KafkaConfig{Address: "127.0.0.1:9092", Topic: "topic"}},
+				{Name: "webhook", Type: WebhookType, ChainIDs: chainIDs, Webhook: &WebhookConfig{URL: "https://example.com"}},
+			}},
+		},
+		{
+			name: "duplicate name",
+			config: Config{Sinks: []SinkConfig{
+				{Name: "file", Type: FileType, ChainIDs: chainIDs, File: &FileConfig{Directory: "dir"}},
+				{Name: "file", Type: FileType, ChainIDs: chainIDs, File: &FileConfig{Directory: "dir"}},
+			}},
+			expectedErr: errDuplicateName,
+		},
+		{
+			name:        "no name",
+			config:      Config{Sinks: []SinkConfig{{Type: FileType, ChainIDs: chainIDs, File: &FileConfig{Directory: "dir"}}}},
+			expectedErr: errNoName,
+		},
+		{
+			name:        "no chain IDs",
+			config:      Config{Sinks: []SinkConfig{{Name: "file", Type: FileType, File: &FileConfig{Directory: "dir"}}}},
+			expectedErr: errNoChainIDs,
+		},
+		{
+			name:        "unknown events",
+			config:      Config{Sinks: []SinkConfig{{Name: "file", Type: FileType, ChainIDs: chainIDs, Events: "blocks", File: &FileConfig{Directory: "dir"}}}},
+			expectedErr: errUnknownEvents,
+		},
+		{
+			name:        "negative timeout",
+			config:      Config{Sinks: []SinkConfig{{Name: "file", Type: FileType, ChainIDs: chainIDs, Timeout: -1, File: &FileConfig{Directory: "dir"}}}},
+			expectedErr: errNegativeDuration,
+		},
+		{
+			name:        "negative max pending age",
+			config:      Config{Sinks: []SinkConfig{{Name: "file", Type: FileType, ChainIDs: chainIDs, MaxPendingAge: -1, File: &FileConfig{Directory: "dir"}}}},
+			expectedErr: errNegativeDuration,
+		},
+		{
+			name:        "unknown overflow policy",
+			config:      Config{Sinks: []SinkConfig{{Name: "file", Type: FileType, ChainIDs: chainIDs, OverflowPolicy: "retry", File: &FileConfig{Directory: "dir"}}}},
+			expectedErr: errUnknownPolicy,
+		},
+		{
+			name:        "unknown type",
+			config:      Config{Sinks: []SinkConfig{{Name: "ipc", Type: "ipc", ChainIDs: chainIDs}}},
+			expectedErr: errUnknownType,
+		},
+		{
+			name:        "missing config",
+			config:      Config{Sinks: []SinkConfig{{Name: "file", Type: FileType, ChainIDs: chainIDs}}},
+			expectedErr: errMissingConfig,
+		},
+		{
+			name:        "no directory",
+			config:      Config{Sinks: []SinkConfig{{Name: "file", Type: FileType, ChainIDs: chainIDs, File: &FileConfig{}}}},
+			expectedErr: errNoDirectory,
+		},
+		{
+			name:        "no address",
+			config:      Config{Sinks: []SinkConfig{{Name: "kafka", Type: KafkaType, ChainIDs: chainIDs, Kafka: &KafkaConfig{Topic: "topic"}}}},
+			expectedErr: errNoAddress,
+		},
+		{
+			name:        "no topic",
+			config:      Config{Sinks: []SinkConfig{{Name: "kafka", Type: KafkaType, ChainIDs: chainIDs, Kafka: &KafkaConfig{Address: "127.0.0.1:9092"}}}},
+			expectedErr: errNoTopic,
+		},
+		{
+			name:        "invalid URL",
+			config:      Config{Sinks: []SinkConfig{{Name: "webhook", Type: WebhookType, ChainIDs: chainIDs, Webhook: &WebhookConfig{URL: "ftp://example.com"}}}},
+			expectedErr: errInvalidURL,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, test.config.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestManager(t *testing.T) {
+	require := require.New(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	consensusAcceptorGroup := snow.NewAcceptorGroup(logging.NoLog{})
+	decisionAcceptorGroup := snow.NewAcceptorGroup(logging.NoLog{})
+	dir := t.TempDir()
+
+	m, err := New(
+		logging.NoLog{},
+		memdb.New(),
+		"",
+		prometheus.NewRegistry(),
+		Config{Sinks: []SinkConfig{{
+			Name:     "file",
+			Type:     FileType,
+			ChainIDs: []ids.ID{ctx.ChainID},
+			File:     &FileConfig{Directory: dir},
+		}}},
+		consensusAcceptorGroup,
+		decisionAcceptorGroup,
+	)
+	require.NoError(err)
+
+	// Consensus events aren't delivered to the sink.
+	require.NoError(consensusAcceptorGroup.Accept(ctx, ids.GenerateTestID(), []byte{0}))
+	containerID := ids.GenerateTestID()
+	require.NoError(decisionAcceptorGroup.Accept(ctx, containerID, []byte{1}))
+
+	var records []*Record
+	require.Eventually(func() bool {
+		segments, err := Segments(dir)
+		if err != nil || len(segments) != 1 {
+			return false
+		}
+		records, err = ReadSegment(segments[0])
+		return err == nil && len(records) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(containerID, records[0].ContainerID)
+	require.Equal([]byte{1}, records[0].Container)
+
+	_, err = m.HealthCheck(context.Background())
+	require.NoError(err)
+
+	require.NoError(m.Shutdown())
+	// The sink was deregistered by the shutdown.
+	require.Error(decisionAcceptorGroup.DeregisterAcceptor(ctx.ChainID, acceptorNamePrefix+"file"))
+}
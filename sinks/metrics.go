@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sinks
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/coinflect/coinflectchain/utils/wrappers"
+)
+
+const sinkLabel = "sink"
+
+type metrics struct {
+	queued     *prometheus.CounterVec
+	delivered  *prometheus.CounterVec
+	failures   *prometheus.CounterVec
+	pending    *prometheus.GaugeVec
+	dropped    *prometheus.CounterVec
+	deliveryNs *prometheus.CounterVec
+}
+
+func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, error) {
+	labels := []string{sinkLabel}
+	m := &metrics{
+		queued: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "queued",
+			Help:      "Number of accepted containers queued for delivery",
+		}, labels),
+		delivered: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "delivered",
+			Help:      "Number of accepted containers delivered",
+		}, labels),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "delivery_failures",
+			Help:      "Number of failed delivery attempts",
+		}, labels),
+		pending: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "pending",
+			Help:      "Number of queued containers that haven't been delivered yet",
+		}, labels),
+		dropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "dropped",
+			Help:      "Number of queued containers dropped without being delivered",
+		}, labels),
+		deliveryNs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "delivery_time",
+			Help:      "Time spent delivering containers, including failed attempts (in nanoseconds)",
+		}, labels),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.queued),
+		registerer.Register(m.delivered),
+		registerer.Register(m.failures),
+		registerer.Register(m.pending),
+		registerer.Register(m.dropped),
+		registerer.Register(m.deliveryNs),
+	)
+	return m, errs.Err
+}
+
+// sinkMetrics are the metrics of a single sink
+type sinkMetrics struct {
+	queued     prometheus.Counter
+	delivered  prometheus.Counter
+	failures   prometheus.Counter
+	pending    prometheus.Gauge
+	dropped    prometheus.Counter
+	deliveryNs prometheus.Counter
+}
+
+func (m *metrics) forSink(name string) *sinkMetrics {
+	labels := prometheus.Labels{sinkLabel: name}
+	return &sinkMetrics{
+		queued:     m.queued.With(labels),
+		delivered:  m.delivered.With(labels),
+		failures:   m.failures.With(labels),
+		pending:    m.pending.With(labels),
+		dropped:    m.dropped.With(labels),
+		deliveryNs: m.deliveryNs.With(labels),
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package sinks delivers the containers accepted on chains to external
+// destinations, such as files, Kafka topics and webhooks.
+//
+// Every accepted container is queued in the node's database before the chain
+// commits it. Each sink then delivers its queued containers in order, retrying
+// failed deliveries, and persists the index of the next container to deliver
+// once a delivery succeeds. After a restart, the delivery resumes from the
+// persisted cursor, so every container is delivered at least once.
+package sinks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/utils/hashing"
+	"github.com/coinflect/coinflectchain/utils/wrappers"
+)
+
+// recordHeaderLen is the length of a marshalled record without its container
+const recordHeaderLen = 2*wrappers.LongLen + 2*hashing.HashLen + wrappers.IntLen
+
+// Record is a container accepted on a chain that is delivered to a sink
+type Record struct {
+	// Index of the record in the sequence of records that were queued for
+	// the sink. Records that are delivered more than once keep their index,
+	// so it can be used to ignore duplicates.
+	Index       uint64
+	ChainID     ids.ID
+	ContainerID ids.ID
+	// Timestamp is the time the container was accepted at
+	Timestamp time.Time
+	Container []byte
+}
+
+// Sink is an external destination of accepted containers
+type Sink interface {
+	// Deliver hands [record] over to the destination. Once it returns nil,
+	// [record] must be durably stored by the destination. Otherwise, the
+	// delivery of [record] is retried.
+	Deliver(ctx context.Context, record *Record) error
+
+	// Close releases the resources of the sink
+	Close() error
+}
+
+// Bytes returns the binary representation of the record:
+//
+//	index (8 bytes) | chainID (32 bytes) | containerID (32 bytes) |
+//	timestamp in unix nanoseconds (8 bytes) |
+//	container length (4 bytes) | container
+func (r *Record) Bytes() []byte {
+	p := wrappers.Packer{Bytes: make([]byte, recordHeaderLen+len(r.Container))}
+	p.PackLong(r.Index)
+	p.PackFixedBytes(r.ChainID[:])
+	p.PackFixedBytes(r.ContainerID[:])
+	p.PackLong(uint64(r.Timestamp.UnixNano()))
+	p.PackBytes(r.Container)
+	return p.Bytes
+}
+
+// ParseRecord parses the binary representation of a record. See Record.Bytes.
+func ParseRecord(recordBytes []byte) (*Record, error) {
+	p := wrappers.Packer{Bytes: recordBytes}
+	record := &Record{
+		Index: p.UnpackLong(),
+	}
+	chainIDBytes := p.UnpackFixedBytes(hashing.HashLen)
+	containerIDBytes := p.UnpackFixedBytes(hashing.HashLen)
+	record.Timestamp = time.Unix(0, int64(p.UnpackLong()))
+	record.Container = p.UnpackBytes()
+	if p.Err != nil {
+		return nil, fmt.Errorf("couldn't parse record: %w", p.Err)
+	}
+	if p.Offset != len(recordBytes) {
+		return nil, fmt.Errorf("couldn't parse record: %d trailing bytes", len(recordBytes)-p.Offset)
+	}
+
+	var err error
+	record.ChainID, err = ids.ToID(chainIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	record.ContainerID, err = ids.ToID(containerIDBytes)
+	return record, err
+}
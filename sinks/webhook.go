@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/utils/formatting"
+
+	cjson "github.com/coinflect/coinflectchain/utils/json"
+)
+
+// maxDiscardedBodySize bounds how much of a response body is read so that the
+// connection can be reused
+const maxDiscardedBodySize = 4096
+
+var _ Sink = (*webhookSink)(nil)
+
+// WebhookRecord is the body of the requests that the webhook sinks make
+type WebhookRecord struct {
+	Index       cjson.Uint64 `json:"index"`
+	ChainID     ids.ID       `json:"chainID"`
+	ContainerID ids.ID       `json:"containerID"`
+	Timestamp   time.Time    `json:"timestamp"`
+	// Container is hex encoded
+	Container string `json:"container"`
+}
+
+// webhookSink POSTs the records to a URL as JSON encoded WebhookRecords. A
+// record is acknowledged once the URL responds with a 2xx status code.
+type webhookSink struct {
+	config WebhookConfig
+	client http.Client
+}
+
+func newWebhookSink(config *WebhookConfig) *webhookSink {
+	return &webhookSink{
+		config: *config,
+	}
+}
+
+func (s *webhookSink) Deliver(ctx context.Context, record *Record) error {
+	container, err := formatting.Encode(formatting.Hex, record.Container)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(&WebhookRecord{
+		Index:       cjson.Uint64(record.Index),
+		ChainID:     record.ChainID,
+		ContainerID: record.ContainerID,
+		Timestamp:   record.Timestamp.UTC(),
+		Container:   container,
+	})
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for key, value := range s.config.Headers {
+		request.Header.Set(key, value)
+	}
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, maxDiscardedBodySize))
+	if err := response.Body.Close(); err != nil {
+		return err
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %s", response.Status)
+	}
+	return nil
+}
+
+func (s *webhookSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
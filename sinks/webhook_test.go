@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/utils/formatting"
+)
+
+func TestWebhookSinkDeliver(t *testing.T) {
+	require := require.New(t)
+
+	record := newTestRecord(7)
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(http.MethodPost, r.Method)
+		require.Equal("application/json", r.Header.Get("Content-Type"))
+		require.Equal("secret", r.Header.Get("Authorization"))
+
+		webhookRecord := WebhookRecord{}
+		require.NoError(json.NewDecoder(r.Body).Decode(&webhookRecord))
+		require.Equal(record.Index, uint64(webhookRecord.Index))
+		require.Equal(record.ChainID, webhookRecord.ChainID)
+		require.Equal(record.ContainerID, webhookRecord.ContainerID)
+		require.True(record.Timestamp.Equal(webhookRecord.Timestamp))
+		container, err := formatting.Decode(formatting.Hex, webhookRecord.Container)
+		require.NoError(err)
+		require.Equal(record.Container, container)
+
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sink := newWebhookSink(&WebhookConfig{
+		URL: server.URL,
+		Headers: map[string]string{
+			"Authorization": "secret",
+		},
+	})
+	require.NoError(sink.Deliver(context.Background(), record))
+
+	status = http.StatusServiceUnavailable
+	require.Error(sink.Deliver(context.Background(), record))
+	require.NoError(sink.Close())
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/coinflect/coinflectchain/snow/consensus/coinflect"
 	"github.com/coinflect/coinflectchain/snow/engine/coinflect/vertex"
 	"github.com/coinflect/coinflectchain/snow/engine/common"
+	"github.com/coinflect/coinflectchain/snow/engine/common/queue"
 	"github.com/coinflect/coinflectchain/version"
 )
 
@@ -120,6 +121,15 @@ func (b *bootstrapper) Clear() error {
 	if err := b.TxBlocked.Clear(); err != nil {
 		return err
 	}
+	return b.commit()
+}
+
+// commit writes the changes to both job queues. If a journal was provided,
+// they're written atomically.
+func (b *bootstrapper) commit() error {
+	if b.Journal != nil {
+		return queue.CommitAll(b.Journal, b.VtxBlocked, b.TxBlocked)
+	}
 	if err := b.VtxBlocked.Commit(); err != nil {
 		return err
 	}
@@ -501,10 +511,7 @@ func (b *bootstrapper) process(ctx context.Context, vtxs ...coinflect.Vertex) er
 		}
 	}
 
-	if err := b.TxBlocked.Commit(); err != nil {
-		return err
-	}
-	if err := b.VtxBlocked.Commit(); err != nil {
+	if err := b.commit(); err != nil {
 		return err
 	}
 
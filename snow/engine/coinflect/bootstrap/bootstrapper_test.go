@@ -12,6 +12,7 @@ import (
 
 	"github.com/coinflect/coinflectchain/database/memdb"
 	"github.com/coinflect/coinflectchain/database/prefixdb"
+	"github.com/coinflect/coinflectchain/database/versiondb"
 	"github.com/coinflect/coinflectchain/ids"
 	"github.com/coinflect/coinflectchain/snow"
 	"github.com/coinflect/coinflectchain/snow/choices"
@@ -62,6 +63,10 @@ func newConfig(t *testing.T) (Config, ids.NodeID, *common.SenderTest, *vertex.Te
 		t.Fatal(err)
 	}
 
+	journal, err := versiondb.NewJournal(db)
+	if err != nil {
+		t.Fatal(err)
+	}
 	vtxBlocker, err := queue.NewWithMissing(prefixdb.New([]byte("vtx"), db), "vtx", ctx.Registerer)
 	if err != nil {
 		t.Fatal(err)
@@ -100,6 +105,7 @@ func newConfig(t *testing.T) (Config, ids.NodeID, *common.SenderTest, *vertex.Te
 		AllGetsServer: avaGetHandler,
 		VtxBlocked:    vtxBlocker,
 		TxBlocked:     txBlocker,
+		Journal:       journal,
 		Manager:       manager,
 		VM:            vm,
 	}, peer, sender, manager, vm
@@ -5,6 +5,7 @@
 package bootstrap
 
 import (
+	"github.com/coinflect/coinflectchain/database/versiondb"
 	"github.com/coinflect/coinflectchain/snow/engine/coinflect/vertex"
 	"github.com/coinflect/coinflectchain/snow/engine/common"
 	"github.com/coinflect/coinflectchain/snow/engine/common/queue"
@@ -18,6 +19,9 @@ type Config struct {
 	VtxBlocked *queue.JobsWithMissing
 	// TxBlocked tracks operations that are blocked on transactions
 	TxBlocked *queue.Jobs
+	// Journal, if non-nil, commits [VtxBlocked] and [TxBlocked] atomically.
+	// Both queues must be backed by its database.
+	Journal *versiondb.Journal
 
 	Manager vertex.Manager
 	VM      vertex.DAGVM
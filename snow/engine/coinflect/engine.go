@@ -19,4 +19,7 @@ type Engine interface {
 	// GetVtx returns a vertex by its ID.
 	// Returns an error if unknown.
 	GetVtx(ctx context.Context, vtxID ids.ID) (coinflect.Vertex, error)
+
+	// Edge returns the IDs of the vertices in the accepted frontier.
+	Edge(ctx context.Context) []ids.ID
 }
@@ -254,6 +254,20 @@ func (mr *MockEngineMockRecorder) Disconnected(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disconnected", reflect.TypeOf((*MockEngine)(nil).Disconnected), arg0, arg1)
 }
 
+// Edge mocks base method.
+func (m *MockEngine) Edge(arg0 context.Context) []ids.ID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Edge", arg0)
+	ret0, _ := ret[0].([]ids.ID)
+	return ret0
+}
+
+// Edge indicates an expected call of Edge.
+func (mr *MockEngineMockRecorder) Edge(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Edge", reflect.TypeOf((*MockEngine)(nil).Edge), arg0)
+}
+
 // Get mocks base method.
 func (m *MockEngine) Get(arg0 context.Context, arg1 ids.NodeID, arg2 uint32, arg3 ids.ID) error {
 	m.ctrl.T.Helper()
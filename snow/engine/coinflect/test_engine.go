@@ -23,13 +23,16 @@ var (
 type EngineTest struct {
 	common.EngineTest
 
-	CantGetVtx bool
-	GetVtxF    func(ctx context.Context, vtxID ids.ID) (coinflect.Vertex, error)
+	CantGetVtx, CantEdge bool
+
+	GetVtxF func(ctx context.Context, vtxID ids.ID) (coinflect.Vertex, error)
+	EdgeF   func(ctx context.Context) []ids.ID
 }
 
 func (e *EngineTest) Default(cant bool) {
 	e.EngineTest.Default(cant)
 	e.CantGetVtx = false
+	e.CantEdge = false
 }
 
 func (e *EngineTest) GetVtx(ctx context.Context, vtxID ids.ID) (coinflect.Vertex, error) {
@@ -41,3 +44,13 @@ func (e *EngineTest) GetVtx(ctx context.Context, vtxID ids.ID) (coinflect.Vertex
 	}
 	return nil, errGetVtx
 }
+
+func (e *EngineTest) Edge(ctx context.Context) []ids.ID {
+	if e.EdgeF != nil {
+		return e.EdgeF(ctx)
+	}
+	if e.CantEdge && e.T != nil {
+		e.T.Fatalf("Unexpectedly called Edge")
+	}
+	return nil
+}
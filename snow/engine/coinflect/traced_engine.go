@@ -41,3 +41,10 @@ func (e *tracedEngine) GetVtx(ctx context.Context, vtxID ids.ID) (coinflect.Vert
 
 	return e.engine.GetVtx(ctx, vtxID)
 }
+
+func (e *tracedEngine) Edge(ctx context.Context) []ids.ID {
+	ctx, span := e.tracer.Start(ctx, "tracedEngine.Edge")
+	defer span.End()
+
+	return e.engine.Edge(ctx)
+}
@@ -399,6 +399,10 @@ func (t *Transitive) GetVtx(ctx context.Context, vtxID ids.ID) (coinflect.Vertex
 	return t.Manager.GetVtx(ctx, vtxID)
 }
 
+func (t *Transitive) Edge(ctx context.Context) []ids.ID {
+	return t.Manager.Edge(ctx)
+}
+
 func (t *Transitive) attemptToIssueTxs(ctx context.Context) error {
 	err := t.errs.Err
 	if err != nil {
@@ -241,6 +241,32 @@ func (j *Jobs) Commit() error {
 	return j.db.Commit()
 }
 
+func (j *Jobs) stage() (*versiondb.Database, error) {
+	return j.db, nil
+}
+
+// Committer is a job queue that can be committed together with other queues
+// by CommitAll.
+type Committer interface {
+	// stage writes the pending changes of the queue to its versionDB and
+	// returns the versionDB.
+	stage() (*versiondb.Database, error)
+}
+
+// CommitAll atomically commits the versionDBs of [queues] through [journal].
+// The queues must be backed by the journal's database.
+func CommitAll(journal *versiondb.Journal, queues ...Committer) error {
+	dbs := make([]*versiondb.Database, len(queues))
+	for i, q := range queues {
+		db, err := q.stage()
+		if err != nil {
+			return err
+		}
+		dbs[i] = db
+	}
+	return journal.Commit(dbs...)
+}
+
 type JobsWithMissing struct {
 	*Jobs
 
@@ -363,19 +389,27 @@ func (jm *JobsWithMissing) NumMissingIDs() int {
 
 // Commit the versionDB to the underlying database.
 func (jm *JobsWithMissing) Commit() error {
+	if _, err := jm.stage(); err != nil {
+		return err
+	}
+	return jm.Jobs.Commit()
+}
+
+// stage writes the changes to the missing IDs to the versionDB.
+func (jm *JobsWithMissing) stage() (*versiondb.Database, error) {
 	if jm.addToMissingIDs.Len() != 0 {
 		if err := jm.state.AddMissingJobIDs(jm.addToMissingIDs); err != nil {
-			return err
+			return nil, err
 		}
 		jm.addToMissingIDs.Clear()
 	}
 	if jm.removeFromMissingIDs.Len() != 0 {
 		if err := jm.state.RemoveMissingJobIDs(jm.removeFromMissingIDs); err != nil {
-			return err
+			return nil, err
 		}
 		jm.removeFromMissingIDs.Clear()
 	}
-	return jm.Jobs.Commit()
+	return jm.db, nil
 }
 
 // cleanRunnableStack iterates over the jobs on the runnable stack and resets any job
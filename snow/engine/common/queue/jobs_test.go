@@ -15,6 +15,8 @@ import (
 
 	"github.com/coinflect/coinflectchain/database"
 	"github.com/coinflect/coinflectchain/database/memdb"
+	"github.com/coinflect/coinflectchain/database/prefixdb"
+	"github.com/coinflect/coinflectchain/database/versiondb"
 	"github.com/coinflect/coinflectchain/ids"
 	"github.com/coinflect/coinflectchain/snow"
 	"github.com/coinflect/coinflectchain/snow/engine/common"
@@ -332,6 +334,49 @@ func TestMissingJobs(t *testing.T) {
 	require.False(containsJob1ID)
 }
 
+func TestCommitAll(t *testing.T) {
+	require := require.New(t)
+
+	parser := &TestParser{T: t}
+	db := memdb.New()
+	vtxDB := prefixdb.New([]byte("vtx"), db)
+	txDB := prefixdb.New([]byte("tx"), db)
+
+	journal, err := versiondb.NewJournal(db)
+	require.NoError(err)
+	vtxJobs, err := NewWithMissing(vtxDB, "vtx", prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(vtxJobs.SetParser(context.Background(), parser))
+	txJobs, err := New(txDB, "tx", prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(txJobs.SetParser(parser))
+
+	missingID := ids.GenerateTestID()
+	vtxJobs.AddMissingID(missingID)
+	jobID := ids.GenerateTestID()
+	job := testJob(t, jobID, nil, ids.Empty, nil)
+	parser.ParseF = func(_ context.Context, b []byte) (Job, error) {
+		return job, nil
+	}
+	pushed, err := txJobs.Push(context.Background(), job)
+	require.NoError(err)
+	require.True(pushed)
+
+	require.NoError(CommitAll(journal, vtxJobs, txJobs))
+
+	vtxJobs, err = NewWithMissing(vtxDB, "vtx", prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(vtxJobs.SetParser(context.Background(), parser))
+	require.Equal([]ids.ID{missingID}, vtxJobs.MissingIDs())
+
+	txJobs, err = New(txDB, "tx", prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(txJobs.SetParser(parser))
+	hasJob, err := txJobs.Has(jobID)
+	require.NoError(err)
+	require.True(hasJob)
+}
+
 func TestHandleJobWithMissingDependencyOnRunnableStack(t *testing.T) {
 	require := require.New(t)
 
@@ -63,6 +63,22 @@ type Client interface {
 	GetBalance(ctx context.Context, addr ids.ShortID, assetID string, includePartial bool, options ...rpc.Option) (*GetBalanceReply, error)
 	// GetAllBalances returns all asset balances for [addr]
 	GetAllBalances(ctx context.Context, addr ids.ShortID, includePartial bool, options ...rpc.Option) ([]Balance, error)
+	// GetBalanceAtHeight returns the balance of [assetID] held by [addr] once
+	// the transaction at [height] of the chain's transaction index was accepted
+	GetBalanceAtHeight(ctx context.Context, addr ids.ShortID, assetID string, height uint64, options ...rpc.Option) (uint64, error)
+	// GetBalanceAtTime returns the balance of [assetID] held by [addr] at
+	// [timestamp]
+	GetBalanceAtTime(ctx context.Context, addr ids.ShortID, assetID string, timestamp time.Time, options ...rpc.Option) (uint64, error)
+	// GetBalanceHistory returns the changes to the balance of [assetID] held by
+	// [addr], starting at [cursor], and the cursor of the next page
+	GetBalanceHistory(
+		ctx context.Context,
+		addr ids.ShortID,
+		assetID string,
+		cursor uint64,
+		pageSize uint64,
+		options ...rpc.Option,
+	) ([]BalanceChange, uint64, error)
 	// CreateAsset creates a new asset and returns its assetID
 	CreateAsset(
 		ctx context.Context,
@@ -339,6 +355,58 @@ func (c *client) GetAllBalances(
 	return res.Balances, err
 }
 
+func (c *client) GetBalanceAtHeight(
+	ctx context.Context,
+	addr ids.ShortID,
+	assetID string,
+	height uint64,
+	options ...rpc.Option,
+) (uint64, error) {
+	jsonHeight := cjson.Uint64(height)
+	res := &GetBalanceAtReply{}
+	err := c.requester.SendRequest(ctx, "avm.getBalanceAt", &GetBalanceAtArgs{
+		JSONAddress: api.JSONAddress{Address: addr.String()},
+		AssetID:     assetID,
+		Height:      &jsonHeight,
+	}, res, options...)
+	return uint64(res.Balance), err
+}
+
+func (c *client) GetBalanceAtTime(
+	ctx context.Context,
+	addr ids.ShortID,
+	assetID string,
+	timestamp time.Time,
+	options ...rpc.Option,
+) (uint64, error) {
+	jsonTime := cjson.Uint64(timestamp.Unix())
+	res := &GetBalanceAtReply{}
+	err := c.requester.SendRequest(ctx, "avm.getBalanceAt", &GetBalanceAtArgs{
+		JSONAddress: api.JSONAddress{Address: addr.String()},
+		AssetID:     assetID,
+		Time:        &jsonTime,
+	}, res, options...)
+	return uint64(res.Balance), err
+}
+
+func (c *client) GetBalanceHistory(
+	ctx context.Context,
+	addr ids.ShortID,
+	assetID string,
+	cursor uint64,
+	pageSize uint64,
+	options ...rpc.Option,
+) ([]BalanceChange, uint64, error) {
+	res := &GetBalanceHistoryReply{}
+	err := c.requester.SendRequest(ctx, "avm.getBalanceHistory", &GetBalanceHistoryArgs{
+		JSONAddress: api.JSONAddress{Address: addr.String()},
+		Cursor:      cjson.Uint64(cursor),
+		PageSize:    cjson.Uint64(pageSize),
+		AssetID:     assetID,
+	}, res, options...)
+	return res.Changes, uint64(res.Cursor), err
+}
+
 // ClientHolder describes how much an address owns of an asset
 type ClientHolder struct {
 	Amount  uint64
@@ -5,41 +5,33 @@
 package avm
 
 import (
-	"github.com/coinflect/coinflectchain/api"
 	"github.com/coinflect/coinflectchain/pubsub"
 	"github.com/coinflect/coinflectchain/vms/avm/txs"
 	"github.com/coinflect/coinflectchain/vms/components/cflt"
 )
 
-var _ pubsub.Filterer = (*filterer)(nil)
-
-type filterer struct {
-	tx *txs.Tx
-}
-
-func NewPubSubFilterer(tx *txs.Tx) pubsub.Filterer {
-	return &filterer{tx: tx}
-}
-
-// Apply the filter on the addresses.
-func (f *filterer) Filter(filters []pubsub.Filter) ([]bool, interface{}) {
-	resp := make([]bool, len(filters))
-	for _, utxo := range f.tx.UTXOs() {
+// NewPubSubFilterer returns the filterer that sends [tx] to the connections
+// whose filter matches the addresses of its outputs. The X-chain isn't linear,
+// so the events don't have a height.
+func NewPubSubFilterer(formatter pubsub.AddressFormatter, tx *txs.Tx) pubsub.Filterer {
+	f := &pubsub.TxFilterer{
+		Formatter: formatter,
+		TxID:      tx.ID(),
+	}
+	for _, utxo := range tx.UTXOs() {
 		addressable, ok := utxo.Out.(cflt.Addressable)
 		if !ok {
 			continue
 		}
 
-		for _, address := range addressable.Addresses() {
-			for i, c := range filters {
-				if resp[i] {
-					continue
-				}
-				resp[i] = c.Check(address)
-			}
+		out := pubsub.Output{
+			Addresses: addressable.Addresses(),
+			AssetID:   utxo.AssetID(),
 		}
+		if amounter, ok := utxo.Out.(cflt.Amounter); ok {
+			out.Amount = amounter.Amount()
+		}
+		f.Outputs = append(f.Outputs, out)
 	}
-	return resp, api.JSONTxID{
-		TxID: f.tx.ID(),
-	}
+	return f
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/coinflect/coinflectchain/api"
 	"github.com/coinflect/coinflectchain/ids"
 	"github.com/coinflect/coinflectchain/pubsub"
 	"github.com/coinflect/coinflectchain/vms/avm/txs"
@@ -25,14 +26,23 @@ func (f *mockFilter) Check(addr []byte) bool {
 	return bytes.Equal(addr, f.addr)
 }
 
+type mockFormatter struct{}
+
+func (mockFormatter) FormatLocalAddress(addr ids.ShortID) (string, error) {
+	return "X-" + addr.String(), nil
+}
+
 func TestFilter(t *testing.T) {
 	require := require.New(t)
 
 	addrID := ids.ShortID{1}
+	assetID := ids.ID{2}
 	tx := txs.Tx{Unsigned: &txs.BaseTx{BaseTx: cflt.BaseTx{
 		Outs: []*cflt.TransferableOutput{
 			{
+				Asset: cflt.Asset{ID: assetID},
 				Out: &secp256k1fx.TransferOutput{
+					Amt: 5,
 					OutputOwners: secp256k1fx.OutputOwners{
 						Addrs: []ids.ShortID{addrID},
 					},
@@ -46,7 +56,20 @@ func TestFilter(t *testing.T) {
 	err := fp.Add(addrBytes)
 	require.NoError(err)
 
-	parser := NewPubSubFilterer(&tx)
-	fr, _ := parser.Filter([]pubsub.Filter{&mockFilter{addr: addrBytes}})
-	require.Equal([]bool{true}, fr)
+	parser := NewPubSubFilterer(mockFormatter{}, &tx)
+	msgs := parser.Filter([]pubsub.Filter{
+		&mockFilter{addr: addrBytes},
+		&mockFilter{addr: ids.ShortEmpty[:]},
+	})
+	require.Equal([]interface{}{
+		&api.TxEvent{
+			TxID:      tx.ID(),
+			Addresses: []string{"X-" + addrID.String()},
+			Assets: []api.AssetAmount{{
+				AssetID: assetID,
+				Amount:  5,
+			}},
+		},
+		nil,
+	}, msgs)
 }
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -52,6 +53,7 @@ var (
 	errNoAddresses            = errors.New("no addresses provided")
 	errNoKeys                 = errors.New("from addresses have no keys or funds")
 	errMissingPrivateKey      = errors.New("argument 'privateKey' not given")
+	errHeightOrTime           = errors.New("exactly one of 'height' and 'time' must be given")
 )
 
 // Service defines the base service for the asset vm
@@ -501,6 +503,145 @@ func (service *Service) GetAllBalances(_ *http.Request, args *GetAllBalancesArgs
 	return nil
 }
 
+// GetBalanceAtArgs are arguments for passing into GetBalanceAt requests
+type GetBalanceAtArgs struct {
+	api.JSONAddress
+	// AssetID defaulted to CFLT if omitted or left blank
+	AssetID string `json:"assetID"`
+	// Height is the index, in the chain's transaction index, of the
+	// transaction to get the balance once it was accepted
+	Height *json.Uint64 `json:"height,omitempty"`
+	// Time, in seconds since the Unix epoch, to get the balance at, according
+	// to when this node accepted the transactions
+	Time *json.Uint64 `json:"time,omitempty"`
+}
+
+// GetBalanceAtReply defines the GetBalanceAt replies returned from the API
+type GetBalanceAtReply struct {
+	Balance json.Uint64 `json:"balance"`
+}
+
+// GetBalanceAt returns the balance of an asset that an address held at a
+// height or a time, according to the balance journal. The balance includes
+// the assets held only partially by the address, and the balances with
+// locktime in the future.
+func (service *Service) GetBalanceAt(_ *http.Request, args *GetBalanceAtArgs, reply *GetBalanceAtReply) error {
+	service.vm.ctx.Log.Debug("AVM: GetBalanceAt called",
+		logging.UserString("address", args.Address),
+		logging.UserString("assetID", args.AssetID),
+	)
+
+	if (args.Height == nil) == (args.Time == nil) {
+		return errHeightOrTime
+	}
+
+	address, err := cflt.ParseServiceAddress(service.vm, args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse argument 'address' to address: %w", err)
+	}
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return fmt.Errorf("specified `assetID` is invalid: %w", err)
+	}
+
+	var balance uint64
+	if args.Height != nil {
+		balance, err = service.vm.balanceJournal.BalanceAtHeight(address[:], assetID, uint64(*args.Height))
+	} else {
+		timestamp := time.Unix(int64(*args.Time), 0)
+		balance, err = service.vm.balanceJournal.BalanceAtTime(address[:], assetID, timestamp)
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't get balance: %w", err)
+	}
+	reply.Balance = json.Uint64(balance)
+	return nil
+}
+
+// BalanceChange is a change that an accepted transaction made to an address's
+// balance of an asset
+type BalanceChange struct {
+	TxID ids.ID `json:"txID"`
+	// Height is the index of the transaction in the chain's transaction index
+	// (see the index API), which is the number of transactions accepted before
+	// it, not counting the genesis transactions. Genesis allocations have
+	// height 0.
+	Height json.Uint64 `json:"height"`
+	// Timestamp is the time, in seconds since the Unix epoch, that this node
+	// accepted the transaction at. Transactions accepted while bootstrapping
+	// have the time they were bootstrapped at. Genesis allocations have
+	// timestamp 0.
+	Timestamp json.Uint64 `json:"timestamp"`
+	Received  json.Uint64 `json:"received"`
+	Sent      json.Uint64 `json:"sent"`
+	// Balance is the balance once the transaction was accepted
+	Balance json.Uint64 `json:"balance"`
+}
+
+type GetBalanceHistoryArgs struct {
+	api.JSONAddress
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+	// PageSize num of items per page
+	PageSize json.Uint64 `json:"pageSize"`
+	// AssetID defaulted to CFLT if omitted or left blank
+	AssetID string `json:"assetID"`
+}
+
+type GetBalanceHistoryReply struct {
+	Changes []BalanceChange `json:"changes"`
+	// Cursor used as a page index / offset
+	Cursor json.Uint64 `json:"cursor"`
+}
+
+// GetBalanceHistory returns the changes that accepted transactions made to the
+// balance of an asset held by an address, in order of acceptance
+func (service *Service) GetBalanceHistory(_ *http.Request, args *GetBalanceHistoryArgs, reply *GetBalanceHistoryReply) error {
+	cursor := uint64(args.Cursor)
+	pageSize := uint64(args.PageSize)
+	service.vm.ctx.Log.Debug("AVM: GetBalanceHistory called",
+		logging.UserString("address", args.Address),
+		logging.UserString("assetID", args.AssetID),
+		zap.Uint64("cursor", cursor),
+		zap.Uint64("pageSize", pageSize),
+	)
+	if pageSize > maxPageSize {
+		return fmt.Errorf("pageSize > maximum allowed (%d)", maxPageSize)
+	} else if pageSize == 0 {
+		pageSize = maxPageSize
+	}
+
+	address, err := cflt.ParseServiceAddress(service.vm, args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse argument 'address' to address: %w", err)
+	}
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return fmt.Errorf("specified `assetID` is invalid: %w", err)
+	}
+
+	changes, err := service.vm.balanceJournal.History(address[:], assetID, cursor, pageSize)
+	if err != nil {
+		return fmt.Errorf("couldn't get balance history: %w", err)
+	}
+
+	reply.Changes = make([]BalanceChange, len(changes))
+	for i, change := range changes {
+		reply.Changes[i] = BalanceChange{
+			TxID:      change.TxID,
+			Height:    json.Uint64(change.Height),
+			Timestamp: json.Uint64(change.Timestamp),
+			Received:  json.Uint64(change.Received),
+			Sent:      json.Uint64(change.Sent),
+			Balance:   json.Uint64(change.Balance),
+		}
+	}
+	reply.Cursor = json.Uint64(cursor + uint64(len(changes)))
+	return nil
+}
+
 // Holder describes how much an address owns of an asset
 type Holder struct {
 	Amount  json.Uint64 `json:"amount"`
@@ -2125,6 +2125,175 @@ func TestGetBalance(t *testing.T) {
 	}
 }
 
+func TestGetBalanceHistory(t *testing.T) {
+	require := require.New(t)
+
+	genesisBytes := BuildGenesisTest(t)
+	issuer := make(chan common.Message, 1)
+	baseDBManager := manager.NewMemDB(version.Semantic1_0_0)
+	ctx := NewContext(t)
+	vm := setupTestVM(t, ctx, baseDBManager, genesisBytes, issuer, Config{IndexBalances: true})
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+		ctx.Lock.Unlock()
+	}()
+	s := &Service{vm: vm}
+
+	cfltAssetID := GetCFLTTxFromGenesisTest(genesisBytes, t).ID()
+	addrStr, err := vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	require.NoError(err)
+	historyArgs := &GetBalanceHistoryArgs{
+		JSONAddress: api.JSONAddress{Address: addrStr},
+		AssetID:     cfltAssetID.String(),
+	}
+
+	// The genesis allocation is journaled before the first transaction.
+	historyReply := &GetBalanceHistoryReply{}
+	require.NoError(s.GetBalanceHistory(nil, historyArgs, historyReply))
+	require.Len(historyReply.Changes, 1)
+	genesisChange := historyReply.Changes[0]
+	require.Zero(genesisChange.Height)
+	require.Zero(genesisChange.Timestamp)
+	require.Zero(genesisChange.Sent)
+	require.Equal(genesisChange.Received, genesisChange.Balance)
+	genesisBalance := uint64(genesisChange.Balance)
+
+	balanceReply := &GetBalanceReply{}
+	require.NoError(s.GetBalance(nil, &GetBalanceArgs{
+		Address:        addrStr,
+		AssetID:        cfltAssetID.String(),
+		IncludePartial: true,
+	}, balanceReply))
+	require.Equal(genesisBalance, uint64(balanceReply.Balance))
+
+	// The genesis allocation precedes every transaction.
+	err = s.GetBalanceAt(nil, &GetBalanceAtArgs{
+		JSONAddress: api.JSONAddress{Address: addrStr},
+		AssetID:     cfltAssetID.String(),
+		Height:      &genesisChange.Height,
+	}, &GetBalanceAtReply{})
+	require.Error(err)
+
+	// Burn one of the genesis UTXOs of the address in the first transaction.
+	acceptTime := time.Unix(1_600_000_000, 0)
+	vm.clock.Set(acceptTime)
+
+	ctx.Lock.Lock()
+	tx := NewTx(t, genesisBytes, vm)
+	_, err = vm.IssueTx(tx.Bytes())
+	require.NoError(err)
+
+	ctx.Lock.Unlock()
+	require.Equal(common.PendingTxs, <-issuer)
+	ctx.Lock.Lock()
+
+	pendingTxs := vm.PendingTxs(context.Background())
+	require.Len(pendingTxs, 1)
+	require.NoError(pendingTxs[0].Accept(context.Background()))
+
+	historyReply = &GetBalanceHistoryReply{}
+	require.NoError(s.GetBalanceHistory(nil, historyArgs, historyReply))
+	require.Len(historyReply.Changes, 2)
+	require.Equal(json.Uint64(2), historyReply.Cursor)
+	burnChange := historyReply.Changes[1]
+	require.Equal(tx.ID(), burnChange.TxID)
+	require.Zero(burnChange.Height)
+	require.Equal(json.Uint64(acceptTime.Unix()), burnChange.Timestamp)
+	require.Zero(burnChange.Received)
+	require.Equal(json.Uint64(startBalance), burnChange.Sent)
+	require.Equal(genesisBalance-startBalance, uint64(burnChange.Balance))
+
+	// Pages continue from the cursor.
+	historyArgs.Cursor = 1
+	historyArgs.PageSize = 1
+	historyReply = &GetBalanceHistoryReply{}
+	require.NoError(s.GetBalanceHistory(nil, historyArgs, historyReply))
+	require.Equal([]BalanceChange{burnChange}, historyReply.Changes)
+	require.Equal(json.Uint64(2), historyReply.Cursor)
+
+	historyArgs.PageSize = json.Uint64(maxPageSize + 1)
+	require.Error(s.GetBalanceHistory(nil, historyArgs, &GetBalanceHistoryReply{}))
+
+	tests := []struct {
+		name            string
+		height          *json.Uint64
+		time            *json.Uint64
+		expectedBalance uint64
+		shouldErr       bool
+	}{
+		{
+			name:            "burn height",
+			height:          &burnChange.Height,
+			expectedBalance: genesisBalance - startBalance,
+		},
+		{
+			name:      "unaccepted height",
+			height:    func() *json.Uint64 { h := burnChange.Height + 1; return &h }(),
+			shouldErr: true,
+		},
+		{
+			name:            "before burn",
+			time:            func() *json.Uint64 { t := burnChange.Timestamp - 1; return &t }(),
+			expectedBalance: genesisBalance,
+		},
+		{
+			name:            "genesis time",
+			time:            &genesisChange.Timestamp,
+			expectedBalance: genesisBalance,
+		},
+		{
+			name:            "burn time",
+			time:            &burnChange.Timestamp,
+			expectedBalance: genesisBalance - startBalance,
+		},
+		{
+			name:      "neither height nor time",
+			shouldErr: true,
+		},
+		{
+			name:      "both height and time",
+			height:    &burnChange.Height,
+			time:      &burnChange.Timestamp,
+			shouldErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(*testing.T) {
+			reply := &GetBalanceAtReply{}
+			err := s.GetBalanceAt(nil, &GetBalanceAtArgs{
+				JSONAddress: api.JSONAddress{Address: addrStr},
+				AssetID:     cfltAssetID.String(),
+				Height:      test.height,
+				Time:        test.time,
+			}, reply)
+			if test.shouldErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			require.Equal(test.expectedBalance, uint64(reply.Balance))
+		})
+	}
+}
+
+func TestGetBalanceAtJournalDisabled(t *testing.T) {
+	_, vm, s, _, genesisTx := setup(t, true)
+	defer func() {
+		require.NoError(t, vm.Shutdown(context.Background()))
+		vm.ctx.Lock.Unlock()
+	}()
+
+	addrStr, err := vm.FormatLocalAddress(keys[0].PublicKey().Address())
+	require.NoError(t, err)
+	height := json.Uint64(0)
+	err = s.GetBalanceAt(nil, &GetBalanceAtArgs{
+		JSONAddress: api.JSONAddress{Address: addrStr},
+		AssetID:     genesisTx.ID().String(),
+		Height:      &height,
+	}, &GetBalanceAtReply{})
+	require.Error(t, err)
+}
+
 func TestCreateFixedCapAsset(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
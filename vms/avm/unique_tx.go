@@ -154,6 +154,9 @@ func (tx *UniqueTx) Accept(context.Context) error {
 	if err := tx.vm.addressTxsIndexer.Accept(tx.ID(), inputUTXOs, outputUTXOs); err != nil {
 		return fmt.Errorf("error indexing tx: %w", err)
 	}
+	if err := tx.vm.balanceJournal.Accept(txID, tx.vm.clock.Time(), inputUTXOs, outputUTXOs); err != nil {
+		return fmt.Errorf("error journaling tx: %w", err)
+	}
 
 	// Remove spent utxos
 	for _, utxo := range inputUTXOIDs {
@@ -192,7 +195,7 @@ func (tx *UniqueTx) Accept(context.Context) error {
 		return fmt.Errorf("ExecuteWithSideEffects erred while processing tx %s: %w", txID, err)
 	}
 
-	tx.vm.pubsub.Publish(NewPubSubFilterer(tx.Tx))
+	tx.vm.pubsub.Publish(NewPubSubFilterer(tx.vm, tx.Tx))
 	tx.vm.walletService.decided(txID)
 
 	tx.deps = nil // Needed to prevent a memory leak
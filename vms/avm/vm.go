@@ -14,15 +14,15 @@ import (
 
 	stdjson "encoding/json"
 
-	"github.com/gorilla/rpc/v2"
-
 	"github.com/prometheus/client_golang/prometheus"
 
 	"go.uber.org/zap"
 
+	"github.com/coinflect/coinflectchain/api/openrpc"
 	"github.com/coinflect/coinflectchain/cache"
 	"github.com/coinflect/coinflectchain/database"
 	"github.com/coinflect/coinflectchain/database/manager"
+	"github.com/coinflect/coinflectchain/database/prefixdb"
 	"github.com/coinflect/coinflectchain/database/versiondb"
 	"github.com/coinflect/coinflectchain/ids"
 	"github.com/coinflect/coinflectchain/pubsub"
@@ -63,6 +63,8 @@ var (
 	errBootstrapping             = errors.New("chain is currently bootstrapping")
 	errInsufficientFunds         = errors.New("insufficient funds")
 
+	balanceJournalPrefix = []byte("balanceJournal")
+
 	_ vertex.DAGVM = (*VM)(nil)
 )
 
@@ -110,6 +112,7 @@ type VM struct {
 	walletService WalletService
 
 	addressTxsIndexer index.AddressTxsIndexer
+	balanceJournal    index.BalanceJournal
 
 	uniqueTxs cache.Deduplicator
 }
@@ -130,6 +133,7 @@ func (*VM) Disconnected(context.Context, ids.NodeID) error {
 
 type Config struct {
 	IndexTransactions    bool `json:"index-transactions"`
+	IndexBalances        bool `json:"index-balances"`
 	IndexAllowIncomplete bool `json:"index-allow-incomplete"`
 }
 
@@ -212,6 +216,23 @@ func (vm *VM) Initialize(
 
 	vm.state = state
 
+	// The balance journal is initialized before the genesis, so that the
+	// genesis allocations are journaled.
+	balanceJournalDB := prefixdb.New(balanceJournalPrefix, vm.db)
+	if avmConfig.IndexBalances {
+		vm.ctx.Log.Info("balance journaling is enabled")
+		vm.balanceJournal, err = index.NewBalanceJournal(balanceJournalDB, vm.ctx.Log, "", registerer, avmConfig.IndexAllowIncomplete)
+		if err != nil {
+			return fmt.Errorf("failed to initialize balance journal: %w", err)
+		}
+	} else {
+		vm.ctx.Log.Info("balance journaling is disabled")
+		vm.balanceJournal, err = index.NewNoBalanceJournal(balanceJournalDB, avmConfig.IndexAllowIncomplete)
+		if err != nil {
+			return fmt.Errorf("failed to initialize disabled balance journal: %w", err)
+		}
+	}
+
 	if err := vm.initGenesis(genesisBytes); err != nil {
 		return err
 	}
@@ -301,7 +322,7 @@ func (*VM) Version(context.Context) (string, error) {
 func (vm *VM) CreateHandlers(context.Context) (map[string]*common.HTTPHandler, error) {
 	codec := json.NewCodec()
 
-	rpcServer := rpc.NewServer()
+	rpcServer := openrpc.NewServer()
 	rpcServer.RegisterCodec(codec, "application/json")
 	rpcServer.RegisterCodec(codec, "application/json;charset=UTF-8")
 	rpcServer.RegisterInterceptFunc(vm.metrics.apiRequestMetric.InterceptRequest)
@@ -311,7 +332,7 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]*common.HTTPHandler, e
 		return nil, err
 	}
 
-	walletServer := rpc.NewServer()
+	walletServer := openrpc.NewServer()
 	walletServer.RegisterCodec(codec, "application/json")
 	walletServer.RegisterCodec(codec, "application/json;charset=UTF-8")
 	walletServer.RegisterInterceptFunc(vm.metrics.apiRequestMetric.InterceptRequest)
@@ -327,7 +348,7 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]*common.HTTPHandler, e
 }
 
 func (*VM) CreateStaticHandlers(context.Context) (map[string]*common.HTTPHandler, error) {
-	newServer := rpc.NewServer()
+	newServer := openrpc.NewServer()
 	codec := json.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
@@ -484,12 +505,13 @@ func (vm *VM) initState(tx txs.Tx) error {
 	if err := vm.state.PutStatus(txID, choices.Accepted); err != nil {
 		return err
 	}
-	for _, utxo := range tx.UTXOs() {
+	utxos := tx.UTXOs()
+	for _, utxo := range utxos {
 		if err := vm.state.PutUTXO(utxo); err != nil {
 			return err
 		}
 	}
-	return nil
+	return vm.balanceJournal.AcceptGenesis(txID, utxos)
 }
 
 func (vm *VM) parseTx(bytes []byte) (*UniqueTx, error) {
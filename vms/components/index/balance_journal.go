@@ -0,0 +1,491 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package index
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"github.com/coinflect/coinflectchain/database"
+	"github.com/coinflect/coinflectchain/database/prefixdb"
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/utils/hashing"
+	"github.com/coinflect/coinflectchain/utils/logging"
+	"github.com/coinflect/coinflectchain/utils/math"
+	"github.com/coinflect/coinflectchain/utils/wrappers"
+	"github.com/coinflect/coinflectchain/vms/components/cflt"
+)
+
+const balanceChangeLen = 5*wrappers.LongLen + hashing.HashLen
+
+var (
+	heightKey    = []byte("height")
+	timestampKey = []byte("timestamp")
+
+	errBalanceJournalDisabled = errors.New("balance journaling is disabled")
+	errHeightNotAccepted      = errors.New("no transaction has been accepted at height")
+
+	_ BalanceJournal = (*balanceJournal)(nil)
+	_ BalanceJournal = (*noBalanceJournal)(nil)
+)
+
+// BalanceChange is a change that an accepted transaction made to an address's
+// balance of an asset.
+type BalanceChange struct {
+	// TxID is the transaction that changed the balance
+	TxID ids.ID
+	// Height is the index of [TxID] in the chain's transaction index, which
+	// is the number of transactions the chain accepted before [TxID], not
+	// counting the genesis transactions. The changes made by the genesis
+	// transactions have height 0.
+	Height uint64
+	// Timestamp is the time this node accepted [TxID] at, in seconds since the
+	// Unix epoch. Transactions accepted while bootstrapping have the time they
+	// were bootstrapped at. The changes made by the genesis transactions have
+	// timestamp 0.
+	Timestamp uint64
+	// Received is the amount that the outputs of [TxID] sent to the address
+	Received uint64
+	// Sent is the amount that the inputs of [TxID] spent from the address
+	Sent uint64
+	// Balance is the address's balance once [TxID] was accepted
+	Balance uint64
+}
+
+// BalanceJournal records how each accepted transaction changed the balances of
+// the addresses it touches, so that an address's balance of an asset can be
+// queried as of any point in the chain's history.
+// An address's balance of an asset is the sum of the amounts of the unspent
+// UTXOs of the asset that the address at least partially owns, regardless of
+// their locktime. UTXOs that don't have an amount are ignored.
+// If the journal is incomplete, the balances only reflect the journaled
+// transactions and the heights don't match the transaction index.
+type BalanceJournal interface {
+	// AcceptGenesis is called when the genesis transaction [txID] is
+	// accepted. [outputUTXOs] are the UTXOs [txID] creates.
+	// Genesis transactions precede every other transaction, so they don't
+	// advance the height.
+	AcceptGenesis(txID ids.ID, outputUTXOs []*cflt.UTXO) error
+
+	// Accept is called when [txID] is accepted at [timestamp].
+	// [inputUTXOs] are the UTXOs [txID] consumes.
+	// [outputUTXOs] are the UTXOs [txID] creates.
+	// If the error is non-nil, do not persist [txID] to disk as accepted in the VM
+	Accept(
+		txID ids.ID,
+		timestamp time.Time,
+		inputUTXOs []*cflt.UTXO,
+		outputUTXOs []*cflt.UTXO,
+	) error
+
+	// BalanceAtHeight returns [address]'s balance of [assetID] once the
+	// transaction at [height] of the transaction index was accepted.
+	BalanceAtHeight(address []byte, assetID ids.ID, height uint64) (uint64, error)
+
+	// BalanceAtTime returns [address]'s balance of [assetID] once all the
+	// transactions accepted at or before [timestamp] were accepted.
+	BalanceAtTime(address []byte, assetID ids.ID, timestamp time.Time) (uint64, error)
+
+	// History returns the changes to [address]'s balance of [assetID], in
+	// order of increasing height.
+	// The length of the returned slice <= [pageSize].
+	// [cursor] is the offset to start reading from.
+	History(address []byte, assetID ids.ID, cursor, pageSize uint64) ([]BalanceChange, error)
+}
+
+type balanceJournal struct {
+	log     logging.Logger
+	metrics journalMetrics
+	db      database.Database
+}
+
+// NewBalanceJournal returns a new BalanceJournal that persists into [db].
+// [db] must not be shared with an AddressTxsIndexer.
+func NewBalanceJournal(
+	db database.Database,
+	log logging.Logger,
+	metricsNamespace string,
+	metricsRegisterer prometheus.Registerer,
+	allowIncompleteIndices bool,
+) (BalanceJournal, error) {
+	j := &balanceJournal{
+		db:  db,
+		log: log,
+	}
+	if err := checkIndexStatus(j.db, true, allowIncompleteIndices); err != nil {
+		return nil, err
+	}
+	if err := j.metrics.initialize(metricsNamespace, metricsRegisterer); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// AcceptGenesis persists how [txID] changed the balances of the addresses it
+// touches, with the height of the first transaction and timestamp 0.
+// See interface documentation BalanceJournal.AcceptGenesis
+func (j *balanceJournal) AcceptGenesis(txID ids.ID, outputUTXOs []*cflt.UTXO) error {
+	height, err := getUint64(j.db, heightKey)
+	if err != nil {
+		return fmt.Errorf("failed to get the height while journaling %s: %w", txID, err)
+	}
+	if err := j.journal(txID, height, 0, nil, outputUTXOs); err != nil {
+		return err
+	}
+	j.metrics.numTxsJournaled.Inc()
+	return nil
+}
+
+// Accept persists how [txID] changed the balances of the addresses it touches.
+// The database structure is:
+// "height"    => 3		Height of the next transaction to be accepted
+// "timestamp" => 1668000000	Timestamp of the last accepted transaction
+// [address]
+// |  [assetID]
+// |  |
+// |  | "idx" => 2 		Running balance change index key, represents the next index
+// |  | "0"   => change1	Encoded BalanceChange
+// |  | "1"   => change2
+// See interface documentation BalanceJournal.Accept
+func (j *balanceJournal) Accept(
+	txID ids.ID,
+	timestamp time.Time,
+	inputUTXOs []*cflt.UTXO,
+	outputUTXOs []*cflt.UTXO,
+) error {
+	height, err := getUint64(j.db, heightKey)
+	if err != nil {
+		return fmt.Errorf("failed to get the height while journaling %s: %w", txID, err)
+	}
+	lastTimestamp, err := getUint64(j.db, timestampKey)
+	if err != nil {
+		return fmt.Errorf("failed to get the timestamp while journaling %s: %w", txID, err)
+	}
+	// Timestamps never decrease, so that balances can be searched for by time.
+	unixTimestamp := math.Max(uint64(timestamp.Unix()), lastTimestamp)
+	if err := j.journal(txID, height, unixTimestamp, inputUTXOs, outputUTXOs); err != nil {
+		return err
+	}
+
+	if err := j.db.Put(heightKey, uint64Bytes(height+1)); err != nil {
+		return fmt.Errorf("failed to write height while journaling %s: %w", txID, err)
+	}
+	if err := j.db.Put(timestampKey, uint64Bytes(unixTimestamp)); err != nil {
+		return fmt.Errorf("failed to write timestamp while journaling %s: %w", txID, err)
+	}
+	j.metrics.numTxsJournaled.Inc()
+	return nil
+}
+
+// journal persists the changes that [txID], accepted at [height] and
+// [unixTimestamp], made to the balances of the addresses it touches.
+func (j *balanceJournal) journal(
+	txID ids.ID,
+	height uint64,
+	unixTimestamp uint64,
+	inputUTXOs []*cflt.UTXO,
+	outputUTXOs []*cflt.UTXO,
+) error {
+	// Address -> AssetID -> the change of the address's balance of the asset
+	balanceChanges := make(map[string]map[ids.ID]*BalanceChange)
+	addChanges := func(utxos []*cflt.UTXO, sent bool) error {
+		for _, utxo := range utxos {
+			amounter, ok := utxo.Out.(cflt.Amounter)
+			if !ok {
+				continue
+			}
+			addressable, ok := utxo.Out.(cflt.Addressable)
+			if !ok {
+				j.log.Verbo("skipping UTXO for journaling",
+					zap.Stringer("utxoID", utxo.InputID()),
+				)
+				continue
+			}
+
+			amount := amounter.Amount()
+			assetID := utxo.AssetID()
+			for _, addressBytes := range addressable.Addresses() {
+				address := string(addressBytes)
+
+				addressChanges, exists := balanceChanges[address]
+				if !exists {
+					addressChanges = make(map[ids.ID]*BalanceChange)
+					balanceChanges[address] = addressChanges
+				}
+				change, exists := addressChanges[assetID]
+				if !exists {
+					change = &BalanceChange{
+						TxID:      txID,
+						Height:    height,
+						Timestamp: unixTimestamp,
+					}
+					addressChanges[assetID] = change
+				}
+
+				var err error
+				if sent {
+					change.Sent, err = math.Add64(change.Sent, amount)
+				} else {
+					change.Received, err = math.Add64(change.Received, amount)
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := addChanges(inputUTXOs, true); err != nil {
+		return fmt.Errorf("failed to sum the inputs of %s: %w", txID, err)
+	}
+	if err := addChanges(outputUTXOs, false); err != nil {
+		return fmt.Errorf("failed to sum the outputs of %s: %w", txID, err)
+	}
+
+	for address, assetChanges := range balanceChanges {
+		addressPrefixDB := prefixdb.New([]byte(address), j.db)
+		for assetID, change := range assetChanges {
+			assetPrefixDB := prefixdb.New(assetID[:], addressPrefixDB)
+
+			idx, err := getUint64(assetPrefixDB, idxKey)
+			if err != nil {
+				return fmt.Errorf("unexpected error when journaling txID %s: %w", txID, err)
+			}
+
+			var balance uint64
+			if idx > 0 {
+				last, err := getBalanceChange(assetPrefixDB, idx-1)
+				if err != nil {
+					return fmt.Errorf("failed to get the last balance change while journaling %s: %w", txID, err)
+				}
+				balance = last.Balance
+			}
+			balance, err = math.Add64(balance, change.Received)
+			if err != nil {
+				return fmt.Errorf("balance overflow while journaling %s: %w", txID, err)
+			}
+			// If the journal is incomplete, the address may spend funds whose
+			// receipt wasn't journaled.
+			change.Balance, err = math.Sub(balance, change.Sent)
+			if err != nil {
+				j.log.Debug("balance underflow while journaling",
+					zap.String("address", address),
+					zap.Stringer("assetID", assetID),
+					zap.Stringer("txID", txID),
+				)
+				change.Balance = 0
+			}
+
+			j.log.Verbo("writing balance change to DB",
+				zap.String("address", address),
+				zap.Stringer("assetID", assetID),
+				zap.Uint64("index", idx),
+				zap.Stringer("txID", txID),
+				zap.Uint64("balance", change.Balance),
+			)
+			if err := assetPrefixDB.Put(uint64Bytes(idx), marshalBalanceChange(change)); err != nil {
+				return fmt.Errorf("failed to write balance change while journaling %s: %w", txID, err)
+			}
+			if err := assetPrefixDB.Put(idxKey, uint64Bytes(idx+1)); err != nil {
+				return fmt.Errorf("failed to write index while journaling %s: %w", txID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// BalanceAtHeight returns the balance of the last change to [address]'s
+// balance of [assetID] at or before [height].
+// See BalanceJournal
+func (j *balanceJournal) BalanceAtHeight(address []byte, assetID ids.ID, height uint64) (uint64, error) {
+	nextHeight, err := getUint64(j.db, heightKey)
+	if err != nil {
+		return 0, err
+	}
+	if height >= nextHeight {
+		return 0, fmt.Errorf("%w %d", errHeightNotAccepted, height)
+	}
+	return j.balanceAt(address, assetID, func(change *BalanceChange) bool {
+		return change.Height > height
+	})
+}
+
+// BalanceAtTime returns the balance of the last change to [address]'s balance
+// of [assetID] at or before [timestamp].
+// See BalanceJournal
+func (j *balanceJournal) BalanceAtTime(address []byte, assetID ids.ID, timestamp time.Time) (uint64, error) {
+	unixTimestamp := timestamp.Unix()
+	return j.balanceAt(address, assetID, func(change *BalanceChange) bool {
+		return int64(change.Timestamp) > unixTimestamp
+	})
+}
+
+// balanceAt returns the balance of the change preceding the first change of
+// [address]'s balance of [assetID] that [after] returns true for. [after] must
+// return false for a prefix of the changes, and true for the rest.
+func (j *balanceJournal) balanceAt(
+	address []byte,
+	assetID ids.ID,
+	after func(*BalanceChange) bool,
+) (uint64, error) {
+	addressPrefixDB := prefixdb.New(address, j.db)
+	assetPrefixDB := prefixdb.New(assetID[:], addressPrefixDB)
+
+	numChanges, err := getUint64(assetPrefixDB, idxKey)
+	if err != nil {
+		return 0, err
+	}
+
+	var searchErr error
+	idx := sort.Search(int(numChanges), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		change, err := getBalanceChange(assetPrefixDB, uint64(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return after(change)
+	})
+	if searchErr != nil {
+		return 0, searchErr
+	}
+	if idx == 0 {
+		// The address didn't hold any of the asset yet.
+		return 0, nil
+	}
+
+	change, err := getBalanceChange(assetPrefixDB, uint64(idx-1))
+	if err != nil {
+		return 0, err
+	}
+	return change.Balance, nil
+}
+
+// History returns the changes to [address]'s balance of [assetID], starting at
+// [cursor], in order of transaction acceptance.
+// Returns at most [pageSize] elements.
+// See BalanceJournal
+func (j *balanceJournal) History(address []byte, assetID ids.ID, cursor, pageSize uint64) ([]BalanceChange, error) {
+	addressPrefixDB := prefixdb.New(address, j.db)
+	assetPrefixDB := prefixdb.New(assetID[:], addressPrefixDB)
+
+	// numeric keys maintain the order (see Accept)
+	iter := assetPrefixDB.NewIteratorWithStart(uint64Bytes(cursor))
+	defer iter.Release()
+
+	var changes []BalanceChange
+	for uint64(len(changes)) < pageSize && iter.Next() {
+		if bytes.Equal(idxKey, iter.Key()) {
+			// This key has the next index to use, not a balance change
+			continue
+		}
+
+		change, err := unmarshalBalanceChange(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, *change)
+	}
+	return changes, iter.Error()
+}
+
+type noBalanceJournal struct{}
+
+func NewNoBalanceJournal(db database.Database, allowIncomplete bool) (BalanceJournal, error) {
+	return &noBalanceJournal{}, checkIndexStatus(db, false, allowIncomplete)
+}
+
+func (*noBalanceJournal) AcceptGenesis(ids.ID, []*cflt.UTXO) error {
+	return nil
+}
+
+func (*noBalanceJournal) Accept(ids.ID, time.Time, []*cflt.UTXO, []*cflt.UTXO) error {
+	return nil
+}
+
+func (*noBalanceJournal) BalanceAtHeight([]byte, ids.ID, uint64) (uint64, error) {
+	return 0, errBalanceJournalDisabled
+}
+
+func (*noBalanceJournal) BalanceAtTime([]byte, ids.ID, time.Time) (uint64, error) {
+	return 0, errBalanceJournalDisabled
+}
+
+func (*noBalanceJournal) History([]byte, ids.ID, uint64, uint64) ([]BalanceChange, error) {
+	return nil, errBalanceJournalDisabled
+}
+
+// getUint64 returns the value of [key], or 0 if it doesn't exist.
+func getUint64(db database.KeyValueReader, key []byte) (uint64, error) {
+	valueBytes, err := db.Get(key)
+	switch err {
+	case nil:
+		if len(valueBytes) != wrappers.LongLen {
+			return 0, fmt.Errorf("expected %d bytes but got %d", wrappers.LongLen, len(valueBytes))
+		}
+		return binary.BigEndian.Uint64(valueBytes), nil
+	case database.ErrNotFound:
+		return 0, nil
+	default:
+		return 0, err
+	}
+}
+
+func uint64Bytes(value uint64) []byte {
+	valueBytes := make([]byte, wrappers.LongLen)
+	binary.BigEndian.PutUint64(valueBytes, value)
+	return valueBytes
+}
+
+func getBalanceChange(db database.KeyValueReader, idx uint64) (*BalanceChange, error) {
+	changeBytes, err := db.Get(uint64Bytes(idx))
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalBalanceChange(changeBytes)
+}
+
+func marshalBalanceChange(change *BalanceChange) []byte {
+	p := wrappers.Packer{Bytes: make([]byte, balanceChangeLen)}
+	p.PackLong(change.Height)
+	p.PackLong(change.Timestamp)
+	p.PackFixedBytes(change.TxID[:])
+	p.PackLong(change.Received)
+	p.PackLong(change.Sent)
+	p.PackLong(change.Balance)
+	return p.Bytes
+}
+
+func unmarshalBalanceChange(changeBytes []byte) (*BalanceChange, error) {
+	if len(changeBytes) != balanceChangeLen {
+		return nil, fmt.Errorf("expected %d bytes but got %d", balanceChangeLen, len(changeBytes))
+	}
+	p := wrappers.Packer{Bytes: changeBytes}
+	change := &BalanceChange{
+		Height:    p.UnpackLong(),
+		Timestamp: p.UnpackLong(),
+	}
+	txIDBytes := p.UnpackFixedBytes(hashing.HashLen)
+	change.Received = p.UnpackLong()
+	change.Sent = p.UnpackLong()
+	change.Balance = p.UnpackLong()
+	if p.Err != nil {
+		return nil, p.Err
+	}
+
+	var err error
+	change.TxID, err = ids.ToID(txIDBytes)
+	return change, err
+}
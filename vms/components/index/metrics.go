@@ -20,3 +20,16 @@ func (m *metrics) initialize(namespace string, registerer prometheus.Registerer)
 	})
 	return registerer.Register(m.numTxsIndexed)
 }
+
+type journalMetrics struct {
+	numTxsJournaled prometheus.Counter
+}
+
+func (m *journalMetrics) initialize(namespace string, registerer prometheus.Registerer) error {
+	m.numTxsJournaled = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "txs_journaled",
+		Help:      "Number of transactions whose balance changes were journaled",
+	})
+	return registerer.Register(m.numTxsJournaled)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/coinflect/coinflectchain/database/prefixdb"
 	"github.com/coinflect/coinflectchain/database/versiondb"
 	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/pubsub"
 	"github.com/coinflect/coinflectchain/snow"
 	"github.com/coinflect/coinflectchain/snow/engine/common"
 	"github.com/coinflect/coinflectchain/snow/uptime"
@@ -189,6 +190,8 @@ func newEnvironment(t *testing.T) *environment {
 		res.state,
 		&res.backend,
 		window,
+		pubsub.New(res.ctx.Log),
+		nil,
 	)
 
 	res.Builder = New(
@@ -10,9 +10,12 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/pubsub"
 	"github.com/coinflect/coinflectchain/snow/choices"
 	"github.com/coinflect/coinflectchain/utils"
 	"github.com/coinflect/coinflectchain/utils/window"
+	"github.com/coinflect/coinflectchain/vms/components/cflt"
+	"github.com/coinflect/coinflectchain/vms/components/index"
 	"github.com/coinflect/coinflectchain/vms/platformvm/blocks"
 	"github.com/coinflect/coinflectchain/vms/platformvm/metrics"
 	"github.com/coinflect/coinflectchain/vms/platformvm/state"
@@ -28,6 +31,12 @@ type acceptor struct {
 	metrics          metrics.Metrics
 	recentlyAccepted window.Window[ids.ID]
 	bootstrapped     *utils.AtomicBool
+	pubsub           *pubsub.Server
+	addrManager      cflt.AddressManager
+
+	// addressTxsIndexer, if not nil, indexes the accepted transactions by the
+	// addresses whose balances they change.
+	addressTxsIndexer index.AddressTxsIndexer
 }
 
 func (a *acceptor) BanffAbortBlock(b *blocks.BanffAbortBlock) error {
@@ -149,10 +158,14 @@ func (a *acceptor) ApricotAtomicBlock(b *blocks.ApricotAtomicBlock) error {
 		return fmt.Errorf("couldn't find state of block %s", blkID)
 	}
 
+	defer a.state.Abort()
+	if err := a.index(b, blkState.onAcceptState); err != nil {
+		return err
+	}
+
 	// Update the state to reflect the changes made in [onAcceptState].
 	blkState.onAcceptState.Apply(a.state)
 
-	defer a.state.Abort()
 	batch, err := a.state.CommitBatch()
 	if err != nil {
 		return fmt.Errorf(
@@ -171,6 +184,8 @@ func (a *acceptor) ApricotAtomicBlock(b *blocks.ApricotAtomicBlock) error {
 			err,
 		)
 	}
+
+	a.publish(b)
 	return nil
 }
 
@@ -207,7 +222,14 @@ func (a *acceptor) commitBlock(b blocks.Block) error {
 		}
 	}
 
-	return a.optionBlock(b, parentState.statelessBlock)
+	if err := a.optionBlock(b, parentState.statelessBlock); err != nil {
+		return err
+	}
+
+	// The transaction of the proposal block is only published once it's
+	// committed.
+	a.publish(parentState.statelessBlock)
+	return nil
 }
 
 func (a *acceptor) optionBlock(b, parent blocks.Block) error {
@@ -234,6 +256,13 @@ func (a *acceptor) optionBlock(b, parent blocks.Block) error {
 	if !ok {
 		return fmt.Errorf("couldn't find state of block %s", blkID)
 	}
+
+	// The transactions of the proposal block are accepted with the changes
+	// of the accepted option.
+	if err := a.index(parent, blkState.onAcceptState); err != nil {
+		a.state.Abort()
+		return err
+	}
 	blkState.onAcceptState.Apply(a.state)
 	return a.state.Commit()
 }
@@ -271,10 +300,14 @@ func (a *acceptor) standardBlock(b blocks.Block) error {
 		return fmt.Errorf("couldn't find state of block %s", blkID)
 	}
 
+	defer a.state.Abort()
+	if err := a.index(b, blkState.onAcceptState); err != nil {
+		return err
+	}
+
 	// Update the state to reflect the changes made in [onAcceptState].
 	blkState.onAcceptState.Apply(a.state)
 
-	defer a.state.Abort()
 	batch, err := a.state.CommitBatch()
 	if err != nil {
 		return fmt.Errorf(
@@ -292,6 +325,8 @@ func (a *acceptor) standardBlock(b blocks.Block) error {
 	if onAcceptFunc := blkState.onAcceptFunc; onAcceptFunc != nil {
 		onAcceptFunc()
 	}
+
+	a.publish(b)
 	return nil
 }
 
@@ -309,3 +344,12 @@ func (a *acceptor) commonAccept(b blocks.Block) error {
 	a.recentlyAccepted.Add(blkID)
 	return nil
 }
+
+// publish sends the transactions of the accepted block [b] to the connections
+// whose filter matches them.
+func (a *acceptor) publish(b blocks.Block) {
+	height := b.Height()
+	for _, tx := range b.Txs() {
+		a.pubsub.Publish(NewPubSubFilterer(a.addrManager, height, tx))
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/coinflect/coinflectchain/chains/atomic"
 	"github.com/coinflect/coinflectchain/database"
 	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/pubsub"
 	"github.com/coinflect/coinflectchain/snow"
 	"github.com/coinflect/coinflectchain/snow/choices"
 	"github.com/coinflect/coinflectchain/utils"
@@ -100,6 +101,7 @@ func TestAcceptorVisitAtomicBlock(t *testing.T) {
 			},
 		},
 		metrics: metrics.Noop,
+		pubsub:  pubsub.New(logging.NoLog{}),
 		recentlyAccepted: window.New[ids.ID](window.Config{
 			Clock:   &mockable.Clock{},
 			MaxSize: 1,
@@ -185,6 +187,7 @@ func TestAcceptorVisitStandardBlock(t *testing.T) {
 			},
 		},
 		metrics: metrics.Noop,
+		pubsub:  pubsub.New(logging.NoLog{}),
 		recentlyAccepted: window.New[ids.ID](window.Config{
 			Clock:   clk,
 			MaxSize: 1,
@@ -280,6 +283,7 @@ func TestAcceptorVisitCommitBlock(t *testing.T) {
 			},
 		},
 		metrics: metrics.Noop,
+		pubsub:  pubsub.New(logging.NoLog{}),
 		recentlyAccepted: window.New[ids.ID](window.Config{
 			Clock:   &mockable.Clock{},
 			MaxSize: 1,
@@ -343,6 +347,11 @@ func TestAcceptorVisitCommitBlock(t *testing.T) {
 
 		onAcceptState.EXPECT().Apply(s).Times(1),
 		s.EXPECT().Commit().Return(nil).Times(1),
+
+		// The transaction of the proposal block is published once it's
+		// committed.
+		parentStatelessBlk.EXPECT().Height().Return(blk.Height()-1).Times(1),
+		parentStatelessBlk.EXPECT().Txs().Return(nil).Times(1),
 	)
 
 	err = acceptor.ApricotCommitBlock(blk)
@@ -370,6 +379,7 @@ func TestAcceptorVisitAbortBlock(t *testing.T) {
 			},
 		},
 		metrics: metrics.Noop,
+		pubsub:  pubsub.New(logging.NoLog{}),
 		recentlyAccepted: window.New[ids.ID](window.Config{
 			Clock:   &mockable.Clock{},
 			MaxSize: 1,
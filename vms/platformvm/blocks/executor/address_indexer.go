@@ -0,0 +1,203 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/vms/components/cflt"
+	"github.com/coinflect/coinflectchain/vms/components/verify"
+	"github.com/coinflect/coinflectchain/vms/platformvm/blocks"
+	"github.com/coinflect/coinflectchain/vms/platformvm/state"
+	"github.com/coinflect/coinflectchain/vms/platformvm/txs"
+)
+
+// index records which addresses the transactions of [b], accepted with the
+// changes of [onAcceptState], changed the balances of. It must be called before
+// [onAcceptState] is applied, as the UTXOs the transactions consume are read
+// from the last accepted state.
+func (a *acceptor) index(b blocks.Block, onAcceptState state.Chain) error {
+	if a.addressTxsIndexer == nil {
+		return nil
+	}
+
+	// A transaction may consume the UTXOs produced by an earlier transaction
+	// of the same block, which aren't in the last accepted state.
+	produced := make(map[ids.ID]*cflt.UTXO)
+	for _, tx := range b.Txs() {
+		txID := tx.ID()
+		inputUTXOs, err := a.inputUTXOs(tx, produced)
+		if err != nil {
+			return fmt.Errorf("failed to get the inputs of tx %s: %w", txID, err)
+		}
+
+		utxos := tx.UTXOs()
+		for _, utxo := range utxos {
+			produced[utxo.InputID()] = utxo
+		}
+
+		outputUTXOs, err := a.outputUTXOs(tx, onAcceptState)
+		if err != nil {
+			return fmt.Errorf("failed to get the outputs of tx %s: %w", txID, err)
+		}
+		outputUTXOs = append(outputUTXOs, utxos...)
+
+		if err := a.addressTxsIndexer.Accept(txID, inputUTXOs, outputUTXOs); err != nil {
+			return fmt.Errorf("failed to index tx %s: %w", txID, err)
+		}
+	}
+	return nil
+}
+
+// inputUTXOs returns the UTXOs that [tx] consumes, including the ones it
+// imports from shared memory.
+func (a *acceptor) inputUTXOs(tx *txs.Tx, produced map[ids.ID]*cflt.UTXO) ([]*cflt.UTXO, error) {
+	utxoIDs := tx.Unsigned.InputIDs()
+	utxos := make([]*cflt.UTXO, 0, utxoIDs.Len())
+	if importTx, ok := tx.Unsigned.(*txs.ImportTx); ok {
+		importedIDs := importTx.InputUTXOs()
+		importedUTXOs, err := a.importedUTXOs(importTx.SourceChain, importedIDs)
+		if err != nil {
+			return nil, err
+		}
+		utxos = append(utxos, importedUTXOs...)
+		utxoIDs.Difference(importedIDs)
+	}
+
+	for utxoID := range utxoIDs {
+		if utxo, ok := produced[utxoID]; ok {
+			utxos = append(utxos, utxo)
+			continue
+		}
+
+		utxo, err := a.state.GetUTXO(utxoID)
+		if err != nil {
+			// should never happen because the UTXO was verified to exist
+			return nil, fmt.Errorf("failed to get UTXO %s: %w", utxoID, err)
+		}
+		utxos = append(utxos, utxo)
+	}
+	return utxos, nil
+}
+
+// importedUTXOs returns the UTXOs [utxoIDs] exported to this chain by
+// [sourceChain].
+func (a *acceptor) importedUTXOs(sourceChain ids.ID, utxoIDs ids.Set) ([]*cflt.UTXO, error) {
+	keys := make([][]byte, 0, utxoIDs.Len())
+	for utxoID := range utxoIDs {
+		utxoID := utxoID
+		keys = append(keys, utxoID[:])
+	}
+
+	allUTXOBytes, err := a.ctx.SharedMemory.Get(sourceChain, keys)
+	if err != nil {
+		if a.bootstrapped.GetValue() {
+			return nil, fmt.Errorf("failed to get shared memory: %w", err)
+		}
+
+		// While bootstrapping, the imported UTXOs aren't verified to be in
+		// shared memory, so only the addresses of the UTXOs the import
+		// produces are indexed.
+		a.ctx.Log.Debug("skipping indexing imported UTXOs",
+			zap.Stringer("sourceChain", sourceChain),
+			zap.Error(err),
+		)
+		return nil, nil
+	}
+
+	utxos := make([]*cflt.UTXO, len(allUTXOBytes))
+	for i, utxoBytes := range allUTXOBytes {
+		utxo := &cflt.UTXO{}
+		if _, err := txs.Codec.Unmarshal(utxoBytes, utxo); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal UTXO: %w", err)
+		}
+		utxos[i] = utxo
+	}
+	return utxos, nil
+}
+
+// outputUTXOs returns the UTXOs that [tx] produces, or registers to be
+// produced, other than the ones in its outputs:
+// - the UTXOs it exports
+// - the UTXOs it stakes, and the owners of the rewards for staking them
+// - the staked UTXOs it returns and the reward UTXOs it issues
+func (a *acceptor) outputUTXOs(tx *txs.Tx, onAcceptState state.Chain) ([]*cflt.UTXO, error) {
+	txID := tx.ID()
+	switch utx := tx.Unsigned.(type) {
+	case *txs.ExportTx:
+		return newUTXOs(txID, len(utx.Outs), utx.ExportedOutputs), nil
+	case *txs.RewardValidatorTx:
+		stakerTx, _, err := a.state.GetTx(utx.TxID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get staker tx %s: %w", utx.TxID, err)
+		}
+		staker, ok := stakerTx.Unsigned.(txs.PermissionlessStaker)
+		if !ok {
+			return nil, fmt.Errorf("staker tx %s has unexpected type %T", utx.TxID, stakerTx.Unsigned)
+		}
+
+		// Note that the returned stake and the rewards are UTXOs of the staker
+		// tx rather than of [tx].
+		utxos := newUTXOs(utx.TxID, len(staker.Outputs()), staker.Stake())
+		rewardUTXOs, err := onAcceptState.GetRewardUTXOs(utx.TxID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the reward UTXOs of %s: %w", utx.TxID, err)
+		}
+		return append(utxos, rewardUTXOs...), nil
+	case txs.PermissionlessStaker:
+		stake := utx.Stake()
+		utxos := newUTXOs(txID, len(utx.Outputs()), stake)
+		if len(stake) == 0 {
+			return utxos, nil
+		}
+
+		// Invariant: The staked asset must be equal to the reward asset.
+		rewardAsset := stake[0].Asset
+		switch utx := utx.(type) {
+		case txs.Validator:
+			utxos = appendOwner(utxos, txID, rewardAsset, utx.ValidationRewardsOwner())
+			utxos = appendOwner(utxos, txID, rewardAsset, utx.DelegationRewardsOwner())
+		case txs.Delegator:
+			utxos = appendOwner(utxos, txID, rewardAsset, utx.RewardsOwner())
+		}
+		return utxos, nil
+	default:
+		return nil, nil
+	}
+}
+
+// newUTXOs returns the UTXOs of [outs], produced by [txID] starting at
+// [offset].
+func newUTXOs(txID ids.ID, offset int, outs []*cflt.TransferableOutput) []*cflt.UTXO {
+	utxos := make([]*cflt.UTXO, len(outs))
+	for i, out := range outs {
+		utxos[i] = &cflt.UTXO{
+			UTXOID: cflt.UTXOID{
+				TxID:        txID,
+				OutputIndex: uint32(offset + i),
+			},
+			Asset: out.Asset,
+			Out:   out.Output(),
+		}
+	}
+	return utxos
+}
+
+// appendOwner adds [owner] to [utxos] as though it held an amount of [asset],
+// so that its addresses are indexed for the rewards it may receive.
+func appendOwner(utxos []*cflt.UTXO, txID ids.ID, asset cflt.Asset, owner interface{}) []*cflt.UTXO {
+	out, ok := owner.(verify.State)
+	if !ok {
+		return utxos
+	}
+	return append(utxos, &cflt.UTXO{
+		UTXOID: cflt.UTXOID{TxID: txID},
+		Asset:  asset,
+		Out:    out,
+	})
+}
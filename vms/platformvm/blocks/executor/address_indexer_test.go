@@ -0,0 +1,167 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/snow/consensus/snowman"
+	"github.com/coinflect/coinflectchain/utils/crypto"
+	"github.com/coinflect/coinflectchain/utils/logging"
+	"github.com/coinflect/coinflectchain/vms/components/index"
+	"github.com/coinflect/coinflectchain/vms/platformvm/blocks"
+	"github.com/coinflect/coinflectchain/vms/platformvm/reward"
+	"github.com/coinflect/coinflectchain/vms/platformvm/state"
+	"github.com/coinflect/coinflectchain/vms/platformvm/status"
+	"github.com/coinflect/coinflectchain/vms/platformvm/txs"
+)
+
+func newIndexedEnvironment(t *testing.T) (*environment, index.AddressTxsIndexer) {
+	env := newEnvironment(t, nil)
+	env.config.BanffTime = time.Time{} // activate Banff
+
+	indexer, err := index.NewIndexer(
+		env.state.AddressTxsDB(),
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		true,
+	)
+	require.NoError(t, err)
+	env.blkManager.(*manager).acceptor.(*acceptor).addressTxsIndexer = indexer
+	return env, indexer
+}
+
+func TestAddressIndexerStandardBlock(t *testing.T) {
+	require := require.New(t)
+	env, indexer := newIndexedEnvironment(t)
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	// Make the subnet creation consume the payer's funds.
+	env.config.CreateAssetTxFee = defaultTxFee
+
+	var (
+		payer      = preFundedKeys[0].PublicKey().Address()
+		owner      = ids.GenerateTestShortID()
+		changeAddr = ids.GenerateTestShortID()
+	)
+	tx, err := env.txBuilder.NewCreateSubnetTx(
+		1,
+		[]ids.ShortID{owner},
+		[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+		changeAddr,
+	)
+	require.NoError(err)
+
+	parentID := env.state.GetLastAccepted()
+	parentBlk, _, err := env.state.GetStatelessBlock(parentID)
+	require.NoError(err)
+	statelessBlk, err := blocks.NewBanffStandardBlock(
+		env.state.GetTimestamp(),
+		parentID,
+		parentBlk.Height()+1,
+		[]*txs.Tx{tx},
+	)
+	require.NoError(err)
+	blk := env.blkManager.NewBlock(statelessBlk)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+
+	// The payer's consumed UTXOs and the change are indexed.
+	for _, addr := range []ids.ShortID{payer, changeAddr} {
+		txIDs, err := indexer.Read(addr[:], env.ctx.CFLTAssetID, 0, 10)
+		require.NoError(err)
+		require.Equal([]ids.ID{tx.ID()}, txIDs)
+	}
+
+	// The owners of the subnet don't hold any funds.
+	txIDs, err := indexer.Read(owner[:], env.ctx.CFLTAssetID, 0, 10)
+	require.NoError(err)
+	require.Empty(txIDs)
+}
+
+func TestAddressIndexerRewardValidator(t *testing.T) {
+	require := require.New(t)
+	env, indexer := newIndexedEnvironment(t)
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	var (
+		rewardAddr = ids.GenerateTestShortID()
+		changeAddr = ids.GenerateTestShortID()
+		startTime  = defaultValidateStartTime
+		endTime    = startTime.Add(defaultMinStakingDuration)
+	)
+	addValidatorTx, err := env.txBuilder.NewAddValidatorTx(
+		env.config.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		ids.GenerateTestNodeID(),
+		rewardAddr,
+		reward.PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+		changeAddr,
+	)
+	require.NoError(err)
+
+	staker, err := state.NewCurrentStaker(
+		addValidatorTx.ID(),
+		addValidatorTx.Unsigned.(*txs.AddValidatorTx),
+		1000, // potential reward
+	)
+	require.NoError(err)
+
+	env.state.PutCurrentValidator(staker)
+	env.state.AddTx(addValidatorTx, status.Committed)
+	require.NoError(env.state.Commit())
+
+	rewardTx, err := env.txBuilder.NewRewardValidatorTx(addValidatorTx.ID())
+	require.NoError(err)
+
+	env.clk.Set(endTime)
+	parentID := env.state.GetLastAccepted()
+	parentBlk, _, err := env.state.GetStatelessBlock(parentID)
+	require.NoError(err)
+	statelessBlk, err := blocks.NewBanffProposalBlock(
+		endTime,
+		parentID,
+		parentBlk.Height()+1,
+		rewardTx,
+	)
+	require.NoError(err)
+	propBlk := env.blkManager.NewBlock(statelessBlk)
+	require.NoError(propBlk.Verify(context.Background()))
+
+	options, err := propBlk.(snowman.OracleBlock).Options(context.Background())
+	require.NoError(err)
+	commitBlk := options[0]
+	require.NoError(commitBlk.Verify(context.Background()))
+	require.NoError(propBlk.Accept(context.Background()))
+	require.NoError(commitBlk.Accept(context.Background()))
+
+	// The validator is rewarded, and its stake is returned to the change
+	// address.
+	for _, addr := range []ids.ShortID{rewardAddr, changeAddr} {
+		txIDs, err := indexer.Read(addr[:], env.ctx.CFLTAssetID, 0, 10)
+		require.NoError(err)
+		require.Equal([]ids.ID{rewardTx.ID()}, txIDs)
+	}
+
+	// The payer of the staking tx isn't touched by the reward tx.
+	payer := preFundedKeys[0].PublicKey().Address()
+	txIDs, err := indexer.Read(payer[:], env.ctx.CFLTAssetID, 0, 10)
+	require.NoError(err)
+	require.Empty(txIDs)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/coinflect/coinflectchain/database/prefixdb"
 	"github.com/coinflect/coinflectchain/database/versiondb"
 	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/pubsub"
 	"github.com/coinflect/coinflectchain/snow"
 	"github.com/coinflect/coinflectchain/snow/engine/common"
 	"github.com/coinflect/coinflectchain/snow/uptime"
@@ -224,6 +225,8 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller) *environment {
 			res.state,
 			res.backend,
 			window,
+			pubsub.New(res.ctx.Log),
+			nil,
 		)
 		addSubnet(res)
 	} else {
@@ -233,6 +236,8 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller) *environment {
 			res.mockedState,
 			res.backend,
 			window,
+			pubsub.New(res.ctx.Log),
+			nil,
 		)
 		// we do not add any subnet to state, since we can mock
 		// whatever we need
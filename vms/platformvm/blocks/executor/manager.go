@@ -6,8 +6,11 @@ package executor
 
 import (
 	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/pubsub"
 	"github.com/coinflect/coinflectchain/snow/consensus/snowman"
 	"github.com/coinflect/coinflectchain/utils/window"
+	"github.com/coinflect/coinflectchain/vms/components/cflt"
+	"github.com/coinflect/coinflectchain/vms/components/index"
 	"github.com/coinflect/coinflectchain/vms/platformvm/blocks"
 	"github.com/coinflect/coinflectchain/vms/platformvm/metrics"
 	"github.com/coinflect/coinflectchain/vms/platformvm/state"
@@ -33,6 +36,8 @@ func NewManager(
 	s state.State,
 	txExecutorBackend *executor.Backend,
 	recentlyAccepted window.Window[ids.ID],
+	pubsub *pubsub.Server,
+	addressTxsIndexer index.AddressTxsIndexer,
 ) Manager {
 	backend := &backend{
 		Mempool:      mempool,
@@ -53,6 +58,10 @@ func NewManager(
 			metrics:          metrics,
 			recentlyAccepted: recentlyAccepted,
 			bootstrapped:     txExecutorBackend.Bootstrapped,
+			pubsub:           pubsub,
+			addrManager:      cflt.NewAddressManager(txExecutorBackend.Ctx),
+
+			addressTxsIndexer: addressTxsIndexer,
 		},
 		rejector: &rejector{backend: backend},
 	}
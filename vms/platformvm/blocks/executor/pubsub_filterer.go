@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/pubsub"
+	"github.com/coinflect/coinflectchain/vms/components/cflt"
+	"github.com/coinflect/coinflectchain/vms/platformvm/txs"
+)
+
+// NewPubSubFilterer returns the filterer that sends [tx], accepted in the block
+// at [height], to the connections whose filter matches:
+// - the owners of the UTXOs it produces, including its staked outputs
+// - the owners of the staking rewards it registers
+// - the ID of the validator it adds, removes or delegates to
+func NewPubSubFilterer(formatter pubsub.AddressFormatter, height uint64, tx *txs.Tx) pubsub.Filterer {
+	f := &pubsub.TxFilterer{
+		Formatter: formatter,
+		TxID:      tx.ID(),
+		Height:    &height,
+	}
+
+	for _, out := range tx.Unsigned.Outputs() {
+		addOutput(f, out.AssetID(), out.Out)
+	}
+	if staker, ok := tx.Unsigned.(txs.PermissionlessStaker); ok {
+		for _, out := range staker.Stake() {
+			addOutput(f, out.AssetID(), out.Out)
+		}
+	}
+
+	switch utx := tx.Unsigned.(type) {
+	case txs.Validator:
+		addOutput(f, ids.Empty, utx.ValidationRewardsOwner())
+		addOutput(f, ids.Empty, utx.DelegationRewardsOwner())
+	case txs.Delegator:
+		addOutput(f, ids.Empty, utx.RewardsOwner())
+	}
+
+	switch utx := tx.Unsigned.(type) {
+	case txs.Staker:
+		f.NodeIDs = append(f.NodeIDs, utx.NodeID())
+	case *txs.RemoveSubnetValidatorTx:
+		f.NodeIDs = append(f.NodeIDs, utx.NodeID)
+	}
+	return f
+}
+
+// addOutput adds the owners of [owned] to the outputs matched by [f]. Owners
+// that don't hold an amount of [assetID], such as reward owners, are added
+// with a zero amount.
+func addOutput(f *pubsub.TxFilterer, assetID ids.ID, owned interface{}) {
+	addressable, ok := owned.(cflt.Addressable)
+	if !ok {
+		return
+	}
+
+	out := pubsub.Output{
+		Addresses: addressable.Addresses(),
+		AssetID:   assetID,
+	}
+	if amounter, ok := owned.(cflt.Amounter); ok {
+		out.Amount = amounter.Amount()
+	}
+	f.Outputs = append(f.Outputs, out)
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// Copyright (C) 2022, Coinflect, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coinflect/coinflectchain/api"
+	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/pubsub"
+	"github.com/coinflect/coinflectchain/utils/json"
+	"github.com/coinflect/coinflectchain/vms/components/cflt"
+	"github.com/coinflect/coinflectchain/vms/platformvm/stakeable"
+	"github.com/coinflect/coinflectchain/vms/platformvm/txs"
+	"github.com/coinflect/coinflectchain/vms/platformvm/validator"
+	"github.com/coinflect/coinflectchain/vms/secp256k1fx"
+)
+
+type testFilter struct {
+	addr []byte
+}
+
+func (f *testFilter) Check(addr []byte) bool {
+	return bytes.Equal(addr, f.addr)
+}
+
+type testFormatter struct{}
+
+func (testFormatter) FormatLocalAddress(addr ids.ShortID) (string, error) {
+	return "P-" + addr.String(), nil
+}
+
+func TestPubSubFilterer(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		assetID     = ids.GenerateTestID()
+		ownerAddr   = ids.GenerateTestShortID()
+		rewardsAddr = ids.GenerateTestShortID()
+		nodeID      = ids.GenerateTestNodeID()
+		height      = uint64(5)
+	)
+	tx := &txs.Tx{Unsigned: &txs.AddValidatorTx{
+		BaseTx: txs.BaseTx{BaseTx: cflt.BaseTx{
+			Outs: []*cflt.TransferableOutput{{
+				Asset: cflt.Asset{ID: assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: 1,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Addrs: []ids.ShortID{ownerAddr},
+					},
+				},
+			}},
+		}},
+		Validator: validator.Validator{
+			NodeID: nodeID,
+		},
+		StakeOuts: []*cflt.TransferableOutput{{
+			Asset: cflt.Asset{ID: assetID},
+			Out: &stakeable.LockOut{
+				TransferableOut: &secp256k1fx.TransferOutput{
+					Amt: 2,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Addrs: []ids.ShortID{ownerAddr},
+					},
+				},
+			},
+		}},
+		RewardsOwner: &secp256k1fx.OutputOwners{
+			Addrs: []ids.ShortID{rewardsAddr},
+		},
+	}}
+
+	f := NewPubSubFilterer(testFormatter{}, height, tx)
+	msgs := f.Filter([]pubsub.Filter{
+		&testFilter{addr: ownerAddr[:]},
+		&testFilter{addr: rewardsAddr[:]},
+		&testFilter{addr: nodeID.Bytes()},
+		&testFilter{addr: ids.ShortEmpty[:]},
+	})
+
+	jsonHeight := json.Uint64(height)
+	require.Equal([]interface{}{
+		// The staked outputs are matched with the UTXOs
+		&api.TxEvent{
+			TxID:      tx.ID(),
+			Height:    &jsonHeight,
+			Addresses: []string{"P-" + ownerAddr.String()},
+			Assets: []api.AssetAmount{{
+				AssetID: assetID,
+				Amount:  3,
+			}},
+		},
+		// The rewards owner doesn't receive any funds
+		&api.TxEvent{
+			TxID:      tx.ID(),
+			Height:    &jsonHeight,
+			Addresses: []string{"P-" + rewardsAddr.String()},
+			Assets:    []api.AssetAmount{},
+		},
+		&api.TxEvent{
+			TxID:      tx.ID(),
+			Height:    &jsonHeight,
+			Addresses: []string{},
+			Assets:    []api.AssetAmount{},
+			NodeIDs:   []ids.NodeID{nodeID},
+		},
+		nil,
+	}, msgs)
+}
@@ -15,12 +15,15 @@ import (
 
 	stdjson "encoding/json"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/stretchr/testify/require"
 
 	"github.com/coinflect/coinflectchain/api"
 	"github.com/coinflect/coinflectchain/api/keystore"
 	"github.com/coinflect/coinflectchain/chains/atomic"
 	"github.com/coinflect/coinflectchain/database/manager"
+	"github.com/coinflect/coinflectchain/database/memdb"
 	"github.com/coinflect/coinflectchain/database/prefixdb"
 	"github.com/coinflect/coinflectchain/ids"
 	"github.com/coinflect/coinflectchain/snow/consensus/snowman"
@@ -31,6 +34,7 @@ import (
 	"github.com/coinflect/coinflectchain/utils/logging"
 	"github.com/coinflect/coinflectchain/version"
 	"github.com/coinflect/coinflectchain/vms/components/cflt"
+	"github.com/coinflect/coinflectchain/vms/components/index"
 	"github.com/coinflect/coinflectchain/vms/platformvm/blocks"
 	"github.com/coinflect/coinflectchain/vms/platformvm/state"
 	"github.com/coinflect/coinflectchain/vms/platformvm/status"
@@ -775,6 +779,68 @@ func TestGetTimestamp(t *testing.T) {
 	require.Equal(newTimestamp, reply.Timestamp)
 }
 
+func TestGetAddressTxs(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown(context.Background()))
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	indexer, err := index.NewIndexer(
+		memdb.New(),
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		false,
+	)
+	require.NoError(err)
+	service.vm.addressTxsIndexer = indexer
+
+	addr := ids.GenerateTestShortID()
+	txIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()}
+	for _, txID := range txIDs {
+		utxo := &cflt.UTXO{
+			UTXOID: cflt.UTXOID{TxID: txID},
+			Asset:  cflt.Asset{ID: service.vm.ctx.CFLTAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+		}
+		require.NoError(indexer.Accept(txID, nil, []*cflt.UTXO{utxo}))
+	}
+
+	addrStr, err := service.addrManager.FormatLocalAddress(addr)
+	require.NoError(err)
+	args := &GetAddressTxsArgs{
+		JSONAddress: api.JSONAddress{Address: addrStr},
+		PageSize:    2,
+	}
+	reply := &GetAddressTxsReply{}
+	require.NoError(service.GetAddressTxs(nil, args, reply))
+	require.Equal(txIDs[:2], reply.TxIDs)
+	require.EqualValues(2, reply.Cursor)
+
+	args.Cursor = reply.Cursor
+	require.NoError(service.GetAddressTxs(nil, args, reply))
+	require.Equal(txIDs[2:], reply.TxIDs)
+	require.EqualValues(3, reply.Cursor)
+
+	// No transactions changed the balance of another asset.
+	args.Cursor = 0
+	args.AssetID = ids.GenerateTestID().String()
+	require.NoError(service.GetAddressTxs(nil, args, reply))
+	require.Empty(reply.TxIDs)
+
+	args.PageSize = json.Uint64(maxGetAddressTxsPageSize + 1)
+	require.Error(service.GetAddressTxs(nil, args, reply))
+}
+
 func TestGetBlock(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -142,6 +142,20 @@ func (mr *MockStateMockRecorder) AddUTXO(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUTXO", reflect.TypeOf((*MockState)(nil).AddUTXO), arg0)
 }
 
+// AddressTxsDB mocks base method.
+func (m *MockState) AddressTxsDB() database.Database {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddressTxsDB")
+	ret0, _ := ret[0].(database.Database)
+	return ret0
+}
+
+// AddressTxsDB indicates an expected call of AddressTxsDB.
+func (mr *MockStateMockRecorder) AddressTxsDB() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddressTxsDB", reflect.TypeOf((*MockState)(nil).AddressTxsDB))
+}
+
 // Close mocks base method.
 func (m *MockState) Close() error {
 	m.ctrl.T.Helper()
@@ -69,6 +69,7 @@ var (
 	supplyPrefix            = []byte("supply")
 	chainPrefix             = []byte("chain")
 	singletonPrefix         = []byte("singleton")
+	addressTxsPrefix        = []byte("addressTxs")
 
 	timestampKey     = []byte("timestamp")
 	currentSupplyKey = []byte("current supply")
@@ -130,6 +131,10 @@ type State interface {
 
 	SetHeight(height uint64)
 
+	// AddressTxsDB returns the database that the accepted transactions are
+	// indexed by address in. Its changes are committed with the state.
+	AddressTxsDB() database.Database
+
 	// Discard uncommitted changes to the database.
 	Abort()
 
@@ -199,11 +204,16 @@ type stateBlk struct {
  * | '-. subnetID
  * |   '-. list
  * |     '-- txID -> nil
- * '-. singletons
- *   |-- initializedKey -> nil
- *   |-- timestampKey -> timestamp
- *   |-- currentSupplyKey -> currentSupply
- *   '-- lastAcceptedKey -> lastAccepted
+ * |-. singletons
+ * | |-- initializedKey -> nil
+ * | |-- timestampKey -> timestamp
+ * | |-- currentSupplyKey -> currentSupply
+ * | '-- lastAcceptedKey -> lastAccepted
+ * '-. addressTxs
+ *   '-. address
+ *     '-. assetID
+ *       |-- idxKey -> next index
+ *       '-- index -> txID
  */
 type state struct {
 	cfg     *config.Config
@@ -284,6 +294,8 @@ type state struct {
 	// [lastAccepted] is the most recently accepted block.
 	lastAccepted, persistedLastAccepted ids.ID
 	singletonDB                         database.Database
+
+	addressTxsDB database.Database
 }
 
 type ValidatorWeightDiff struct {
@@ -537,6 +549,8 @@ func new(
 		chainDBCache: chainDBCache,
 
 		singletonDB: prefixdb.New(singletonPrefix, baseDB),
+
+		addressTxsDB: prefixdb.New(addressTxsPrefix, baseDB),
 	}, nil
 }
 
@@ -1351,6 +1365,7 @@ func (s *state) Close() error {
 		s.chainDB.Close(),
 		s.singletonDB.Close(),
 		s.blockDB.Close(),
+		s.addressTxsDB.Close(),
 	)
 	return errs.Err
 }
@@ -1430,6 +1445,10 @@ func (s *state) Commit() error {
 	return batch.Write()
 }
 
+func (s *state) AddressTxsDB() database.Database {
+	return s.addressTxsDB
+}
+
 func (s *state) Abort() {
 	s.baseDB.Abort()
 }
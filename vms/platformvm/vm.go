@@ -10,18 +10,20 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/gorilla/rpc/v2"
+	stdjson "encoding/json"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"go.uber.org/zap"
 
+	"github.com/coinflect/coinflectchain/api/openrpc"
 	"github.com/coinflect/coinflectchain/cache"
 	"github.com/coinflect/coinflectchain/codec"
 	"github.com/coinflect/coinflectchain/codec/linearcodec"
 	"github.com/coinflect/coinflectchain/database"
 	"github.com/coinflect/coinflectchain/database/manager"
 	"github.com/coinflect/coinflectchain/ids"
+	"github.com/coinflect/coinflectchain/pubsub"
 	"github.com/coinflect/coinflectchain/snow"
 	"github.com/coinflect/coinflectchain/snow/consensus/snowman"
 	"github.com/coinflect/coinflectchain/snow/engine/common"
@@ -38,6 +40,7 @@ import (
 	"github.com/coinflect/coinflectchain/utils/wrappers"
 	"github.com/coinflect/coinflectchain/version"
 	"github.com/coinflect/coinflectchain/vms/components/cflt"
+	"github.com/coinflect/coinflectchain/vms/components/index"
 	"github.com/coinflect/coinflectchain/vms/platformvm/api"
 	"github.com/coinflect/coinflectchain/vms/platformvm/blocks"
 	"github.com/coinflect/coinflectchain/vms/platformvm/fx"
@@ -70,6 +73,13 @@ var (
 	errMissingValidatorSet = errors.New("missing validator set")
 )
 
+// ChainConfig is the configuration of the P-chain, read from its chain config
+// file.
+type ChainConfig struct {
+	IndexTransactions    bool `json:"index-transactions"`
+	IndexAllowIncomplete bool `json:"index-allow-incomplete"`
+}
+
 type VM struct {
 	Factory
 	blockbuilder.Builder
@@ -105,6 +115,14 @@ type VM struct {
 	txBuilder         txbuilder.Builder
 	txExecutorBackend *txexecutor.Backend
 	manager           blockexecutor.Manager
+
+	// Sends the accepted transactions to the subscribers of the chain's
+	// events
+	pubsub *pubsub.Server
+
+	// Indexes the accepted transactions by the addresses whose balances they
+	// change
+	addressTxsIndexer index.AddressTxsIndexer
 }
 
 // Initialize this blockchain.
@@ -115,13 +133,23 @@ func (vm *VM) Initialize(
 	dbManager manager.Manager,
 	genesisBytes []byte,
 	_ []byte,
-	_ []byte,
+	configBytes []byte,
 	toEngine chan<- common.Message,
 	_ []*common.Fx,
 	appSender common.AppSender,
 ) error {
 	chainCtx.Log.Verbo("initializing platform chain")
 
+	chainConfig := ChainConfig{}
+	if len(configBytes) > 0 {
+		if err := stdjson.Unmarshal(configBytes, &chainConfig); err != nil {
+			return err
+		}
+		chainCtx.Log.Info("VM config initialized",
+			zap.Reflect("config", chainConfig),
+		)
+	}
+
 	registerer := prometheus.NewRegistry()
 	if err := chainCtx.Metrics.Register(registerer); err != nil {
 		return err
@@ -136,6 +164,7 @@ func (vm *VM) Initialize(
 
 	vm.ctx = chainCtx
 	vm.dbManager = dbManager
+	vm.pubsub = pubsub.New(chainCtx.Log)
 
 	vm.codecRegistry = linearcodec.NewDefault()
 	vm.fx = &secp256k1fx.Fx{}
@@ -166,6 +195,27 @@ func (vm *VM) Initialize(
 		return err
 	}
 
+	// The index is stored with the state, so that the transactions are
+	// indexed atomically with their acceptance.
+	var addressTxsIndexer index.AddressTxsIndexer
+	if chainConfig.IndexTransactions {
+		chainCtx.Log.Info("address transaction indexing is enabled")
+		vm.addressTxsIndexer, err = index.NewIndexer(vm.state.AddressTxsDB(), chainCtx.Log, "", registerer, chainConfig.IndexAllowIncomplete)
+		if err != nil {
+			return fmt.Errorf("failed to initialize address transaction indexer: %w", err)
+		}
+		addressTxsIndexer = vm.addressTxsIndexer
+	} else {
+		chainCtx.Log.Info("address transaction indexing is disabled")
+		vm.addressTxsIndexer, err = index.NewNoIndexer(vm.state.AddressTxsDB(), chainConfig.IndexAllowIncomplete)
+		if err != nil {
+			return fmt.Errorf("failed to initialize disabled indexer: %w", err)
+		}
+	}
+	if err := vm.state.Commit(); err != nil {
+		return err
+	}
+
 	vm.atomicUtxosManager = cflt.NewAtomicUTXOManager(chainCtx.SharedMemory, txs.Codec)
 	utxoHandler := utxo.NewHandler(vm.ctx, &vm.clock, vm.state, vm.fx)
 	vm.uptimeManager = uptime.NewManager(vm.state)
@@ -205,6 +255,8 @@ func (vm *VM) Initialize(
 		vm.state,
 		vm.txExecutorBackend,
 		vm.recentlyAccepted,
+		vm.pubsub,
+		addressTxsIndexer,
 	)
 	vm.Builder = blockbuilder.New(
 		mempool,
@@ -396,7 +448,7 @@ func (*VM) Version(context.Context) (string, error) {
 // * keys are API endpoint extensions
 // * values are API handlers
 func (vm *VM) CreateHandlers(context.Context) (map[string]*common.HTTPHandler, error) {
-	server := rpc.NewServer()
+	server := openrpc.NewServer()
 	server.RegisterCodec(json.NewCodec(), "application/json")
 	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
 	server.RegisterInterceptFunc(vm.metrics.InterceptRequest)
@@ -415,6 +467,10 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]*common.HTTPHandler, e
 		"": {
 			Handler: server,
 		},
+		"/events": {
+			LockOptions: common.NoLock,
+			Handler:     vm.pubsub,
+		},
 	}, nil
 }
 
@@ -422,7 +478,7 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]*common.HTTPHandler, e
 // * keys are API endpoint extensions
 // * values are API handlers
 func (*VM) CreateStaticHandlers(context.Context) (map[string]*common.HTTPHandler, error) {
-	server := rpc.NewServer()
+	server := openrpc.NewServer()
 	server.RegisterCodec(json.NewCodec(), "application/json")
 	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
 	if err := server.RegisterService(&api.StaticService{}, "platform"); err != nil {